@@ -0,0 +1,391 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: auditumio/auditum/v1alpha1/api_key.proto
+
+package auditumv1alpha1
+
+import (
+	_ "github.com/auditumio/auditum/api/gen/go/google/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Enumerates available permissions.
+type ApiKeyPermission_Enum int32
+
+const (
+	// Permission not provided.
+	ApiKeyPermission_UNSPECIFIED ApiKeyPermission_Enum = 0
+	// Allows reading projects and records.
+	ApiKeyPermission_READ ApiKeyPermission_Enum = 1
+	// Allows creating, updating and deleting records.
+	ApiKeyPermission_WRITE ApiKeyPermission_Enum = 2
+	// Allows everything, including managing projects and API keys.
+	ApiKeyPermission_ADMIN ApiKeyPermission_Enum = 3
+)
+
+// Enum value maps for ApiKeyPermission_Enum.
+var (
+	ApiKeyPermission_Enum_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "READ",
+		2: "WRITE",
+		3: "ADMIN",
+	}
+	ApiKeyPermission_Enum_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"READ":        1,
+		"WRITE":       2,
+		"ADMIN":       3,
+	}
+)
+
+func (x ApiKeyPermission_Enum) Enum() *ApiKeyPermission_Enum {
+	p := new(ApiKeyPermission_Enum)
+	*p = x
+	return p
+}
+
+func (x ApiKeyPermission_Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiKeyPermission_Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_auditumio_auditum_v1alpha1_api_key_proto_enumTypes[0].Descriptor()
+}
+
+func (ApiKeyPermission_Enum) Type() protoreflect.EnumType {
+	return &file_auditumio_auditum_v1alpha1_api_key_proto_enumTypes[0]
+}
+
+func (x ApiKeyPermission_Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiKeyPermission_Enum.Descriptor instead.
+func (ApiKeyPermission_Enum) EnumDescriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_api_key_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// Represents an API key to authenticate requests.
+//
+// The key is sent in the `authorization` header as `Bearer <key>`.
+// The key itself is returned only once on creation and is not stored.
+type ApiKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// API key identifier.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Time when the API key was created.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Display name of the API key, e.g. the name of the service using it.
+	//
+	// REQUIREMENTS.
+	// The value must be 3-64 characters long.
+	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// The beginning of the key, which helps to identify it.
+	KeyPrefix string `protobuf:"bytes,4,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	// Projects the key grants access to.
+	// If empty, the key grants access to all projects.
+	// Only keys with access to all projects can create and list projects,
+	// and manage API keys.
+	ProjectIds []string `protobuf:"bytes,5,rep,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	// Permissions granted by the key.
+	//
+	// REQUIREMENTS.
+	// At least one permission must be specified.
+	Permissions []ApiKeyPermission_Enum `protobuf:"varint,6,rep,packed,name=permissions,proto3,enum=auditumio.auditum.v1alpha1.ApiKeyPermission_Enum" json:"permissions,omitempty"`
+	// Time when the API key was revoked. Unset if the key is active.
+	RevokeTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoke_time,json=revokeTime,proto3" json:"revoke_time,omitempty"`
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_api_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApiKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiKey) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *ApiKey) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ApiKey) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *ApiKey) GetProjectIds() []string {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+func (x *ApiKey) GetPermissions() []ApiKeyPermission_Enum {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *ApiKey) GetRevokeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokeTime
+	}
+	return nil
+}
+
+// Wraps API key permission enumeration.
+type ApiKeyPermission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ApiKeyPermission) Reset() {
+	*x = ApiKeyPermission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApiKeyPermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKeyPermission) ProtoMessage() {}
+
+func (x *ApiKeyPermission) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKeyPermission.ProtoReflect.Descriptor instead.
+func (*ApiKeyPermission) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_api_key_proto_rawDescGZIP(), []int{1}
+}
+
+var File_auditumio_auditum_v1alpha1_api_key_proto protoreflect.FileDescriptor
+
+var file_auditumio_auditum_v1alpha1_api_key_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x61, 0x70, 0x69,
+	0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf4, 0x02, 0x0a, 0x06, 0x41, 0x70, 0x69,
+	0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x04, 0xe2, 0x41, 0x01, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x41, 0x0a, 0x0b, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x03,
+	0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x02, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x03, 0x52,
+	0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x25, 0x0a, 0x0b, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x42,
+	0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64,
+	0x73, 0x12, 0x59, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x02, 0x52,
+	0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x0b,
+	0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x04, 0xe2,
+	0x41, 0x01, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x4b, 0x0a, 0x10, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x37, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x52, 0x45, 0x41, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10,
+	0x02, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x03, 0x42, 0x8b, 0x02, 0x0a,
+	0x1e, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x42,
+	0x0b, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x52,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69,
+	0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x41, 0x58, 0xaa, 0x02, 0x1a, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x56, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69,
+	0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0xe2, 0x02, 0x26, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1c, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_auditumio_auditum_v1alpha1_api_key_proto_rawDescOnce sync.Once
+	file_auditumio_auditum_v1alpha1_api_key_proto_rawDescData = file_auditumio_auditum_v1alpha1_api_key_proto_rawDesc
+)
+
+func file_auditumio_auditum_v1alpha1_api_key_proto_rawDescGZIP() []byte {
+	file_auditumio_auditum_v1alpha1_api_key_proto_rawDescOnce.Do(func() {
+		file_auditumio_auditum_v1alpha1_api_key_proto_rawDescData = protoimpl.X.CompressGZIP(file_auditumio_auditum_v1alpha1_api_key_proto_rawDescData)
+	})
+	return file_auditumio_auditum_v1alpha1_api_key_proto_rawDescData
+}
+
+var file_auditumio_auditum_v1alpha1_api_key_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_auditumio_auditum_v1alpha1_api_key_proto_goTypes = []any{
+	(ApiKeyPermission_Enum)(0),    // 0: auditumio.auditum.v1alpha1.ApiKeyPermission.Enum
+	(*ApiKey)(nil),                // 1: auditumio.auditum.v1alpha1.ApiKey
+	(*ApiKeyPermission)(nil),      // 2: auditumio.auditum.v1alpha1.ApiKeyPermission
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_auditumio_auditum_v1alpha1_api_key_proto_depIdxs = []int32{
+	3, // 0: auditumio.auditum.v1alpha1.ApiKey.create_time:type_name -> google.protobuf.Timestamp
+	0, // 1: auditumio.auditum.v1alpha1.ApiKey.permissions:type_name -> auditumio.auditum.v1alpha1.ApiKeyPermission.Enum
+	3, // 2: auditumio.auditum.v1alpha1.ApiKey.revoke_time:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_auditumio_auditum_v1alpha1_api_key_proto_init() }
+func file_auditumio_auditum_v1alpha1_api_key_proto_init() {
+	if File_auditumio_auditum_v1alpha1_api_key_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ApiKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ApiKeyPermission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_auditumio_auditum_v1alpha1_api_key_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auditumio_auditum_v1alpha1_api_key_proto_goTypes,
+		DependencyIndexes: file_auditumio_auditum_v1alpha1_api_key_proto_depIdxs,
+		EnumInfos:         file_auditumio_auditum_v1alpha1_api_key_proto_enumTypes,
+		MessageInfos:      file_auditumio_auditum_v1alpha1_api_key_proto_msgTypes,
+	}.Build()
+	File_auditumio_auditum_v1alpha1_api_key_proto = out.File
+	file_auditumio_auditum_v1alpha1_api_key_proto_rawDesc = nil
+	file_auditumio_auditum_v1alpha1_api_key_proto_goTypes = nil
+	file_auditumio_auditum_v1alpha1_api_key_proto_depIdxs = nil
+}
@@ -69,25 +69,805 @@ type Project struct {
 	// REQUIREMENTS.
 	// The value must be 3-64 characters long.
 	ExternalId *string `protobuf:"bytes,6,opt,name=external_id,json=externalId,proto3,oneof" json:"external_id,omitempty"`
+	// Ingestion quota of the project.
+	// Requests that would exceed the quota fail with RESOURCE_EXHAUSTED.
+	// Defaults to no limits.
+	Quota *ProjectQuota `protobuf:"bytes,7,opt,name=quota,proto3" json:"quota,omitempty"`
+	// Overrides of record restrictions for this project.
+	// Limits that are set override the global settings, unset (zero) limits
+	// inherit them. Use it when a project legitimately needs larger records.
+	// Defaults to unset.
+	RecordsRestrictions *RecordsRestrictions `protobuf:"bytes,8,opt,name=records_restrictions,json=recordsRestrictions,proto3" json:"records_restrictions,omitempty"`
+	// Description of the project.
+	// Defaults to unset.
+	//
+	// REQUIREMENTS.
+	// The value must be at most 1024 characters long.
+	Description string `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	// Labels of the project, e.g. owner or team.
+	// Projects can be filtered by labels.
+	// Defaults to unset.
+	//
+	// REQUIREMENTS.
+	// Keys must match regexp `[a-zA-Z0-9-_]+` and be at most 64 bytes long,
+	// values must be at most 256 bytes long. There can be at most 32 labels.
+	Labels map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Project) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Project) GetUpdateRecordEnabled() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.UpdateRecordEnabled
+	}
+	return nil
+}
+
+func (x *Project) GetDeleteRecordEnabled() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.DeleteRecordEnabled
+	}
+	return nil
+}
+
+func (x *Project) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *Project) GetQuota() *ProjectQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *Project) GetRecordsRestrictions() *RecordsRestrictions {
+	if x != nil {
+		return x.RecordsRestrictions
+	}
+	return nil
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// Represents restrictions on sizes of record fields.
+// A zero value of a limit means the limit is not set.
+//
+// REQUIREMENTS.
+// The values must not be negative.
+type RecordsRestrictions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restrictions on record labels.
+	Labels *RecordsRestrictions_KeyValue `protobuf:"bytes,1,opt,name=labels,proto3" json:"labels,omitempty"`
+	// Restrictions on a record resource.
+	Resource *RecordsRestrictions_Resource `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Restrictions on a record operation.
+	Operation *RecordsRestrictions_Operation `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Restrictions on a record actor.
+	Actor *RecordsRestrictions_Actor `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+}
+
+func (x *RecordsRestrictions) Reset() {
+	*x = RecordsRestrictions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions) ProtoMessage() {}
+
+func (x *RecordsRestrictions) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordsRestrictions) GetLabels() *RecordsRestrictions_KeyValue {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions) GetResource() *RecordsRestrictions_Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions) GetOperation() *RecordsRestrictions_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions) GetActor() *RecordsRestrictions_Actor {
+	if x != nil {
+		return x.Actor
+	}
+	return nil
+}
+
+// Represents ingestion limits of a project.
+// A zero value of a limit means there is no limit.
+//
+// REQUIREMENTS.
+// The values must not be negative.
+type ProjectQuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of records created within a UTC minute.
+	RecordsPerMinute int64 `protobuf:"varint,1,opt,name=records_per_minute,json=recordsPerMinute,proto3" json:"records_per_minute,omitempty"`
+	// Maximum number of records created within a UTC day.
+	RecordsPerDay int64 `protobuf:"varint,2,opt,name=records_per_day,json=recordsPerDay,proto3" json:"records_per_day,omitempty"`
+	// Maximum number of records stored in the project.
+	MaxRecords int64 `protobuf:"varint,3,opt,name=max_records,json=maxRecords,proto3" json:"max_records,omitempty"`
+	// Maximum total size of records stored in the project, in bytes.
+	// The size of a record is the length of all its text and JSON values,
+	// including metadata keys.
+	MaxBytes int64 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+}
+
+func (x *ProjectQuota) Reset() {
+	*x = ProjectQuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectQuota) ProtoMessage() {}
+
+func (x *ProjectQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectQuota.ProtoReflect.Descriptor instead.
+func (*ProjectQuota) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProjectQuota) GetRecordsPerMinute() int64 {
+	if x != nil {
+		return x.RecordsPerMinute
+	}
+	return 0
+}
+
+func (x *ProjectQuota) GetRecordsPerDay() int64 {
+	if x != nil {
+		return x.RecordsPerDay
+	}
+	return 0
+}
+
+func (x *ProjectQuota) GetMaxRecords() int64 {
+	if x != nil {
+		return x.MaxRecords
+	}
+	return 0
+}
+
+func (x *ProjectQuota) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+// Represents usage counters of a project.
+type ProjectUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Project identifier.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Total number of records stored in the project.
+	Records int64 `protobuf:"varint,2,opt,name=records,proto3" json:"records,omitempty"`
+	// Total size of records stored in the project, in bytes.
+	Bytes int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Start of the current UTC minute.
+	MinuteStartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=minute_start_time,json=minuteStartTime,proto3" json:"minute_start_time,omitempty"`
+	// Number of records created within the current UTC minute.
+	MinuteRecords int64 `protobuf:"varint,5,opt,name=minute_records,json=minuteRecords,proto3" json:"minute_records,omitempty"`
+	// Start of the current UTC day.
+	DayStartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=day_start_time,json=dayStartTime,proto3" json:"day_start_time,omitempty"`
+	// Number of records created within the current UTC day.
+	DayRecords int64 `protobuf:"varint,7,opt,name=day_records,json=dayRecords,proto3" json:"day_records,omitempty"`
+}
+
+func (x *ProjectUsage) Reset() {
+	*x = ProjectUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectUsage) ProtoMessage() {}
+
+func (x *ProjectUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectUsage.ProtoReflect.Descriptor instead.
+func (*ProjectUsage) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProjectUsage) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ProjectUsage) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *ProjectUsage) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *ProjectUsage) GetMinuteStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MinuteStartTime
+	}
+	return nil
+}
+
+func (x *ProjectUsage) GetMinuteRecords() int64 {
+	if x != nil {
+		return x.MinuteRecords
+	}
+	return 0
+}
+
+func (x *ProjectUsage) GetDayStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DayStartTime
+	}
+	return nil
+}
+
+func (x *ProjectUsage) GetDayRecords() int64 {
+	if x != nil {
+		return x.DayRecords
+	}
+	return 0
+}
+
+// ProjectStats represents statistics of project records.
+type ProjectStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Project identifier.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Number of records stored in the project.
+	Records int64 `protobuf:"varint,2,opt,name=records,proto3" json:"records,omitempty"`
+	// Approximate storage size of the project records, in bytes.
+	//
+	// On PostgreSQL it is the size of the project partitions, including
+	// indexes. On SQLite it is the size of the records data.
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Operation time of the earliest record. Unset if there are no records.
+	FirstOperationTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_operation_time,json=firstOperationTime,proto3" json:"first_operation_time,omitempty"`
+	// Operation time of the latest record. Unset if there are no records.
+	LastOperationTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_operation_time,json=lastOperationTime,proto3" json:"last_operation_time,omitempty"`
+	// Time when the last record was ingested. Unset if there are no records.
+	LastCreateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_create_time,json=lastCreateTime,proto3" json:"last_create_time,omitempty"`
+	// Number of distinct resource types.
+	ResourceTypes int64 `protobuf:"varint,7,opt,name=resource_types,json=resourceTypes,proto3" json:"resource_types,omitempty"`
+	// Number of distinct operation types.
+	OperationTypes int64 `protobuf:"varint,8,opt,name=operation_types,json=operationTypes,proto3" json:"operation_types,omitempty"`
+	// Number of distinct actor types.
+	ActorTypes int64 `protobuf:"varint,9,opt,name=actor_types,json=actorTypes,proto3" json:"actor_types,omitempty"`
+}
+
+func (x *ProjectStats) Reset() {
+	*x = ProjectStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectStats) ProtoMessage() {}
+
+func (x *ProjectStats) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectStats.ProtoReflect.Descriptor instead.
+func (*ProjectStats) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProjectStats) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ProjectStats) GetRecords() int64 {
+	if x != nil {
+		return x.Records
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetFirstOperationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstOperationTime
+	}
+	return nil
+}
+
+func (x *ProjectStats) GetLastOperationTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOperationTime
+	}
+	return nil
+}
+
+func (x *ProjectStats) GetLastCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCreateTime
+	}
+	return nil
+}
+
+func (x *ProjectStats) GetResourceTypes() int64 {
+	if x != nil {
+		return x.ResourceTypes
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetOperationTypes() int64 {
+	if x != nil {
+		return x.OperationTypes
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetActorTypes() int64 {
+	if x != nil {
+		return x.ActorTypes
+	}
+	return 0
+}
+
+// Restrictions on a map of keys and values, e.g. labels or metadata.
+type RecordsRestrictions_KeyValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum size of a key in bytes.
+	KeyMaxSizeBytes int32 `protobuf:"varint,1,opt,name=key_max_size_bytes,json=keyMaxSizeBytes,proto3" json:"key_max_size_bytes,omitempty"`
+	// Maximum size of a value in bytes.
+	ValueMaxSizeBytes int32 `protobuf:"varint,2,opt,name=value_max_size_bytes,json=valueMaxSizeBytes,proto3" json:"value_max_size_bytes,omitempty"`
+	// Maximum total size of all keys and values in bytes.
+	TotalMaxSizeBytes int32 `protobuf:"varint,3,opt,name=total_max_size_bytes,json=totalMaxSizeBytes,proto3" json:"total_max_size_bytes,omitempty"`
+}
+
+func (x *RecordsRestrictions_KeyValue) Reset() {
+	*x = RecordsRestrictions_KeyValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_KeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_KeyValue) ProtoMessage() {}
+
+func (x *RecordsRestrictions_KeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions_KeyValue.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_KeyValue) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *RecordsRestrictions_KeyValue) GetKeyMaxSizeBytes() int32 {
+	if x != nil {
+		return x.KeyMaxSizeBytes
+	}
+	return 0
+}
+
+func (x *RecordsRestrictions_KeyValue) GetValueMaxSizeBytes() int32 {
+	if x != nil {
+		return x.ValueMaxSizeBytes
+	}
+	return 0
+}
+
+func (x *RecordsRestrictions_KeyValue) GetTotalMaxSizeBytes() int32 {
+	if x != nil {
+		return x.TotalMaxSizeBytes
+	}
+	return 0
+}
+
+// Restrictions on a string.
+type RecordsRestrictions_String struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum size in bytes.
+	MaxSizeBytes int32 `protobuf:"varint,1,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+}
+
+func (x *RecordsRestrictions_String) Reset() {
+	*x = RecordsRestrictions_String{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_String) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_String) ProtoMessage() {}
+
+func (x *RecordsRestrictions_String) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions_String.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_String) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *RecordsRestrictions_String) GetMaxSizeBytes() int32 {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return 0
+}
+
+// Restrictions on a value, which size is measured in JSON encoding.
+type RecordsRestrictions_Bytes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum size in bytes.
+	MaxSizeBytes int32 `protobuf:"varint,1,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+}
+
+func (x *RecordsRestrictions_Bytes) Reset() {
+	*x = RecordsRestrictions_Bytes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_Bytes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_Bytes) ProtoMessage() {}
+
+func (x *RecordsRestrictions_Bytes) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions_Bytes.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_Bytes) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *RecordsRestrictions_Bytes) GetMaxSizeBytes() int32 {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return 0
+}
+
+// Restrictions on resource changes.
+type RecordsRestrictions_ResourceChanges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of changes in a record.
+	TotalMaxCount int32 `protobuf:"varint,1,opt,name=total_max_count,json=totalMaxCount,proto3" json:"total_max_count,omitempty"`
+	// Restrictions on a change name.
+	Name *RecordsRestrictions_String `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Restrictions on a change description.
+	Description *RecordsRestrictions_String `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Restrictions on an old value.
+	OldValue *RecordsRestrictions_Bytes `protobuf:"bytes,4,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	// Restrictions on a new value.
+	NewValue *RecordsRestrictions_Bytes `protobuf:"bytes,5,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (x *RecordsRestrictions_ResourceChanges) Reset() {
+	*x = RecordsRestrictions_ResourceChanges{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_ResourceChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_ResourceChanges) ProtoMessage() {}
+
+func (x *RecordsRestrictions_ResourceChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Project) Reset() {
-	*x = Project{}
+// Deprecated: Use RecordsRestrictions_ResourceChanges.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_ResourceChanges) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *RecordsRestrictions_ResourceChanges) GetTotalMaxCount() int32 {
+	if x != nil {
+		return x.TotalMaxCount
+	}
+	return 0
+}
+
+func (x *RecordsRestrictions_ResourceChanges) GetName() *RecordsRestrictions_String {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_ResourceChanges) GetDescription() *RecordsRestrictions_String {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_ResourceChanges) GetOldValue() *RecordsRestrictions_Bytes {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_ResourceChanges) GetNewValue() *RecordsRestrictions_Bytes {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+// Restrictions on a resource.
+type RecordsRestrictions_Resource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restrictions on a resource type.
+	Type *RecordsRestrictions_String `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Restrictions on a resource id.
+	Id *RecordsRestrictions_String `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Restrictions on resource metadata.
+	Metadata *RecordsRestrictions_KeyValue `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Restrictions on resource changes.
+	Changes *RecordsRestrictions_ResourceChanges `protobuf:"bytes,4,opt,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *RecordsRestrictions_Resource) Reset() {
+	*x = RecordsRestrictions_Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[0]
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Project) String() string {
+func (x *RecordsRestrictions_Resource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Project) ProtoMessage() {}
+func (*RecordsRestrictions_Resource) ProtoMessage() {}
 
-func (x *Project) ProtoReflect() protoreflect.Message {
-	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[0]
+func (x *RecordsRestrictions_Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -98,51 +878,171 @@ func (x *Project) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Project.ProtoReflect.Descriptor instead.
-func (*Project) Descriptor() ([]byte, []int) {
-	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RecordsRestrictions_Resource.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_Resource) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 4}
 }
 
-func (x *Project) GetId() string {
+func (x *RecordsRestrictions_Resource) GetType() *RecordsRestrictions_String {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_Resource) GetId() *RecordsRestrictions_String {
 	if x != nil {
 		return x.Id
 	}
-	return ""
+	return nil
 }
 
-func (x *Project) GetCreateTime() *timestamppb.Timestamp {
+func (x *RecordsRestrictions_Resource) GetMetadata() *RecordsRestrictions_KeyValue {
 	if x != nil {
-		return x.CreateTime
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *Project) GetDisplayName() string {
+func (x *RecordsRestrictions_Resource) GetChanges() *RecordsRestrictions_ResourceChanges {
 	if x != nil {
-		return x.DisplayName
+		return x.Changes
 	}
-	return ""
+	return nil
 }
 
-func (x *Project) GetUpdateRecordEnabled() *wrapperspb.BoolValue {
+// Restrictions on an operation.
+type RecordsRestrictions_Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restrictions on an operation type.
+	Type *RecordsRestrictions_String `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Restrictions on an operation id.
+	Id *RecordsRestrictions_String `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Restrictions on operation metadata.
+	Metadata *RecordsRestrictions_KeyValue `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *RecordsRestrictions_Operation) Reset() {
+	*x = RecordsRestrictions_Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_Operation) ProtoMessage() {}
+
+func (x *RecordsRestrictions_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions_Operation.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_Operation) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *RecordsRestrictions_Operation) GetType() *RecordsRestrictions_String {
 	if x != nil {
-		return x.UpdateRecordEnabled
+		return x.Type
 	}
 	return nil
 }
 
-func (x *Project) GetDeleteRecordEnabled() *wrapperspb.BoolValue {
+func (x *RecordsRestrictions_Operation) GetId() *RecordsRestrictions_String {
 	if x != nil {
-		return x.DeleteRecordEnabled
+		return x.Id
 	}
 	return nil
 }
 
-func (x *Project) GetExternalId() string {
-	if x != nil && x.ExternalId != nil {
-		return *x.ExternalId
+func (x *RecordsRestrictions_Operation) GetMetadata() *RecordsRestrictions_KeyValue {
+	if x != nil {
+		return x.Metadata
 	}
-	return ""
+	return nil
+}
+
+// Restrictions on an actor.
+type RecordsRestrictions_Actor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Restrictions on an actor type.
+	Type *RecordsRestrictions_String `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Restrictions on an actor id.
+	Id *RecordsRestrictions_String `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Restrictions on actor metadata.
+	Metadata *RecordsRestrictions_KeyValue `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *RecordsRestrictions_Actor) Reset() {
+	*x = RecordsRestrictions_Actor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordsRestrictions_Actor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRestrictions_Actor) ProtoMessage() {}
+
+func (x *RecordsRestrictions_Actor) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_project_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRestrictions_Actor.ProtoReflect.Descriptor instead.
+func (*RecordsRestrictions_Actor) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP(), []int{1, 6}
+}
+
+func (x *RecordsRestrictions_Actor) GetType() *RecordsRestrictions_String {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_Actor) GetId() *RecordsRestrictions_String {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *RecordsRestrictions_Actor) GetMetadata() *RecordsRestrictions_KeyValue {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
 }
 
 var File_auditumio_auditum_v1alpha1_project_proto protoreflect.FileDescriptor
@@ -161,7 +1061,7 @@ var file_auditumio_auditum_v1alpha1_project_proto_rawDesc = []byte{
 	0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
 	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f,
 	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x03, 0x0a, 0x07, 0x50, 0x72, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x05, 0x0a, 0x07, 0x50, 0x72, 0x6f,
 	0x6a, 0x65, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x42, 0x17, 0x92, 0x41, 0x10, 0xca, 0x3e, 0x0d, 0xfa, 0x02, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
 	0x63, 0x74, 0x5f, 0x69, 0x64, 0xe2, 0x41, 0x01, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x41, 0x0a,
@@ -185,25 +1085,229 @@ var file_auditumio_auditum_v1alpha1_project_proto_rawDesc = []byte{
 	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
 	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01,
 	0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x88, 0x01,
-	0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69,
-	0x64, 0x42, 0x8c, 0x02, 0x0a, 0x1e, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75,
-	0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x42, 0x0c, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74,
-	0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x75,
-	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2f,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x41, 0x58, 0xaa, 0x02,
-	0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74,
-	0x75, 0x6d, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x1a, 0x41, 0x75,
-	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x5c,
-	0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x26, 0x41, 0x75, 0x64, 0x69, 0x74,
-	0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x5c, 0x56, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0xea, 0x02, 0x1c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x3a, 0x3a, 0x41,
-	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x12, 0x44, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01,
+	0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x68, 0x0a, 0x14, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69,
+	0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x13, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x26, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01,
+	0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x5f, 0x69, 0x64, 0x22, 0xb6, 0x0f, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x56, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x5a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69,
+	0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42,
+	0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x5d, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x39, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x04, 0xe2,
+	0x41, 0x01, 0x01, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51,
+	0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41,
+	0x63, 0x74, 0x6f, 0x72, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x1a, 0xab, 0x01, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x31,
+	0x0a, 0x12, 0x6b, 0x65, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01,
+	0x52, 0x0f, 0x6b, 0x65, 0x79, 0x4d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x12, 0x35, 0x0a, 0x14, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42,
+	0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4d, 0x61, 0x78, 0x53,
+	0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x11, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x4d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a,
+	0x34, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x0e, 0x6d, 0x61, 0x78,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x1a, 0x33, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a,
+	0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0c, 0x6d, 0x61,
+	0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a, 0xa5, 0x03, 0x0a, 0x0f, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2c,
+	0x0a, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0d, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x50, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5e,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01,
+	0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x58,
+	0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x35, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x08,
+	0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x58, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0xe7, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x50, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x4c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75,
+	0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x5a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x38, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xe2, 0x41, 0x01,
+	0x01, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5f, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x42, 0x04, 0xe2,
+	0x41, 0x01, 0x01, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x87, 0x02, 0x0a,
+	0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x4c, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x5a, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4b, 0x65,
+	0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x83, 0x02, 0x0a, 0x05, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x50, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36,
+	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x4c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36,
+	0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x5a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x38, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x04, 0xe2, 0x41,
+	0x01, 0x01, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xba, 0x01, 0x0a,
+	0x0c, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x32, 0x0a,
+	0x12, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52,
+	0x10, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x50, 0x65, 0x72, 0x4d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x12, 0x2c, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01,
+	0x52, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x50, 0x65, 0x72, 0x44, 0x61, 0x79, 0x12,
+	0x25, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52,
+	0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xaf, 0x02, 0x0a, 0x0c, 0x50, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x11, 0x6d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x0e, 0x64, 0x61, 0x79, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x64, 0x61,
+	0x79, 0x53, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61,
+	0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x64, 0x61, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xb7, 0x03, 0x0a, 0x0c,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x4c, 0x0a, 0x14, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x12,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x4a, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73,
+	0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x44,
+	0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x8c, 0x02, 0x0a, 0x1e, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x42, 0x0c, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67,
+	0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x75, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x41,
+	0x41, 0x58, 0xaa, 0x02, 0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca,
+	0x02, 0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x26, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69,
+	0x6f, 0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -218,21 +1322,59 @@ func file_auditumio_auditum_v1alpha1_project_proto_rawDescGZIP() []byte {
 	return file_auditumio_auditum_v1alpha1_project_proto_rawDescData
 }
 
-var file_auditumio_auditum_v1alpha1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auditumio_auditum_v1alpha1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_auditumio_auditum_v1alpha1_project_proto_goTypes = []any{
-	(*Project)(nil),               // 0: auditumio.auditum.v1alpha1.Project
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
-	(*wrapperspb.BoolValue)(nil),  // 2: google.protobuf.BoolValue
+	(*Project)(nil),                             // 0: auditumio.auditum.v1alpha1.Project
+	(*RecordsRestrictions)(nil),                 // 1: auditumio.auditum.v1alpha1.RecordsRestrictions
+	(*ProjectQuota)(nil),                        // 2: auditumio.auditum.v1alpha1.ProjectQuota
+	(*ProjectUsage)(nil),                        // 3: auditumio.auditum.v1alpha1.ProjectUsage
+	(*ProjectStats)(nil),                        // 4: auditumio.auditum.v1alpha1.ProjectStats
+	nil,                                         // 5: auditumio.auditum.v1alpha1.Project.LabelsEntry
+	(*RecordsRestrictions_KeyValue)(nil),        // 6: auditumio.auditum.v1alpha1.RecordsRestrictions.KeyValue
+	(*RecordsRestrictions_String)(nil),          // 7: auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	(*RecordsRestrictions_Bytes)(nil),           // 8: auditumio.auditum.v1alpha1.RecordsRestrictions.Bytes
+	(*RecordsRestrictions_ResourceChanges)(nil), // 9: auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges
+	(*RecordsRestrictions_Resource)(nil),        // 10: auditumio.auditum.v1alpha1.RecordsRestrictions.Resource
+	(*RecordsRestrictions_Operation)(nil),       // 11: auditumio.auditum.v1alpha1.RecordsRestrictions.Operation
+	(*RecordsRestrictions_Actor)(nil),           // 12: auditumio.auditum.v1alpha1.RecordsRestrictions.Actor
+	(*timestamppb.Timestamp)(nil),               // 13: google.protobuf.Timestamp
+	(*wrapperspb.BoolValue)(nil),                // 14: google.protobuf.BoolValue
 }
 var file_auditumio_auditum_v1alpha1_project_proto_depIdxs = []int32{
-	1, // 0: auditumio.auditum.v1alpha1.Project.create_time:type_name -> google.protobuf.Timestamp
-	2, // 1: auditumio.auditum.v1alpha1.Project.update_record_enabled:type_name -> google.protobuf.BoolValue
-	2, // 2: auditumio.auditum.v1alpha1.Project.delete_record_enabled:type_name -> google.protobuf.BoolValue
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	13, // 0: auditumio.auditum.v1alpha1.Project.create_time:type_name -> google.protobuf.Timestamp
+	14, // 1: auditumio.auditum.v1alpha1.Project.update_record_enabled:type_name -> google.protobuf.BoolValue
+	14, // 2: auditumio.auditum.v1alpha1.Project.delete_record_enabled:type_name -> google.protobuf.BoolValue
+	2,  // 3: auditumio.auditum.v1alpha1.Project.quota:type_name -> auditumio.auditum.v1alpha1.ProjectQuota
+	1,  // 4: auditumio.auditum.v1alpha1.Project.records_restrictions:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions
+	5,  // 5: auditumio.auditum.v1alpha1.Project.labels:type_name -> auditumio.auditum.v1alpha1.Project.LabelsEntry
+	6,  // 6: auditumio.auditum.v1alpha1.RecordsRestrictions.labels:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.KeyValue
+	10, // 7: auditumio.auditum.v1alpha1.RecordsRestrictions.resource:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.Resource
+	11, // 8: auditumio.auditum.v1alpha1.RecordsRestrictions.operation:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.Operation
+	12, // 9: auditumio.auditum.v1alpha1.RecordsRestrictions.actor:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.Actor
+	13, // 10: auditumio.auditum.v1alpha1.ProjectUsage.minute_start_time:type_name -> google.protobuf.Timestamp
+	13, // 11: auditumio.auditum.v1alpha1.ProjectUsage.day_start_time:type_name -> google.protobuf.Timestamp
+	13, // 12: auditumio.auditum.v1alpha1.ProjectStats.first_operation_time:type_name -> google.protobuf.Timestamp
+	13, // 13: auditumio.auditum.v1alpha1.ProjectStats.last_operation_time:type_name -> google.protobuf.Timestamp
+	13, // 14: auditumio.auditum.v1alpha1.ProjectStats.last_create_time:type_name -> google.protobuf.Timestamp
+	7,  // 15: auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges.name:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	7,  // 16: auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges.description:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	8,  // 17: auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges.old_value:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.Bytes
+	8,  // 18: auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges.new_value:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.Bytes
+	7,  // 19: auditumio.auditum.v1alpha1.RecordsRestrictions.Resource.type:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	7,  // 20: auditumio.auditum.v1alpha1.RecordsRestrictions.Resource.id:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	6,  // 21: auditumio.auditum.v1alpha1.RecordsRestrictions.Resource.metadata:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.KeyValue
+	9,  // 22: auditumio.auditum.v1alpha1.RecordsRestrictions.Resource.changes:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.ResourceChanges
+	7,  // 23: auditumio.auditum.v1alpha1.RecordsRestrictions.Operation.type:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	7,  // 24: auditumio.auditum.v1alpha1.RecordsRestrictions.Operation.id:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	6,  // 25: auditumio.auditum.v1alpha1.RecordsRestrictions.Operation.metadata:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.KeyValue
+	7,  // 26: auditumio.auditum.v1alpha1.RecordsRestrictions.Actor.type:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	7,  // 27: auditumio.auditum.v1alpha1.RecordsRestrictions.Actor.id:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.String
+	6,  // 28: auditumio.auditum.v1alpha1.RecordsRestrictions.Actor.metadata:type_name -> auditumio.auditum.v1alpha1.RecordsRestrictions.KeyValue
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_auditumio_auditum_v1alpha1_project_proto_init() }
@@ -253,6 +1395,138 @@ func file_auditumio_auditum_v1alpha1_project_proto_init() {
 				return nil
 			}
 		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ProjectQuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ProjectUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ProjectStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_KeyValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_String); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_Bytes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_ResourceChanges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_Operation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_project_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*RecordsRestrictions_Actor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_auditumio_auditum_v1alpha1_project_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
@@ -261,7 +1535,7 @@ func file_auditumio_auditum_v1alpha1_project_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_auditumio_auditum_v1alpha1_project_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
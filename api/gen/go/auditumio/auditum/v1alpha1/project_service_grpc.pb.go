@@ -33,10 +33,12 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ProjectService_CreateProject_FullMethodName = "/auditumio.auditum.v1alpha1.ProjectService/CreateProject"
-	ProjectService_GetProject_FullMethodName    = "/auditumio.auditum.v1alpha1.ProjectService/GetProject"
-	ProjectService_ListProjects_FullMethodName  = "/auditumio.auditum.v1alpha1.ProjectService/ListProjects"
-	ProjectService_UpdateProject_FullMethodName = "/auditumio.auditum.v1alpha1.ProjectService/UpdateProject"
+	ProjectService_CreateProject_FullMethodName   = "/auditumio.auditum.v1alpha1.ProjectService/CreateProject"
+	ProjectService_GetProject_FullMethodName      = "/auditumio.auditum.v1alpha1.ProjectService/GetProject"
+	ProjectService_ListProjects_FullMethodName    = "/auditumio.auditum.v1alpha1.ProjectService/ListProjects"
+	ProjectService_UpdateProject_FullMethodName   = "/auditumio.auditum.v1alpha1.ProjectService/UpdateProject"
+	ProjectService_GetProjectUsage_FullMethodName = "/auditumio.auditum.v1alpha1.ProjectService/GetProjectUsage"
+	ProjectService_GetProjectStats_FullMethodName = "/auditumio.auditum.v1alpha1.ProjectService/GetProjectStats"
 )
 
 // ProjectServiceClient is the client API for ProjectService service.
@@ -47,6 +49,9 @@ type ProjectServiceClient interface {
 	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
+	GetProjectUsage(ctx context.Context, in *GetProjectUsageRequest, opts ...grpc.CallOption) (*GetProjectUsageResponse, error)
+	// Get project statistics.
+	GetProjectStats(ctx context.Context, in *GetProjectStatsRequest, opts ...grpc.CallOption) (*GetProjectStatsResponse, error)
 }
 
 type projectServiceClient struct {
@@ -97,6 +102,26 @@ func (c *projectServiceClient) UpdateProject(ctx context.Context, in *UpdateProj
 	return out, nil
 }
 
+func (c *projectServiceClient) GetProjectUsage(ctx context.Context, in *GetProjectUsageRequest, opts ...grpc.CallOption) (*GetProjectUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectUsageResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetProjectUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProjectStats(ctx context.Context, in *GetProjectStatsRequest, opts ...grpc.CallOption) (*GetProjectStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectStatsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetProjectStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProjectServiceServer is the server API for ProjectService service.
 // All implementations must embed UnimplementedProjectServiceServer
 // for forward compatibility
@@ -105,6 +130,9 @@ type ProjectServiceServer interface {
 	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
 	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
+	GetProjectUsage(context.Context, *GetProjectUsageRequest) (*GetProjectUsageResponse, error)
+	// Get project statistics.
+	GetProjectStats(context.Context, *GetProjectStatsRequest) (*GetProjectStatsResponse, error)
 	mustEmbedUnimplementedProjectServiceServer()
 }
 
@@ -124,6 +152,12 @@ func (UnimplementedProjectServiceServer) ListProjects(context.Context, *ListProj
 func (UnimplementedProjectServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
 }
+func (UnimplementedProjectServiceServer) GetProjectUsage(context.Context, *GetProjectUsageRequest) (*GetProjectUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectUsage not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProjectStats(context.Context, *GetProjectStatsRequest) (*GetProjectStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectStats not implemented")
+}
 func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
 
 // UnsafeProjectServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -209,6 +243,42 @@ func _ProjectService_UpdateProject_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_GetProjectUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProjectUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectUsage(ctx, req.(*GetProjectUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProjectStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProjectStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectStats(ctx, req.(*GetProjectStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -232,6 +302,14 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProject",
 			Handler:    _ProjectService_UpdateProject_Handler,
 		},
+		{
+			MethodName: "GetProjectUsage",
+			Handler:    _ProjectService_GetProjectUsage_Handler,
+		},
+		{
+			MethodName: "GetProjectStats",
+			Handler:    _ProjectService_GetProjectStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auditumio/auditum/v1alpha1/project_service.proto",
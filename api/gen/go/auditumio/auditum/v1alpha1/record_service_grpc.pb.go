@@ -33,12 +33,15 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	RecordService_CreateRecord_FullMethodName       = "/auditumio.auditum.v1alpha1.RecordService/CreateRecord"
-	RecordService_BatchCreateRecords_FullMethodName = "/auditumio.auditum.v1alpha1.RecordService/BatchCreateRecords"
-	RecordService_GetRecord_FullMethodName          = "/auditumio.auditum.v1alpha1.RecordService/GetRecord"
-	RecordService_ListRecords_FullMethodName        = "/auditumio.auditum.v1alpha1.RecordService/ListRecords"
-	RecordService_UpdateRecord_FullMethodName       = "/auditumio.auditum.v1alpha1.RecordService/UpdateRecord"
-	RecordService_DeleteRecord_FullMethodName       = "/auditumio.auditum.v1alpha1.RecordService/DeleteRecord"
+	RecordService_CreateRecord_FullMethodName        = "/auditumio.auditum.v1alpha1.RecordService/CreateRecord"
+	RecordService_BatchCreateRecords_FullMethodName  = "/auditumio.auditum.v1alpha1.RecordService/BatchCreateRecords"
+	RecordService_StreamCreateRecords_FullMethodName = "/auditumio.auditum.v1alpha1.RecordService/StreamCreateRecords"
+	RecordService_GetRecord_FullMethodName           = "/auditumio.auditum.v1alpha1.RecordService/GetRecord"
+	RecordService_ListRecords_FullMethodName         = "/auditumio.auditum.v1alpha1.RecordService/ListRecords"
+	RecordService_ExportRecords_FullMethodName       = "/auditumio.auditum.v1alpha1.RecordService/ExportRecords"
+	RecordService_WatchRecords_FullMethodName        = "/auditumio.auditum.v1alpha1.RecordService/WatchRecords"
+	RecordService_UpdateRecord_FullMethodName        = "/auditumio.auditum.v1alpha1.RecordService/UpdateRecord"
+	RecordService_DeleteRecord_FullMethodName        = "/auditumio.auditum.v1alpha1.RecordService/DeleteRecord"
 )
 
 // RecordServiceClient is the client API for RecordService service.
@@ -47,8 +50,50 @@ const (
 type RecordServiceClient interface {
 	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*CreateRecordResponse, error)
 	BatchCreateRecords(ctx context.Context, in *BatchCreateRecordsRequest, opts ...grpc.CallOption) (*BatchCreateRecordsResponse, error)
+	// Creates records sent continuously over a single stream, for high-volume
+	// producers.
+	//
+	// The server creates received records in batches and acknowledges every
+	// batch with a response containing the result of each record, in the order
+	// the records were sent. Invalid records and records exceeding the project
+	// quota are reported in the results and do not end the stream. The server
+	// receives the next records only after the current batch is created, so
+	// slow storage applies backpressure to the producer.
+	//
+	// The stream ends when the client closes sending and all records are
+	// acknowledged.
+	//
+	// buf:lint:ignore RPC_NO_CLIENT_STREAMING
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	StreamCreateRecords(ctx context.Context, opts ...grpc.CallOption) (RecordService_StreamCreateRecordsClient, error)
 	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordResponse, error)
 	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (*ListRecordsResponse, error)
+	// Streams all records matching the filter, in the same order as
+	// ListRecords, without pagination.
+	//
+	// Over HTTP, records are exported as NDJSON or CSV with
+	// `GET /api/v1alpha1/projects/{project_id}/records:export`. See
+	// "Usage Guide :: Search Records" for details.
+	//
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	ExportRecords(ctx context.Context, in *ExportRecordsRequest, opts ...grpc.CallOption) (RecordService_ExportRecordsClient, error)
+	// Streams records created in the project and matching the filter, as they
+	// are created, until the client cancels the call.
+	//
+	// Every response contains a page token. To resume watching after a
+	// disconnect, send the last received token as `page_token`: records created
+	// after the record of the token are sent first, then new records. Some
+	// records may be sent twice after resuming.
+	//
+	// The call ends with ABORTED status if the client does not receive records
+	// as fast as they are created. The client should resume watching.
+	//
+	// Over HTTP, records are streamed as Server-Sent Events with
+	// `GET /api/v1alpha1/projects/{project_id}/records:watch`. See
+	// "Usage Guide :: Search Records" for details.
+	//
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	WatchRecords(ctx context.Context, in *WatchRecordsRequest, opts ...grpc.CallOption) (RecordService_WatchRecordsClient, error)
 	UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*UpdateRecordResponse, error)
 	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponse, error)
 }
@@ -81,6 +126,38 @@ func (c *recordServiceClient) BatchCreateRecords(ctx context.Context, in *BatchC
 	return out, nil
 }
 
+func (c *recordServiceClient) StreamCreateRecords(ctx context.Context, opts ...grpc.CallOption) (RecordService_StreamCreateRecordsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RecordService_ServiceDesc.Streams[0], RecordService_StreamCreateRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recordServiceStreamCreateRecordsClient{ClientStream: stream}
+	return x, nil
+}
+
+type RecordService_StreamCreateRecordsClient interface {
+	Send(*StreamCreateRecordsRequest) error
+	Recv() (*StreamCreateRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type recordServiceStreamCreateRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *recordServiceStreamCreateRecordsClient) Send(m *StreamCreateRecordsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *recordServiceStreamCreateRecordsClient) Recv() (*StreamCreateRecordsResponse, error) {
+	m := new(StreamCreateRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *recordServiceClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetRecordResponse)
@@ -101,6 +178,72 @@ func (c *recordServiceClient) ListRecords(ctx context.Context, in *ListRecordsRe
 	return out, nil
 }
 
+func (c *recordServiceClient) ExportRecords(ctx context.Context, in *ExportRecordsRequest, opts ...grpc.CallOption) (RecordService_ExportRecordsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RecordService_ServiceDesc.Streams[1], RecordService_ExportRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recordServiceExportRecordsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecordService_ExportRecordsClient interface {
+	Recv() (*ExportRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type recordServiceExportRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *recordServiceExportRecordsClient) Recv() (*ExportRecordsResponse, error) {
+	m := new(ExportRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recordServiceClient) WatchRecords(ctx context.Context, in *WatchRecordsRequest, opts ...grpc.CallOption) (RecordService_WatchRecordsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RecordService_ServiceDesc.Streams[2], RecordService_WatchRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recordServiceWatchRecordsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecordService_WatchRecordsClient interface {
+	Recv() (*WatchRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type recordServiceWatchRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *recordServiceWatchRecordsClient) Recv() (*WatchRecordsResponse, error) {
+	m := new(WatchRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *recordServiceClient) UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*UpdateRecordResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateRecordResponse)
@@ -127,8 +270,50 @@ func (c *recordServiceClient) DeleteRecord(ctx context.Context, in *DeleteRecord
 type RecordServiceServer interface {
 	CreateRecord(context.Context, *CreateRecordRequest) (*CreateRecordResponse, error)
 	BatchCreateRecords(context.Context, *BatchCreateRecordsRequest) (*BatchCreateRecordsResponse, error)
+	// Creates records sent continuously over a single stream, for high-volume
+	// producers.
+	//
+	// The server creates received records in batches and acknowledges every
+	// batch with a response containing the result of each record, in the order
+	// the records were sent. Invalid records and records exceeding the project
+	// quota are reported in the results and do not end the stream. The server
+	// receives the next records only after the current batch is created, so
+	// slow storage applies backpressure to the producer.
+	//
+	// The stream ends when the client closes sending and all records are
+	// acknowledged.
+	//
+	// buf:lint:ignore RPC_NO_CLIENT_STREAMING
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	StreamCreateRecords(RecordService_StreamCreateRecordsServer) error
 	GetRecord(context.Context, *GetRecordRequest) (*GetRecordResponse, error)
 	ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error)
+	// Streams all records matching the filter, in the same order as
+	// ListRecords, without pagination.
+	//
+	// Over HTTP, records are exported as NDJSON or CSV with
+	// `GET /api/v1alpha1/projects/{project_id}/records:export`. See
+	// "Usage Guide :: Search Records" for details.
+	//
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	ExportRecords(*ExportRecordsRequest, RecordService_ExportRecordsServer) error
+	// Streams records created in the project and matching the filter, as they
+	// are created, until the client cancels the call.
+	//
+	// Every response contains a page token. To resume watching after a
+	// disconnect, send the last received token as `page_token`: records created
+	// after the record of the token are sent first, then new records. Some
+	// records may be sent twice after resuming.
+	//
+	// The call ends with ABORTED status if the client does not receive records
+	// as fast as they are created. The client should resume watching.
+	//
+	// Over HTTP, records are streamed as Server-Sent Events with
+	// `GET /api/v1alpha1/projects/{project_id}/records:watch`. See
+	// "Usage Guide :: Search Records" for details.
+	//
+	// buf:lint:ignore RPC_NO_SERVER_STREAMING
+	WatchRecords(*WatchRecordsRequest, RecordService_WatchRecordsServer) error
 	UpdateRecord(context.Context, *UpdateRecordRequest) (*UpdateRecordResponse, error)
 	DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponse, error)
 	mustEmbedUnimplementedRecordServiceServer()
@@ -144,12 +329,21 @@ func (UnimplementedRecordServiceServer) CreateRecord(context.Context, *CreateRec
 func (UnimplementedRecordServiceServer) BatchCreateRecords(context.Context, *BatchCreateRecordsRequest) (*BatchCreateRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateRecords not implemented")
 }
+func (UnimplementedRecordServiceServer) StreamCreateRecords(RecordService_StreamCreateRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCreateRecords not implemented")
+}
 func (UnimplementedRecordServiceServer) GetRecord(context.Context, *GetRecordRequest) (*GetRecordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
 }
 func (UnimplementedRecordServiceServer) ListRecords(context.Context, *ListRecordsRequest) (*ListRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
 }
+func (UnimplementedRecordServiceServer) ExportRecords(*ExportRecordsRequest, RecordService_ExportRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRecords not implemented")
+}
+func (UnimplementedRecordServiceServer) WatchRecords(*WatchRecordsRequest, RecordService_WatchRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRecords not implemented")
+}
 func (UnimplementedRecordServiceServer) UpdateRecord(context.Context, *UpdateRecordRequest) (*UpdateRecordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecord not implemented")
 }
@@ -205,6 +399,32 @@ func _RecordService_BatchCreateRecords_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RecordService_StreamCreateRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RecordServiceServer).StreamCreateRecords(&recordServiceStreamCreateRecordsServer{ServerStream: stream})
+}
+
+type RecordService_StreamCreateRecordsServer interface {
+	Send(*StreamCreateRecordsResponse) error
+	Recv() (*StreamCreateRecordsRequest, error)
+	grpc.ServerStream
+}
+
+type recordServiceStreamCreateRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *recordServiceStreamCreateRecordsServer) Send(m *StreamCreateRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *recordServiceStreamCreateRecordsServer) Recv() (*StreamCreateRecordsRequest, error) {
+	m := new(StreamCreateRecordsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _RecordService_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRecordRequest)
 	if err := dec(in); err != nil {
@@ -241,6 +461,48 @@ func _RecordService_ListRecords_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RecordService_ExportRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecordServiceServer).ExportRecords(m, &recordServiceExportRecordsServer{ServerStream: stream})
+}
+
+type RecordService_ExportRecordsServer interface {
+	Send(*ExportRecordsResponse) error
+	grpc.ServerStream
+}
+
+type recordServiceExportRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *recordServiceExportRecordsServer) Send(m *ExportRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecordService_WatchRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecordServiceServer).WatchRecords(m, &recordServiceWatchRecordsServer{ServerStream: stream})
+}
+
+type RecordService_WatchRecordsServer interface {
+	Send(*WatchRecordsResponse) error
+	grpc.ServerStream
+}
+
+type recordServiceWatchRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *recordServiceWatchRecordsServer) Send(m *WatchRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RecordService_UpdateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateRecordRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +571,23 @@ var RecordService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _RecordService_DeleteRecord_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCreateRecords",
+			Handler:       _RecordService_StreamCreateRecords_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportRecords",
+			Handler:       _RecordService_ExportRecords_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRecords",
+			Handler:       _RecordService_WatchRecords_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "auditumio/auditum/v1alpha1/record_service.proto",
 }
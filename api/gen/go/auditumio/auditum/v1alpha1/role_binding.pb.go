@@ -0,0 +1,369 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: auditumio/auditum/v1alpha1/role_binding.proto
+
+package auditumv1alpha1
+
+import (
+	_ "github.com/auditumio/auditum/api/gen/go/google/api"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Enumerates available roles. Each role includes all permissions of the
+// previous roles.
+type ProjectRole_Enum int32
+
+const (
+	// Role not provided.
+	ProjectRole_UNSPECIFIED ProjectRole_Enum = 0
+	// Allows getting the project and reading its records.
+	ProjectRole_VIEWER ProjectRole_Enum = 1
+	// Allows creating records.
+	ProjectRole_WRITER ProjectRole_Enum = 2
+	// Allows updating and deleting records.
+	ProjectRole_EDITOR ProjectRole_Enum = 3
+	// Allows updating the project and managing its role bindings.
+	ProjectRole_ADMIN ProjectRole_Enum = 4
+)
+
+// Enum value maps for ProjectRole_Enum.
+var (
+	ProjectRole_Enum_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "VIEWER",
+		2: "WRITER",
+		3: "EDITOR",
+		4: "ADMIN",
+	}
+	ProjectRole_Enum_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"VIEWER":      1,
+		"WRITER":      2,
+		"EDITOR":      3,
+		"ADMIN":       4,
+	}
+)
+
+func (x ProjectRole_Enum) Enum() *ProjectRole_Enum {
+	p := new(ProjectRole_Enum)
+	*p = x
+	return p
+}
+
+func (x ProjectRole_Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectRole_Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_auditumio_auditum_v1alpha1_role_binding_proto_enumTypes[0].Descriptor()
+}
+
+func (ProjectRole_Enum) Type() protoreflect.EnumType {
+	return &file_auditumio_auditum_v1alpha1_role_binding_proto_enumTypes[0]
+}
+
+func (x ProjectRole_Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectRole_Enum.Descriptor instead.
+func (ProjectRole_Enum) EnumDescriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// Represents a role of a principal in a project.
+//
+// A principal has at most one role in a project.
+type RoleBinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Project identifier.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Principal identifier, e.g. `apikey:<id>` for API keys, `jwt:<subject>`
+	// for JWT bearer tokens or `cert:<subject>` for client certificates.
+	//
+	// REQUIREMENTS.
+	// The value must start with `apikey:`, `jwt:` or `cert:`,
+	// and be at most 256 characters long.
+	PrincipalId string `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3" json:"principal_id,omitempty"`
+	// Role of the principal in the project.
+	Role ProjectRole_Enum `protobuf:"varint,3,opt,name=role,proto3,enum=auditumio.auditum.v1alpha1.ProjectRole_Enum" json:"role,omitempty"`
+	// Time when the role binding was created.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Time when the role was last changed.
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *RoleBinding) Reset() {
+	*x = RoleBinding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoleBinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleBinding) ProtoMessage() {}
+
+func (x *RoleBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleBinding.ProtoReflect.Descriptor instead.
+func (*RoleBinding) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RoleBinding) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *RoleBinding) GetPrincipalId() string {
+	if x != nil {
+		return x.PrincipalId
+	}
+	return ""
+}
+
+func (x *RoleBinding) GetRole() ProjectRole_Enum {
+	if x != nil {
+		return x.Role
+	}
+	return ProjectRole_UNSPECIFIED
+}
+
+func (x *RoleBinding) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *RoleBinding) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// Wraps project role enumeration.
+type ProjectRole struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProjectRole) Reset() {
+	*x = ProjectRole{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectRole) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectRole) ProtoMessage() {}
+
+func (x *ProjectRole) ProtoReflect() protoreflect.Message {
+	mi := &file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectRole.ProtoReflect.Descriptor instead.
+func (*ProjectRole) Descriptor() ([]byte, []int) {
+	return file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescGZIP(), []int{1}
+}
+
+var File_auditumio_auditum_v1alpha1_role_binding_proto protoreflect.FileDescriptor
+
+var file_auditumio_auditum_v1alpha1_role_binding_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1a, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa9, 0x02,
+	0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x27, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x02, 0x52, 0x0b,
+	0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x46, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x6f,
+	0x6c, 0x65, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x02, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x03, 0x52, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x55, 0x0a, 0x0b, 0x50, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x46, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d,
+	0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x56, 0x49, 0x45, 0x57, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x57, 0x52, 0x49, 0x54, 0x45, 0x52, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x44, 0x49,
+	0x54, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x04,
+	0x42, 0x90, 0x02, 0x0a, 0x1e, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d,
+	0x69, 0x6f, 0x2e, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x42, 0x10, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
+	0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x75, 0x6d, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x41,
+	0x58, 0xaa, 0x02, 0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x2e, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02,
+	0x1a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x75, 0x6d, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x26, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f, 0x5c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x5c,
+	0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x69, 0x6f,
+	0x3a, 0x3a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x75, 0x6d, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescOnce sync.Once
+	file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescData = file_auditumio_auditum_v1alpha1_role_binding_proto_rawDesc
+)
+
+func file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescGZIP() []byte {
+	file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescOnce.Do(func() {
+		file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescData = protoimpl.X.CompressGZIP(file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescData)
+	})
+	return file_auditumio_auditum_v1alpha1_role_binding_proto_rawDescData
+}
+
+var file_auditumio_auditum_v1alpha1_role_binding_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_auditumio_auditum_v1alpha1_role_binding_proto_goTypes = []any{
+	(ProjectRole_Enum)(0),         // 0: auditumio.auditum.v1alpha1.ProjectRole.Enum
+	(*RoleBinding)(nil),           // 1: auditumio.auditum.v1alpha1.RoleBinding
+	(*ProjectRole)(nil),           // 2: auditumio.auditum.v1alpha1.ProjectRole
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_auditumio_auditum_v1alpha1_role_binding_proto_depIdxs = []int32{
+	0, // 0: auditumio.auditum.v1alpha1.RoleBinding.role:type_name -> auditumio.auditum.v1alpha1.ProjectRole.Enum
+	3, // 1: auditumio.auditum.v1alpha1.RoleBinding.create_time:type_name -> google.protobuf.Timestamp
+	3, // 2: auditumio.auditum.v1alpha1.RoleBinding.update_time:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_auditumio_auditum_v1alpha1_role_binding_proto_init() }
+func file_auditumio_auditum_v1alpha1_role_binding_proto_init() {
+	if File_auditumio_auditum_v1alpha1_role_binding_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*RoleBinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ProjectRole); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_auditumio_auditum_v1alpha1_role_binding_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auditumio_auditum_v1alpha1_role_binding_proto_goTypes,
+		DependencyIndexes: file_auditumio_auditum_v1alpha1_role_binding_proto_depIdxs,
+		EnumInfos:         file_auditumio_auditum_v1alpha1_role_binding_proto_enumTypes,
+		MessageInfos:      file_auditumio_auditum_v1alpha1_role_binding_proto_msgTypes,
+	}.Build()
+	File_auditumio_auditum_v1alpha1_role_binding_proto = out.File
+	file_auditumio_auditum_v1alpha1_role_binding_proto_rawDesc = nil
+	file_auditumio_auditum_v1alpha1_role_binding_proto_goTypes = nil
+	file_auditumio_auditum_v1alpha1_role_binding_proto_depIdxs = nil
+}
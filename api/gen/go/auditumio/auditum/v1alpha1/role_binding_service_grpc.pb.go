@@ -0,0 +1,200 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: auditumio/auditum/v1alpha1/role_binding_service.proto
+
+package auditumv1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	RoleBindingService_SetRoleBinding_FullMethodName    = "/auditumio.auditum.v1alpha1.RoleBindingService/SetRoleBinding"
+	RoleBindingService_ListRoleBindings_FullMethodName  = "/auditumio.auditum.v1alpha1.RoleBindingService/ListRoleBindings"
+	RoleBindingService_DeleteRoleBinding_FullMethodName = "/auditumio.auditum.v1alpha1.RoleBindingService/DeleteRoleBinding"
+)
+
+// RoleBindingServiceClient is the client API for RoleBindingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoleBindingServiceClient interface {
+	SetRoleBinding(ctx context.Context, in *SetRoleBindingRequest, opts ...grpc.CallOption) (*SetRoleBindingResponse, error)
+	ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListRoleBindingsResponse, error)
+	DeleteRoleBinding(ctx context.Context, in *DeleteRoleBindingRequest, opts ...grpc.CallOption) (*DeleteRoleBindingResponse, error)
+}
+
+type roleBindingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoleBindingServiceClient(cc grpc.ClientConnInterface) RoleBindingServiceClient {
+	return &roleBindingServiceClient{cc}
+}
+
+func (c *roleBindingServiceClient) SetRoleBinding(ctx context.Context, in *SetRoleBindingRequest, opts ...grpc.CallOption) (*SetRoleBindingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRoleBindingResponse)
+	err := c.cc.Invoke(ctx, RoleBindingService_SetRoleBinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleBindingServiceClient) ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListRoleBindingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoleBindingsResponse)
+	err := c.cc.Invoke(ctx, RoleBindingService_ListRoleBindings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleBindingServiceClient) DeleteRoleBinding(ctx context.Context, in *DeleteRoleBindingRequest, opts ...grpc.CallOption) (*DeleteRoleBindingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRoleBindingResponse)
+	err := c.cc.Invoke(ctx, RoleBindingService_DeleteRoleBinding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoleBindingServiceServer is the server API for RoleBindingService service.
+// All implementations must embed UnimplementedRoleBindingServiceServer
+// for forward compatibility
+type RoleBindingServiceServer interface {
+	SetRoleBinding(context.Context, *SetRoleBindingRequest) (*SetRoleBindingResponse, error)
+	ListRoleBindings(context.Context, *ListRoleBindingsRequest) (*ListRoleBindingsResponse, error)
+	DeleteRoleBinding(context.Context, *DeleteRoleBindingRequest) (*DeleteRoleBindingResponse, error)
+	mustEmbedUnimplementedRoleBindingServiceServer()
+}
+
+// UnimplementedRoleBindingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRoleBindingServiceServer struct {
+}
+
+func (UnimplementedRoleBindingServiceServer) SetRoleBinding(context.Context, *SetRoleBindingRequest) (*SetRoleBindingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleBinding not implemented")
+}
+func (UnimplementedRoleBindingServiceServer) ListRoleBindings(context.Context, *ListRoleBindingsRequest) (*ListRoleBindingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleBindings not implemented")
+}
+func (UnimplementedRoleBindingServiceServer) DeleteRoleBinding(context.Context, *DeleteRoleBindingRequest) (*DeleteRoleBindingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoleBinding not implemented")
+}
+func (UnimplementedRoleBindingServiceServer) mustEmbedUnimplementedRoleBindingServiceServer() {}
+
+// UnsafeRoleBindingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoleBindingServiceServer will
+// result in compilation errors.
+type UnsafeRoleBindingServiceServer interface {
+	mustEmbedUnimplementedRoleBindingServiceServer()
+}
+
+func RegisterRoleBindingServiceServer(s grpc.ServiceRegistrar, srv RoleBindingServiceServer) {
+	s.RegisterService(&RoleBindingService_ServiceDesc, srv)
+}
+
+func _RoleBindingService_SetRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleBindingServiceServer).SetRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleBindingService_SetRoleBinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleBindingServiceServer).SetRoleBinding(ctx, req.(*SetRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleBindingService_ListRoleBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleBindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleBindingServiceServer).ListRoleBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleBindingService_ListRoleBindings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleBindingServiceServer).ListRoleBindings(ctx, req.(*ListRoleBindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleBindingService_DeleteRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleBindingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleBindingServiceServer).DeleteRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleBindingService_DeleteRoleBinding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleBindingServiceServer).DeleteRoleBinding(ctx, req.(*DeleteRoleBindingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RoleBindingService_ServiceDesc is the grpc.ServiceDesc for RoleBindingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoleBindingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "auditumio.auditum.v1alpha1.RoleBindingService",
+	HandlerType: (*RoleBindingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetRoleBinding",
+			Handler:    _RoleBindingService_SetRoleBinding_Handler,
+		},
+		{
+			MethodName: "ListRoleBindings",
+			Handler:    _RoleBindingService_ListRoleBindings_Handler,
+		},
+		{
+			MethodName: "DeleteRoleBinding",
+			Handler:    _RoleBindingService_DeleteRoleBinding_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auditumio/auditum/v1alpha1/role_binding_service.proto",
+}
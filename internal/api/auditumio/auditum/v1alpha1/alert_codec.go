@@ -0,0 +1,207 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+const (
+	alertRuleMaxCount   = 1000
+	alertRuleMinSteps   = 2
+	alertRuleMaxSteps   = 10
+	alertRuleMaxGroupBy = 5
+	alertRuleMinWindow  = time.Second
+	alertRuleMaxWindow  = 30 * 24 * time.Hour
+)
+
+func decodeAlertRule(src *auditumv1alpha1.AlertRule) (dst aud.AlertRule, err error) {
+	// Display names of alert rules have the same requirements as of projects.
+	if err := validateProjectDisplayName(src.GetDisplayName()); err != nil {
+		return dst, fmt.Errorf(`invalid "display_name": %v`, err)
+	}
+
+	switch {
+	case src.GetThreshold() != nil && src.GetSequence() != nil:
+		return dst, fmt.Errorf(`only one of "threshold" and "sequence" must be set`)
+	case src.GetThreshold() != nil:
+		threshold, err := decodeAlertRuleThreshold(src.GetThreshold())
+		if err != nil {
+			return dst, fmt.Errorf(`invalid "threshold": %v`, err)
+		}
+		dst.Threshold = &threshold
+	case src.GetSequence() != nil:
+		sequence, err := decodeAlertRuleSequence(src.GetSequence())
+		if err != nil {
+			return dst, fmt.Errorf(`invalid "sequence": %v`, err)
+		}
+		dst.Sequence = &sequence
+	default:
+		return dst, fmt.Errorf(`one of "threshold" and "sequence" must be set`)
+	}
+
+	if len(src.GetGroupBy()) > alertRuleMaxGroupBy {
+		return dst, fmt.Errorf(`invalid "group_by": must have at most %d items`, alertRuleMaxGroupBy)
+	}
+	for i, field := range src.GetGroupBy() {
+		if err := aud.ValidateAlertRuleGroupBy(field); err != nil {
+			return dst, fmt.Errorf(`invalid "group_by": item %d: %v`, i, err)
+		}
+	}
+
+	window, err := decodeAlertRuleWindow(src.GetWindow())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "window": %v`, err)
+	}
+
+	dst.DisplayName = src.GetDisplayName()
+	dst.GroupBy = src.GetGroupBy()
+	dst.Window = window
+
+	return dst, nil
+}
+
+func decodeAlertRuleThreshold(src *auditumv1alpha1.AlertRule_Threshold) (dst aud.AlertRuleThreshold, err error) {
+	if _, err := decodeFilterExpression(src.GetFilter()); err != nil {
+		return dst, fmt.Errorf(`invalid "filter": %v`, err)
+	}
+
+	if src.GetCount() < 1 || src.GetCount() > alertRuleMaxCount {
+		return dst, fmt.Errorf(`invalid "count": must be in range 1-%d`, alertRuleMaxCount)
+	}
+
+	return aud.AlertRuleThreshold{
+		Filter: src.GetFilter(),
+		Count:  int(src.GetCount()),
+	}, nil
+}
+
+func decodeAlertRuleSequence(src *auditumv1alpha1.AlertRule_Sequence) (dst aud.AlertRuleSequence, err error) {
+	steps := src.GetSteps()
+	if len(steps) < alertRuleMinSteps || len(steps) > alertRuleMaxSteps {
+		return dst, fmt.Errorf(`invalid "steps": must have %d-%d items`, alertRuleMinSteps, alertRuleMaxSteps)
+	}
+
+	for i, step := range steps {
+		if step == "" {
+			return dst, fmt.Errorf(`invalid "steps": item %d: must not be empty`, i)
+		}
+		if _, err := decodeFilterExpression(step); err != nil {
+			return dst, fmt.Errorf(`invalid "steps": item %d: %v`, i, err)
+		}
+	}
+
+	return aud.AlertRuleSequence{
+		Steps: steps,
+	}, nil
+}
+
+func decodeAlertRuleWindow(src *durationpb.Duration) (time.Duration, error) {
+	if src == nil {
+		return 0, fmt.Errorf("must be set")
+	}
+	if err := src.CheckValid(); err != nil {
+		return 0, fmt.Errorf("must be a valid duration")
+	}
+
+	window := src.AsDuration()
+	if window%time.Second != 0 {
+		return 0, fmt.Errorf("must be whole seconds")
+	}
+	if window < alertRuleMinWindow || window > alertRuleMaxWindow {
+		return 0, fmt.Errorf("must be from %s to %s", alertRuleMinWindow, alertRuleMaxWindow)
+	}
+
+	return window, nil
+}
+
+func decodeAlertFilter(src *auditumv1alpha1.ListAlertsRequest_Filter) (dst aud.AlertFilter, err error) {
+	if src.GetRuleId() != "" {
+		id, err := decodeID(src.GetRuleId())
+		if err != nil {
+			return dst, fmt.Errorf(`invalid "rule_id": %v`, err)
+		}
+		dst.RuleID = &id
+	}
+
+	return dst, nil
+}
+
+func encodeAlertRule(src aud.AlertRule) *auditumv1alpha1.AlertRule {
+	dst := &auditumv1alpha1.AlertRule{
+		Id:          src.ID.String(),
+		ProjectId:   src.ProjectID.String(),
+		CreateTime:  timestamppb.New(src.CreateTime),
+		DisplayName: src.DisplayName,
+		GroupBy:     src.GroupBy,
+		Window:      durationpb.New(src.Window),
+	}
+
+	if src.Threshold != nil {
+		dst.Threshold = &auditumv1alpha1.AlertRule_Threshold{
+			Filter: src.Threshold.Filter,
+			Count:  int32(src.Threshold.Count),
+		}
+	}
+
+	if src.Sequence != nil {
+		dst.Sequence = &auditumv1alpha1.AlertRule_Sequence{
+			Steps: src.Sequence.Steps,
+		}
+	}
+
+	return dst
+}
+
+func encodeAlertRules(src []aud.AlertRule) []*auditumv1alpha1.AlertRule {
+	dst := make([]*auditumv1alpha1.AlertRule, len(src))
+	for i := range src {
+		dst[i] = encodeAlertRule(src[i])
+	}
+	return dst
+}
+
+func encodeAlert(src aud.Alert) *auditumv1alpha1.Alert {
+	recordIDs := make([]string, len(src.RecordIDs))
+	for i, id := range src.RecordIDs {
+		recordIDs[i] = id.String()
+	}
+
+	return &auditumv1alpha1.Alert{
+		Id:                 src.ID.String(),
+		ProjectId:          src.ProjectID.String(),
+		RuleId:             src.RuleID.String(),
+		CreateTime:         timestamppb.New(src.CreateTime),
+		Group:              src.Group,
+		RecordIds:          recordIDs,
+		FirstOperationTime: timestamppb.New(src.FirstOperationTime),
+		LastOperationTime:  timestamppb.New(src.LastOperationTime),
+	}
+}
+
+func encodeAlerts(src []aud.Alert) []*auditumv1alpha1.Alert {
+	dst := make([]*auditumv1alpha1.Alert, len(src))
+	for i := range src {
+		dst[i] = encodeAlert(src[i])
+	}
+	return dst
+}
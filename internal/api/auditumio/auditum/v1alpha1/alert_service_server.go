@@ -0,0 +1,280 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/pkg/fragma/grpcx"
+)
+
+type AlertServiceServer struct {
+	auditumv1alpha1.UnimplementedAlertServiceServer
+
+	store Store
+	log   *zap.Logger
+
+	id  func() aud.ID
+	now func() time.Time
+}
+
+func NewAlertServiceServer(
+	store Store,
+	log *zap.Logger,
+) *AlertServiceServer {
+	return &AlertServiceServer{
+		store: store,
+		log:   log.Named("alert_service_server"),
+		id:    aud.MustNewID,
+		now:   time.Now,
+	}
+}
+
+func (s *AlertServiceServer) CreateAlertRule(
+	ctx context.Context,
+	req *auditumv1alpha1.CreateAlertRuleRequest,
+) (*auditumv1alpha1.CreateAlertRuleResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	rule, err := decodeAlertRule(req.GetAlertRule())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "alert_rule": %v.`,
+			err.Error(),
+		)
+	}
+
+	rule.ID = s.id()
+	rule.ProjectID = projectID
+	rule.CreateTime = s.now().UTC()
+
+	err = s.store.CreateAlertRule(ctx, rule)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("Create alert rule in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.CreateAlertRuleResponse{
+		AlertRule: encodeAlertRule(rule),
+	}, nil
+}
+
+func (s *AlertServiceServer) GetAlertRule(
+	ctx context.Context,
+	req *auditumv1alpha1.GetAlertRuleRequest,
+) (*auditumv1alpha1.GetAlertRuleResponse, error) {
+	projectID, ruleID, err := decodeAlertRuleIDs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.store.GetAlertRule(ctx, projectID, ruleID)
+	if errors.Is(err, aud.ErrAlertRuleNotFound) {
+		return nil, status.Error(codes.NotFound, "Alert rule not found.")
+	}
+	if err != nil {
+		s.log.Error("Get alert rule from store",
+			zap.String("project_id", projectID.String()),
+			zap.String("alert_rule_id", ruleID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.GetAlertRuleResponse{
+		AlertRule: encodeAlertRule(rule),
+	}, nil
+}
+
+func (s *AlertServiceServer) ListAlertRules(
+	ctx context.Context,
+	req *auditumv1alpha1.ListAlertRulesRequest,
+) (*auditumv1alpha1.ListAlertRulesResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	rules, err := s.store.ListAlertRules(ctx, projectID)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("List alert rules in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListAlertRulesResponse{
+		AlertRules: encodeAlertRules(rules),
+	}, nil
+}
+
+func (s *AlertServiceServer) DeleteAlertRule(
+	ctx context.Context,
+	req *auditumv1alpha1.DeleteAlertRuleRequest,
+) (*auditumv1alpha1.DeleteAlertRuleResponse, error) {
+	projectID, ruleID, err := decodeAlertRuleIDs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.DeleteAlertRule(ctx, projectID, ruleID)
+	if errors.Is(err, aud.ErrAlertRuleNotFound) {
+		return nil, status.Error(codes.NotFound, "Alert rule not found.")
+	}
+	if err != nil {
+		s.log.Error("Delete alert rule from store",
+			zap.String("project_id", projectID.String()),
+			zap.String("alert_rule_id", ruleID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.DeleteAlertRuleResponse{}, nil
+}
+
+func (s *AlertServiceServer) ListAlerts(
+	ctx context.Context,
+	req *auditumv1alpha1.ListAlertsRequest,
+) (*auditumv1alpha1.ListAlertsResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	filter, err := decodeAlertFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter": %v.`,
+			err.Error(),
+		)
+	}
+
+	const (
+		defaultPageSize = 10
+		maxPageSize     = 100
+	)
+	pageSize, err := grpcx.GetPageSize(defaultPageSize, maxPageSize, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor aud.AlertCursor
+	if err := aud.DecodePageToken(req.GetPageToken(), &cursor); err != nil {
+		s.log.Warn("Decode page token", zap.Error(err))
+		return nil, status.Error(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "page_token".`,
+		)
+	}
+
+	alerts, err := s.store.ListAlerts(ctx, projectID, filter, pageSize, cursor)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("List alerts in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	cursor = aud.NewAlertCursor(alerts, pageSize)
+	nextPageToken, err := aud.EncodePageToken(cursor)
+	if err != nil {
+		s.log.Error("Encode page token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListAlertsResponse{
+		Alerts:        encodeAlerts(alerts),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *AlertServiceServer) RegisterServer(srv *grpc.Server) {
+	auditumv1alpha1.RegisterAlertServiceServer(srv, s)
+}
+
+func (s *AlertServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return auditumv1alpha1.RegisterAlertServiceHandler(ctx, mux, conn)
+}
+
+type alertRuleRequest interface {
+	GetProjectId() string
+	GetAlertRuleId() string
+}
+
+// decodeAlertRuleIDs returns project and alert rule identifiers of the
+// request. The returned error is a gRPC status.
+func decodeAlertRuleIDs(req alertRuleRequest) (projectID aud.ID, ruleID aud.ID, err error) {
+	projectID, err = decodeID(req.GetProjectId())
+	if err != nil {
+		return projectID, ruleID, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	ruleID, err = decodeID(req.GetAlertRuleId())
+	if err != nil {
+		return projectID, ruleID, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "alert_rule_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	return projectID, ruleID, nil
+}
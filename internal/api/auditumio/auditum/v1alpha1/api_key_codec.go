@@ -0,0 +1,136 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func decodeAPIKey(src *auditumv1alpha1.ApiKey) (dst aud.APIKey, err error) {
+	// Display names of API keys have the same requirements as of projects.
+	if err := validateProjectDisplayName(src.GetDisplayName()); err != nil {
+		return dst, fmt.Errorf(`invalid "display_name": %v`, err)
+	}
+
+	projectIDs, err := decodeAPIKeyProjectIDs(src.GetProjectIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "project_ids": %v`, err)
+	}
+
+	permissions, err := decodeAPIKeyPermissions(src.GetPermissions())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "permissions": %v`, err)
+	}
+
+	return aud.APIKey{
+		DisplayName: src.GetDisplayName(),
+		ProjectIDs:  projectIDs,
+		Permissions: permissions,
+	}, nil
+}
+
+func decodeAPIKeyProjectIDs(src []string) ([]aud.ID, error) {
+	var dst []aud.ID
+	for i, s := range src {
+		id, err := decodeID(s)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %v", i, err)
+		}
+		if !slices.Contains(dst, id) {
+			dst = append(dst, id)
+		}
+	}
+	return dst, nil
+}
+
+func decodeAPIKeyPermissions(src []auditumv1alpha1.ApiKeyPermission_Enum) ([]aud.Permission, error) {
+	if len(src) == 0 {
+		return nil, fmt.Errorf("must not be empty")
+	}
+
+	var dst []aud.Permission
+	for i, s := range src {
+		p := decodeAPIKeyPermission(s)
+		if p == aud.PermissionUnspecified {
+			return nil, fmt.Errorf("item %d: must be specified", i)
+		}
+		if !slices.Contains(dst, p) {
+			dst = append(dst, p)
+		}
+	}
+	return dst, nil
+}
+
+func decodeAPIKeyPermission(src auditumv1alpha1.ApiKeyPermission_Enum) aud.Permission {
+	switch src {
+	case auditumv1alpha1.ApiKeyPermission_READ:
+		return aud.PermissionRead
+	case auditumv1alpha1.ApiKeyPermission_WRITE:
+		return aud.PermissionWrite
+	case auditumv1alpha1.ApiKeyPermission_ADMIN:
+		return aud.PermissionAdmin
+	default:
+		return aud.PermissionUnspecified
+	}
+}
+
+func encodeAPIKey(src aud.APIKey) *auditumv1alpha1.ApiKey {
+	projectIDs := make([]string, len(src.ProjectIDs))
+	for i, id := range src.ProjectIDs {
+		projectIDs[i] = id.String()
+	}
+
+	permissions := make([]auditumv1alpha1.ApiKeyPermission_Enum, len(src.Permissions))
+	for i, p := range src.Permissions {
+		permissions[i] = encodeAPIKeyPermission(p)
+	}
+
+	return &auditumv1alpha1.ApiKey{
+		Id:          src.ID.String(),
+		CreateTime:  timestamppb.New(src.CreateTime),
+		DisplayName: src.DisplayName,
+		KeyPrefix:   src.KeyPrefix,
+		ProjectIds:  projectIDs,
+		Permissions: permissions,
+		RevokeTime:  encodeOptionalTime(src.RevokeTime),
+	}
+}
+
+func encodeAPIKeys(src []aud.APIKey) []*auditumv1alpha1.ApiKey {
+	dst := make([]*auditumv1alpha1.ApiKey, len(src))
+	for i := range src {
+		dst[i] = encodeAPIKey(src[i])
+	}
+	return dst
+}
+
+func encodeAPIKeyPermission(src aud.Permission) auditumv1alpha1.ApiKeyPermission_Enum {
+	switch src {
+	case aud.PermissionRead:
+		return auditumv1alpha1.ApiKeyPermission_READ
+	case aud.PermissionWrite:
+		return auditumv1alpha1.ApiKeyPermission_WRITE
+	case aud.PermissionAdmin:
+		return auditumv1alpha1.ApiKeyPermission_ADMIN
+	default:
+		return auditumv1alpha1.ApiKeyPermission_UNSPECIFIED
+	}
+}
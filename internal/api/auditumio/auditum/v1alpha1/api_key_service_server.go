@@ -0,0 +1,183 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/pkg/fragma/grpcx"
+)
+
+type ApiKeyServiceServer struct {
+	auditumv1alpha1.UnimplementedApiKeyServiceServer
+
+	store Store
+	log   *zap.Logger
+
+	id  func() aud.ID
+	now func() time.Time
+}
+
+func NewApiKeyServiceServer(
+	store Store,
+	log *zap.Logger,
+) *ApiKeyServiceServer {
+	return &ApiKeyServiceServer{
+		store: store,
+		log:   log.Named("api_key_service_server"),
+		id:    aud.MustNewID,
+		now:   time.Now,
+	}
+}
+
+func (s *ApiKeyServiceServer) CreateApiKey(
+	ctx context.Context,
+	req *auditumv1alpha1.CreateApiKeyRequest,
+) (*auditumv1alpha1.CreateApiKeyResponse, error) {
+	src, err := decodeAPIKey(req.GetApiKey())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "api_key": %v.`,
+			err.Error(),
+		)
+	}
+
+	for _, projectID := range src.ProjectIDs {
+		_, err := s.store.GetProject(ctx, projectID)
+		if errors.Is(err, aud.ErrProjectNotFound) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				`Request is invalid. Invalid "api_key": invalid "project_ids": project %s not found.`,
+				projectID.String(),
+			)
+		}
+		if err != nil {
+			s.log.Error("Get project from store", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "")
+		}
+	}
+
+	key, secret, err := aud.NewAPIKey(
+		s.id(),
+		s.now().UTC(),
+		src.DisplayName,
+		src.ProjectIDs,
+		src.Permissions,
+	)
+	if err != nil {
+		s.log.Error("Generate api key", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	if err := s.store.CreateAPIKey(ctx, key); err != nil {
+		s.log.Error("Create api key in store", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.CreateApiKeyResponse{
+		ApiKey: encodeAPIKey(key),
+		Key:    secret,
+	}, nil
+}
+
+func (s *ApiKeyServiceServer) ListApiKeys(
+	ctx context.Context,
+	req *auditumv1alpha1.ListApiKeysRequest,
+) (*auditumv1alpha1.ListApiKeysResponse, error) {
+	const (
+		defaultPageSize = 10
+		maxPageSize     = 100
+	)
+	pageSize, err := grpcx.GetPageSize(defaultPageSize, maxPageSize, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor aud.APIKeyCursor
+	if err := aud.DecodePageToken(req.GetPageToken(), &cursor); err != nil {
+		s.log.Warn("Decode page token", zap.Error(err))
+		return nil, status.Error(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "page_token".`,
+		)
+	}
+
+	keys, err := s.store.ListAPIKeys(ctx, pageSize, cursor)
+	if err != nil {
+		s.log.Error("List api keys in store", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	cursor = aud.NewAPIKeyCursor(keys, pageSize)
+	nextPageToken, err := aud.EncodePageToken(cursor)
+	if err != nil {
+		s.log.Error("Encode page token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListApiKeysResponse{
+		ApiKeys:       encodeAPIKeys(keys),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *ApiKeyServiceServer) RevokeApiKey(
+	ctx context.Context,
+	req *auditumv1alpha1.RevokeApiKeyRequest,
+) (*auditumv1alpha1.RevokeApiKeyResponse, error) {
+	id, err := decodeID(req.GetApiKeyId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "api_key_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	key, err := s.store.RevokeAPIKey(ctx, id, s.now().UTC())
+	if errors.Is(err, aud.ErrAPIKeyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("Revoke api key in store",
+			zap.String("api_key_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.RevokeApiKeyResponse{
+		ApiKey: encodeAPIKey(key),
+	}, nil
+}
+
+func (s *ApiKeyServiceServer) RegisterServer(srv *grpc.Server) {
+	auditumv1alpha1.RegisterApiKeyServiceServer(srv, s)
+}
+
+func (s *ApiKeyServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return auditumv1alpha1.RegisterApiKeyServiceHandler(ctx, mux, conn)
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+)
+
+// AuthRules returns access rules of the API methods.
+func AuthRules() map[string]auth.Rule {
+	return map[string]auth.Rule{
+		// Projects.
+		auditumv1alpha1.ProjectService_CreateProject_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+		},
+		auditumv1alpha1.ProjectService_GetProject_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.ProjectService_ListProjects_FullMethodName: {
+			// Lists only projects visible to the principal.
+			AnyPrincipal: true,
+		},
+		auditumv1alpha1.ProjectService_UpdateProject_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID: func(req any) string {
+				return req.(*auditumv1alpha1.UpdateProjectRequest).GetProject().GetId()
+			},
+		},
+		auditumv1alpha1.ProjectService_GetProjectUsage_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.ProjectService_GetProjectStats_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+
+		// Records.
+		auditumv1alpha1.RecordService_CreateRecord_FullMethodName: {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleWriter,
+			ProjectID: func(req any) string {
+				return req.(*auditumv1alpha1.CreateRecordRequest).GetRecord().GetProjectId()
+			},
+		},
+		auditumv1alpha1.RecordService_BatchCreateRecords_FullMethodName: {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleWriter,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_StreamCreateRecords_FullMethodName: {
+			// Every message of the stream is authorized.
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleWriter,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_GetRecord_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_ListRecords_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_ExportRecords_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_WatchRecords_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RecordService_UpdateRecord_FullMethodName: {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleEditor,
+			ProjectID: func(req any) string {
+				return req.(*auditumv1alpha1.UpdateRecordRequest).GetRecord().GetProjectId()
+			},
+		},
+		auditumv1alpha1.RecordService_DeleteRecord_FullMethodName: {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleEditor,
+			ProjectID:  requestProjectID,
+		},
+
+		// Role bindings.
+		auditumv1alpha1.RoleBindingService_SetRoleBinding_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RoleBindingService_ListRoleBindings_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.RoleBindingService_DeleteRoleBinding_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+
+		// Webhooks.
+		auditumv1alpha1.WebhookService_CreateWebhook_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.WebhookService_GetWebhook_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.WebhookService_ListWebhooks_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.WebhookService_DeleteWebhook_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.WebhookService_ListWebhookDeliveries_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+
+		// Alerts.
+		auditumv1alpha1.AlertService_CreateAlertRule_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.AlertService_GetAlertRule_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.AlertService_ListAlertRules_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.AlertService_DeleteAlertRule_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+			Role:       aud.RoleAdmin,
+			ProjectID:  requestProjectID,
+		},
+		auditumv1alpha1.AlertService_ListAlerts_FullMethodName: {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  requestProjectID,
+		},
+
+		// API keys.
+		auditumv1alpha1.ApiKeyService_CreateApiKey_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+		},
+		auditumv1alpha1.ApiKeyService_ListApiKeys_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+		},
+		auditumv1alpha1.ApiKeyService_RevokeApiKey_FullMethodName: {
+			Permission: aud.PermissionAdmin,
+		},
+	}
+}
+
+func requestProjectID(req any) string {
+	if r, ok := req.(interface{ GetProjectId() string }); ok {
+		return r.GetProjectId()
+	}
+	return ""
+}
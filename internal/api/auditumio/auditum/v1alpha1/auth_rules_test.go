@@ -0,0 +1,59 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	auditumv1alpha1pb "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	auditumv1alpha1 "github.com/auditumio/auditum/internal/api/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestAuthRules(t *testing.T) {
+	rules := auditumv1alpha1.AuthRules()
+
+	descs := []grpc.ServiceDesc{
+		auditumv1alpha1pb.ProjectService_ServiceDesc,
+		auditumv1alpha1pb.RecordService_ServiceDesc,
+		auditumv1alpha1pb.ApiKeyService_ServiceDesc,
+		auditumv1alpha1pb.RoleBindingService_ServiceDesc,
+		auditumv1alpha1pb.WebhookService_ServiceDesc,
+		auditumv1alpha1pb.AlertService_ServiceDesc,
+	}
+
+	for _, desc := range descs {
+		var methods []string
+		for _, m := range desc.Methods {
+			methods = append(methods, m.MethodName)
+		}
+		for _, s := range desc.Streams {
+			methods = append(methods, s.StreamName)
+		}
+
+		for _, method := range methods {
+			fullMethod := "/" + desc.ServiceName + "/" + method
+			assert.Contains(t, rules, fullMethod, "missing access rule for method")
+
+			rule := rules[fullMethod]
+			if rule.ProjectID != nil {
+				assert.NotEqual(t, aud.RoleUnspecified, rule.Role, "missing role for project method %s", fullMethod)
+			}
+		}
+	}
+}
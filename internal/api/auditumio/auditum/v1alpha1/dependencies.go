@@ -16,6 +16,7 @@ package auditumv1alpha1
 
 import (
 	"context"
+	"time"
 
 	"github.com/auditumio/auditum/internal/aud"
 )
@@ -29,19 +30,77 @@ type Store interface {
 	ListProjects(
 		ctx context.Context,
 		filter aud.ProjectFilter,
+		order aud.ProjectOrder,
 		limit int32,
 		cursor aud.ProjectCursor,
 	) ([]aud.Project, error)
 
+	// May return [aud.ErrConflict].
 	UpdateProject(
 		ctx context.Context,
 		projectID aud.ID,
 		update aud.ProjectUpdate,
 	) (aud.Project, error)
 
-	CreateRecord(ctx context.Context, record aud.Record) error
+	GetProjectUsage(ctx context.Context, projectID aud.ID) (aud.ProjectUsage, error)
+	GetProjectStats(ctx context.Context, projectID aud.ID) (aud.ProjectStats, error)
+
+	CreateAPIKey(ctx context.Context, key aud.APIKey) error
+	ListAPIKeys(ctx context.Context, limit int32, cursor aud.APIKeyCursor) ([]aud.APIKey, error)
+	// May return [aud.ErrAPIKeyNotFound].
+	RevokeAPIKey(ctx context.Context, id aud.ID, revokeTime time.Time) (aud.APIKey, error)
+
+	// May return [aud.ErrProjectNotFound].
+	SetRoleBinding(ctx context.Context, binding aud.RoleBinding) (aud.RoleBinding, error)
+	// May return [aud.ErrProjectNotFound].
+	ListRoleBindings(ctx context.Context, projectID aud.ID) ([]aud.RoleBinding, error)
+	ListPrincipalRoleBindings(ctx context.Context, principalID string) ([]aud.RoleBinding, error)
+	// May return [aud.ErrRoleBindingNotFound].
+	DeleteRoleBinding(ctx context.Context, projectID aud.ID, principalID string) error
+
+	// May return [aud.ErrProjectNotFound].
+	CreateWebhook(ctx context.Context, webhook aud.Webhook) error
+	// May return [aud.ErrWebhookNotFound].
+	GetWebhook(ctx context.Context, projectID aud.ID, id aud.ID) (aud.Webhook, error)
+	// May return [aud.ErrProjectNotFound].
+	ListWebhooks(ctx context.Context, projectID aud.ID) ([]aud.Webhook, error)
+	// May return [aud.ErrWebhookNotFound].
+	DeleteWebhook(ctx context.Context, projectID aud.ID, id aud.ID) error
+	// May return [aud.ErrWebhookNotFound].
+	ListWebhookDeliveries(
+		ctx context.Context,
+		projectID aud.ID,
+		webhookID aud.ID,
+		filter aud.WebhookDeliveryFilter,
+		limit int32,
+		cursor aud.WebhookDeliveryCursor,
+	) ([]aud.WebhookDelivery, error)
+
+	// May return [aud.ErrProjectNotFound].
+	CreateAlertRule(ctx context.Context, rule aud.AlertRule) error
+	// May return [aud.ErrAlertRuleNotFound].
+	GetAlertRule(ctx context.Context, projectID aud.ID, id aud.ID) (aud.AlertRule, error)
+	// May return [aud.ErrProjectNotFound].
+	ListAlertRules(ctx context.Context, projectID aud.ID) ([]aud.AlertRule, error)
+	// May return [aud.ErrAlertRuleNotFound].
+	DeleteAlertRule(ctx context.Context, projectID aud.ID, id aud.ID) error
+	// May return [aud.ErrProjectNotFound].
+	ListAlerts(
+		ctx context.Context,
+		projectID aud.ID,
+		filter aud.AlertFilter,
+		limit int32,
+		cursor aud.AlertCursor,
+	) ([]aud.Alert, error)
+
+	// Returns the record as stored, which is the existing record if one
+	// with the same id exists.
+	// May return [aud.ErrQuotaExceeded].
+	CreateRecord(ctx context.Context, record aud.Record) (aud.Record, error)
 
-	CreateRecords(ctx context.Context, records []aud.Record) error
+	// Returns the records as stored, see CreateRecord.
+	// May return [aud.ErrQuotaExceeded].
+	CreateRecords(ctx context.Context, records []aud.Record) ([]aud.Record, error)
 
 	GetRecord(
 		ctx context.Context,
@@ -57,6 +116,25 @@ type Store interface {
 		cursor aud.RecordCursor,
 	) ([]aud.Record, error)
 
+	ExportRecords(
+		ctx context.Context,
+		projectID aud.ID,
+		filter aud.RecordFilter,
+		fn func(aud.Record) error,
+	) error
+
+	// Returns records ordered by create time.
+	ListRecordsCreatedAfter(
+		ctx context.Context,
+		projectID aud.ID,
+		filter aud.RecordFilter,
+		limit int32,
+		cursor aud.RecordWatchCursor,
+	) ([]aud.Record, error)
+
+	// May return [aud.ErrWatchUnavailable].
+	SubscribeRecords(ctx context.Context, projectID aud.ID) (<-chan []aud.ID, error)
+
 	UpdateRecord(
 		ctx context.Context,
 		projectID aud.ID,
@@ -16,6 +16,7 @@ package auditumv1alpha1
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -35,18 +36,42 @@ func decodeProject(src *auditumv1alpha1.Project) (dst aud.Project, err error) {
 		return dst, fmt.Errorf(`invalid "display_name": %v`, err)
 	}
 
+	description, err := decodeProjectDescription(src.GetDescription())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "description": %v`, err)
+	}
+
+	labels, err := decodeProjectLabels(src.GetLabels())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "labels": %v`, err)
+	}
+
 	externalID, err := decodeExternalID(src.GetExternalId())
 	if err != nil {
 		return dst, fmt.Errorf(`invalid "external_id": %v`, err)
 	}
 
+	quota, err := decodeProjectQuota(src.GetQuota())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "quota": %v`, err)
+	}
+
+	restrictions, err := decodeRecordsRestrictions(src.GetRecordsRestrictions())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "records_restrictions": %v`, err)
+	}
+
 	return aud.Project{
 		ID:                  id,
 		CreateTime:          time.Time{}, // Ignored as OUTPUT_ONLY.
 		DisplayName:         displayName,
+		Description:         description,
+		Labels:              labels,
 		UpdateRecordEnabled: decodeBoolValue(src.GetUpdateRecordEnabled()),
 		DeleteRecordEnabled: decodeBoolValue(src.GetDeleteRecordEnabled()),
 		ExternalID:          externalID,
+		Quota:               quota,
+		RecordsRestrictions: restrictions,
 	}, nil
 }
 
@@ -58,6 +83,26 @@ func decodeProjectDisplayName(src string) (string, error) {
 	return src, nil
 }
 
+func decodeProjectDescription(src string) (string, error) {
+	if err := validateProjectDescription(src); err != nil {
+		return "", err
+	}
+
+	return src, nil
+}
+
+func decodeProjectLabels(src map[string]string) (map[string]string, error) {
+	if err := validateProjectLabels(src); err != nil {
+		return nil, err
+	}
+
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	return src, nil
+}
+
 func decodeExternalID(src string) (string, error) {
 	if err := validateProjectExternalID(src); err != nil {
 		return "", err
@@ -66,17 +111,80 @@ func decodeExternalID(src string) (string, error) {
 	return src, nil
 }
 
+func decodeProjectQuota(src *auditumv1alpha1.ProjectQuota) (dst aud.ProjectQuota, err error) {
+	if err := validateProjectQuota(src); err != nil {
+		return dst, err
+	}
+
+	return aud.ProjectQuota{
+		RecordsPerMinute: src.GetRecordsPerMinute(),
+		RecordsPerDay:    src.GetRecordsPerDay(),
+		MaxRecords:       src.GetMaxRecords(),
+		MaxBytes:         src.GetMaxBytes(),
+	}, nil
+}
+
 func encodeProject(src aud.Project) *auditumv1alpha1.Project {
 	return &auditumv1alpha1.Project{
 		Id:                  src.ID.String(),
 		CreateTime:          timestamppb.New(src.CreateTime),
 		DisplayName:         src.DisplayName,
+		Description:         src.Description,
+		Labels:              src.Labels,
 		UpdateRecordEnabled: encodeBoolValue(src.UpdateRecordEnabled),
 		DeleteRecordEnabled: encodeBoolValue(src.DeleteRecordEnabled),
 		ExternalId:          encodeOptionalString(src.ExternalID),
+		Quota:               encodeProjectQuota(src.Quota),
+		RecordsRestrictions: encodeRecordsRestrictions(src.RecordsRestrictions),
 	}
 }
 
+func encodeProjectQuota(src aud.ProjectQuota) *auditumv1alpha1.ProjectQuota {
+	if src == (aud.ProjectQuota{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.ProjectQuota{
+		RecordsPerMinute: src.RecordsPerMinute,
+		RecordsPerDay:    src.RecordsPerDay,
+		MaxRecords:       src.MaxRecords,
+		MaxBytes:         src.MaxBytes,
+	}
+}
+
+func encodeProjectUsage(src aud.ProjectUsage) *auditumv1alpha1.ProjectUsage {
+	return &auditumv1alpha1.ProjectUsage{
+		ProjectId:       src.ProjectID.String(),
+		Records:         src.Records,
+		Bytes:           src.Bytes,
+		MinuteStartTime: timestamppb.New(src.MinuteStartTime),
+		MinuteRecords:   src.MinuteRecords,
+		DayStartTime:    timestamppb.New(src.DayStartTime),
+		DayRecords:      src.DayRecords,
+	}
+}
+
+func encodeProjectStats(src aud.ProjectStats) *auditumv1alpha1.ProjectStats {
+	return &auditumv1alpha1.ProjectStats{
+		ProjectId:          src.ProjectID.String(),
+		Records:            src.Records,
+		SizeBytes:          src.SizeBytes,
+		FirstOperationTime: encodeOptionalTime(src.FirstOperationTime),
+		LastOperationTime:  encodeOptionalTime(src.LastOperationTime),
+		LastCreateTime:     encodeOptionalTime(src.LastCreateTime),
+		ResourceTypes:      src.ResourceTypes,
+		OperationTypes:     src.OperationTypes,
+		ActorTypes:         src.ActorTypes,
+	}
+}
+
+func encodeOptionalTime(src time.Time) *timestamppb.Timestamp {
+	if src.IsZero() {
+		return nil
+	}
+	return timestamppb.New(src)
+}
+
 func encodeProjects(src []aud.Project) []*auditumv1alpha1.Project {
 	dst := make([]*auditumv1alpha1.Project, len(src))
 	for i := range src {
@@ -87,6 +195,23 @@ func encodeProjects(src []aud.Project) []*auditumv1alpha1.Project {
 
 func decodeProjectFilter(src *auditumv1alpha1.ListProjectsRequest_Filter) (dst aud.ProjectFilter) {
 	return aud.ProjectFilter{
-		ExternalIDs: src.GetExternalIds(),
+		ExternalIDs:         src.GetExternalIds(),
+		Labels:              src.GetLabels(),
+		DisplayNameContains: src.GetDisplayNameContains(),
+	}
+}
+
+func decodeProjectOrder(src string) (aud.ProjectOrder, error) {
+	switch strings.Join(strings.Fields(src), " ") {
+	case "", "create_time desc":
+		return aud.ProjectOrderCreateTimeDesc, nil
+	case "create_time", "create_time asc":
+		return aud.ProjectOrderCreateTimeAsc, nil
+	case "display_name", "display_name asc":
+		return aud.ProjectOrderDisplayNameAsc, nil
+	case "display_name desc":
+		return aud.ProjectOrderDisplayNameDesc, nil
+	default:
+		return 0, fmt.Errorf("unsupported value %q", src)
 	}
 }
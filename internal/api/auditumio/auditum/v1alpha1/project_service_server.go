@@ -17,6 +17,8 @@ package auditumv1alpha1
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -27,6 +29,7 @@ import (
 
 	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
 	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
 	"github.com/auditumio/auditum/pkg/fragma/grpcx"
 )
 
@@ -115,6 +118,15 @@ func (s *ProjectServiceServer) ListProjects(
 ) (*auditumv1alpha1.ListProjectsResponse, error) {
 	filter := decodeProjectFilter(req.GetFilter())
 
+	order, err := decodeProjectOrder(req.GetOrderBy())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "order_by": %v.`,
+			err.Error(),
+		)
+	}
+
 	const (
 		defaultPageSize = 10
 		maxPageSize     = 100
@@ -133,9 +145,24 @@ func (s *ProjectServiceServer) ListProjects(
 		)
 	}
 
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		visible, all, err := s.visibleProjects(ctx, principal)
+		if err != nil {
+			s.log.Error("Get visible projects", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "")
+		}
+		if !all {
+			if len(visible) == 0 {
+				return &auditumv1alpha1.ListProjectsResponse{}, nil
+			}
+			filter.IDs = visible
+		}
+	}
+
 	projects, err := s.store.ListProjects(
 		ctx,
 		filter,
+		order,
 		pageSize,
 		cursor,
 	)
@@ -157,6 +184,32 @@ func (s *ProjectServiceServer) ListProjects(
 	}, nil
 }
 
+// visibleProjects returns projects the principal can read: projects it has
+// read permission for, and projects it has any role in. Returns true if all
+// projects are visible.
+func (s *ProjectServiceServer) visibleProjects(ctx context.Context, principal auth.Principal) ([]aud.ID, bool, error) {
+	if principal.HasPermission(aud.PermissionRead) && principal.AllProjects() {
+		return nil, true, nil
+	}
+
+	var visible []aud.ID
+	if principal.HasPermission(aud.PermissionRead) {
+		visible = append(visible, principal.ProjectIDs...)
+	}
+
+	bindings, err := s.store.ListPrincipalRoleBindings(ctx, principal.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("list principal role bindings: %v", err)
+	}
+	for _, binding := range bindings {
+		if !slices.Contains(visible, binding.ProjectID) {
+			visible = append(visible, binding.ProjectID)
+		}
+	}
+
+	return visible, false, nil
+}
+
 func (s *ProjectServiceServer) UpdateProject(
 	ctx context.Context,
 	req *auditumv1alpha1.UpdateProjectRequest,
@@ -185,12 +238,67 @@ func (s *ProjectServiceServer) UpdateProject(
 		case "display_name":
 			update.DisplayName = req.GetProject().GetDisplayName()
 			update.UpdateDisplayName = true
+		case "description":
+			description, err := decodeProjectDescription(req.GetProject().GetDescription())
+			if err != nil {
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					`Request is invalid. Invalid "description": %v.`,
+					err.Error(),
+				)
+			}
+			update.Description = description
+			update.UpdateDescription = true
+		case "labels":
+			labels, err := decodeProjectLabels(req.GetProject().GetLabels())
+			if err != nil {
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					`Request is invalid. Invalid "labels": %v.`,
+					err.Error(),
+				)
+			}
+			update.Labels = labels
+			update.UpdateLabels = true
+		case "external_id":
+			externalID, err := decodeExternalID(req.GetProject().GetExternalId())
+			if err != nil {
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					`Request is invalid. Invalid "external_id": %v.`,
+					err.Error(),
+				)
+			}
+			update.ExternalID = externalID
+			update.UpdateExternalID = true
 		case "update_record_enabled":
 			update.UpdateRecordEnabled = decodeBoolValue(req.GetProject().GetUpdateRecordEnabled())
 			update.UpdateUpdateRecordEnabled = true
 		case "delete_record_enabled":
 			update.DeleteRecordEnabled = decodeBoolValue(req.GetProject().GetDeleteRecordEnabled())
 			update.UpdateDeleteRecordEnabled = true
+		case "quota":
+			quota, err := decodeProjectQuota(req.GetProject().GetQuota())
+			if err != nil {
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					`Request is invalid. Invalid "quota": %v.`,
+					err.Error(),
+				)
+			}
+			update.Quota = quota
+			update.UpdateQuota = true
+		case "records_restrictions":
+			restrictions, err := decodeRecordsRestrictions(req.GetProject().GetRecordsRestrictions())
+			if err != nil {
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					`Request is invalid. Invalid "records_restrictions": %v.`,
+					err.Error(),
+				)
+			}
+			update.RecordsRestrictions = restrictions
+			update.UpdateRecordsRestrictions = true
 		default:
 			return nil, status.Errorf(
 				codes.InvalidArgument,
@@ -208,6 +316,9 @@ func (s *ProjectServiceServer) UpdateProject(
 	if errors.Is(err, aud.ErrProjectNotFound) {
 		return nil, status.Errorf(codes.NotFound, "")
 	}
+	if errors.Is(err, aud.ErrConflict) {
+		return nil, status.Errorf(codes.AlreadyExists, "Project with the same external id already exists.")
+	}
 	if err != nil {
 		s.log.Error("Update project in store",
 			zap.String("project_id", projectID.String()),
@@ -221,6 +332,66 @@ func (s *ProjectServiceServer) UpdateProject(
 	}, nil
 }
 
+func (s *ProjectServiceServer) GetProjectUsage(
+	ctx context.Context,
+	req *auditumv1alpha1.GetProjectUsageRequest,
+) (*auditumv1alpha1.GetProjectUsageResponse, error) {
+	id, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	usage, err := s.store.GetProjectUsage(ctx, id)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("Get project usage from store",
+			zap.String("project_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.GetProjectUsageResponse{
+		Usage: encodeProjectUsage(usage.At(s.now())),
+	}, nil
+}
+
+func (s *ProjectServiceServer) GetProjectStats(
+	ctx context.Context,
+	req *auditumv1alpha1.GetProjectStatsRequest,
+) (*auditumv1alpha1.GetProjectStatsResponse, error) {
+	id, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	stats, err := s.store.GetProjectStats(ctx, id)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("Get project stats from store",
+			zap.String("project_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.GetProjectStatsResponse{
+		Stats: encodeProjectStats(stats),
+	}, nil
+}
+
 func (s *ProjectServiceServer) RegisterServer(srv *grpc.Server) {
 	auditumv1alpha1.RegisterProjectServiceServer(srv, s)
 }
@@ -17,6 +17,9 @@ package auditumv1alpha1
 import (
 	"fmt"
 	"unicode/utf8"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
 )
 
 func validateProjectDisplayName(src string) error {
@@ -60,3 +63,47 @@ func validateProjectExternalID(src string) error {
 
 	return nil
 }
+
+func validateProjectDescription(src string) error {
+	const maxChars = 1024
+
+	if utf8.RuneCountInString(src) > maxChars {
+		return fmt.Errorf(`must not be longer than %d characters`, maxChars)
+	}
+
+	return nil
+}
+
+func validateProjectLabels(src map[string]string) error {
+	const maxLabels = 32
+
+	if len(src) > maxLabels {
+		return fmt.Errorf(`must have at most %d labels`, maxLabels)
+	}
+
+	return validateLabelsOrMetadata(src, aud.RestrictionsKeyValue{
+		KeyMaxSizeBytes:   64,
+		ValueMaxSizeBytes: 256,
+		TotalMaxSizeBytes: maxLabels * (64 + 256),
+	})
+}
+
+func validateProjectQuota(src *auditumv1alpha1.ProjectQuota) error {
+	limits := []struct {
+		name  string
+		value int64
+	}{
+		{"records_per_minute", src.GetRecordsPerMinute()},
+		{"records_per_day", src.GetRecordsPerDay()},
+		{"max_records", src.GetMaxRecords()},
+		{"max_bytes", src.GetMaxBytes()},
+	}
+
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return fmt.Errorf(`invalid %q: must not be negative`, limit.name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+// RateLimitRules returns rate limiting rules of the API methods. Requests
+// target the same projects as in access rules.
+func RateLimitRules() map[string]ratelimit.Rule {
+	classes := map[string]ratelimit.Class{
+		// Projects.
+		auditumv1alpha1.ProjectService_CreateProject_FullMethodName:   ratelimit.ClassWrite,
+		auditumv1alpha1.ProjectService_GetProject_FullMethodName:      ratelimit.ClassRead,
+		auditumv1alpha1.ProjectService_ListProjects_FullMethodName:    ratelimit.ClassRead,
+		auditumv1alpha1.ProjectService_UpdateProject_FullMethodName:   ratelimit.ClassWrite,
+		auditumv1alpha1.ProjectService_GetProjectUsage_FullMethodName: ratelimit.ClassRead,
+		auditumv1alpha1.ProjectService_GetProjectStats_FullMethodName: ratelimit.ClassRead,
+
+		// Records.
+		auditumv1alpha1.RecordService_CreateRecord_FullMethodName:        ratelimit.ClassWrite,
+		auditumv1alpha1.RecordService_BatchCreateRecords_FullMethodName:  ratelimit.ClassWrite,
+		auditumv1alpha1.RecordService_StreamCreateRecords_FullMethodName: ratelimit.ClassWrite,
+		auditumv1alpha1.RecordService_GetRecord_FullMethodName:           ratelimit.ClassRead,
+		auditumv1alpha1.RecordService_ListRecords_FullMethodName:         ratelimit.ClassRead,
+		auditumv1alpha1.RecordService_ExportRecords_FullMethodName:       ratelimit.ClassRead,
+		auditumv1alpha1.RecordService_WatchRecords_FullMethodName:        ratelimit.ClassRead,
+		auditumv1alpha1.RecordService_UpdateRecord_FullMethodName:        ratelimit.ClassWrite,
+		auditumv1alpha1.RecordService_DeleteRecord_FullMethodName:        ratelimit.ClassWrite,
+
+		// Role bindings.
+		auditumv1alpha1.RoleBindingService_SetRoleBinding_FullMethodName:    ratelimit.ClassWrite,
+		auditumv1alpha1.RoleBindingService_ListRoleBindings_FullMethodName:  ratelimit.ClassRead,
+		auditumv1alpha1.RoleBindingService_DeleteRoleBinding_FullMethodName: ratelimit.ClassWrite,
+
+		// Webhooks.
+		auditumv1alpha1.WebhookService_CreateWebhook_FullMethodName:         ratelimit.ClassWrite,
+		auditumv1alpha1.WebhookService_GetWebhook_FullMethodName:            ratelimit.ClassRead,
+		auditumv1alpha1.WebhookService_ListWebhooks_FullMethodName:          ratelimit.ClassRead,
+		auditumv1alpha1.WebhookService_DeleteWebhook_FullMethodName:         ratelimit.ClassWrite,
+		auditumv1alpha1.WebhookService_ListWebhookDeliveries_FullMethodName: ratelimit.ClassRead,
+
+		// Alerts.
+		auditumv1alpha1.AlertService_CreateAlertRule_FullMethodName: ratelimit.ClassWrite,
+		auditumv1alpha1.AlertService_GetAlertRule_FullMethodName:    ratelimit.ClassRead,
+		auditumv1alpha1.AlertService_ListAlertRules_FullMethodName:  ratelimit.ClassRead,
+		auditumv1alpha1.AlertService_DeleteAlertRule_FullMethodName: ratelimit.ClassWrite,
+		auditumv1alpha1.AlertService_ListAlerts_FullMethodName:      ratelimit.ClassRead,
+
+		// API keys.
+		auditumv1alpha1.ApiKeyService_CreateApiKey_FullMethodName: ratelimit.ClassWrite,
+		auditumv1alpha1.ApiKeyService_ListApiKeys_FullMethodName:  ratelimit.ClassRead,
+		auditumv1alpha1.ApiKeyService_RevokeApiKey_FullMethodName: ratelimit.ClassWrite,
+	}
+
+	authRules := AuthRules()
+
+	rules := make(map[string]ratelimit.Rule, len(classes))
+	for method, class := range classes {
+		rules[method] = ratelimit.Rule{
+			Class:     class,
+			ProjectID: authRules[method].ProjectID,
+		}
+	}
+
+	return rules
+}
@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
 	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/aud/expr"
 )
 
 func decodeRecords(projectID string, src []*auditumv1alpha1.Record, restrictions aud.RecordsRestrictions) ([]aud.Record, error) {
@@ -51,6 +53,10 @@ func decodeRecord(src *auditumv1alpha1.Record, restrictions aud.RecordsRestricti
 		return dst, fmt.Errorf(`invalid "project_id": %v`, err)
 	}
 
+	if err := validateIdempotencyKey(src.GetIdempotencyKey()); err != nil {
+		return dst, fmt.Errorf(`invalid "idempotency_key": %v`, err)
+	}
+
 	labels, err := decodeLabels(src.GetLabels(), restrictions.Labels)
 	if err != nil {
 		return dst, fmt.Errorf(`invalid "labels": %v`, err)
@@ -82,6 +88,24 @@ func decodeRecord(src *auditumv1alpha1.Record, restrictions aud.RecordsRestricti
 	}, nil
 }
 
+// DecodeImportRecord decodes a record to import. Unlike records created via
+// API, imported records keep their id and create time if provided.
+func DecodeImportRecord(src *auditumv1alpha1.Record, restrictions aud.RecordsRestrictions) (aud.Record, error) {
+	dst, err := decodeRecord(src, restrictions)
+	if err != nil {
+		return dst, err
+	}
+
+	if v := src.GetCreateTime(); v != nil {
+		if !v.IsValid() {
+			return dst, fmt.Errorf(`invalid "create_time" time value`)
+		}
+		dst.CreateTime = v.AsTime()
+	}
+
+	return dst, nil
+}
+
 func decodeLabels(src map[string]string, restrictions aud.RestrictionsKeyValue) (map[string]string, error) {
 	if len(src) == 0 {
 		return nil, nil
@@ -395,6 +419,12 @@ func encodeRecords(src []aud.Record) []*auditumv1alpha1.Record {
 	return dst
 }
 
+// MarshalRecordJSON encodes the record to JSON as it is returned by the HTTP
+// API, e.g. for webhook payloads.
+func MarshalRecordJSON(record aud.Record) ([]byte, error) {
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(encodeRecord(record))
+}
+
 func encodeRecord(src aud.Record) *auditumv1alpha1.Record {
 	return &auditumv1alpha1.Record{
 		Id:         src.ID.String(),
@@ -482,6 +512,12 @@ func encodeActor(src aud.Actor) *auditumv1alpha1.Actor {
 	}
 }
 
+// maxFilterValues is the maximum number of values for a single filter field.
+const maxFilterValues = 100
+
+// maxFilterExpressionLength is the maximum length of a filter expression.
+const maxFilterExpressionLength = 2048
+
 func decodeRecordFilter(src *auditumv1alpha1.ListRecordsRequest_Filter) (dst aud.RecordFilter, err error) {
 	var operationTimeFrom time.Time
 	if v := src.GetOperationTimeFrom(); v != nil {
@@ -499,15 +535,152 @@ func decodeRecordFilter(src *auditumv1alpha1.ListRecordsRequest_Filter) (dst aud
 		operationTimeTo = v.AsTime()
 	}
 
+	resourceTypes, err := decodeFilterValues(src.GetResourceType(), src.GetResourceTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "resource_types": %v`, err)
+	}
+
+	resourceIDs, err := decodeFilterValues(src.GetResourceId(), src.GetResourceIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "resource_ids": %v`, err)
+	}
+
+	operationTypes, err := decodeFilterValues(src.GetOperationType(), src.GetOperationTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_types": %v`, err)
+	}
+
+	operationIDs, err := decodeFilterValues(src.GetOperationId(), src.GetOperationIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_ids": %v`, err)
+	}
+
+	operationStatuses, err := decodeFilterOperationStatuses(src.GetOperationStatuses())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_statuses": %v`, err)
+	}
+
+	actorTypes, err := decodeFilterValues(src.GetActorType(), src.GetActorTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "actor_types": %v`, err)
+	}
+
+	actorIDs, err := decodeFilterValues(src.GetActorId(), src.GetActorIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "actor_ids": %v`, err)
+	}
+
+	not, err := decodeRecordFilterNot(src.GetNot())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "not": %v`, err)
+	}
+
 	return aud.RecordFilter{
 		Labels:            src.GetLabels(),
-		ResourceType:      src.GetResourceType(),
-		ResourceID:        src.GetResourceId(),
-		OperationType:     src.GetOperationType(),
-		OperationID:       src.GetOperationId(),
+		ResourceTypes:     resourceTypes,
+		ResourceIDs:       resourceIDs,
+		ResourceIDPrefix:  src.GetResourceIdPrefix(),
+		OperationTypes:    operationTypes,
+		OperationIDs:      operationIDs,
+		OperationStatuses: operationStatuses,
 		OperationTimeFrom: operationTimeFrom,
 		OperationTimeTo:   operationTimeTo,
-		ActorType:         src.GetActorType(),
-		ActorID:           src.GetActorId(),
+		ActorTypes:        actorTypes,
+		ActorIDs:          actorIDs,
+		Not:               not,
 	}, nil
 }
+
+func decodeRecordFilterNot(src *auditumv1alpha1.ListRecordsRequest_Filter_Not) (dst aud.RecordFilterNot, err error) {
+	resourceTypes, err := decodeFilterValues("", src.GetResourceTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "resource_types": %v`, err)
+	}
+
+	resourceIDs, err := decodeFilterValues("", src.GetResourceIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "resource_ids": %v`, err)
+	}
+
+	operationTypes, err := decodeFilterValues("", src.GetOperationTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_types": %v`, err)
+	}
+
+	operationIDs, err := decodeFilterValues("", src.GetOperationIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_ids": %v`, err)
+	}
+
+	operationStatuses, err := decodeFilterOperationStatuses(src.GetOperationStatuses())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "operation_statuses": %v`, err)
+	}
+
+	actorTypes, err := decodeFilterValues("", src.GetActorTypes())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "actor_types": %v`, err)
+	}
+
+	actorIDs, err := decodeFilterValues("", src.GetActorIds())
+	if err != nil {
+		return dst, fmt.Errorf(`invalid "actor_ids": %v`, err)
+	}
+
+	return aud.RecordFilterNot{
+		Labels:            src.GetLabels(),
+		ResourceTypes:     resourceTypes,
+		ResourceIDs:       resourceIDs,
+		OperationTypes:    operationTypes,
+		OperationIDs:      operationIDs,
+		OperationStatuses: operationStatuses,
+		ActorTypes:        actorTypes,
+		ActorIDs:          actorIDs,
+	}, nil
+}
+
+// decodeFilterValues merges the single value field with its repeated
+// counterpart.
+func decodeFilterValues(value string, values []string) ([]string, error) {
+	if value != "" {
+		values = append([]string{value}, values...)
+	}
+
+	if len(values) > maxFilterValues {
+		return nil, fmt.Errorf("must contain at most %d values", maxFilterValues)
+	}
+
+	return values, nil
+}
+
+func decodeFilterOperationStatuses(src []auditumv1alpha1.OperationStatus_Enum) ([]aud.OperationStatus, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	if len(src) > maxFilterValues {
+		return nil, fmt.Errorf("must contain at most %d values", maxFilterValues)
+	}
+
+	dst := make([]aud.OperationStatus, len(src))
+	for i, v := range src {
+		if _, ok := auditumv1alpha1.OperationStatus_Enum_name[int32(v)]; !ok {
+			return nil, fmt.Errorf("unknown value %d", v)
+		}
+		dst[i] = decodeOperationStatus(v)
+	}
+
+	return dst, nil
+}
+
+func decodeFilterExpression(src string) (expr.Expr, error) {
+	if src == "" {
+		return nil, nil
+	}
+
+	if len(src) > maxFilterExpressionLength {
+		return nil, fmt.Errorf("must be at most %d characters long", maxFilterExpressionLength)
+	}
+
+	return aud.ParseRecordFilterExpression(src)
+}
@@ -0,0 +1,360 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+)
+
+// recordCSVColumns lists CSV columns of a record in the default order.
+var recordCSVColumns = []string{
+	"id",
+	"project_id",
+	"create_time",
+	"labels",
+	"resource.type",
+	"resource.id",
+	"resource.metadata",
+	"resource.changes",
+	"operation.type",
+	"operation.id",
+	"operation.time",
+	"operation.metadata",
+	"operation.trace_context.traceparent",
+	"operation.trace_context.tracestate",
+	"operation.status",
+	"actor.type",
+	"actor.id",
+	"actor.metadata",
+}
+
+// recordCSVMapColumns lists CSV columns of string maps. A single value can be
+// selected by key, e.g. "labels.env".
+var recordCSVMapColumns = []string{
+	"labels",
+	"resource.metadata",
+	"operation.metadata",
+	"actor.metadata",
+}
+
+// maxCSVColumns is the maximum number of selected CSV columns.
+const maxCSVColumns = 100
+
+// decodeRecordCSVColumns decodes selected CSV columns. Each value may contain
+// multiple comma-separated columns. Returns all columns if none are selected.
+func decodeRecordCSVColumns(src []string) ([]string, error) {
+	var dst []string
+
+	for _, v := range src {
+		for _, column := range strings.Split(v, ",") {
+			column = strings.TrimSpace(column)
+			if column == "" {
+				continue
+			}
+
+			if !isRecordCSVColumn(column) {
+				return nil, fmt.Errorf("unknown column %q", column)
+			}
+
+			dst = append(dst, column)
+		}
+	}
+
+	if len(dst) > maxCSVColumns {
+		return nil, fmt.Errorf("must contain at most %d columns", maxCSVColumns)
+	}
+
+	if len(dst) == 0 {
+		return recordCSVColumns, nil
+	}
+
+	return dst, nil
+}
+
+// ValidateRecordCSVColumns checks that all columns are known record columns.
+func ValidateRecordCSVColumns(columns []string) error {
+	for _, column := range columns {
+		if !isRecordCSVColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+	return nil
+}
+
+func isRecordCSVColumn(column string) bool {
+	for _, c := range recordCSVColumns {
+		if c == column {
+			return true
+		}
+	}
+
+	_, key := splitRecordCSVMapColumn(column)
+	return key != ""
+}
+
+// splitRecordCSVMapColumn splits column like "labels.env" into map column
+// and key. Returns empty key if the column does not refer to a map value.
+func splitRecordCSVMapColumn(column string) (string, string) {
+	for _, c := range recordCSVMapColumns {
+		if key, ok := strings.CutPrefix(column, c+"."); ok {
+			return c, key
+		}
+	}
+	return "", ""
+}
+
+func encodeRecordCSVValue(record *auditumv1alpha1.Record, column string) string {
+	switch column {
+	case "id":
+		return record.GetId()
+	case "project_id":
+		return record.GetProjectId()
+	case "create_time":
+		return encodeCSVTime(record.GetCreateTime())
+	case "labels":
+		return encodeCSVMap(record.GetLabels())
+	case "resource.type":
+		return record.GetResource().GetType()
+	case "resource.id":
+		return record.GetResource().GetId()
+	case "resource.metadata":
+		return encodeCSVMap(record.GetResource().GetMetadata())
+	case "resource.changes":
+		return encodeCSVResourceChanges(record.GetResource().GetChanges())
+	case "operation.type":
+		return record.GetOperation().GetType()
+	case "operation.id":
+		return record.GetOperation().GetId()
+	case "operation.time":
+		return encodeCSVTime(record.GetOperation().GetTime())
+	case "operation.metadata":
+		return encodeCSVMap(record.GetOperation().GetMetadata())
+	case "operation.trace_context.traceparent":
+		return record.GetOperation().GetTraceContext().GetTraceparent()
+	case "operation.trace_context.tracestate":
+		return record.GetOperation().GetTraceContext().GetTracestate()
+	case "operation.status":
+		return record.GetOperation().GetStatus().String()
+	case "actor.type":
+		return record.GetActor().GetType()
+	case "actor.id":
+		return record.GetActor().GetId()
+	case "actor.metadata":
+		return encodeCSVMap(record.GetActor().GetMetadata())
+	}
+
+	mapColumn, key := splitRecordCSVMapColumn(column)
+	switch mapColumn {
+	case "labels":
+		return record.GetLabels()[key]
+	case "resource.metadata":
+		return record.GetResource().GetMetadata()[key]
+	case "operation.metadata":
+		return record.GetOperation().GetMetadata()[key]
+	case "actor.metadata":
+		return record.GetActor().GetMetadata()[key]
+	}
+
+	return ""
+}
+
+func encodeCSVTime(src *timestamppb.Timestamp) string {
+	if src == nil {
+		return ""
+	}
+	return src.AsTime().Format(time.RFC3339Nano)
+}
+
+func encodeCSVMap(src map[string]string) string {
+	if len(src) == 0 {
+		return ""
+	}
+
+	b, err := json.Marshal(src)
+	if err != nil {
+		// This is exceptional.
+		panic(fmt.Errorf("marshal map to json: %v", err))
+	}
+
+	return string(b)
+}
+
+func encodeCSVResourceChanges(src []*auditumv1alpha1.ResourceChange) string {
+	if len(src) == 0 {
+		return ""
+	}
+
+	changes := make([]json.RawMessage, len(src))
+	for i, change := range src {
+		b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(change)
+		if err != nil {
+			// This is exceptional.
+			panic(fmt.Errorf("marshal resource change to json: %v", err))
+		}
+		changes[i] = b
+	}
+
+	b, err := json.Marshal(changes)
+	if err != nil {
+		// This is exceptional.
+		panic(fmt.Errorf("marshal resource changes to json: %v", err))
+	}
+
+	return string(b)
+}
+
+// DecodeRecordCSV decodes a CSV row into a record. Columns are the same as
+// for export. Empty values are ignored.
+func DecodeRecordCSV(columns []string, row []string) (*auditumv1alpha1.Record, error) {
+	if len(row) != len(columns) {
+		return nil, fmt.Errorf("expected %d values, got %d", len(columns), len(row))
+	}
+
+	dst := &auditumv1alpha1.Record{
+		Resource:  &auditumv1alpha1.Resource{},
+		Operation: &auditumv1alpha1.Operation{},
+		Actor:     &auditumv1alpha1.Actor{},
+	}
+
+	for i, column := range columns {
+		if row[i] == "" {
+			continue
+		}
+
+		if err := decodeRecordCSVValue(dst, column, row[i]); err != nil {
+			return nil, fmt.Errorf("invalid %q: %v", column, err)
+		}
+	}
+
+	return dst, nil
+}
+
+func decodeRecordCSVValue(dst *auditumv1alpha1.Record, column string, value string) error {
+	var err error
+
+	switch column {
+	case "id":
+		dst.Id = value
+	case "project_id":
+		dst.ProjectId = value
+	case "create_time":
+		dst.CreateTime, err = decodeCSVTime(value)
+	case "labels":
+		dst.Labels, err = decodeCSVMap(value)
+	case "resource.type":
+		dst.Resource.Type = value
+	case "resource.id":
+		dst.Resource.Id = value
+	case "resource.metadata":
+		dst.Resource.Metadata, err = decodeCSVMap(value)
+	case "resource.changes":
+		dst.Resource.Changes, err = decodeCSVResourceChanges(value)
+	case "operation.type":
+		dst.Operation.Type = value
+	case "operation.id":
+		dst.Operation.Id = value
+	case "operation.time":
+		dst.Operation.Time, err = decodeCSVTime(value)
+	case "operation.metadata":
+		dst.Operation.Metadata, err = decodeCSVMap(value)
+	case "operation.trace_context.traceparent":
+		if dst.Operation.TraceContext == nil {
+			dst.Operation.TraceContext = &auditumv1alpha1.TraceContext{}
+		}
+		dst.Operation.TraceContext.Traceparent = value
+	case "operation.trace_context.tracestate":
+		if dst.Operation.TraceContext == nil {
+			dst.Operation.TraceContext = &auditumv1alpha1.TraceContext{}
+		}
+		dst.Operation.TraceContext.Tracestate = value
+	case "operation.status":
+		v, ok := auditumv1alpha1.OperationStatus_Enum_value[value]
+		if !ok {
+			return fmt.Errorf("unknown value %q", value)
+		}
+		dst.Operation.Status = auditumv1alpha1.OperationStatus_Enum(v)
+	case "actor.type":
+		dst.Actor.Type = value
+	case "actor.id":
+		dst.Actor.Id = value
+	case "actor.metadata":
+		dst.Actor.Metadata, err = decodeCSVMap(value)
+	default:
+		mapColumn, key := splitRecordCSVMapColumn(column)
+		switch mapColumn {
+		case "labels":
+			dst.Labels = setCSVMapValue(dst.Labels, key, value)
+		case "resource.metadata":
+			dst.Resource.Metadata = setCSVMapValue(dst.Resource.Metadata, key, value)
+		case "operation.metadata":
+			dst.Operation.Metadata = setCSVMapValue(dst.Operation.Metadata, key, value)
+		case "actor.metadata":
+			dst.Actor.Metadata = setCSVMapValue(dst.Actor.Metadata, key, value)
+		default:
+			return fmt.Errorf("unknown column")
+		}
+	}
+
+	return err
+}
+
+func decodeCSVTime(src string) (*timestamppb.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339Nano, src)
+	if err != nil {
+		return nil, fmt.Errorf("must be RFC 3339 timestamp")
+	}
+	return timestamppb.New(t), nil
+}
+
+func decodeCSVMap(src string) (map[string]string, error) {
+	var dst map[string]string
+	if err := json.Unmarshal([]byte(src), &dst); err != nil {
+		return nil, fmt.Errorf("must be JSON object with string values")
+	}
+	return dst, nil
+}
+
+func setCSVMapValue(dst map[string]string, key string, value string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string)
+	}
+	dst[key] = value
+	return dst
+}
+
+func decodeCSVResourceChanges(src string) ([]*auditumv1alpha1.ResourceChange, error) {
+	var changes []json.RawMessage
+	if err := json.Unmarshal([]byte(src), &changes); err != nil {
+		return nil, fmt.Errorf("must be JSON array")
+	}
+
+	dst := make([]*auditumv1alpha1.ResourceChange, len(changes))
+	for i, change := range changes {
+		dst[i] = &auditumv1alpha1.ResourceChange{}
+		if err := protojson.Unmarshal(change, dst[i]); err != nil {
+			return nil, fmt.Errorf("invalid change %d: %v", i, err)
+		}
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+)
+
+const exportRecordsPathPattern = "/projects/{project_id}/records:export"
+
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+)
+
+// exportFlushInterval is the number of records written between flushes of
+// the response.
+const exportFlushInterval = 100
+
+var exportRecordsQueryFilter = utilities.NewDoubleArray([][]string{{"project_id"}})
+
+// recordExportHandler serves ExportRecords over HTTP as NDJSON or CSV.
+//
+// It is used instead of the gateway streaming response, which wraps every
+// message in a JSON object and does not support CSV.
+type recordExportHandler struct {
+	mux    *runtime.ServeMux
+	client auditumv1alpha1.RecordServiceClient
+	log    *zap.Logger
+}
+
+func (h *recordExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+
+	ctx, err := runtime.AnnotateContext(
+		ctx,
+		h.mux,
+		r,
+		auditumv1alpha1.RecordService_ExportRecords_FullMethodName,
+		runtime.WithHTTPPathPattern(exportRecordsPathPattern),
+	)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+
+	query := r.URL.Query()
+
+	format, err := decodeExportFormat(query.Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "format": %v.`,
+			err.Error(),
+		))
+		return
+	}
+
+	columns, err := decodeRecordCSVColumns(query["columns"])
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "columns": %v.`,
+			err.Error(),
+		))
+		return
+	}
+
+	query.Del("format")
+	query.Del("columns")
+
+	req := &auditumv1alpha1.ExportRecordsRequest{
+		ProjectId: pathParams["project_id"],
+	}
+	if err := runtime.PopulateQueryParameters(req, query, exportRecordsQueryFilter); err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	stream, err := h.client.ExportRecords(ctx, req)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{HeaderMD: header})
+
+	// Receive the first record before writing the response, so that request
+	// errors are returned with the appropriate status code.
+	first, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	var rw recordWriter
+	switch format {
+	case exportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="records.csv"`)
+		rw = newRecordCSVWriter(w, columns)
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="records.ndjson"`)
+		rw = newRecordNDJSONWriter(w)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.writeRecords(w, rw, stream, first); err != nil {
+		if ctx.Err() != nil {
+			// Client has gone away.
+			return
+		}
+
+		h.log.Error("Export records over HTTP", zap.Error(err))
+
+		// The status code has been sent already, so abort the response to
+		// let the client know it is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (h *recordExportHandler) writeRecords(
+	w http.ResponseWriter,
+	rw recordWriter,
+	stream auditumv1alpha1.RecordService_ExportRecordsClient,
+	first *auditumv1alpha1.ExportRecordsResponse,
+) error {
+	rc := http.NewResponseController(w)
+
+	if err := rw.WriteHeader(); err != nil {
+		return fmt.Errorf("write header: %v", err)
+	}
+
+	resp := first
+	for n := 1; resp != nil; n++ {
+		if err := rw.Write(resp.GetRecord()); err != nil {
+			return fmt.Errorf("write record: %v", err)
+		}
+
+		if n%exportFlushInterval == 0 {
+			if err := rw.Flush(); err != nil {
+				return fmt.Errorf("flush records: %v", err)
+			}
+			_ = rc.Flush()
+		}
+
+		var err error
+		resp, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("receive record: %v", err)
+		}
+	}
+
+	if err := rw.Flush(); err != nil {
+		return fmt.Errorf("flush records: %v", err)
+	}
+
+	return nil
+}
+
+// decodeExportFormat returns export format from the query parameter or,
+// if it is not set, from the Accept header. Defaults to NDJSON.
+func decodeExportFormat(format string, accept string) (string, error) {
+	switch format {
+	case exportFormatNDJSON, exportFormatCSV:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf(`must be one of "%s", "%s"`, exportFormatNDJSON, exportFormatCSV)
+	}
+
+	for _, v := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(v)
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case "text/csv":
+			return exportFormatCSV, nil
+		case "application/x-ndjson", "application/jsonl", "application/json":
+			return exportFormatNDJSON, nil
+		}
+	}
+
+	return exportFormatNDJSON, nil
+}
+
+type recordWriter interface {
+	WriteHeader() error
+	Write(record *auditumv1alpha1.Record) error
+	Flush() error
+}
+
+type recordNDJSONWriter struct {
+	w *bufio.Writer
+}
+
+func newRecordNDJSONWriter(w io.Writer) *recordNDJSONWriter {
+	return &recordNDJSONWriter{w: bufio.NewWriter(w)}
+}
+
+func (rw *recordNDJSONWriter) WriteHeader() error {
+	return nil
+}
+
+func (rw *recordNDJSONWriter) Write(record *auditumv1alpha1.Record) error {
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err := rw.w.Write(b); err != nil {
+		return err
+	}
+
+	return rw.w.WriteByte('\n')
+}
+
+func (rw *recordNDJSONWriter) Flush() error {
+	return rw.w.Flush()
+}
+
+type recordCSVWriter struct {
+	w       *csv.Writer
+	columns []string
+	row     []string
+}
+
+func newRecordCSVWriter(w io.Writer, columns []string) *recordCSVWriter {
+	return &recordCSVWriter{
+		w:       csv.NewWriter(w),
+		columns: columns,
+		row:     make([]string, len(columns)),
+	}
+}
+
+func (rw *recordCSVWriter) WriteHeader() error {
+	return rw.w.Write(rw.columns)
+}
+
+func (rw *recordCSVWriter) Write(record *auditumv1alpha1.Record) error {
+	for i, column := range rw.columns {
+		rw.row[i] = encodeRecordCSVValue(record, column)
+	}
+
+	return rw.w.Write(rw.row)
+}
+
+func (rw *recordCSVWriter) Flush() error {
+	rw.w.Flush()
+	return rw.w.Error()
+}
@@ -17,16 +17,26 @@ package auditumv1alpha1
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
 	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/metrics"
 	"github.com/auditumio/auditum/pkg/fragma/grpcx"
 )
 
@@ -59,7 +69,21 @@ func (s *RecordServiceServer) CreateRecord(
 	ctx context.Context,
 	req *auditumv1alpha1.CreateRecordRequest,
 ) (*auditumv1alpha1.CreateRecordResponse, error) {
-	record, err := decodeRecord(req.GetRecord(), s.settings.Records.Restrictions)
+	projectID, err := decodeID(req.GetRecord().GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "record": invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	restrictions, err := s.recordsRestrictions(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := decodeRecord(req.GetRecord(), restrictions)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.InvalidArgument,
@@ -68,13 +92,16 @@ func (s *RecordServiceServer) CreateRecord(
 		)
 	}
 
-	record.ID = s.id()
-	record.CreateTime = s.now().UTC()
+	record.ID = s.recordID(projectID, req.GetRecord().GetIdempotencyKey())
+	record.CreateTime = s.createTime()
 
-	err = s.store.CreateRecord(ctx, record)
+	record, err = s.store.CreateRecord(ctx, record)
 	if errors.Is(err, aud.ErrProjectNotFound) {
 		return nil, status.Error(codes.NotFound, "Project not found.")
 	}
+	if quotaErr := (*aud.QuotaExceededError)(nil); errors.As(err, &quotaErr) {
+		return nil, quotaExceededError(ctx, quotaErr)
+	}
 	if err != nil {
 		s.log.Error("Create record in store", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "")
@@ -89,10 +116,24 @@ func (s *RecordServiceServer) BatchCreateRecords(
 	ctx context.Context,
 	req *auditumv1alpha1.BatchCreateRecordsRequest,
 ) (*auditumv1alpha1.BatchCreateRecordsResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	restrictions, err := s.recordsRestrictions(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
 	records, err := decodeRecords(
 		req.GetProjectId(),
 		req.GetRecords(),
-		s.settings.Records.Restrictions,
+		restrictions,
 	)
 	if err != nil {
 		return nil, status.Errorf(
@@ -102,17 +143,20 @@ func (s *RecordServiceServer) BatchCreateRecords(
 		)
 	}
 
-	now := s.now().UTC()
+	now := s.createTime()
 
 	for i := range records {
-		records[i].ID = s.id()
+		records[i].ID = s.recordID(projectID, req.GetRecords()[i].GetIdempotencyKey())
 		records[i].CreateTime = now
 	}
 
-	err = s.store.CreateRecords(ctx, records)
+	records, err = s.store.CreateRecords(ctx, records)
 	if errors.Is(err, aud.ErrProjectNotFound) {
 		return nil, status.Error(codes.NotFound, "Project not found.")
 	}
+	if quotaErr := (*aud.QuotaExceededError)(nil); errors.As(err, &quotaErr) {
+		return nil, quotaExceededError(ctx, quotaErr)
+	}
 	if err != nil {
 		s.log.Error("Create records in store", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "")
@@ -123,6 +167,244 @@ func (s *RecordServiceServer) BatchCreateRecords(
 	}, nil
 }
 
+// recordID returns the id of a record to create. With an idempotency key,
+// the id is derived from the project and the key, so that the store skips
+// creating the record again.
+func (s *RecordServiceServer) recordID(projectID aud.ID, idempotencyKey string) aud.ID {
+	if idempotencyKey == "" {
+		return s.id()
+	}
+	return aud.NewKeyedID(projectID, idempotencyKey)
+}
+
+// createTime returns the create time of records to create, truncated to the
+// precision of the store, so that records returned on creation are the same
+// as the ones returned when retried or read back.
+func (s *RecordServiceServer) createTime() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+const (
+	// streamCreateRecordsMessageSize is the maximum number of records in a
+	// message of StreamCreateRecords.
+	streamCreateRecordsMessageSize = 100
+	// streamCreateRecordsBatchSize is the maximum number of records created
+	// at once by StreamCreateRecords.
+	streamCreateRecordsBatchSize = 500
+)
+
+func (s *RecordServiceServer) StreamCreateRecords(
+	stream auditumv1alpha1.RecordService_StreamCreateRecordsServer,
+) error {
+	ctx := stream.Context()
+
+	// Requests are received in background, so requests already sent by the
+	// client are collected into one batch. The channel is not buffered:
+	// while a batch is created, at most one request is held and flow control
+	// stops the client from sending more.
+	requests := make(chan *auditumv1alpha1.StreamCreateRecordsRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(requests)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				recvErr <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	var (
+		projectID aud.ID
+		index     int64
+		next      *auditumv1alpha1.StreamCreateRecordsRequest
+	)
+
+	for {
+		req := next
+		next = nil
+		if req == nil {
+			var ok bool
+			req, ok = <-requests
+			if !ok {
+				err := <-recvErr
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if ctx.Err() != nil {
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				return err
+			}
+		}
+
+		if err := validateStreamCreateRecordsRequest(req, &projectID); err != nil {
+			return err
+		}
+
+		// Collect requests already sent by the client into the batch.
+		batch := req.GetRecords()
+
+	collect:
+		for len(batch) < streamCreateRecordsBatchSize {
+			select {
+			case more, ok := <-requests:
+				if !ok {
+					break collect
+				}
+				if len(batch)+len(more.GetRecords()) > streamCreateRecordsBatchSize {
+					next = more
+					break collect
+				}
+				if err := validateStreamCreateRecordsRequest(more, &projectID); err != nil {
+					return err
+				}
+				batch = append(batch, more.GetRecords()...)
+			default:
+				break collect
+			}
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		results, err := s.createRecordsBatch(ctx, projectID, batch, index)
+		if err != nil {
+			return err
+		}
+
+		index += int64(len(batch))
+
+		err = stream.Send(&auditumv1alpha1.StreamCreateRecordsResponse{
+			Results: results,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// validateStreamCreateRecordsRequest validates the request of
+// StreamCreateRecords. The project of the stream is set by the first request
+// and must be the same in the following requests. The returned error is a
+// gRPC status.
+func validateStreamCreateRecordsRequest(
+	req *auditumv1alpha1.StreamCreateRecordsRequest,
+	projectID *aud.ID,
+) error {
+	id, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	if projectID.IsEmpty() {
+		*projectID = id
+	} else if id != *projectID {
+		return status.Error(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": must be the same in all messages of the stream.`,
+		)
+	}
+
+	if len(req.GetRecords()) > streamCreateRecordsMessageSize {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "records": must have at most %d records.`,
+			streamCreateRecordsMessageSize,
+		)
+	}
+
+	return nil
+}
+
+// createRecordsBatch creates a batch of records received in the stream,
+// starting at the index. Records that are invalid or exceed the project
+// quota are reported in the results. The returned error is a gRPC status.
+func (s *RecordServiceServer) createRecordsBatch(
+	ctx context.Context,
+	projectID aud.ID,
+	batch []*auditumv1alpha1.Record,
+	index int64,
+) ([]*auditumv1alpha1.StreamCreateRecordsResponse_Result, error) {
+	// Restrictions are loaded for every batch, so project changes apply to
+	// long-lived streams.
+	restrictions, err := s.recordsRestrictions(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*auditumv1alpha1.StreamCreateRecordsResponse_Result, len(batch))
+
+	var (
+		records []aud.Record
+		created []int
+	)
+
+	now := s.createTime()
+
+	for i, src := range batch {
+		results[i] = &auditumv1alpha1.StreamCreateRecordsResponse_Result{
+			Index: index + int64(i),
+		}
+
+		src.ProjectId = projectID.String()
+		record, err := decodeRecord(src, restrictions)
+		if err != nil {
+			results[i].ErrorCode = int32(codes.InvalidArgument)
+			results[i].ErrorMessage = fmt.Sprintf(`Record is invalid: %v.`, err)
+			continue
+		}
+
+		record.ID = s.recordID(projectID, src.GetIdempotencyKey())
+		record.CreateTime = now
+
+		records = append(records, record)
+		created = append(created, i)
+	}
+
+	if len(records) == 0 {
+		return results, nil
+	}
+
+	records, err = s.store.CreateRecords(ctx, records)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if quotaErr := (*aud.QuotaExceededError)(nil); errors.As(err, &quotaErr) {
+		st := status.Convert(quotaExceededError(ctx, quotaErr))
+		for _, i := range created {
+			results[i].ErrorCode = int32(st.Code())
+			results[i].ErrorMessage = st.Message()
+		}
+		return results, nil
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		s.log.Error("Create records in store", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	for j, i := range created {
+		results[i].Record = encodeRecord(records[j])
+	}
+
+	return results, nil
+}
+
 func (s *RecordServiceServer) GetRecord(
 	ctx context.Context,
 	req *auditumv1alpha1.GetRecordRequest,
@@ -188,6 +470,15 @@ func (s *RecordServiceServer) ListRecords(
 		)
 	}
 
+	filter.Expression, err = decodeFilterExpression(req.GetFilterExpression())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter_expression": %v.`,
+			err.Error(),
+		)
+	}
+
 	const (
 		defaultPageSize = 10
 		maxPageSize     = 100
@@ -234,6 +525,267 @@ func (s *RecordServiceServer) ListRecords(
 	}, nil
 }
 
+func (s *RecordServiceServer) ExportRecords(
+	req *auditumv1alpha1.ExportRecordsRequest,
+	stream auditumv1alpha1.RecordService_ExportRecordsServer,
+) error {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	filter, err := decodeRecordFilter(req.GetFilter())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter": %v.`,
+			err.Error(),
+		)
+	}
+
+	filter.Expression, err = decodeFilterExpression(req.GetFilterExpression())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter_expression": %v.`,
+			err.Error(),
+		)
+	}
+
+	ctx := stream.Context()
+
+	err = s.store.ExportRecords(ctx, projectID, filter, func(record aud.Record) error {
+		return stream.Send(&auditumv1alpha1.ExportRecordsResponse{
+			Record: encodeRecord(record),
+		})
+	})
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		s.log.Error("Export records from store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return status.Errorf(codes.Internal, "")
+	}
+
+	return nil
+}
+
+const (
+	// watchRecordsPageSize is the number of records read at once when
+	// resuming watching.
+	watchRecordsPageSize = 100
+	// watchRecordsLookback is the maximum time between setting create time of
+	// a record and notifying about it, including clock skew of instances.
+	watchRecordsLookback = time.Minute
+)
+
+func (s *RecordServiceServer) WatchRecords(
+	req *auditumv1alpha1.WatchRecordsRequest,
+	stream auditumv1alpha1.RecordService_WatchRecordsServer,
+) error {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	filter, err := decodeRecordFilter(req.GetFilter())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter": %v.`,
+			err.Error(),
+		)
+	}
+
+	filter.Expression, err = decodeFilterExpression(req.GetFilterExpression())
+	if err != nil {
+		return status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter_expression": %v.`,
+			err.Error(),
+		)
+	}
+
+	var cursor aud.RecordWatchCursor
+	if err := aud.DecodePageToken(req.GetPageToken(), &cursor); err != nil {
+		s.log.Warn("Decode page token", zap.Error(err))
+		return status.Error(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "page_token".`,
+		)
+	}
+
+	ctx := stream.Context()
+
+	_, err = s.store.GetProject(ctx, projectID)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("Get project from store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return status.Errorf(codes.Internal, "")
+	}
+
+	// Subscribe before reading records created after the cursor, so that
+	// records created in between are not missed.
+	subscribeTime := s.now().UTC()
+	created, err := s.store.SubscribeRecords(ctx, projectID)
+	if errors.Is(err, aud.ErrWatchUnavailable) {
+		return status.Error(codes.Unavailable, "Watching records is temporarily unavailable. Retry later.")
+	}
+	if err != nil {
+		s.log.Error("Subscribe to created records", zap.Error(err))
+		return status.Errorf(codes.Internal, "")
+	}
+
+	// Send headers, so clients know the watch has started before the first
+	// record is created.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	w := &recordsWatch{
+		stream: stream,
+		cursor: cursor,
+		resent: make(map[aud.ID]struct{}),
+	}
+
+	if !cursor.Empty() {
+		for {
+			records, err := s.store.ListRecordsCreatedAfter(ctx, projectID, filter, watchRecordsPageSize, w.cursor)
+			if err != nil {
+				return s.watchRecordsError(ctx, projectID, err)
+			}
+
+			for _, record := range records {
+				// Records created shortly before subscribing may be
+				// notified as well.
+				if !record.CreateTime.Before(subscribeTime.Add(-watchRecordsLookback)) {
+					w.resent[record.ID] = struct{}{}
+					if until := record.CreateTime.Add(watchRecordsLookback); until.After(w.resentUntil) {
+						w.resentUntil = until
+					}
+				}
+
+				if err := w.send(record); err != nil {
+					return err
+				}
+			}
+
+			if len(records) < watchRecordsPageSize {
+				break
+			}
+		}
+	}
+
+	// Resent records are notified within the lookback after their create
+	// time, if at all, so they are forgotten afterwards.
+	var resentExpired <-chan time.Time
+	if len(w.resent) > 0 {
+		timer := time.NewTimer(w.resentUntil.Sub(s.now()))
+		defer timer.Stop()
+		resentExpired = timer.C
+	}
+
+	for {
+		var ids []aud.ID
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-resentExpired:
+			w.resent = nil
+			resentExpired = nil
+			continue
+		case ids, ok = <-created:
+		}
+		if !ok {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return status.Error(
+				codes.Aborted,
+				"Watch is interrupted and records may be missed. Resume with the last page token.",
+			)
+		}
+
+		idsFilter := filter
+		idsFilter.IDs = ids
+
+		records, err := s.store.ListRecordsCreatedAfter(ctx, projectID, idsFilter, int32(len(ids)), aud.RecordWatchCursor{})
+		if err != nil {
+			return s.watchRecordsError(ctx, projectID, err)
+		}
+
+		for _, record := range records {
+			if _, ok := w.resent[record.ID]; ok {
+				delete(w.resent, record.ID)
+				continue
+			}
+
+			if err := w.send(record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *RecordServiceServer) watchRecordsError(ctx context.Context, projectID aud.ID, err error) error {
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return status.Error(codes.NotFound, "Project not found.")
+	}
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	s.log.Error("List created records in store",
+		zap.String("project_id", projectID.String()),
+		zap.Error(err),
+	)
+	return status.Errorf(codes.Internal, "")
+}
+
+// recordsWatch sends watched records with page tokens to resume watching.
+type recordsWatch struct {
+	stream auditumv1alpha1.RecordService_WatchRecordsServer
+	cursor aud.RecordWatchCursor
+	// resent are records sent when resuming, which may be notified as well.
+	resent map[aud.ID]struct{}
+	// resentUntil is the time after which resent records are not notified.
+	resentUntil time.Time
+}
+
+func (w *recordsWatch) send(record aud.Record) error {
+	w.cursor = w.cursor.Advance(record)
+
+	token, err := aud.EncodePageToken(w.cursor)
+	if err != nil {
+		return status.Errorf(codes.Internal, "")
+	}
+
+	return w.stream.Send(&auditumv1alpha1.WatchRecordsResponse{
+		Record:        encodeRecord(record),
+		NextPageToken: token,
+	})
+}
+
 func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1alpha1.UpdateRecordRequest) (*auditumv1alpha1.UpdateRecordResponse, error) {
 	if !s.settings.Records.UpdateEnabled {
 		return nil, status.Error(codes.Unimplemented, "UpdateRecord is disabled.")
@@ -265,6 +817,11 @@ func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1al
 		)
 	}
 
+	restrictions, err := s.recordsRestrictions(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
 	var update aud.RecordUpdate
 
 	for _, path := range paths {
@@ -272,7 +829,7 @@ func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1al
 		case "labels":
 			labels, err := decodeLabels(
 				req.GetRecord().GetLabels(),
-				s.settings.Records.Restrictions.Labels,
+				restrictions.Labels,
 			)
 			if err != nil {
 				return nil, status.Errorf(
@@ -286,7 +843,7 @@ func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1al
 		case "resource":
 			resource, err := decodeResource(
 				req.GetRecord().GetResource(),
-				s.settings.Records.Restrictions.Resource,
+				restrictions.Resource,
 			)
 			if err != nil {
 				return nil, status.Errorf(
@@ -300,7 +857,7 @@ func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1al
 		case "operation":
 			operation, err := decodeOperation(
 				req.GetRecord().GetOperation(),
-				s.settings.Records.Restrictions.Operation,
+				restrictions.Operation,
 			)
 			if err != nil {
 				return nil, status.Errorf(
@@ -314,7 +871,7 @@ func (s *RecordServiceServer) UpdateRecord(ctx context.Context, req *auditumv1al
 		case "actor":
 			actor, err := decodeActor(
 				req.GetRecord().GetActor(),
-				s.settings.Records.Restrictions.Actor,
+				restrictions.Actor,
 			)
 			if err != nil {
 				return nil, status.Errorf(
@@ -404,5 +961,87 @@ func (s *RecordServiceServer) RegisterServer(srv *grpc.Server) {
 }
 
 func (s *RecordServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return auditumv1alpha1.RegisterRecordServiceHandler(ctx, mux, conn)
+	if err := auditumv1alpha1.RegisterRecordServiceHandler(ctx, mux, conn); err != nil {
+		return err
+	}
+
+	export := &recordExportHandler{
+		mux:    mux,
+		client: auditumv1alpha1.NewRecordServiceClient(conn),
+		log:    s.log,
+	}
+
+	if err := mux.HandlePath(http.MethodGet, exportRecordsPathPattern, export.ServeHTTP); err != nil {
+		return err
+	}
+
+	watch := &recordWatchHandler{
+		mux:    mux,
+		client: auditumv1alpha1.NewRecordServiceClient(conn),
+		log:    s.log,
+	}
+
+	return mux.HandlePath(http.MethodGet, watchRecordsPathPattern, watch.ServeHTTP)
+}
+
+// recordsRestrictions returns restrictions for records of the project, which
+// are the global settings merged with the project overrides. The returned
+// error is a gRPC status.
+func (s *RecordServiceServer) recordsRestrictions(
+	ctx context.Context,
+	projectID aud.ID,
+) (aud.RecordsRestrictions, error) {
+	project, err := s.store.GetProject(ctx, projectID)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return aud.RecordsRestrictions{}, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("Get project from store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return aud.RecordsRestrictions{}, status.Errorf(codes.Internal, "")
+	}
+
+	return s.settings.Records.Restrictions.Merge(project.RecordsRestrictions), nil
+}
+
+// quotaExceededError returns RESOURCE_EXHAUSTED status with details of the
+// exceeded quota. When the quota resets over time, retry delay is also sent
+// in "retry-after" header, which is forwarded by the gateway to HTTP clients.
+func quotaExceededError(ctx context.Context, src *aud.QuotaExceededError) error {
+	metrics.QuotaExceeded(src.ProjectID, src.Quota)
+
+	st := status.Newf(
+		codes.ResourceExhausted,
+		"Project quota exceeded: %s is limited to %d.",
+		src.Quota,
+		src.Limit,
+	)
+
+	details := []protoadapt.MessageV1{
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     "project:" + src.ProjectID.String(),
+					Description: fmt.Sprintf("%s is limited to %d", src.Quota, src.Limit),
+				},
+			},
+		},
+	}
+
+	if src.RetryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(src.RetryDelay),
+		})
+
+		seconds := int64(math.Ceil(src.RetryDelay.Seconds()))
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
 }
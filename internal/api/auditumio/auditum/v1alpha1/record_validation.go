@@ -79,6 +79,17 @@ func validateLabelOrMetadataValue(src string, restrictions aud.RestrictionsKeyVa
 	return nil
 }
 
+// maxIdempotencyKeySize is the maximum size of an idempotency key in bytes.
+const maxIdempotencyKeySize = 256
+
+func validateIdempotencyKey(src string) error {
+	if len(src) > maxIdempotencyKeySize {
+		return fmt.Errorf("must be at most %d bytes", maxIdempotencyKeySize)
+	}
+
+	return nil
+}
+
 func validateResourceType(src string, restrictions aud.RestrictionsString) error {
 	if len(src) == 0 {
 		return fmt.Errorf("must not be empty")
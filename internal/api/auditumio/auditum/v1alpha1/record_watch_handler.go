@@ -0,0 +1,212 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+)
+
+const watchRecordsPathPattern = "/projects/{project_id}/records:watch"
+
+// watchKeepaliveInterval is the interval between comments sent to keep idle
+// connections open through proxies.
+const watchKeepaliveInterval = 15 * time.Second
+
+var watchRecordsQueryFilter = utilities.NewDoubleArray([][]string{{"project_id"}})
+
+// recordWatchHandler serves WatchRecords over HTTP as Server-Sent Events.
+//
+// Every record is sent as a "record" event with the page token as the event
+// id, so that clients resume watching with the Last-Event-ID header after
+// reconnecting.
+type recordWatchHandler struct {
+	mux    *runtime.ServeMux
+	client auditumv1alpha1.RecordServiceClient
+	log    *zap.Logger
+}
+
+func (h *recordWatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+
+	ctx, err := runtime.AnnotateContext(
+		ctx,
+		h.mux,
+		r,
+		auditumv1alpha1.RecordService_WatchRecords_FullMethodName,
+		runtime.WithHTTPPathPattern(watchRecordsPathPattern),
+	)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+
+	req := &auditumv1alpha1.WatchRecordsRequest{
+		ProjectId: pathParams["project_id"],
+	}
+	if err := runtime.PopulateQueryParameters(req, r.URL.Query(), watchRecordsQueryFilter); err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		req.PageToken = lastEventID
+	}
+
+	stream, err := h.client.WatchRecords(ctx, req)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	// The server sends headers once the watch has started. Otherwise the
+	// call has failed, and the error is returned with the appropriate status
+	// code.
+	header, err := stream.Header()
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+	if header == nil {
+		_, err := stream.Recv()
+		if err == nil {
+			err = status.Error(codes.Internal, "")
+		}
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disables response buffering in nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	_ = rc.Flush()
+
+	if err := h.writeEvents(ctx, w, rc, stream); err != nil {
+		if ctx.Err() != nil {
+			// Client has gone away.
+			return
+		}
+
+		h.log.Error("Watch records over HTTP", zap.Error(err))
+
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (h *recordWatchHandler) writeEvents(
+	ctx context.Context,
+	w io.Writer,
+	rc *http.ResponseController,
+	stream auditumv1alpha1.RecordService_WatchRecordsClient,
+) error {
+	type result struct {
+		resp *auditumv1alpha1.WatchRecordsResponse
+		err  error
+	}
+
+	results := make(chan result)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			select {
+			case results <- result{resp: resp, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	keepalive := time.NewTicker(watchKeepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-keepalive.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return fmt.Errorf("write keepalive: %v", err)
+			}
+		case res := <-results:
+			if res.err != nil {
+				if errors.Is(res.err, io.EOF) {
+					return nil
+				}
+
+				// Let the client know why the watch has ended, e.g. to
+				// resume watching after ABORTED.
+				if err := writeWatchErrorEvent(w, res.err); err != nil {
+					return fmt.Errorf("write error event: %v", err)
+				}
+				_ = rc.Flush()
+				return nil
+			}
+
+			if err := writeWatchRecordEvent(w, res.resp); err != nil {
+				return fmt.Errorf("write record event: %v", err)
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return fmt.Errorf("flush events: %v", err)
+		}
+	}
+}
+
+func writeWatchRecordEvent(w io.Writer, resp *auditumv1alpha1.WatchRecordsResponse) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp.GetRecord())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: record\ndata: %s\n\n", resp.GetNextPageToken(), data)
+	return err
+}
+
+func writeWatchErrorEvent(w io.Writer, err error) error {
+	st := status.Convert(err)
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(st.Proto())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	return err
+}
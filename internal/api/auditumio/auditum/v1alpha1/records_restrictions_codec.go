@@ -0,0 +1,254 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"fmt"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func decodeRecordsRestrictions(src *auditumv1alpha1.RecordsRestrictions) (dst aud.RecordsRestrictions, err error) {
+	if dst.Labels, err = decodeRestrictionsKeyValue(src.GetLabels()); err != nil {
+		return dst, fmt.Errorf(`invalid "labels": %v`, err)
+	}
+
+	if dst.Resource, err = decodeRestrictionsResource(src.GetResource()); err != nil {
+		return dst, fmt.Errorf(`invalid "resource": %v`, err)
+	}
+
+	if dst.Operation, err = decodeRestrictionsOperation(src.GetOperation()); err != nil {
+		return dst, fmt.Errorf(`invalid "operation": %v`, err)
+	}
+
+	if dst.Actor, err = decodeRestrictionsActor(src.GetActor()); err != nil {
+		return dst, fmt.Errorf(`invalid "actor": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsResource(src *auditumv1alpha1.RecordsRestrictions_Resource) (dst aud.RecordsRestrictionsResource, err error) {
+	if dst.Type, err = decodeRestrictionsString(src.GetType()); err != nil {
+		return dst, fmt.Errorf(`invalid "type": %v`, err)
+	}
+
+	if dst.ID, err = decodeRestrictionsString(src.GetId()); err != nil {
+		return dst, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	if dst.Metadata, err = decodeRestrictionsKeyValue(src.GetMetadata()); err != nil {
+		return dst, fmt.Errorf(`invalid "metadata": %v`, err)
+	}
+
+	if dst.Changes, err = decodeRestrictionsResourceChanges(src.GetChanges()); err != nil {
+		return dst, fmt.Errorf(`invalid "changes": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsResourceChanges(src *auditumv1alpha1.RecordsRestrictions_ResourceChanges) (dst aud.RecordsRestrictionsResourceChanges, err error) {
+	if dst.TotalMaxCount, err = decodeRestrictionsLimit(src.GetTotalMaxCount()); err != nil {
+		return dst, fmt.Errorf(`invalid "total_max_count": %v`, err)
+	}
+
+	if dst.Name, err = decodeRestrictionsString(src.GetName()); err != nil {
+		return dst, fmt.Errorf(`invalid "name": %v`, err)
+	}
+
+	if dst.Description, err = decodeRestrictionsString(src.GetDescription()); err != nil {
+		return dst, fmt.Errorf(`invalid "description": %v`, err)
+	}
+
+	if dst.OldValue, err = decodeRestrictionsBytes(src.GetOldValue()); err != nil {
+		return dst, fmt.Errorf(`invalid "old_value": %v`, err)
+	}
+
+	if dst.NewValue, err = decodeRestrictionsBytes(src.GetNewValue()); err != nil {
+		return dst, fmt.Errorf(`invalid "new_value": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsOperation(src *auditumv1alpha1.RecordsRestrictions_Operation) (dst aud.RecordsRestrictionsOperation, err error) {
+	if dst.Type, err = decodeRestrictionsString(src.GetType()); err != nil {
+		return dst, fmt.Errorf(`invalid "type": %v`, err)
+	}
+
+	if dst.ID, err = decodeRestrictionsString(src.GetId()); err != nil {
+		return dst, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	if dst.Metadata, err = decodeRestrictionsKeyValue(src.GetMetadata()); err != nil {
+		return dst, fmt.Errorf(`invalid "metadata": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsActor(src *auditumv1alpha1.RecordsRestrictions_Actor) (dst aud.RecordsRestrictionsActor, err error) {
+	if dst.Type, err = decodeRestrictionsString(src.GetType()); err != nil {
+		return dst, fmt.Errorf(`invalid "type": %v`, err)
+	}
+
+	if dst.ID, err = decodeRestrictionsString(src.GetId()); err != nil {
+		return dst, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	if dst.Metadata, err = decodeRestrictionsKeyValue(src.GetMetadata()); err != nil {
+		return dst, fmt.Errorf(`invalid "metadata": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsKeyValue(src *auditumv1alpha1.RecordsRestrictions_KeyValue) (dst aud.RestrictionsKeyValue, err error) {
+	if dst.KeyMaxSizeBytes, err = decodeRestrictionsLimit(src.GetKeyMaxSizeBytes()); err != nil {
+		return dst, fmt.Errorf(`invalid "key_max_size_bytes": %v`, err)
+	}
+
+	if dst.ValueMaxSizeBytes, err = decodeRestrictionsLimit(src.GetValueMaxSizeBytes()); err != nil {
+		return dst, fmt.Errorf(`invalid "value_max_size_bytes": %v`, err)
+	}
+
+	if dst.TotalMaxSizeBytes, err = decodeRestrictionsLimit(src.GetTotalMaxSizeBytes()); err != nil {
+		return dst, fmt.Errorf(`invalid "total_max_size_bytes": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsString(src *auditumv1alpha1.RecordsRestrictions_String) (dst aud.RestrictionsString, err error) {
+	if dst.MaxSizeBytes, err = decodeRestrictionsLimit(src.GetMaxSizeBytes()); err != nil {
+		return dst, fmt.Errorf(`invalid "max_size_bytes": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsBytes(src *auditumv1alpha1.RecordsRestrictions_Bytes) (dst aud.RestrictionsBytes, err error) {
+	if dst.MaxSizeBytes, err = decodeRestrictionsLimit(src.GetMaxSizeBytes()); err != nil {
+		return dst, fmt.Errorf(`invalid "max_size_bytes": %v`, err)
+	}
+
+	return dst, nil
+}
+
+func decodeRestrictionsLimit(src int32) (int, error) {
+	if src < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+
+	return int(src), nil
+}
+
+func encodeRecordsRestrictions(src aud.RecordsRestrictions) *auditumv1alpha1.RecordsRestrictions {
+	if src == (aud.RecordsRestrictions{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions{
+		Labels:    encodeRestrictionsKeyValue(src.Labels),
+		Resource:  encodeRestrictionsResource(src.Resource),
+		Operation: encodeRestrictionsOperation(src.Operation),
+		Actor:     encodeRestrictionsActor(src.Actor),
+	}
+}
+
+func encodeRestrictionsResource(src aud.RecordsRestrictionsResource) *auditumv1alpha1.RecordsRestrictions_Resource {
+	if src == (aud.RecordsRestrictionsResource{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_Resource{
+		Type:     encodeRestrictionsString(src.Type),
+		Id:       encodeRestrictionsString(src.ID),
+		Metadata: encodeRestrictionsKeyValue(src.Metadata),
+		Changes:  encodeRestrictionsResourceChanges(src.Changes),
+	}
+}
+
+func encodeRestrictionsResourceChanges(src aud.RecordsRestrictionsResourceChanges) *auditumv1alpha1.RecordsRestrictions_ResourceChanges {
+	if src == (aud.RecordsRestrictionsResourceChanges{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_ResourceChanges{
+		TotalMaxCount: int32(src.TotalMaxCount),
+		Name:          encodeRestrictionsString(src.Name),
+		Description:   encodeRestrictionsString(src.Description),
+		OldValue:      encodeRestrictionsBytes(src.OldValue),
+		NewValue:      encodeRestrictionsBytes(src.NewValue),
+	}
+}
+
+func encodeRestrictionsOperation(src aud.RecordsRestrictionsOperation) *auditumv1alpha1.RecordsRestrictions_Operation {
+	if src == (aud.RecordsRestrictionsOperation{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_Operation{
+		Type:     encodeRestrictionsString(src.Type),
+		Id:       encodeRestrictionsString(src.ID),
+		Metadata: encodeRestrictionsKeyValue(src.Metadata),
+	}
+}
+
+func encodeRestrictionsActor(src aud.RecordsRestrictionsActor) *auditumv1alpha1.RecordsRestrictions_Actor {
+	if src == (aud.RecordsRestrictionsActor{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_Actor{
+		Type:     encodeRestrictionsString(src.Type),
+		Id:       encodeRestrictionsString(src.ID),
+		Metadata: encodeRestrictionsKeyValue(src.Metadata),
+	}
+}
+
+func encodeRestrictionsKeyValue(src aud.RestrictionsKeyValue) *auditumv1alpha1.RecordsRestrictions_KeyValue {
+	if src == (aud.RestrictionsKeyValue{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_KeyValue{
+		KeyMaxSizeBytes:   int32(src.KeyMaxSizeBytes),
+		ValueMaxSizeBytes: int32(src.ValueMaxSizeBytes),
+		TotalMaxSizeBytes: int32(src.TotalMaxSizeBytes),
+	}
+}
+
+func encodeRestrictionsString(src aud.RestrictionsString) *auditumv1alpha1.RecordsRestrictions_String {
+	if src == (aud.RestrictionsString{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_String{
+		MaxSizeBytes: int32(src.MaxSizeBytes),
+	}
+}
+
+func encodeRestrictionsBytes(src aud.RestrictionsBytes) *auditumv1alpha1.RecordsRestrictions_Bytes {
+	if src == (aud.RestrictionsBytes{}) {
+		return nil
+	}
+
+	return &auditumv1alpha1.RecordsRestrictions_Bytes{
+		MaxSizeBytes: int32(src.MaxSizeBytes),
+	}
+}
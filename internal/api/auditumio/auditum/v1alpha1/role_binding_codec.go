@@ -0,0 +1,110 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// principalIDPrefixes are prefixes of principal IDs of the supported
+// authentication methods.
+var principalIDPrefixes = []string{"apikey:", "jwt:", "cert:"}
+
+const principalIDMaxLength = 256
+
+func decodeRoleBinding(src *auditumv1alpha1.RoleBinding) (dst aud.RoleBinding, err error) {
+	if err := validatePrincipalID(src.GetPrincipalId()); err != nil {
+		return dst, fmt.Errorf(`invalid "principal_id": %v`, err)
+	}
+
+	role := decodeProjectRole(src.GetRole())
+	if role == aud.RoleUnspecified {
+		return dst, fmt.Errorf(`invalid "role": must be specified`)
+	}
+
+	return aud.RoleBinding{
+		PrincipalID: src.GetPrincipalId(),
+		Role:        role,
+	}, nil
+}
+
+func validatePrincipalID(id string) error {
+	if id == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if len(id) > principalIDMaxLength {
+		return fmt.Errorf("must be at most %d characters long", principalIDMaxLength)
+	}
+	for _, prefix := range principalIDPrefixes {
+		if strings.HasPrefix(id, prefix) && len(id) > len(prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf(`must start with one of "%s"`, strings.Join(principalIDPrefixes, `", "`))
+}
+
+func decodeProjectRole(src auditumv1alpha1.ProjectRole_Enum) aud.Role {
+	switch src {
+	case auditumv1alpha1.ProjectRole_VIEWER:
+		return aud.RoleViewer
+	case auditumv1alpha1.ProjectRole_WRITER:
+		return aud.RoleWriter
+	case auditumv1alpha1.ProjectRole_EDITOR:
+		return aud.RoleEditor
+	case auditumv1alpha1.ProjectRole_ADMIN:
+		return aud.RoleAdmin
+	default:
+		return aud.RoleUnspecified
+	}
+}
+
+func encodeRoleBinding(src aud.RoleBinding) *auditumv1alpha1.RoleBinding {
+	return &auditumv1alpha1.RoleBinding{
+		ProjectId:   src.ProjectID.String(),
+		PrincipalId: src.PrincipalID,
+		Role:        encodeProjectRole(src.Role),
+		CreateTime:  timestamppb.New(src.CreateTime),
+		UpdateTime:  timestamppb.New(src.UpdateTime),
+	}
+}
+
+func encodeRoleBindings(src []aud.RoleBinding) []*auditumv1alpha1.RoleBinding {
+	dst := make([]*auditumv1alpha1.RoleBinding, len(src))
+	for i := range src {
+		dst[i] = encodeRoleBinding(src[i])
+	}
+	return dst
+}
+
+func encodeProjectRole(src aud.Role) auditumv1alpha1.ProjectRole_Enum {
+	switch src {
+	case aud.RoleViewer:
+		return auditumv1alpha1.ProjectRole_VIEWER
+	case aud.RoleWriter:
+		return auditumv1alpha1.ProjectRole_WRITER
+	case aud.RoleEditor:
+		return auditumv1alpha1.ProjectRole_EDITOR
+	case aud.RoleAdmin:
+		return auditumv1alpha1.ProjectRole_ADMIN
+	default:
+		return auditumv1alpha1.ProjectRole_UNSPECIFIED
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type RoleBindingServiceServer struct {
+	auditumv1alpha1.UnimplementedRoleBindingServiceServer
+
+	store Store
+	log   *zap.Logger
+
+	now func() time.Time
+}
+
+func NewRoleBindingServiceServer(
+	store Store,
+	log *zap.Logger,
+) *RoleBindingServiceServer {
+	return &RoleBindingServiceServer{
+		store: store,
+		log:   log.Named("role_binding_service_server"),
+		now:   time.Now,
+	}
+}
+
+func (s *RoleBindingServiceServer) SetRoleBinding(
+	ctx context.Context,
+	req *auditumv1alpha1.SetRoleBindingRequest,
+) (*auditumv1alpha1.SetRoleBindingResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	binding, err := decodeRoleBinding(req.GetRoleBinding())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "role_binding": %v.`,
+			err.Error(),
+		)
+	}
+
+	now := s.now().UTC()
+	binding.ProjectID = projectID
+	binding.CreateTime = now
+	binding.UpdateTime = now
+
+	binding, err = s.store.SetRoleBinding(ctx, binding)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("Set role binding in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.SetRoleBindingResponse{
+		RoleBinding: encodeRoleBinding(binding),
+	}, nil
+}
+
+func (s *RoleBindingServiceServer) ListRoleBindings(
+	ctx context.Context,
+	req *auditumv1alpha1.ListRoleBindingsRequest,
+) (*auditumv1alpha1.ListRoleBindingsResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	bindings, err := s.store.ListRoleBindings(ctx, projectID)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("List role bindings in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListRoleBindingsResponse{
+		RoleBindings: encodeRoleBindings(bindings),
+	}, nil
+}
+
+func (s *RoleBindingServiceServer) DeleteRoleBinding(
+	ctx context.Context,
+	req *auditumv1alpha1.DeleteRoleBindingRequest,
+) (*auditumv1alpha1.DeleteRoleBindingResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	if err := validatePrincipalID(req.GetPrincipalId()); err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "principal_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	err = s.store.DeleteRoleBinding(ctx, projectID, req.GetPrincipalId())
+	if errors.Is(err, aud.ErrRoleBindingNotFound) {
+		return nil, status.Errorf(codes.NotFound, "")
+	}
+	if err != nil {
+		s.log.Error("Delete role binding in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.DeleteRoleBindingResponse{}, nil
+}
+
+func (s *RoleBindingServiceServer) RegisterServer(srv *grpc.Server) {
+	auditumv1alpha1.RegisterRoleBindingServiceServer(srv, s)
+}
+
+func (s *RoleBindingServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return auditumv1alpha1.RegisterRoleBindingServiceHandler(ctx, mux, conn)
+}
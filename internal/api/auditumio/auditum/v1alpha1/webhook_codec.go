@@ -0,0 +1,161 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"fmt"
+	"net/url"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+const webhookURLMaxLength = 2048
+
+func decodeWebhook(src *auditumv1alpha1.Webhook) (dst aud.Webhook, err error) {
+	// Display names of webhooks have the same requirements as of projects.
+	if err := validateProjectDisplayName(src.GetDisplayName()); err != nil {
+		return dst, fmt.Errorf(`invalid "display_name": %v`, err)
+	}
+
+	if err := validateWebhookURL(src.GetUrl()); err != nil {
+		return dst, fmt.Errorf(`invalid "url": %v`, err)
+	}
+
+	if _, err := decodeFilterExpression(src.GetFilter()); err != nil {
+		return dst, fmt.Errorf(`invalid "filter": %v`, err)
+	}
+
+	return aud.Webhook{
+		DisplayName: src.GetDisplayName(),
+		URL:         src.GetUrl(),
+		Filter:      src.GetFilter(),
+	}, nil
+}
+
+func validateWebhookURL(src string) error {
+	if src == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if len(src) > webhookURLMaxLength {
+		return fmt.Errorf("must be at most %d characters long", webhookURLMaxLength)
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return fmt.Errorf("must be a valid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf(`must have "http" or "https" scheme`)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("must have a host")
+	}
+
+	return nil
+}
+
+func decodeWebhookDeliveryFilter(src *auditumv1alpha1.ListWebhookDeliveriesRequest_Filter) (dst aud.WebhookDeliveryFilter, err error) {
+	for i, s := range src.GetStatuses() {
+		status := decodeWebhookDeliveryStatus(s)
+		if status == aud.WebhookDeliveryStatusUnspecified {
+			return dst, fmt.Errorf(`invalid "statuses": item %d: must be specified`, i)
+		}
+		dst.Statuses = append(dst.Statuses, status)
+	}
+
+	return dst, nil
+}
+
+func decodeWebhookDeliveryStatus(src auditumv1alpha1.WebhookDeliveryStatus_Enum) aud.WebhookDeliveryStatus {
+	switch src {
+	case auditumv1alpha1.WebhookDeliveryStatus_PENDING:
+		return aud.WebhookDeliveryStatusPending
+	case auditumv1alpha1.WebhookDeliveryStatus_SUCCEEDED:
+		return aud.WebhookDeliveryStatusSucceeded
+	case auditumv1alpha1.WebhookDeliveryStatus_FAILED:
+		return aud.WebhookDeliveryStatusFailed
+	default:
+		return aud.WebhookDeliveryStatusUnspecified
+	}
+}
+
+func encodeWebhook(src aud.Webhook) *auditumv1alpha1.Webhook {
+	return &auditumv1alpha1.Webhook{
+		Id:          src.ID.String(),
+		ProjectId:   src.ProjectID.String(),
+		CreateTime:  timestamppb.New(src.CreateTime),
+		DisplayName: src.DisplayName,
+		Url:         src.URL,
+		Filter:      src.Filter,
+	}
+}
+
+func encodeWebhooks(src []aud.Webhook) []*auditumv1alpha1.Webhook {
+	dst := make([]*auditumv1alpha1.Webhook, len(src))
+	for i := range src {
+		dst[i] = encodeWebhook(src[i])
+	}
+	return dst
+}
+
+func encodeWebhookDelivery(src aud.WebhookDelivery) *auditumv1alpha1.WebhookDelivery {
+	var nextAttemptTime *timestamppb.Timestamp
+	if !src.NextAttemptTime.IsZero() {
+		nextAttemptTime = timestamppb.New(src.NextAttemptTime)
+	}
+
+	var lastAttemptTime *timestamppb.Timestamp
+	if !src.LastAttemptTime.IsZero() {
+		lastAttemptTime = timestamppb.New(src.LastAttemptTime)
+	}
+
+	return &auditumv1alpha1.WebhookDelivery{
+		Id:               src.ID.String(),
+		ProjectId:        src.ProjectID.String(),
+		WebhookId:        src.WebhookID.String(),
+		RecordId:         src.RecordID.String(),
+		CreateTime:       timestamppb.New(src.CreateTime),
+		Status:           encodeWebhookDeliveryStatus(src.Status),
+		Attempts:         int32(src.Attempts),
+		NextAttemptTime:  nextAttemptTime,
+		LastAttemptTime:  lastAttemptTime,
+		LastResponseCode: int32(src.LastResponseCode),
+		LastError:        src.LastError,
+	}
+}
+
+func encodeWebhookDeliveries(src []aud.WebhookDelivery) []*auditumv1alpha1.WebhookDelivery {
+	dst := make([]*auditumv1alpha1.WebhookDelivery, len(src))
+	for i := range src {
+		dst[i] = encodeWebhookDelivery(src[i])
+	}
+	return dst
+}
+
+func encodeWebhookDeliveryStatus(src aud.WebhookDeliveryStatus) auditumv1alpha1.WebhookDeliveryStatus_Enum {
+	switch src {
+	case aud.WebhookDeliveryStatusPending:
+		return auditumv1alpha1.WebhookDeliveryStatus_PENDING
+	case aud.WebhookDeliveryStatusSucceeded:
+		return auditumv1alpha1.WebhookDeliveryStatus_SUCCEEDED
+	case aud.WebhookDeliveryStatusFailed:
+		return auditumv1alpha1.WebhookDeliveryStatus_FAILED
+	default:
+		return auditumv1alpha1.WebhookDeliveryStatus_UNSPECIFIED
+	}
+}
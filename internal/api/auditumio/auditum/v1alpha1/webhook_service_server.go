@@ -0,0 +1,287 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditumv1alpha1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/pkg/fragma/grpcx"
+)
+
+type WebhookServiceServer struct {
+	auditumv1alpha1.UnimplementedWebhookServiceServer
+
+	store Store
+	log   *zap.Logger
+
+	id  func() aud.ID
+	now func() time.Time
+}
+
+func NewWebhookServiceServer(
+	store Store,
+	log *zap.Logger,
+) *WebhookServiceServer {
+	return &WebhookServiceServer{
+		store: store,
+		log:   log.Named("webhook_service_server"),
+		id:    aud.MustNewID,
+		now:   time.Now,
+	}
+}
+
+func (s *WebhookServiceServer) CreateWebhook(
+	ctx context.Context,
+	req *auditumv1alpha1.CreateWebhookRequest,
+) (*auditumv1alpha1.CreateWebhookResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	src, err := decodeWebhook(req.GetWebhook())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "webhook": %v.`,
+			err.Error(),
+		)
+	}
+
+	webhook, err := aud.NewWebhook(
+		s.id(),
+		projectID,
+		s.now().UTC(),
+		src.DisplayName,
+		src.URL,
+		src.Filter,
+	)
+	if err != nil {
+		s.log.Error("Generate webhook", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	err = s.store.CreateWebhook(ctx, webhook)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("Create webhook in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.CreateWebhookResponse{
+		Webhook: encodeWebhook(webhook),
+		Secret:  webhook.Secret,
+	}, nil
+}
+
+func (s *WebhookServiceServer) GetWebhook(
+	ctx context.Context,
+	req *auditumv1alpha1.GetWebhookRequest,
+) (*auditumv1alpha1.GetWebhookResponse, error) {
+	projectID, webhookID, err := decodeWebhookIDs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.store.GetWebhook(ctx, projectID, webhookID)
+	if errors.Is(err, aud.ErrWebhookNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found.")
+	}
+	if err != nil {
+		s.log.Error("Get webhook from store",
+			zap.String("project_id", projectID.String()),
+			zap.String("webhook_id", webhookID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.GetWebhookResponse{
+		Webhook: encodeWebhook(webhook),
+	}, nil
+}
+
+func (s *WebhookServiceServer) ListWebhooks(
+	ctx context.Context,
+	req *auditumv1alpha1.ListWebhooksRequest,
+) (*auditumv1alpha1.ListWebhooksResponse, error) {
+	projectID, err := decodeID(req.GetProjectId())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	webhooks, err := s.store.ListWebhooks(ctx, projectID)
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		return nil, status.Error(codes.NotFound, "Project not found.")
+	}
+	if err != nil {
+		s.log.Error("List webhooks in store",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListWebhooksResponse{
+		Webhooks: encodeWebhooks(webhooks),
+	}, nil
+}
+
+func (s *WebhookServiceServer) DeleteWebhook(
+	ctx context.Context,
+	req *auditumv1alpha1.DeleteWebhookRequest,
+) (*auditumv1alpha1.DeleteWebhookResponse, error) {
+	projectID, webhookID, err := decodeWebhookIDs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.DeleteWebhook(ctx, projectID, webhookID)
+	if errors.Is(err, aud.ErrWebhookNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found.")
+	}
+	if err != nil {
+		s.log.Error("Delete webhook from store",
+			zap.String("project_id", projectID.String()),
+			zap.String("webhook_id", webhookID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.DeleteWebhookResponse{}, nil
+}
+
+func (s *WebhookServiceServer) ListWebhookDeliveries(
+	ctx context.Context,
+	req *auditumv1alpha1.ListWebhookDeliveriesRequest,
+) (*auditumv1alpha1.ListWebhookDeliveriesResponse, error) {
+	projectID, webhookID, err := decodeWebhookIDs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := decodeWebhookDeliveryFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "filter": %v.`,
+			err.Error(),
+		)
+	}
+
+	const (
+		defaultPageSize = 10
+		maxPageSize     = 100
+	)
+	pageSize, err := grpcx.GetPageSize(defaultPageSize, maxPageSize, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor aud.WebhookDeliveryCursor
+	if err := aud.DecodePageToken(req.GetPageToken(), &cursor); err != nil {
+		s.log.Warn("Decode page token", zap.Error(err))
+		return nil, status.Error(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "page_token".`,
+		)
+	}
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, projectID, webhookID, filter, pageSize, cursor)
+	if errors.Is(err, aud.ErrWebhookNotFound) {
+		return nil, status.Error(codes.NotFound, "Webhook not found.")
+	}
+	if err != nil {
+		s.log.Error("List webhook deliveries in store",
+			zap.String("project_id", projectID.String()),
+			zap.String("webhook_id", webhookID.String()),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "")
+	}
+
+	cursor = aud.NewWebhookDeliveryCursor(deliveries, pageSize)
+	nextPageToken, err := aud.EncodePageToken(cursor)
+	if err != nil {
+		s.log.Error("Encode page token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &auditumv1alpha1.ListWebhookDeliveriesResponse{
+		Deliveries:    encodeWebhookDeliveries(deliveries),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *WebhookServiceServer) RegisterServer(srv *grpc.Server) {
+	auditumv1alpha1.RegisterWebhookServiceServer(srv, s)
+}
+
+func (s *WebhookServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return auditumv1alpha1.RegisterWebhookServiceHandler(ctx, mux, conn)
+}
+
+type webhookRequest interface {
+	GetProjectId() string
+	GetWebhookId() string
+}
+
+// decodeWebhookIDs returns project and webhook identifiers of the request.
+// The returned error is a gRPC status.
+func decodeWebhookIDs(req webhookRequest) (projectID aud.ID, webhookID aud.ID, err error) {
+	projectID, err = decodeID(req.GetProjectId())
+	if err != nil {
+		return projectID, webhookID, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	webhookID, err = decodeID(req.GetWebhookId())
+	if err != nil {
+		return projectID, webhookID, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "webhook_id": %v.`,
+			err.Error(),
+		)
+	}
+
+	return projectID, webhookID, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1
+
+import (
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+)
+
+// AuthRules returns access rules of the API methods. Export targets the
+// project of all resources in the request.
+func AuthRules() map[string]auth.Rule {
+	return map[string]auth.Rule{
+		exportFullMethodName: {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleWriter,
+			ProjectID: func(req any) string {
+				return exportProjectID(req.(*collectorlogsv1pb.ExportLogsServiceRequest))
+			},
+		},
+	}
+}
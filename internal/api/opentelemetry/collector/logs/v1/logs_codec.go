@@ -0,0 +1,229 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonv1pb "go.opentelemetry.io/proto/otlp/common/v1"
+	logsv1pb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+// Attributes of log records mapped to records. Attributes of the resource
+// apply to all its log records, unless overridden by log record attributes.
+const (
+	attrProjectID       = "audit.project.id"
+	attrLabelsPrefix    = "audit.labels."
+	attrResourceType    = "audit.resource.type"
+	attrResourceID      = "audit.resource.id"
+	attrResourcePrefix  = "audit.resource.metadata."
+	attrOperationType   = "audit.operation.type"
+	attrOperationID     = "audit.operation.id"
+	attrOperationStatus = "audit.operation.status"
+	attrOperationPrefix = "audit.operation.metadata."
+	attrActorType       = "audit.actor.type"
+	attrActorID         = "audit.actor.id"
+	attrActorPrefix     = "audit.actor.metadata."
+)
+
+const traceparentVersion = "00"
+
+// requiredAttrs are attributes a log record must have to be mapped to a
+// record.
+var requiredAttrs = []string{
+	attrResourceType,
+	attrResourceID,
+	attrOperationType,
+	attrOperationID,
+	attrActorType,
+	attrActorID,
+}
+
+var errMissingAttributes = errors.New("missing audit attributes")
+
+// exportProjectID returns the project of the request, which is the project
+// attribute of all resources. Returns empty string if resources have
+// different or no projects.
+func exportProjectID(req *collectorlogsv1pb.ExportLogsServiceRequest) string {
+	projectID := ""
+	for _, rl := range req.GetResourceLogs() {
+		id := attributeString(rl.GetResource().GetAttributes(), attrProjectID)
+		if id == "" || (projectID != "" && id != projectID) {
+			return ""
+		}
+		projectID = id
+	}
+	return projectID
+}
+
+// decodeLogRecord maps the log record to a record. Returns
+// errMissingAttributes if the log record is not an audit log record.
+func decodeLogRecord(
+	projectID string,
+	resourceAttrs []*commonv1pb.KeyValue,
+	src *logsv1pb.LogRecord,
+) (*auditumv1alpha1.Record, error) {
+	attrs := make(map[string]string, len(resourceAttrs)+len(src.GetAttributes()))
+	for _, kv := range resourceAttrs {
+		attrs[kv.GetKey()] = anyValueString(kv.GetValue())
+	}
+	for _, kv := range src.GetAttributes() {
+		attrs[kv.GetKey()] = anyValueString(kv.GetValue())
+	}
+
+	var missing []string
+	for _, key := range requiredAttrs {
+		if attrs[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", errMissingAttributes, strings.Join(missing, ", "))
+	}
+
+	status, err := ingest.ParseOperationStatus(attrs[attrOperationStatus])
+	if err != nil {
+		return nil, fmt.Errorf(`invalid %q: %v`, attrOperationStatus, err)
+	}
+
+	operationTime := src.GetTimeUnixNano()
+	if operationTime == 0 {
+		operationTime = src.GetObservedTimeUnixNano()
+	}
+	if operationTime == 0 {
+		return nil, fmt.Errorf("missing time")
+	}
+
+	return &auditumv1alpha1.Record{
+		ProjectId: projectID,
+		Labels:    attributesWithPrefix(attrs, attrLabelsPrefix),
+		Resource: &auditumv1alpha1.Resource{
+			Type:     attrs[attrResourceType],
+			Id:       attrs[attrResourceID],
+			Metadata: attributesWithPrefix(attrs, attrResourcePrefix),
+		},
+		Operation: &auditumv1alpha1.Operation{
+			Type:         attrs[attrOperationType],
+			Id:           attrs[attrOperationID],
+			Time:         timestamppb.New(time.Unix(0, int64(operationTime))),
+			Metadata:     attributesWithPrefix(attrs, attrOperationPrefix),
+			TraceContext: decodeTraceContext(src),
+			Status:       status,
+		},
+		Actor: &auditumv1alpha1.Actor{
+			Type:     attrs[attrActorType],
+			Id:       attrs[attrActorID],
+			Metadata: attributesWithPrefix(attrs, attrActorPrefix),
+		},
+	}, nil
+}
+
+// decodeTraceContext returns trace context of the log record with valid
+// trace and span ids, or nil.
+func decodeTraceContext(src *logsv1pb.LogRecord) *auditumv1alpha1.TraceContext {
+	traceID := src.GetTraceId()
+	spanID := src.GetSpanId()
+
+	if len(traceID) != 16 || len(spanID) != 8 || isZero(traceID) || isZero(spanID) {
+		return nil
+	}
+
+	traceFlags := src.GetFlags() & uint32(logsv1pb.LogRecordFlags_LOG_RECORD_FLAGS_TRACE_FLAGS_MASK)
+
+	return &auditumv1alpha1.TraceContext{
+		Traceparent: fmt.Sprintf(
+			"%s-%s-%s-%02x",
+			traceparentVersion,
+			hex.EncodeToString(traceID),
+			hex.EncodeToString(spanID),
+			traceFlags,
+		),
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func attributeString(attrs []*commonv1pb.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return anyValueString(kv.GetValue())
+		}
+	}
+	return ""
+}
+
+func attributesWithPrefix(attrs map[string]string, prefix string) map[string]string {
+	var dst map[string]string
+	for key, value := range attrs {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok || name == "" {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string)
+		}
+		dst[name] = value
+	}
+	return dst
+}
+
+// anyValueString formats the value as a string. Arrays and maps are
+// formatted like JSON.
+func anyValueString(v *commonv1pb.AnyValue) string {
+	switch v := v.GetValue().(type) {
+	case *commonv1pb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonv1pb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonv1pb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonv1pb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+	case *commonv1pb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case *commonv1pb.AnyValue_ArrayValue:
+		values := make([]string, 0, len(v.ArrayValue.GetValues()))
+		for _, value := range v.ArrayValue.GetValues() {
+			values = append(values, strconv.Quote(anyValueString(value)))
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	case *commonv1pb.AnyValue_KvlistValue:
+		values := make([]string, 0, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			values = append(values, strconv.Quote(kv.GetKey())+":"+strconv.Quote(anyValueString(kv.GetValue())))
+		}
+		return "{" + strings.Join(values, ",") + "}"
+	default:
+		return ""
+	}
+}
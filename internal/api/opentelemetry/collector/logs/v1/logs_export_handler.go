@@ -0,0 +1,222 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const exportLogsPathPattern = "/v1/logs"
+
+// maxRequestSize is the maximum size of a request body, after
+// decompression.
+const maxRequestSize = 16 << 20
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// logsExportHandler serves Export over OTLP/HTTP.
+//
+// It is used instead of the gateway handler, which supports neither binary
+// protobuf encoding nor hex encoded trace and span ids of OTLP JSON.
+type logsExportHandler struct {
+	mux    *runtime.ServeMux
+	client collectorlogsv1pb.LogsServiceClient
+	log    *zap.Logger
+}
+
+func (h *logsExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != contentTypeProtobuf && contentType != contentTypeJSON {
+		writeError(w, contentTypeJSON, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Content type must be %q or %q.`,
+			contentTypeProtobuf,
+			contentTypeJSON,
+		), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		h.mux,
+		r,
+		exportFullMethodName,
+		runtime.WithHTTPPathPattern(exportLogsPathPattern),
+	)
+	if err != nil {
+		writeError(w, contentType, err, 0)
+		return
+	}
+
+	req, err := readExportRequest(w, r, contentType)
+	if err != nil {
+		writeError(w, contentType, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. %v.`,
+			err.Error(),
+		), 0)
+		return
+	}
+
+	resp, err := h.client.Export(ctx, req)
+	if err != nil {
+		writeError(w, contentType, err, 0)
+		return
+	}
+
+	writeMessage(w, contentType, http.StatusOK, resp)
+}
+
+func readExportRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	contentType string,
+) (*collectorlogsv1pb.ExportLogsServiceRequest, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	switch r.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %v", err)
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, maxRequestSize+1)
+	default:
+		return nil, fmt.Errorf("content encoding must be gzip or identity")
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %v", err)
+	}
+	if len(data) > maxRequestSize {
+		return nil, fmt.Errorf("body must be at most %d bytes", maxRequestSize)
+	}
+
+	req := &collectorlogsv1pb.ExportLogsServiceRequest{}
+
+	if contentType == contentTypeProtobuf {
+		if err := proto.Unmarshal(data, req); err != nil {
+			return nil, fmt.Errorf("invalid protobuf body: %v", err)
+		}
+		return req, nil
+	}
+
+	data, err = decodeJSONIDs(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %v", err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, req); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %v", err)
+	}
+
+	return req, nil
+}
+
+// decodeJSONIDs replaces hex encoded trace and span ids of log records in
+// OTLP JSON with base64 encoded ones, as expected by protojson.
+func decodeJSONIDs(data []byte) ([]byte, error) {
+	var req map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, err
+	}
+
+	for _, rl := range jsonArray(req, "resourceLogs", "resource_logs") {
+		for _, sl := range jsonArray(rl, "scopeLogs", "scope_logs") {
+			for _, lr := range jsonArray(sl, "logRecords", "log_records") {
+				for _, key := range []string{"traceId", "trace_id", "spanId", "span_id"} {
+					s, ok := lr[key].(string)
+					if !ok || s == "" {
+						continue
+					}
+					b, err := hex.DecodeString(s)
+					if err != nil {
+						return nil, fmt.Errorf("invalid %q: %v", key, err)
+					}
+					lr[key] = base64.StdEncoding.EncodeToString(b)
+				}
+			}
+		}
+	}
+
+	return json.Marshal(req)
+}
+
+func jsonArray(obj map[string]any, keys ...string) []map[string]any {
+	var dst []map[string]any
+	for _, key := range keys {
+		values, _ := obj[key].([]any)
+		for _, v := range values {
+			if m, ok := v.(map[string]any); ok {
+				dst = append(dst, m)
+			}
+		}
+	}
+	return dst
+}
+
+// writeError writes the error as google.rpc.Status, with the status code of
+// the gRPC code unless httpStatus is set.
+func writeError(w http.ResponseWriter, contentType string, err error, httpStatus int) {
+	st := status.Convert(err)
+	if httpStatus == 0 {
+		httpStatus = runtime.HTTPStatusFromCode(st.Code())
+	}
+	writeMessage(w, contentType, httpStatus, st.Proto())
+}
+
+func writeMessage(w http.ResponseWriter, contentType string, httpStatus int, m proto.Message) {
+	var (
+		data []byte
+		err  error
+	)
+	if contentType == contentTypeProtobuf {
+		data, err = proto.Marshal(m)
+	} else {
+		data, err = protojson.Marshal(m)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(data)
+}
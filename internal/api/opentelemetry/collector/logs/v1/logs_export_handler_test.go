@@ -0,0 +1,157 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	logsv1 "github.com/auditumio/auditum/internal/api/opentelemetry/collector/logs/v1"
+)
+
+func newGateway(t *testing.T, records *fakeRecords) http.Handler {
+	t.Helper()
+
+	server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	server.RegisterServer(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, server.RegisterGateway(context.Background(), mux, conn))
+
+	return mux
+}
+
+func TestLogsServiceServer_ExportHTTP(t *testing.T) {
+	t.Run("Should accept JSON with hex encoded ids", func(t *testing.T) {
+		records := &fakeRecords{}
+		handler := newGateway(t, records)
+
+		body := `{
+			"resourceLogs": [{
+				"resource": {"attributes": [
+					{"key": "audit.project.id", "value": {"stringValue": "` + testProjectID + `"}},
+					{"key": "audit.resource.type", "value": {"stringValue": "document"}},
+					{"key": "audit.actor.type", "value": {"stringValue": "user"}}
+				]},
+				"scopeLogs": [{"logRecords": [{
+					"timeUnixNano": "1792411200000000000",
+					"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+					"spanId": "00f067aa0ba902b7",
+					"attributes": [
+						{"key": "audit.resource.id", "value": {"stringValue": "42"}},
+						{"key": "audit.operation.type", "value": {"stringValue": "update"}},
+						{"key": "audit.operation.id", "value": {"stringValue": "op-1"}},
+						{"key": "audit.actor.id", "value": {"stringValue": "alice"}}
+					]
+				}]}]
+			}]
+		}`
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.Len(t, records.created, 1)
+		assert.Equal(
+			t,
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			records.created[0].GetOperation().GetTraceContext().GetTraceparent(),
+		)
+	})
+
+	t.Run("Should accept gzip compressed protobuf", func(t *testing.T) {
+		records := &fakeRecords{}
+		handler := newGateway(t, records)
+
+		data, err := proto.Marshal(exportRequest(testProjectID, auditLogRecord("alice"), auditLogRecord("invalid")))
+		require.NoError(t, err)
+
+		var body bytes.Buffer
+		gz := gzip.NewWriter(&body)
+		_, _ = gz.Write(data)
+		require.NoError(t, gz.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/logs", &body)
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		req.Header.Set("Content-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-protobuf", rec.Header().Get("Content-Type"))
+
+		var resp collectorlogsv1pb.ExportLogsServiceResponse
+		require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.EqualValues(t, 1, resp.GetPartialSuccess().GetRejectedLogRecords())
+		assert.Len(t, records.created, 1)
+	})
+
+	t.Run("Should return status of failed request", func(t *testing.T) {
+		handler := newGateway(t, &fakeRecords{})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader(`{"resourceLogs": [`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":3`)
+	})
+
+	t.Run("Should reject unsupported content type", func(t *testing.T) {
+		handler := newGateway(t, &fakeRecords{})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/logs", strings.NewReader("logs"))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logsv1 implements OpenTelemetry logs collector service, which
+// creates records from log records with audit attributes.
+package logsv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+const metricsSource = "otlp"
+
+// exportFullMethodName is the full gRPC method name of Export, which is not
+// generated by the version of OTLP protos in use.
+const exportFullMethodName = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
+type LogsServiceServer struct {
+	collectorlogsv1pb.UnimplementedLogsServiceServer
+
+	records ingest.RecordCreator
+	log     *zap.Logger
+}
+
+func NewLogsServiceServer(records ingest.RecordCreator, log *zap.Logger) *LogsServiceServer {
+	return &LogsServiceServer{
+		records: records,
+		log:     log.Named("logs_service_server"),
+	}
+}
+
+// Export creates records from log records with audit attributes. Log
+// records without the required attributes, or mapped to invalid records, are
+// dropped and reported as rejected.
+func (s *LogsServiceServer) Export(
+	ctx context.Context,
+	req *collectorlogsv1pb.ExportLogsServiceRequest,
+) (*collectorlogsv1pb.ExportLogsServiceResponse, error) {
+	total := 0
+	for _, rl := range req.GetResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			total += len(sl.GetLogRecords())
+		}
+	}
+	if total == 0 {
+		return &collectorlogsv1pb.ExportLogsServiceResponse{}, nil
+	}
+
+	projectID := exportProjectID(req)
+	if projectID == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. All resources must have the same %q attribute.`,
+			attrProjectID,
+		)
+	}
+
+	var (
+		records  []*auditumv1alpha1.Record
+		rejected int64
+		firstErr error
+	)
+
+	for _, rl := range req.GetResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			for _, lr := range sl.GetLogRecords() {
+				record, err := decodeLogRecord(projectID, rl.GetResource().GetAttributes(), lr)
+				if err != nil {
+					if errors.Is(err, errMissingAttributes) {
+						metrics.IngestedMessage(metricsSource, ingest.ResultUnmatched)
+					} else {
+						metrics.IngestedMessage(metricsSource, ingest.ResultInvalid)
+					}
+					rejected++
+					if firstErr == nil {
+						firstErr = err
+					}
+					continue
+				}
+				records = append(records, record)
+			}
+		}
+	}
+
+	invalid, err := ingest.CreateRecords(ctx, s.records, metricsSource, projectID, records)
+	if err != nil {
+		return nil, err
+	}
+	rejected += int64(len(invalid))
+	if firstErr == nil && len(invalid) > 0 {
+		firstErr = invalid[0]
+	}
+
+	resp := &collectorlogsv1pb.ExportLogsServiceResponse{}
+	if rejected > 0 {
+		resp.PartialSuccess = &collectorlogsv1pb.ExportLogsPartialSuccess{
+			RejectedLogRecords: rejected,
+			ErrorMessage:       fmt.Sprintf("Log records dropped: %v.", firstErr),
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *LogsServiceServer) RegisterServer(srv *grpc.Server) {
+	collectorlogsv1pb.RegisterLogsServiceServer(srv, s)
+}
+
+// RegisterGateway registers OTLP/HTTP endpoint of Export at "/v1/logs",
+// accepting both binary protobuf and JSON encoded requests.
+func (s *LogsServiceServer) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	export := &logsExportHandler{
+		mux:    mux,
+		client: collectorlogsv1pb.NewLogsServiceClient(conn),
+		log:    s.log,
+	}
+
+	return mux.HandlePath(http.MethodPost, exportLogsPathPattern, export.ServeHTTP)
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonv1pb "go.opentelemetry.io/proto/otlp/common/v1"
+	logsv1pb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcev1pb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	logsv1 "github.com/auditumio/auditum/internal/api/opentelemetry/collector/logs/v1"
+)
+
+const testProjectID = "0190dc5c-1b0d-7bd4-9b0e-5e3a1e1b1e1b"
+
+var testTime = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// fakeRecords creates records, rejecting records of invalid actors.
+type fakeRecords struct {
+	created []*auditumv1alpha1.Record
+	batches int
+}
+
+func (f *fakeRecords) CreateRecord(
+	_ context.Context,
+	req *auditumv1alpha1.CreateRecordRequest,
+) (*auditumv1alpha1.CreateRecordResponse, error) {
+	if req.GetRecord().GetActor().GetId() == "invalid" {
+		return nil, status.Error(codes.InvalidArgument, `Request is invalid. Invalid "record": invalid "actor".`)
+	}
+	f.created = append(f.created, req.GetRecord())
+	return &auditumv1alpha1.CreateRecordResponse{Record: req.GetRecord()}, nil
+}
+
+func (f *fakeRecords) BatchCreateRecords(
+	_ context.Context,
+	req *auditumv1alpha1.BatchCreateRecordsRequest,
+) (*auditumv1alpha1.BatchCreateRecordsResponse, error) {
+	f.batches++
+	for _, record := range req.GetRecords() {
+		if record.GetActor().GetId() == "invalid" {
+			return nil, status.Error(codes.InvalidArgument, `Request is invalid. Invalid "records".`)
+		}
+	}
+	f.created = append(f.created, req.GetRecords()...)
+	return &auditumv1alpha1.BatchCreateRecordsResponse{Records: req.GetRecords()}, nil
+}
+
+func stringAttr(key, value string) *commonv1pb.KeyValue {
+	return &commonv1pb.KeyValue{
+		Key:   key,
+		Value: &commonv1pb.AnyValue{Value: &commonv1pb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func auditLogRecord(actorID string) *logsv1pb.LogRecord {
+	return &logsv1pb.LogRecord{
+		TimeUnixNano: uint64(testTime.UnixNano()),
+		Attributes: []*commonv1pb.KeyValue{
+			stringAttr("audit.resource.id", "42"),
+			stringAttr("audit.operation.type", "update"),
+			stringAttr("audit.operation.id", "op-1"),
+			stringAttr("audit.actor.id", actorID),
+		},
+	}
+}
+
+func exportRequest(projectID string, records ...*logsv1pb.LogRecord) *collectorlogsv1pb.ExportLogsServiceRequest {
+	return &collectorlogsv1pb.ExportLogsServiceRequest{
+		ResourceLogs: []*logsv1pb.ResourceLogs{
+			{
+				Resource: &resourcev1pb.Resource{
+					Attributes: []*commonv1pb.KeyValue{
+						stringAttr("audit.project.id", projectID),
+						stringAttr("audit.resource.type", "document"),
+						stringAttr("audit.actor.type", "user"),
+					},
+				},
+				ScopeLogs: []*logsv1pb.ScopeLogs{
+					{LogRecords: records},
+				},
+			},
+		},
+	}
+}
+
+func TestLogsServiceServer_Export(t *testing.T) {
+	t.Run("Should create records from audit log records", func(t *testing.T) {
+		records := &fakeRecords{}
+		server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+		lr := auditLogRecord("alice")
+		lr.TraceId = []byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+		lr.SpanId = []byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+		lr.Flags = 1
+		lr.Attributes = append(lr.Attributes,
+			stringAttr("audit.operation.status", "failed"),
+			stringAttr("audit.labels.env", "prod"),
+			stringAttr("audit.actor.metadata.ip", "10.0.0.1"),
+			&commonv1pb.KeyValue{
+				Key:   "audit.operation.metadata.attempt",
+				Value: &commonv1pb.AnyValue{Value: &commonv1pb.AnyValue_IntValue{IntValue: 3}},
+			},
+			stringAttr("http.method", "PUT"),
+		)
+
+		resp, err := server.Export(context.Background(), exportRequest(testProjectID, lr))
+		require.NoError(t, err)
+		assert.Nil(t, resp.GetPartialSuccess())
+
+		want := &auditumv1alpha1.Record{
+			ProjectId: testProjectID,
+			Labels:    map[string]string{"env": "prod"},
+			Resource: &auditumv1alpha1.Resource{
+				Type: "document",
+				Id:   "42",
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type:     "update",
+				Id:       "op-1",
+				Time:     timestamppb.New(testTime),
+				Metadata: map[string]string{"attempt": "3"},
+				TraceContext: &auditumv1alpha1.TraceContext{
+					Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				},
+				Status: auditumv1alpha1.OperationStatus_FAILED,
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type:     "user",
+				Id:       "alice",
+				Metadata: map[string]string{"ip": "10.0.0.1"},
+			},
+		}
+		require.Len(t, records.created, 1)
+		assert.True(t, proto.Equal(want, records.created[0]), "got %v", records.created[0])
+	})
+
+	t.Run("Should drop log records without audit attributes", func(t *testing.T) {
+		records := &fakeRecords{}
+		server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+		plain := &logsv1pb.LogRecord{
+			TimeUnixNano: uint64(testTime.UnixNano()),
+			Body:         &commonv1pb.AnyValue{Value: &commonv1pb.AnyValue_StringValue{StringValue: "request served"}},
+		}
+
+		resp, err := server.Export(context.Background(), exportRequest(testProjectID, auditLogRecord("alice"), plain))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, resp.GetPartialSuccess().GetRejectedLogRecords())
+		assert.Contains(t, resp.GetPartialSuccess().GetErrorMessage(), "audit.operation.type")
+		assert.Len(t, records.created, 1)
+	})
+
+	t.Run("Should drop only invalid records", func(t *testing.T) {
+		records := &fakeRecords{}
+		server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+		resp, err := server.Export(context.Background(), exportRequest(
+			testProjectID,
+			auditLogRecord("alice"),
+			auditLogRecord("invalid"),
+			auditLogRecord("bob"),
+		))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, resp.GetPartialSuccess().GetRejectedLogRecords())
+		assert.Contains(t, resp.GetPartialSuccess().GetErrorMessage(), `invalid "actor"`)
+		require.Len(t, records.created, 2)
+		assert.Equal(t, "alice", records.created[0].GetActor().GetId())
+		assert.Equal(t, "bob", records.created[1].GetActor().GetId())
+	})
+
+	t.Run("Should reject request with resources of different projects", func(t *testing.T) {
+		records := &fakeRecords{}
+		server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+		req := exportRequest(testProjectID, auditLogRecord("alice"))
+		other := exportRequest("0190dc5c-1b0d-7bd4-9b0e-000000000000", auditLogRecord("bob"))
+		req.ResourceLogs = append(req.ResourceLogs, other.ResourceLogs...)
+
+		_, err := server.Export(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Empty(t, records.created)
+	})
+
+	t.Run("Should accept empty request", func(t *testing.T) {
+		records := &fakeRecords{}
+		server := logsv1.NewLogsServiceServer(records, zap.NewNop())
+
+		_, err := server.Export(context.Background(), &collectorlogsv1pb.ExportLogsServiceRequest{})
+		require.NoError(t, err)
+		assert.Zero(t, records.batches)
+	})
+}
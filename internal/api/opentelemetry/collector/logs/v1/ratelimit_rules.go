@@ -0,0 +1,30 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1
+
+import (
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+// RateLimitRules returns rate limiting rules of the API methods. Requests
+// target the same projects as in access rules.
+func RateLimitRules() map[string]ratelimit.Rule {
+	return map[string]ratelimit.Rule{
+		exportFullMethodName: {
+			Class:     ratelimit.ClassWrite,
+			ProjectID: AuthRules()[exportFullMethodName].ProjectID,
+		},
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logsv1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	collectorlogsv1pb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+
+	logsv1 "github.com/auditumio/auditum/internal/api/opentelemetry/collector/logs/v1"
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+func TestRateLimitRules(t *testing.T) {
+	rules := logsv1.RateLimitRules()
+	authRules := logsv1.AuthRules()
+
+	desc := collectorlogsv1pb.LogsService_ServiceDesc
+
+	for _, m := range desc.Methods {
+		fullMethod := "/" + desc.ServiceName + "/" + m.MethodName
+		assert.Contains(t, rules, fullMethod, "missing rate limit rule for method")
+
+		rule := rules[fullMethod]
+		assert.Equal(t, ratelimit.ClassWrite, rule.Class)
+		assert.Equal(t, authRules[fullMethod].ProjectID != nil, rule.ProjectID != nil)
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/auditumio/auditum/internal/aud/expr"
+)
+
+// AlertRule detects patterns in records created in the project. Records are
+// grouped by values of GroupBy fields, and an alert is fired when records
+// of a group satisfy the condition within Window of operation time.
+//
+// Exactly one of Threshold and Sequence is set.
+type AlertRule struct {
+	ID          ID
+	ProjectID   ID
+	CreateTime  time.Time
+	DisplayName string
+	Threshold   *AlertRuleThreshold
+	Sequence    *AlertRuleSequence
+	// GroupBy are record fields, e.g. "actor.id" or "labels.env". Records
+	// without a value of any of the fields are not evaluated. All records
+	// are in the same group if empty.
+	GroupBy []string
+	Window  time.Duration
+}
+
+// AlertRuleThreshold fires an alert when Count records matching Filter are
+// created within the window.
+type AlertRuleThreshold struct {
+	// Filter is a filter expression of counted records. Counts all records
+	// if empty.
+	Filter string
+	Count  int
+}
+
+// AlertRuleSequence fires an alert when records matching Steps are created
+// one after another within the window.
+type AlertRuleSequence struct {
+	// Steps are filter expressions, at least two.
+	Steps []string
+}
+
+// Alert is fired by an alert rule.
+type Alert struct {
+	ID         ID
+	ProjectID  ID
+	RuleID     ID
+	CreateTime time.Time
+	// Group holds values of group by fields of the rule.
+	Group map[string]string
+	// RecordIDs are the records which fired the alert, ordered by operation
+	// time.
+	RecordIDs          []ID
+	FirstOperationTime time.Time
+	LastOperationTime  time.Time
+}
+
+type AlertFilter struct {
+	RuleID *ID
+}
+
+type AlertCursor struct {
+	LastID *ID `json:"lid,omitempty"`
+}
+
+func (c AlertCursor) Empty() bool {
+	return c.LastID == nil
+}
+
+func NewAlertCursor(alerts []Alert, pageSize int32) AlertCursor {
+	var cursor AlertCursor
+
+	if len(alerts) >= int(pageSize) {
+		last := alerts[len(alerts)-1]
+		cursor.LastID = &last.ID
+	}
+
+	return cursor
+}
+
+// alertRuleGroupByMapFields are map fields grouped by the value of a key,
+// e.g. "labels.env".
+var alertRuleGroupByMapFields = []string{
+	"labels",
+	"resource.metadata",
+	"operation.metadata",
+	"actor.metadata",
+}
+
+// ValidateAlertRuleGroupBy checks that the field can be used to group
+// records.
+func ValidateAlertRuleGroupBy(field string) error {
+	switch field {
+	case "resource.type", "resource.id", "operation.type", "operation.id", "actor.type", "actor.id":
+		return nil
+	}
+
+	for _, name := range alertRuleGroupByMapFields {
+		if key, ok := strings.CutPrefix(field, name+"."); ok && key != "" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported field %q", field)
+}
+
+func recordGroupByValue(field string, record Record) (string, bool) {
+	for _, name := range alertRuleGroupByMapFields {
+		if key, ok := strings.CutPrefix(field, name+"."); ok {
+			value, ok := recordMapField(name, record)[key]
+			return value, ok
+		}
+	}
+
+	value := recordStringField(field, record)
+	return value, value != ""
+}
+
+// AlertEvent is a record evaluated by an alert rule.
+type AlertEvent struct {
+	RecordID ID
+	Time     time.Time
+}
+
+// AlertRuleState is the state of an alert rule for a group of records.
+type AlertRuleState struct {
+	// Events are records counted by a threshold rule within the window,
+	// ordered by time.
+	Events []AlertEvent
+	// Sequences are partial matches of a sequence rule. The element at
+	// index i is the match of steps up to i which started the latest, or
+	// nil if there is none.
+	Sequences [][]AlertEvent
+}
+
+// Empty reports whether the state holds no events and can be discarded.
+func (s AlertRuleState) Empty() bool {
+	if len(s.Events) > 0 {
+		return false
+	}
+	for _, seq := range s.Sequences {
+		if len(seq) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// LastTime returns the time of the latest event held by the state, or zero
+// time if the state is empty.
+func (s AlertRuleState) LastTime() time.Time {
+	var last time.Time
+	for _, event := range s.Events {
+		if event.Time.After(last) {
+			last = event.Time
+		}
+	}
+	for _, seq := range s.Sequences {
+		for _, event := range seq {
+			if event.Time.After(last) {
+				last = event.Time
+			}
+		}
+	}
+	return last
+}
+
+// AlertRuleEvaluator evaluates records against an alert rule.
+type AlertRuleEvaluator struct {
+	rule   AlertRule
+	filter expr.Expr
+	steps  []expr.Expr
+}
+
+func NewAlertRuleEvaluator(rule AlertRule) (*AlertRuleEvaluator, error) {
+	e := &AlertRuleEvaluator{rule: rule}
+
+	switch {
+	case rule.Threshold != nil:
+		if rule.Threshold.Filter != "" {
+			filter, err := ParseRecordFilterExpression(rule.Threshold.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("parse filter: %v", err)
+			}
+			e.filter = filter
+		}
+	case rule.Sequence != nil:
+		for i, step := range rule.Sequence.Steps {
+			filter, err := ParseRecordFilterExpression(step)
+			if err != nil {
+				return nil, fmt.Errorf("parse step %d: %v", i, err)
+			}
+			e.steps = append(e.steps, filter)
+		}
+	default:
+		return nil, fmt.Errorf("rule has no condition")
+	}
+
+	return e, nil
+}
+
+func (e *AlertRuleEvaluator) Rule() AlertRule {
+	return e.rule
+}
+
+// Group returns the values of group by fields of the record along with the
+// key identifying the group. It returns false if the record is not relevant
+// to the rule.
+func (e *AlertRuleEvaluator) Group(record Record) (key string, group map[string]string, ok bool) {
+	if !e.relevant(record) {
+		return "", nil, false
+	}
+
+	values := make([]string, len(e.rule.GroupBy))
+	group = make(map[string]string, len(e.rule.GroupBy))
+	for i, field := range e.rule.GroupBy {
+		value, ok := recordGroupByValue(field, record)
+		if !ok {
+			return "", nil, false
+		}
+		values[i] = value
+		group[field] = value
+	}
+
+	return groupKey(values), group, true
+}
+
+func (e *AlertRuleEvaluator) relevant(record Record) bool {
+	if e.rule.Threshold != nil {
+		return MatchRecordExpression(e.filter, record)
+	}
+
+	for _, step := range e.steps {
+		if MatchRecordExpression(step, record) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupKey joins the values unambiguously, each prefixed with its length.
+func groupKey(values []string) string {
+	var b strings.Builder
+	for _, value := range values {
+		fmt.Fprintf(&b, "%d:%s", len(value), value)
+	}
+	return b.String()
+}
+
+// Evaluate adds the record of the group to the state, and returns events of
+// the fired alert, if any. The state is reset after an alert is fired, so
+// that records fire at most one alert of the rule.
+func (e *AlertRuleEvaluator) Evaluate(state *AlertRuleState, record Record) []AlertEvent {
+	event := AlertEvent{
+		RecordID: record.ID,
+		Time:     record.Operation.Time,
+	}
+
+	if e.rule.Threshold != nil {
+		return e.evaluateThreshold(state, event)
+	}
+	return e.evaluateSequence(state, record, event)
+}
+
+func (e *AlertRuleEvaluator) evaluateThreshold(state *AlertRuleState, event AlertEvent) []AlertEvent {
+	i, _ := slices.BinarySearchFunc(state.Events, event, func(a, b AlertEvent) int {
+		return a.Time.Compare(b.Time)
+	})
+	events := slices.Insert(state.Events, i, event)
+
+	// Drop events outside of the window ending with the latest one.
+	start := events[len(events)-1].Time.Add(-e.rule.Window)
+	events = slices.DeleteFunc(events, func(event AlertEvent) bool {
+		return event.Time.Before(start)
+	})
+
+	if len(events) >= e.rule.Threshold.Count {
+		state.Events = nil
+		return events
+	}
+
+	state.Events = events
+	return nil
+}
+
+func (e *AlertRuleEvaluator) evaluateSequence(state *AlertRuleState, record Record, event AlertEvent) []AlertEvent {
+	last := len(e.steps) - 1
+
+	if len(state.Sequences) != last {
+		state.Sequences = make([][]AlertEvent, last)
+	}
+
+	// Drop partial matches which can not complete within the window.
+	for i, seq := range state.Sequences {
+		if len(seq) > 0 && event.Time.Sub(seq[0].Time) > e.rule.Window {
+			state.Sequences[i] = nil
+		}
+	}
+
+	// Steps are checked from the last one, so that the record extends
+	// matches as they were before it.
+	for i := last; i >= 0; i-- {
+		if !MatchRecordExpression(e.steps[i], record) {
+			continue
+		}
+
+		var seq []AlertEvent
+		if i == 0 {
+			seq = []AlertEvent{event}
+		} else {
+			prev := state.Sequences[i-1]
+			if len(prev) == 0 || event.Time.Before(prev[len(prev)-1].Time) {
+				continue
+			}
+			seq = append(slices.Clip(prev), event)
+		}
+
+		if i == last {
+			state.Sequences = nil
+			return seq
+		}
+
+		// A match which started later stays within the window longer.
+		if cur := state.Sequences[i]; len(cur) == 0 || !seq[0].Time.Before(cur[0].Time) {
+			state.Sequences[i] = seq
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestAlertRuleEvaluator_Threshold(t *testing.T) {
+	e, err := aud.NewAlertRuleEvaluator(aud.AlertRule{
+		Threshold: &aud.AlertRuleThreshold{
+			Filter: `operation.type = "login" AND operation.status = FAILED`,
+			Count:  3,
+		},
+		GroupBy: []string{"actor.id"},
+		Window:  5 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should group records by fields", func(t *testing.T) {
+		key1, group, ok := e.Group(newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start))
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{"actor.id": "alice"}, group)
+
+		key2, _, ok := e.Group(newAlertTestRecord("login", aud.OperationStatusFailed, "bob", start))
+		require.True(t, ok)
+		assert.NotEqual(t, key1, key2)
+	})
+
+	t.Run("Should skip records not matching filter", func(t *testing.T) {
+		_, _, ok := e.Group(newAlertTestRecord("login", aud.OperationStatusSucceeded, "alice", start))
+		assert.False(t, ok)
+	})
+
+	t.Run("Should fire when count is reached within window", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		r1 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start)
+		r2 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start.Add(4*time.Minute))
+		r3 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start.Add(6*time.Minute))
+		r4 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start.Add(7*time.Minute))
+
+		assert.Empty(t, e.Evaluate(&state, r1))
+		assert.Empty(t, e.Evaluate(&state, r2))
+		// r1 is out of the window.
+		assert.Empty(t, e.Evaluate(&state, r3))
+
+		events := e.Evaluate(&state, r4)
+		require.Len(t, events, 3)
+		assert.Equal(t, r2.ID, events[0].RecordID)
+		assert.Equal(t, r3.ID, events[1].RecordID)
+		assert.Equal(t, r4.ID, events[2].RecordID)
+		assert.True(t, state.Empty())
+	})
+
+	t.Run("Should order records by operation time", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		r1 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start.Add(2*time.Minute))
+		r2 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start)
+		r3 := newAlertTestRecord("login", aud.OperationStatusFailed, "alice", start.Add(time.Minute))
+
+		assert.Empty(t, e.Evaluate(&state, r1))
+		assert.Empty(t, e.Evaluate(&state, r2))
+
+		events := e.Evaluate(&state, r3)
+		require.Len(t, events, 3)
+		assert.Equal(t, r2.ID, events[0].RecordID)
+		assert.Equal(t, r3.ID, events[1].RecordID)
+		assert.Equal(t, r1.ID, events[2].RecordID)
+	})
+}
+
+func TestAlertRuleEvaluator_Sequence(t *testing.T) {
+	e, err := aud.NewAlertRuleEvaluator(aud.AlertRule{
+		Sequence: &aud.AlertRuleSequence{
+			Steps: []string{
+				`operation.type = "permission.grant"`,
+				`operation.type = "data.export"`,
+			},
+		},
+		GroupBy: []string{"actor.id"},
+		Window:  time.Hour,
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should skip records not matching any step", func(t *testing.T) {
+		_, _, ok := e.Group(newAlertTestRecord("login", aud.OperationStatusSucceeded, "alice", start))
+		assert.False(t, ok)
+	})
+
+	t.Run("Should fire when steps follow within window", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		grant := newAlertTestRecord("permission.grant", aud.OperationStatusSucceeded, "alice", start)
+		export := newAlertTestRecord("data.export", aud.OperationStatusSucceeded, "alice", start.Add(30*time.Minute))
+
+		assert.Empty(t, e.Evaluate(&state, grant))
+
+		events := e.Evaluate(&state, export)
+		require.Len(t, events, 2)
+		assert.Equal(t, grant.ID, events[0].RecordID)
+		assert.Equal(t, export.ID, events[1].RecordID)
+		assert.True(t, state.Empty())
+	})
+
+	t.Run("Should not fire when steps are out of order", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		export := newAlertTestRecord("data.export", aud.OperationStatusSucceeded, "alice", start)
+		grant := newAlertTestRecord("permission.grant", aud.OperationStatusSucceeded, "alice", start.Add(time.Minute))
+
+		assert.Empty(t, e.Evaluate(&state, export))
+		assert.Empty(t, e.Evaluate(&state, grant))
+		assert.False(t, state.Empty())
+	})
+
+	t.Run("Should not fire when window passed", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		grant := newAlertTestRecord("permission.grant", aud.OperationStatusSucceeded, "alice", start)
+		export := newAlertTestRecord("data.export", aud.OperationStatusSucceeded, "alice", start.Add(2*time.Hour))
+
+		assert.Empty(t, e.Evaluate(&state, grant))
+		assert.Empty(t, e.Evaluate(&state, export))
+		assert.True(t, state.Empty())
+	})
+
+	t.Run("Should start over with the latest first step", func(t *testing.T) {
+		var state aud.AlertRuleState
+
+		grant1 := newAlertTestRecord("permission.grant", aud.OperationStatusSucceeded, "alice", start)
+		grant2 := newAlertTestRecord("permission.grant", aud.OperationStatusSucceeded, "alice", start.Add(50*time.Minute))
+		export := newAlertTestRecord("data.export", aud.OperationStatusSucceeded, "alice", start.Add(70*time.Minute))
+
+		assert.Empty(t, e.Evaluate(&state, grant1))
+		assert.Empty(t, e.Evaluate(&state, grant2))
+
+		events := e.Evaluate(&state, export)
+		require.Len(t, events, 2)
+		assert.Equal(t, grant2.ID, events[0].RecordID)
+		assert.Equal(t, export.ID, events[1].RecordID)
+	})
+}
+
+func TestValidateAlertRuleGroupBy(t *testing.T) {
+	assert.NoError(t, aud.ValidateAlertRuleGroupBy("actor.id"))
+	assert.NoError(t, aud.ValidateAlertRuleGroupBy("labels.env"))
+	assert.NoError(t, aud.ValidateAlertRuleGroupBy("resource.metadata.owner"))
+	assert.Error(t, aud.ValidateAlertRuleGroupBy("labels."))
+	assert.Error(t, aud.ValidateAlertRuleGroupBy("operation.time"))
+	assert.Error(t, aud.ValidateAlertRuleGroupBy("actor"))
+}
+
+func newAlertTestRecord(operationType string, status aud.OperationStatus, actorID string, operationTime time.Time) aud.Record {
+	return aud.Record{
+		ID: aud.MustNewID(),
+		Resource: aud.Resource{
+			Type: "dataset",
+			ID:   "1",
+		},
+		Operation: aud.Operation{
+			Type:   operationType,
+			Time:   operationTime,
+			Status: status,
+		},
+		Actor: aud.Actor{
+			Type: "user",
+			ID:   actorID,
+		},
+	}
+}
+
+func TestAlertRuleState_LastTime(t *testing.T) {
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	assert.True(t, aud.AlertRuleState{}.LastTime().IsZero())
+
+	state := aud.AlertRuleState{
+		Events: []aud.AlertEvent{
+			{RecordID: aud.MustNewID(), Time: t0},
+			{RecordID: aud.MustNewID(), Time: t0.Add(time.Minute)},
+		},
+		Sequences: [][]aud.AlertEvent{
+			nil,
+			{
+				{RecordID: aud.MustNewID(), Time: t0.Add(-time.Minute)},
+				{RecordID: aud.MustNewID(), Time: t0.Add(2 * time.Minute)},
+			},
+		},
+	}
+	assert.Equal(t, t0.Add(2*time.Minute), state.LastTime())
+}
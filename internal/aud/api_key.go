@@ -0,0 +1,152 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Permission grants access to a kind of operations.
+type Permission int
+
+const (
+	PermissionUnspecified Permission = iota
+	// PermissionRead allows reading projects and records.
+	PermissionRead
+	// PermissionWrite allows creating, updating and deleting records.
+	PermissionWrite
+	// PermissionAdmin allows everything, including managing projects and
+	// API keys.
+	PermissionAdmin
+)
+
+func (p Permission) String() string {
+	switch p {
+	case PermissionRead:
+		return "read"
+	case PermissionWrite:
+		return "write"
+	case PermissionAdmin:
+		return "admin"
+	default:
+		return "unspecified"
+	}
+}
+
+func ParsePermission(s string) (Permission, error) {
+	switch s {
+	case "read":
+		return PermissionRead, nil
+	case "write":
+		return PermissionWrite, nil
+	case "admin":
+		return PermissionAdmin, nil
+	default:
+		return PermissionUnspecified, fmt.Errorf("unknown permission %q", s)
+	}
+}
+
+// APIKey is a key to authenticate requests. The key itself is not stored,
+// only its hash.
+type APIKey struct {
+	ID          ID
+	CreateTime  time.Time
+	DisplayName string
+	// KeyPrefix is the beginning of the key, which helps to identify it.
+	KeyPrefix string
+	// KeyHash is the hash of the key, see [HashAPIKey].
+	KeyHash string
+	// ProjectIDs limits access to the projects. If empty, the key grants
+	// access to all projects.
+	ProjectIDs  []ID
+	Permissions []Permission
+	// RevokeTime is the time when the key was revoked, zero if it is active.
+	RevokeTime time.Time
+}
+
+func (k APIKey) Revoked() bool {
+	return !k.RevokeTime.IsZero()
+}
+
+type APIKeyCursor struct {
+	LastID *ID `json:"lid,omitempty"`
+}
+
+func (c APIKeyCursor) Empty() bool {
+	return c.LastID == nil
+}
+
+func NewAPIKeyCursor(keys []APIKey, pageSize int32) APIKeyCursor {
+	var cursor APIKeyCursor
+
+	if len(keys) >= int(pageSize) {
+		last := keys[len(keys)-1]
+		cursor.LastID = &last.ID
+	}
+
+	return cursor
+}
+
+const (
+	apiKeyPrefix        = "auditum_"
+	apiKeyRandomBytes   = 32
+	apiKeyVisiblePrefix = len(apiKeyPrefix) + 6
+)
+
+// NewAPIKey generates a new secret key and returns it along with the
+// API key holding its hash. The secret is shown to the user only once.
+func NewAPIKey(
+	id ID,
+	createTime time.Time,
+	displayName string,
+	projectIDs []ID,
+	permissions []Permission,
+) (key APIKey, secret string, err error) {
+	b := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return APIKey{}, "", fmt.Errorf("generate random key: %v", err)
+	}
+
+	secret = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return APIKey{
+		ID:          id,
+		CreateTime:  createTime,
+		DisplayName: displayName,
+		KeyPrefix:   secret[:apiKeyVisiblePrefix],
+		KeyHash:     HashAPIKey(secret),
+		ProjectIDs:  slices.Clone(projectIDs),
+		Permissions: slices.Clone(permissions),
+	}, secret, nil
+}
+
+// HashAPIKey returns the hash of the secret key. Keys are long random
+// strings, so a fast hash is sufficient.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey reports whether the token looks like an API key.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
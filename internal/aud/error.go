@@ -19,7 +19,18 @@ import "errors"
 var (
 	ErrProjectNotFound = errors.New("project not found")
 	ErrRecordNotFound  = errors.New("record not found")
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+
+	ErrRoleBindingNotFound = errors.New("role binding not found")
+	ErrWebhookNotFound     = errors.New("webhook not found")
+	ErrAlertRuleNotFound   = errors.New("alert rule not found")
 
 	ErrDisabled = errors.New("disabled")
 	ErrConflict = errors.New("conflict")
+
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrWatchUnavailable is returned when created records can not be
+	// watched at the moment.
+	ErrWatchUnavailable = errors.New("watch unavailable")
 )
@@ -0,0 +1,112 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"strings"
+)
+
+// Expr is a node of the filter expression tree.
+type Expr interface {
+	isExpr()
+}
+
+// And matches if all of its arguments match.
+type And struct {
+	Args []Expr
+}
+
+// Or matches if any of its arguments matches.
+type Or struct {
+	Args []Expr
+}
+
+// Not matches if its argument does not match.
+type Not struct {
+	Arg Expr
+}
+
+// Restriction compares a field with a value, e.g. `actor.type = "user"`.
+type Restriction struct {
+	// Path is the field path split by dots, e.g. ["labels", "env"].
+	Path []string
+	Op   Operator
+	// Value is the literal value as written in the expression, unquoted
+	// and unescaped.
+	Value string
+	// Wildcard is true if Value ends with an unescaped "*". An escaped
+	// asterisk, `\*`, is matched literally.
+	Wildcard bool
+	// Pos is the byte offset of the restriction in the expression.
+	Pos int
+
+	// The following fields are set by Check.
+
+	// Field is the schema field the path refers to.
+	Field Field
+	// Key is the map key for fields of FieldTypeMap.
+	Key string
+	// Arg is the value converted according to the field type:
+	// string for FieldTypeString and FieldTypeMap, time.Time for
+	// FieldTypeTimestamp and int for FieldTypeEnum.
+	Arg any
+	// Prefix is true for string comparisons with a trailing wildcard,
+	// e.g. `resource.id = "org-1/*"`. Arg holds the prefix without wildcard.
+	Prefix bool
+}
+
+func (And) isExpr()          {}
+func (Or) isExpr()           {}
+func (Not) isExpr()          {}
+func (*Restriction) isExpr() {}
+
+// FieldPath returns the restriction path joined with dots.
+func (r *Restriction) FieldPath() string {
+	return strings.Join(r.Path, ".")
+}
+
+// Operator is a comparison operator.
+type Operator int
+
+const (
+	OpEquals Operator = iota + 1
+	OpNotEquals
+	OpLess
+	OpLessOrEquals
+	OpGreater
+	OpGreaterOrEquals
+	OpHas
+)
+
+func (o Operator) String() string {
+	switch o {
+	case OpEquals:
+		return "="
+	case OpNotEquals:
+		return "!="
+	case OpLess:
+		return "<"
+	case OpLessOrEquals:
+		return "<="
+	case OpGreater:
+		return ">"
+	case OpGreaterOrEquals:
+		return ">="
+	case OpHas:
+		return ":"
+	default:
+		return "unknown"
+	}
+}
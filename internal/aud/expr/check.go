@@ -0,0 +1,175 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FieldType describes which comparisons and values a field supports.
+type FieldType int
+
+const (
+	// FieldTypeString supports "=" and "!=". A trailing "*" in the value
+	// matches by prefix, an escaped one, `\*`, matches a literal asterisk.
+	FieldTypeString FieldType = iota + 1
+	// FieldTypeTimestamp supports all comparisons except ":". Values must be
+	// in RFC 3339 format.
+	FieldTypeTimestamp
+	// FieldTypeEnum supports "=" and "!=". Values must be one of the enum
+	// value names.
+	FieldTypeEnum
+	// FieldTypeMap is a string map. Values are referenced by key, e.g.
+	// `labels.env = "prod"`, and support the same comparisons as
+	// FieldTypeString. Key presence is checked with `labels:env`.
+	FieldTypeMap
+)
+
+// Field describes a field that may be used in expressions.
+type Field struct {
+	Name string
+	Type FieldType
+	// Values maps enum value names to their values, for FieldTypeEnum.
+	Values map[string]int
+}
+
+// Schema is a whitelist of fields that may be used in expressions.
+type Schema []Field
+
+func (s Schema) lookup(path []string) (field Field, key string, ok bool) {
+	name := strings.Join(path, ".")
+
+	for _, f := range s {
+		if f.Name == name {
+			return f, "", true
+		}
+
+		if f.Type == FieldTypeMap && len(path) > 1 {
+			if strings.Join(path[:len(path)-1], ".") == f.Name {
+				return f, path[len(path)-1], true
+			}
+		}
+	}
+
+	return Field{}, "", false
+}
+
+// Check validates the expression against the schema. It resolves fields of
+// the restrictions and converts their values according to field types.
+func Check(e Expr, schema Schema) error {
+	switch e := e.(type) {
+	case And:
+		for _, arg := range e.Args {
+			if err := Check(arg, schema); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Or:
+		for _, arg := range e.Args {
+			if err := Check(arg, schema); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Not:
+		return Check(e.Arg, schema)
+	case *Restriction:
+		if err := checkRestriction(e, schema); err != nil {
+			return fmt.Errorf("invalid restriction at position %d: %v", e.Pos+1, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported expression %T", e)
+	}
+}
+
+func checkRestriction(r *Restriction, schema Schema) error {
+	field, key, ok := schema.lookup(r.Path)
+	if !ok {
+		return fmt.Errorf("unknown field %q", r.FieldPath())
+	}
+
+	r.Field = field
+	r.Key = key
+
+	switch field.Type {
+	case FieldTypeString:
+		return checkString(r)
+	case FieldTypeTimestamp:
+		return checkTimestamp(r)
+	case FieldTypeEnum:
+		return checkEnum(r)
+	case FieldTypeMap:
+		if r.Op == OpHas {
+			if r.Key != "" {
+				return fmt.Errorf(`field %q does not support ":", use "%s:%s"`, r.FieldPath(), field.Name, r.Key)
+			}
+			r.Key = r.Value
+			return nil
+		}
+		if r.Key == "" {
+			return fmt.Errorf("field %q requires a key, e.g. %s.key", r.FieldPath(), field.Name)
+		}
+		return checkString(r)
+	default:
+		return fmt.Errorf("unsupported type of field %q", r.FieldPath())
+	}
+}
+
+func checkString(r *Restriction) error {
+	if r.Op != OpEquals && r.Op != OpNotEquals {
+		return fmt.Errorf("field %q does not support %q", r.FieldPath(), r.Op.String())
+	}
+
+	if r.Wildcard {
+		r.Arg = strings.TrimSuffix(r.Value, "*")
+		r.Prefix = true
+		return nil
+	}
+
+	r.Arg = r.Value
+	return nil
+}
+
+func checkTimestamp(r *Restriction) error {
+	if r.Op == OpHas {
+		return fmt.Errorf("field %q does not support %q", r.FieldPath(), r.Op.String())
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, r.Value)
+	if err != nil {
+		return fmt.Errorf("field %q requires RFC 3339 timestamp, got %q", r.FieldPath(), r.Value)
+	}
+
+	r.Arg = t.UTC()
+	return nil
+}
+
+func checkEnum(r *Restriction) error {
+	if r.Op != OpEquals && r.Op != OpNotEquals {
+		return fmt.Errorf("field %q does not support %q", r.FieldPath(), r.Op.String())
+	}
+
+	v, ok := r.Field.Values[r.Value]
+	if !ok {
+		return fmt.Errorf("field %q does not support value %q", r.FieldPath(), r.Value)
+	}
+
+	r.Arg = v
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenOperator
+	tokenText
+	tokenString
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	op    Operator
+	pos   int
+	// wildcard is true if the value ends with an unescaped "*".
+	wildcard bool
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("%q", t.value)
+}
+
+// lex splits the expression into tokens.
+func lex(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case isSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i++
+		case c == '=' || c == ':':
+			op := OpEquals
+			if c == ':' {
+				op = OpHas
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: string(c), op: op, pos: i})
+			i++
+		case c == '!' || c == '<' || c == '>':
+			start := i
+			i++
+			eq := i < len(s) && s[i] == '='
+			if eq {
+				i++
+			}
+
+			var op Operator
+			switch {
+			case c == '!' && eq:
+				op = OpNotEquals
+			case c == '<' && eq:
+				op = OpLessOrEquals
+			case c == '<':
+				op = OpLess
+			case c == '>' && eq:
+				op = OpGreaterOrEquals
+			case c == '>':
+				op = OpGreater
+			default:
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, start+1)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, value: s[start:i], op: op, pos: start})
+		case c == '"' || c == '\'':
+			value, wildcard, n, err := lexString(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at position %d", err, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: i, wildcard: wildcard})
+			i += n
+		default:
+			start := i
+			for i < len(s) && !isSpace(s[i]) && !isSpecial(s[i]) {
+				i++
+			}
+
+			value := s[start:i]
+
+			// An escaped trailing "*" is a literal asterisk, otherwise it
+			// is a wildcard.
+			wildcard := strings.HasSuffix(value, "*")
+			if escaped, ok := strings.CutSuffix(value, `\*`); ok {
+				value = escaped + "*"
+				wildcard = false
+			}
+
+			kind := tokenText
+			switch value {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+
+			tokens = append(tokens, token{kind: kind, value: value, pos: start, wildcard: wildcard})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(s)})
+
+	return tokens, nil
+}
+
+// lexString reads a quoted string at the beginning of s. It returns the
+// unquoted value, whether it ends with an unescaped "*" and the number of
+// consumed bytes.
+func lexString(s string) (string, bool, int, error) {
+	quote := s[0]
+
+	var (
+		b        strings.Builder
+		wildcard bool
+	)
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), wildcard, i + 1, nil
+		case c == '\\':
+			i++
+			if i == len(s) {
+				return "", false, 0, fmt.Errorf("unterminated string")
+			}
+			b.WriteByte(s[i])
+			wildcard = false
+		default:
+			b.WriteByte(c)
+			wildcard = c == '*'
+		}
+	}
+
+	return "", false, 0, fmt.Errorf("unterminated string")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isSpecial(c byte) bool {
+	switch c {
+	case '(', ')', '=', ':', '!', '<', '>', '"', '\'':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxDepth is the maximum nesting depth of parentheses and NOT.
+	MaxDepth = 16
+	// MaxRestrictions is the maximum number of restrictions in an expression.
+	MaxRestrictions = 64
+)
+
+// Parse parses the filter expression.
+//
+// The grammar follows AIP-160 (https://google.aip.dev/160):
+//
+//	expression  = sequence { "AND" sequence }
+//	sequence    = factor { factor }
+//	factor      = term { "OR" term }
+//	term        = [ "NOT" ] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field comparator value
+//	comparator  = "=" | "!=" | "<" | "<=" | ">" | ">=" | ":"
+//
+// Note that OR has higher precedence than AND, and adjacent factors of a
+// sequence are combined with AND.
+func Parse(s string) (Expr, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	e, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos+1)
+	}
+
+	return e, nil
+}
+
+type parser struct {
+	tokens       []token
+	pos          int
+	depth        int
+	restrictions int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseExpression() (Expr, error) {
+	var args []Expr
+
+	for {
+		e, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+
+		if p.peek().kind != tokenAnd {
+			break
+		}
+		p.next()
+	}
+
+	return newAnd(args), nil
+}
+
+func (p *parser) parseSequence() (Expr, error) {
+	var args []Expr
+
+	for {
+		e, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+
+		switch p.peek().kind {
+		case tokenLParen, tokenNot, tokenText, tokenString:
+			continue
+		}
+		break
+	}
+
+	return newAnd(args), nil
+}
+
+func (p *parser) parseFactor() (Expr, error) {
+	var args []Expr
+
+	for {
+		e, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.next()
+	}
+
+	if len(args) == 1 {
+		return args[0], nil
+	}
+
+	return Or{Args: args}, nil
+}
+
+func (p *parser) parseTerm() (Expr, error) {
+	if p.peek().kind != tokenNot {
+		return p.parseSimple()
+	}
+
+	t := p.next()
+	if err := p.enter(t); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	e, err := p.parseSimple()
+	if err != nil {
+		return nil, err
+	}
+
+	return Not{Arg: e}, nil
+}
+
+func (p *parser) parseSimple() (Expr, error) {
+	if p.peek().kind != tokenLParen {
+		return p.parseRestriction()
+	}
+
+	t := p.next()
+	if err := p.enter(t); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	e, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.next(); t.kind != tokenRParen {
+		return nil, fmt.Errorf("expected \")\" at position %d, got %s", t.pos+1, t)
+	}
+
+	return e, nil
+}
+
+func (p *parser) parseRestriction() (Expr, error) {
+	field := p.next()
+	if field.kind != tokenText {
+		return nil, fmt.Errorf("expected field at position %d, got %s", field.pos+1, field)
+	}
+
+	path := strings.Split(field.value, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid field %q at position %d", field.value, field.pos+1)
+		}
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, fmt.Errorf("expected comparator after field %q at position %d, got %s", field.value, op.pos+1, op)
+	}
+
+	value := p.next()
+	if value.kind != tokenText && value.kind != tokenString {
+		return nil, fmt.Errorf("expected value at position %d, got %s", value.pos+1, value)
+	}
+
+	p.restrictions++
+	if p.restrictions > MaxRestrictions {
+		return nil, fmt.Errorf("too many restrictions, at most %d allowed", MaxRestrictions)
+	}
+
+	return &Restriction{
+		Path:     path,
+		Op:       op.op,
+		Value:    value.value,
+		Wildcard: value.wildcard,
+		Pos:      field.pos,
+	}, nil
+}
+
+func (p *parser) enter(t token) error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return fmt.Errorf("expression is nested too deeply at position %d, at most %d levels allowed", t.pos+1, MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func newAnd(args []Expr) Expr {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	return And{Args: args}
+}
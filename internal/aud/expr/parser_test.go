@@ -0,0 +1,251 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auditumio/auditum/internal/aud/expr"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want expr.Expr
+	}{
+		{
+			name: "Single restriction",
+			expr: `actor.type = "user"`,
+			want: &expr.Restriction{Path: []string{"actor", "type"}, Op: expr.OpEquals, Value: "user", Pos: 0},
+		},
+		{
+			name: "Unquoted value",
+			expr: `operation.status!=FAILED`,
+			want: &expr.Restriction{Path: []string{"operation", "status"}, Op: expr.OpNotEquals, Value: "FAILED", Pos: 0},
+		},
+		{
+			name: "Implicit AND",
+			expr: `a = 1 b >= 2`,
+			want: expr.And{Args: []expr.Expr{
+				&expr.Restriction{Path: []string{"a"}, Op: expr.OpEquals, Value: "1", Pos: 0},
+				&expr.Restriction{Path: []string{"b"}, Op: expr.OpGreaterOrEquals, Value: "2", Pos: 6},
+			}},
+		},
+		{
+			name: "OR has higher precedence than AND",
+			expr: `a = 1 AND b < 2 OR c:d`,
+			want: expr.And{Args: []expr.Expr{
+				&expr.Restriction{Path: []string{"a"}, Op: expr.OpEquals, Value: "1", Pos: 0},
+				expr.Or{Args: []expr.Expr{
+					&expr.Restriction{Path: []string{"b"}, Op: expr.OpLess, Value: "2", Pos: 10},
+					&expr.Restriction{Path: []string{"c"}, Op: expr.OpHas, Value: "d", Pos: 19},
+				}},
+			}},
+		},
+		{
+			name: "NOT and parentheses",
+			expr: `NOT (a = 'x\'y' OR b <= 2)`,
+			want: expr.Not{Arg: expr.Or{Args: []expr.Expr{
+				&expr.Restriction{Path: []string{"a"}, Op: expr.OpEquals, Value: "x'y", Pos: 5},
+				&expr.Restriction{Path: []string{"b"}, Op: expr.OpLessOrEquals, Value: "2", Pos: 19},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := expr.Parse(test.expr)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name:    "Empty expression",
+			expr:    ``,
+			wantErr: "expected field at position 1, got end of expression",
+		},
+		{
+			name:    "Missing comparator",
+			expr:    `a "b"`,
+			wantErr: `expected comparator after field "a" at position 3, got "b"`,
+		},
+		{
+			name:    "Missing value",
+			expr:    `a = `,
+			wantErr: "expected value at position 5, got end of expression",
+		},
+		{
+			name:    "Unbalanced parentheses",
+			expr:    `(a = b`,
+			wantErr: `expected ")" at position 7, got end of expression`,
+		},
+		{
+			name:    "Unexpected closing parenthesis",
+			expr:    `a = b)`,
+			wantErr: `unexpected ")" at position 6`,
+		},
+		{
+			name:    "Unterminated string",
+			expr:    `a = "b`,
+			wantErr: "unterminated string at position 5",
+		},
+		{
+			name:    "Invalid field",
+			expr:    `a..b = c`,
+			wantErr: `invalid field "a..b" at position 1`,
+		},
+		{
+			name:    "Invalid operator",
+			expr:    `a ! b`,
+			wantErr: `unexpected character '!' at position 3`,
+		},
+		{
+			name:    "Nested too deeply",
+			expr:    strings.Repeat("(", expr.MaxDepth+1) + "a = b" + strings.Repeat(")", expr.MaxDepth+1),
+			wantErr: "expression is nested too deeply at position 17, at most 16 levels allowed",
+		},
+		{
+			name:    "Too many restrictions",
+			expr:    strings.Repeat("a = b ", expr.MaxRestrictions+1),
+			wantErr: "too many restrictions, at most 64 allowed",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := expr.Parse(test.expr)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	schema := expr.Schema{
+		{Name: "resource.id", Type: expr.FieldTypeString},
+		{Name: "operation.time", Type: expr.FieldTypeTimestamp},
+		{Name: "operation.status", Type: expr.FieldTypeEnum, Values: map[string]int{"FAILED": 2}},
+		{Name: "labels", Type: expr.FieldTypeMap},
+	}
+
+	t.Run("Should resolve fields and values", func(t *testing.T) {
+		e, err := expr.Parse(`resource.id = "org-1/*" operation.time > "2023-01-01T00:00:00Z" operation.status = FAILED labels.env = prod labels:team`)
+		require.NoError(t, err)
+
+		err = expr.Check(e, schema)
+		require.NoError(t, err)
+
+		args := e.(expr.And).Args
+
+		prefix := args[0].(*expr.Restriction)
+		assert.Equal(t, "org-1/", prefix.Arg)
+		assert.True(t, prefix.Prefix)
+
+		ts := args[1].(*expr.Restriction)
+		assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), ts.Arg)
+
+		status := args[2].(*expr.Restriction)
+		assert.Equal(t, 2, status.Arg)
+
+		label := args[3].(*expr.Restriction)
+		assert.Equal(t, "labels", label.Field.Name)
+		assert.Equal(t, "env", label.Key)
+		assert.Equal(t, "prod", label.Arg)
+
+		has := args[4].(*expr.Restriction)
+		assert.Equal(t, "labels", has.Field.Name)
+		assert.Equal(t, "team", has.Key)
+	})
+
+	t.Run("Should match escaped asterisk literally", func(t *testing.T) {
+		e, err := expr.Parse(`resource.id = "org-1/\*" resource.id = org-2/\* resource.id = "org-3/\\*"`)
+		require.NoError(t, err)
+
+		err = expr.Check(e, schema)
+		require.NoError(t, err)
+
+		args := e.(expr.And).Args
+
+		quoted := args[0].(*expr.Restriction)
+		assert.Equal(t, "org-1/*", quoted.Arg)
+		assert.False(t, quoted.Prefix)
+
+		unquoted := args[1].(*expr.Restriction)
+		assert.Equal(t, "org-2/*", unquoted.Arg)
+		assert.False(t, unquoted.Prefix)
+
+		backslash := args[2].(*expr.Restriction)
+		assert.Equal(t, `org-3/\`, backslash.Arg)
+		assert.True(t, backslash.Prefix)
+	})
+
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name:    "Unknown field",
+			expr:    `actor.id = a`,
+			wantErr: `invalid restriction at position 1: unknown field "actor.id"`,
+		},
+		{
+			name:    "Unsupported string comparator",
+			expr:    `resource.id > a`,
+			wantErr: `invalid restriction at position 1: field "resource.id" does not support ">"`,
+		},
+		{
+			name:    "Invalid timestamp",
+			expr:    `operation.time < yesterday`,
+			wantErr: `invalid restriction at position 1: field "operation.time" requires RFC 3339 timestamp, got "yesterday"`,
+		},
+		{
+			name:    "Unknown enum value",
+			expr:    `operation.status = BROKEN`,
+			wantErr: `invalid restriction at position 1: field "operation.status" does not support value "BROKEN"`,
+		},
+		{
+			name:    "Map without key",
+			expr:    `labels = a`,
+			wantErr: `invalid restriction at position 1: field "labels" requires a key, e.g. labels.key`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			e, err := expr.Parse(test.expr)
+			require.NoError(t, err)
+
+			err = expr.Check(e, schema)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
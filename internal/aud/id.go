@@ -65,6 +65,12 @@ func MustNewID() ID {
 	return ID(uuid.Must(uuid.NewV7()))
 }
 
+// NewKeyedID returns an identifier derived from the namespace and the key,
+// which is the same for the same namespace and key.
+func NewKeyedID(namespace ID, key string) ID {
+	return ID(uuid.NewV5(uuid.UUID(namespace), key))
+}
+
 func ParseID(s string) (ID, error) {
 	id, err := uuid.FromString(s)
 	if err != nil {
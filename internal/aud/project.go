@@ -24,7 +24,13 @@ type Project struct {
 	ID                  ID
 	CreateTime          time.Time
 	DisplayName         string
+	Description         string
+	Labels              map[string]string
 	UpdateRecordEnabled types.BoolValue
 	DeleteRecordEnabled types.BoolValue
 	ExternalID          string
+	Quota               ProjectQuota
+	// RecordsRestrictions overrides global restrictions for the project,
+	// see [RecordsRestrictions.Merge].
+	RecordsRestrictions RecordsRestrictions
 }
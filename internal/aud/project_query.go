@@ -17,11 +17,32 @@ package aud
 import "github.com/auditumio/auditum/internal/aud/types"
 
 type ProjectFilter struct {
+	// IDs matches projects with any of the IDs.
+	IDs         []ID
 	ExternalIDs []string
+	// Labels matches projects having all the labels.
+	Labels map[string]string
+	// DisplayNameContains matches projects whose display name contains the
+	// value, case-insensitive.
+	DisplayNameContains string
 }
 
+// ProjectOrder is an order of listed projects.
+type ProjectOrder int
+
+const (
+	// ProjectOrderCreateTimeDesc lists newest projects first. This is the
+	// default order.
+	ProjectOrderCreateTimeDesc ProjectOrder = iota
+	ProjectOrderCreateTimeAsc
+	ProjectOrderDisplayNameAsc
+	ProjectOrderDisplayNameDesc
+)
+
 type ProjectCursor struct {
 	LastID *ID `json:"lid,omitempty"`
+	// LastDisplayName is used to paginate projects ordered by display name.
+	LastDisplayName string `json:"ldn,omitempty"`
 }
 
 func (p ProjectCursor) Empty() bool {
@@ -34,6 +55,7 @@ func NewProjectCursor(projects []Project, pageSize int32) ProjectCursor {
 	if len(projects) >= int(pageSize) {
 		last := projects[len(projects)-1]
 		cursor.LastID = &last.ID
+		cursor.LastDisplayName = last.DisplayName
 	}
 
 	return cursor
@@ -48,4 +70,19 @@ type ProjectUpdate struct {
 
 	DeleteRecordEnabled       types.BoolValue
 	UpdateDeleteRecordEnabled bool
+
+	Description       string
+	UpdateDescription bool
+
+	Labels       map[string]string
+	UpdateLabels bool
+
+	ExternalID       string
+	UpdateExternalID bool
+
+	Quota       ProjectQuota
+	UpdateQuota bool
+
+	RecordsRestrictions       RecordsRestrictions
+	UpdateRecordsRestrictions bool
 }
@@ -0,0 +1,151 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"fmt"
+	"time"
+)
+
+// ProjectQuota limits ingestion of records into a project.
+// A zero limit means there is no limit.
+type ProjectQuota struct {
+	// RecordsPerMinute limits records created within a UTC minute.
+	RecordsPerMinute int64
+	// RecordsPerDay limits records created within a UTC day.
+	RecordsPerDay int64
+	// MaxRecords limits the total number of stored records.
+	MaxRecords int64
+	// MaxBytes limits the total size of stored records, see [Record.Size].
+	MaxBytes int64
+}
+
+// Check returns [*QuotaExceededError] if creating n records of the given
+// total size at time t would exceed the quota.
+func (q ProjectQuota) Check(usage ProjectUsage, t time.Time, n, bytes int64) error {
+	usage = usage.At(t)
+
+	if q.RecordsPerMinute > 0 && usage.MinuteRecords+n > q.RecordsPerMinute {
+		return &QuotaExceededError{
+			ProjectID:  usage.ProjectID,
+			Quota:      QuotaRecordsPerMinute,
+			Limit:      q.RecordsPerMinute,
+			RetryDelay: usage.MinuteStartTime.Add(time.Minute).Sub(t),
+		}
+	}
+	if q.RecordsPerDay > 0 && usage.DayRecords+n > q.RecordsPerDay {
+		return &QuotaExceededError{
+			ProjectID:  usage.ProjectID,
+			Quota:      QuotaRecordsPerDay,
+			Limit:      q.RecordsPerDay,
+			RetryDelay: usage.DayStartTime.Add(24 * time.Hour).Sub(t),
+		}
+	}
+	if q.MaxRecords > 0 && usage.Records+n > q.MaxRecords {
+		return &QuotaExceededError{
+			ProjectID: usage.ProjectID,
+			Quota:     QuotaMaxRecords,
+			Limit:     q.MaxRecords,
+		}
+	}
+	if q.MaxBytes > 0 && usage.Bytes+bytes > q.MaxBytes {
+		return &QuotaExceededError{
+			ProjectID: usage.ProjectID,
+			Quota:     QuotaMaxBytes,
+			Limit:     q.MaxBytes,
+		}
+	}
+
+	return nil
+}
+
+// QuotaName identifies a limit of [ProjectQuota].
+type QuotaName string
+
+const (
+	QuotaRecordsPerMinute QuotaName = "records_per_minute"
+	QuotaRecordsPerDay    QuotaName = "records_per_day"
+	QuotaMaxRecords       QuotaName = "max_records"
+	QuotaMaxBytes         QuotaName = "max_bytes"
+)
+
+// QuotaExceededError is returned when a project quota does not allow
+// creating records. It matches [ErrQuotaExceeded].
+type QuotaExceededError struct {
+	ProjectID ID
+	Quota     QuotaName
+	Limit     int64
+	// RetryDelay is the time until the quota window resets. It is zero for
+	// limits that do not reset over time.
+	RetryDelay time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%v: %s is limited to %d", ErrQuotaExceeded, e.Quota, e.Limit)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// ProjectUsage holds usage counters of a project.
+//
+// Records created are counted in fixed windows: the current UTC minute and
+// the current UTC day. Counters of a window are valid only while the window
+// is current, use [ProjectUsage.At] to get the actual values.
+type ProjectUsage struct {
+	ProjectID ID
+	// Records is the total number of stored records.
+	Records int64
+	// Bytes is the total size of stored records, see [Record.Size].
+	Bytes int64
+
+	MinuteStartTime time.Time
+	MinuteRecords   int64
+	DayStartTime    time.Time
+	DayRecords      int64
+}
+
+// At returns usage with windows moved to the ones containing time t.
+// Windows never move backwards, so a slightly skewed t does not reset them.
+func (u ProjectUsage) At(t time.Time) ProjectUsage {
+	if minute := t.UTC().Truncate(time.Minute); minute.After(u.MinuteStartTime) {
+		u.MinuteStartTime = minute
+		u.MinuteRecords = 0
+	}
+	if day := t.UTC().Truncate(24 * time.Hour); day.After(u.DayStartTime) {
+		u.DayStartTime = day
+		u.DayRecords = 0
+	}
+	return u
+}
+
+// Add returns usage after creating n records of the given total size
+// at time t.
+func (u ProjectUsage) Add(t time.Time, n, bytes int64) ProjectUsage {
+	u = u.At(t)
+	u.MinuteRecords += n
+	u.DayRecords += n
+	return u.Adjust(n, bytes)
+}
+
+// Adjust returns usage with totals changed by the given number of records
+// and bytes, which may be negative. Windows are not affected, which suits
+// records that were imported, updated or deleted.
+func (u ProjectUsage) Adjust(n, bytes int64) ProjectUsage {
+	u.Records = max(u.Records+n, 0)
+	u.Bytes = max(u.Bytes+bytes, 0)
+	return u
+}
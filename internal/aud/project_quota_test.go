@@ -0,0 +1,170 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestProjectQuota_Check(t *testing.T) {
+	projectID := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	now := time.Date(2023, 1, 1, 12, 30, 45, 0, time.UTC)
+
+	usage := aud.ProjectUsage{
+		ProjectID:       projectID,
+		Records:         90,
+		Bytes:           9000,
+		MinuteStartTime: time.Date(2023, 1, 1, 12, 30, 0, 0, time.UTC),
+		MinuteRecords:   9,
+		DayStartTime:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		DayRecords:      50,
+	}
+
+	tests := []struct {
+		name  string
+		quota aud.ProjectQuota
+		usage aud.ProjectUsage
+		now   time.Time
+		n     int64
+		bytes int64
+		want  error
+	}{
+		{
+			name:  "no limits",
+			quota: aud.ProjectQuota{},
+			usage: usage,
+			now:   now,
+			n:     1000,
+			bytes: 1 << 30,
+			want:  nil,
+		},
+		{
+			name:  "within limits",
+			quota: aud.ProjectQuota{RecordsPerMinute: 10, RecordsPerDay: 51, MaxRecords: 91, MaxBytes: 9100},
+			usage: usage,
+			now:   now,
+			n:     1,
+			bytes: 100,
+			want:  nil,
+		},
+		{
+			name:  "records per minute exceeded",
+			quota: aud.ProjectQuota{RecordsPerMinute: 10},
+			usage: usage,
+			now:   now,
+			n:     2,
+			want: &aud.QuotaExceededError{
+				ProjectID:  projectID,
+				Quota:      aud.QuotaRecordsPerMinute,
+				Limit:      10,
+				RetryDelay: 15 * time.Second,
+			},
+		},
+		{
+			name:  "records per minute reset in the next minute",
+			quota: aud.ProjectQuota{RecordsPerMinute: 10},
+			usage: usage,
+			now:   now.Add(time.Minute),
+			n:     10,
+			want:  nil,
+		},
+		{
+			name:  "records per day exceeded",
+			quota: aud.ProjectQuota{RecordsPerDay: 50},
+			usage: usage,
+			now:   now,
+			n:     1,
+			want: &aud.QuotaExceededError{
+				ProjectID:  projectID,
+				Quota:      aud.QuotaRecordsPerDay,
+				Limit:      50,
+				RetryDelay: 11*time.Hour + 29*time.Minute + 15*time.Second,
+			},
+		},
+		{
+			name:  "max records exceeded",
+			quota: aud.ProjectQuota{MaxRecords: 90},
+			usage: usage,
+			now:   now.Add(48 * time.Hour),
+			n:     1,
+			want: &aud.QuotaExceededError{
+				ProjectID: projectID,
+				Quota:     aud.QuotaMaxRecords,
+				Limit:     90,
+			},
+		},
+		{
+			name:  "max bytes exceeded",
+			quota: aud.ProjectQuota{MaxBytes: 9100},
+			usage: usage,
+			now:   now,
+			n:     1,
+			bytes: 101,
+			want: &aud.QuotaExceededError{
+				ProjectID: projectID,
+				Quota:     aud.QuotaMaxBytes,
+				Limit:     9100,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.quota.Check(test.usage, test.now, test.n, test.bytes)
+			assert.Equal(t, test.want, err)
+		})
+	}
+}
+
+func TestProjectUsage_Add(t *testing.T) {
+	t0 := time.Date(2023, 1, 1, 23, 59, 30, 0, time.UTC)
+
+	var usage aud.ProjectUsage
+
+	usage = usage.Add(t0, 2, 200)
+	assert.Equal(t, aud.ProjectUsage{
+		Records:         2,
+		Bytes:           200,
+		MinuteStartTime: time.Date(2023, 1, 1, 23, 59, 0, 0, time.UTC),
+		MinuteRecords:   2,
+		DayStartTime:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		DayRecords:      2,
+	}, usage)
+
+	// Time slightly in the past must not move windows backwards.
+	usage = usage.Add(t0.Add(-time.Minute), 1, 100)
+	assert.Equal(t, int64(3), usage.MinuteRecords)
+	assert.Equal(t, int64(3), usage.DayRecords)
+
+	usage = usage.Add(t0.Add(time.Minute), 1, 100)
+	assert.Equal(t, aud.ProjectUsage{
+		Records:         4,
+		Bytes:           400,
+		MinuteStartTime: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		MinuteRecords:   1,
+		DayStartTime:    time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		DayRecords:      1,
+	}, usage)
+
+	usage = usage.Adjust(-5, -500)
+	assert.Zero(t, usage.Records)
+	assert.Zero(t, usage.Bytes)
+	assert.Equal(t, int64(1), usage.MinuteRecords)
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import "time"
+
+// ProjectStats holds statistics of project records.
+type ProjectStats struct {
+	ProjectID ID
+	// Records is the number of records.
+	Records int64
+	// SizeBytes is the approximate storage size of records. It is the size
+	// of the project partitions including indexes on PostgreSQL, and the size
+	// of record data on SQLite, see [Record.Size].
+	SizeBytes int64
+	// FirstOperationTime and LastOperationTime are zero if there are no records.
+	FirstOperationTime time.Time
+	LastOperationTime  time.Time
+	// LastCreateTime is the time of the last ingested record.
+	LastCreateTime time.Time
+	// Numbers of distinct types of records.
+	ResourceTypes  int64
+	OperationTypes int64
+	ActorTypes     int64
+}
@@ -29,6 +29,33 @@ type Record struct {
 	Actor      Actor
 }
 
+// Size returns the approximate size of the record in bytes, which is the
+// length of all its text and JSON values, including metadata keys. Ids,
+// times and status are not counted.
+func (r Record) Size() int64 {
+	size := len(r.Resource.Type) + len(r.Resource.ID) +
+		len(r.Operation.Type) + len(r.Operation.ID) +
+		len(r.Operation.TraceContext.Traceparent) + len(r.Operation.TraceContext.Tracestate) +
+		len(r.Actor.Type) + len(r.Actor.ID)
+
+	for _, m := range []map[string]string{
+		r.Labels,
+		r.Resource.Metadata,
+		r.Operation.Metadata,
+		r.Actor.Metadata,
+	} {
+		for k, v := range m {
+			size += len(k) + len(v)
+		}
+	}
+
+	for _, c := range r.Resource.Changes {
+		size += len(c.Name) + len(c.Description) + len(c.OldValue) + len(c.NewValue)
+	}
+
+	return int64(size)
+}
+
 type Resource struct {
 	Type     string
 	ID       string
@@ -0,0 +1,172 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"strings"
+	"time"
+
+	"github.com/auditumio/auditum/internal/aud/expr"
+)
+
+// ParseRecordFilterExpression parses the filter expression and checks it
+// against RecordFilterSchema.
+func ParseRecordFilterExpression(s string) (expr.Expr, error) {
+	e, err := expr.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expr.Check(e, RecordFilterSchema); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// MatchRecordExpression reports whether the record matches the checked
+// filter expression, with the same semantics as filtering records in the
+// store. A nil expression matches all records.
+func MatchRecordExpression(e expr.Expr, record Record) bool {
+	switch e := e.(type) {
+	case nil:
+		return true
+	case expr.And:
+		for _, arg := range e.Args {
+			if !MatchRecordExpression(arg, record) {
+				return false
+			}
+		}
+		return true
+	case expr.Or:
+		for _, arg := range e.Args {
+			if MatchRecordExpression(arg, record) {
+				return true
+			}
+		}
+		return false
+	case expr.Not:
+		return !MatchRecordExpression(e.Arg, record)
+	case *expr.Restriction:
+		return matchRecordRestriction(e, record)
+	default:
+		return false
+	}
+}
+
+func matchRecordRestriction(r *expr.Restriction, record Record) bool {
+	switch r.Field.Type {
+	case expr.FieldTypeString:
+		return matchString(r, recordStringField(r.Field.Name, record), true)
+	case expr.FieldTypeTimestamp:
+		arg, _ := r.Arg.(time.Time)
+		return matchTime(r.Op, recordTimeField(r.Field.Name, record), arg)
+	case expr.FieldTypeEnum:
+		arg, _ := r.Arg.(int)
+		matched := record.Operation.Status.Int() == arg
+		if r.Op == expr.OpNotEquals {
+			return !matched
+		}
+		return matched
+	case expr.FieldTypeMap:
+		value, ok := recordMapField(r.Field.Name, record)[r.Key]
+		if r.Op == expr.OpHas {
+			return ok
+		}
+		return matchString(r, value, ok)
+	default:
+		return false
+	}
+}
+
+// matchString compares the value, which is missing if not ok. A missing
+// value is not equal to any value.
+func matchString(r *expr.Restriction, value string, ok bool) bool {
+	arg, _ := r.Arg.(string)
+
+	matched := ok && value == arg
+	if r.Prefix {
+		matched = ok && strings.HasPrefix(value, arg)
+	}
+
+	if r.Op == expr.OpNotEquals {
+		return !matched
+	}
+	return matched
+}
+
+func matchTime(op expr.Operator, value time.Time, arg time.Time) bool {
+	switch op {
+	case expr.OpEquals:
+		return value.Equal(arg)
+	case expr.OpNotEquals:
+		return !value.Equal(arg)
+	case expr.OpLess:
+		return value.Before(arg)
+	case expr.OpLessOrEquals:
+		return !value.After(arg)
+	case expr.OpGreater:
+		return value.After(arg)
+	case expr.OpGreaterOrEquals:
+		return !value.Before(arg)
+	default:
+		return false
+	}
+}
+
+func recordStringField(name string, record Record) string {
+	switch name {
+	case "resource.type":
+		return record.Resource.Type
+	case "resource.id":
+		return record.Resource.ID
+	case "operation.type":
+		return record.Operation.Type
+	case "operation.id":
+		return record.Operation.ID
+	case "actor.type":
+		return record.Actor.Type
+	case "actor.id":
+		return record.Actor.ID
+	default:
+		return ""
+	}
+}
+
+func recordTimeField(name string, record Record) time.Time {
+	switch name {
+	case "create_time":
+		return record.CreateTime
+	case "operation.time":
+		return record.Operation.Time
+	default:
+		return time.Time{}
+	}
+}
+
+func recordMapField(name string, record Record) map[string]string {
+	switch name {
+	case "labels":
+		return record.Labels
+	case "resource.metadata":
+		return record.Resource.Metadata
+	case "operation.metadata":
+		return record.Operation.Metadata
+	case "actor.metadata":
+		return record.Actor.Metadata
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestMatchRecordExpression(t *testing.T) {
+	record := aud.Record{
+		CreateTime: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Labels: map[string]string{
+			"env": "prod",
+		},
+		Resource: aud.Resource{
+			Type: "iam.role",
+			ID:   "org-1/admin",
+		},
+		Operation: aud.Operation{
+			Type:   "update",
+			Time:   time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+			Status: aud.OperationStatusFailed,
+		},
+		Actor: aud.Actor{
+			Type: "user",
+			ID:   "alice",
+			Metadata: map[string]string{
+				"email": "alice@example.com",
+			},
+		},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`resource.type = "iam.role"`, true},
+		{`resource.type != "iam.role"`, false},
+		{`resource.id = "org-1/*"`, true},
+		{`resource.id != "org-2/*"`, true},
+		{`operation.status = FAILED AND resource.type = "iam.role"`, true},
+		{`operation.status = SUCCEEDED OR actor.id = "alice"`, true},
+		{`NOT actor.type = "user"`, false},
+		{`operation.time < "2026-10-19T12:00:00Z"`, true},
+		{`create_time >= "2026-10-19T12:00:00Z"`, true},
+		{`create_time > "2026-10-19T12:00:00Z"`, false},
+		{`labels.env = "prod"`, true},
+		{`labels:env`, true},
+		{`labels:team`, false},
+		// Missing key is not equal to any value.
+		{`labels.team = ""`, false},
+		{`labels.team != "core"`, true},
+		{`actor.metadata.email = "alice@*"`, true},
+		{`resource.metadata.owner = "*"`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			e, err := aud.ParseRecordFilterExpression(test.expression)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, aud.MatchRecordExpression(e, record))
+		})
+	}
+
+	t.Run("Should match all records without expression", func(t *testing.T) {
+		assert.True(t, aud.MatchRecordExpression(nil, record))
+	})
+}
@@ -15,23 +15,81 @@
 package aud
 
 import (
+	"bytes"
 	"time"
+
+	"github.com/auditumio/auditum/internal/aud/expr"
 )
 
+// RecordFilter describes records to return. All fields are combined with
+// logical AND. Slice fields match any of their values.
 type RecordFilter struct {
+	// IDs limits records to the identifiers, if not empty.
+	IDs []ID
+
 	Labels map[string]string
 
-	ResourceType string
-	ResourceID   string
+	ResourceTypes    []string
+	ResourceIDs      []string
+	ResourceIDPrefix string
 
-	OperationType string
-	OperationID   string
+	OperationTypes    []string
+	OperationIDs      []string
+	OperationStatuses []OperationStatus
 
 	OperationTimeFrom time.Time
 	OperationTimeTo   time.Time
 
-	ActorType string
-	ActorID   string
+	ActorTypes []string
+	ActorIDs   []string
+
+	Not RecordFilterNot
+
+	// Expression is an optional filter expression checked against
+	// RecordFilterSchema.
+	Expression expr.Expr
+}
+
+// RecordFilterSchema lists record fields that may be used in filter
+// expressions.
+var RecordFilterSchema = expr.Schema{
+	{Name: "create_time", Type: expr.FieldTypeTimestamp},
+	{Name: "labels", Type: expr.FieldTypeMap},
+	{Name: "resource.type", Type: expr.FieldTypeString},
+	{Name: "resource.id", Type: expr.FieldTypeString},
+	{Name: "resource.metadata", Type: expr.FieldTypeMap},
+	{Name: "operation.type", Type: expr.FieldTypeString},
+	{Name: "operation.id", Type: expr.FieldTypeString},
+	{Name: "operation.time", Type: expr.FieldTypeTimestamp},
+	{Name: "operation.metadata", Type: expr.FieldTypeMap},
+	{
+		Name: "operation.status",
+		Type: expr.FieldTypeEnum,
+		Values: map[string]int{
+			"UNSPECIFIED": OperationStatusUnspecified.Int(),
+			"SUCCEEDED":   OperationStatusSucceeded.Int(),
+			"FAILED":      OperationStatusFailed.Int(),
+		},
+	},
+	{Name: "actor.type", Type: expr.FieldTypeString},
+	{Name: "actor.id", Type: expr.FieldTypeString},
+	{Name: "actor.metadata", Type: expr.FieldTypeMap},
+}
+
+// RecordFilterNot describes records to exclude. A record is excluded if it
+// matches any of the values.
+type RecordFilterNot struct {
+	Labels map[string]string
+
+	ResourceTypes []string
+	ResourceIDs   []string
+
+	OperationTypes    []string
+	OperationIDs      []string
+	OperationStatuses []OperationStatus
+
+	ActorTypes []string
+	ActorIDs   []string
 }
 
 type RecordCursor struct {
@@ -55,6 +113,44 @@ func NewRecordCursor(records []Record, pageSize int32) RecordCursor {
 	return cursor
 }
 
+// RecordWatchCursor points to the last record sent when watching records.
+// Records after the cursor are ordered by create time and identifier.
+type RecordWatchCursor struct {
+	LastCreateTime *time.Time `json:"lct,omitempty"`
+	LastID         *ID        `json:"lid,omitempty"`
+}
+
+func (c RecordWatchCursor) Empty() bool {
+	return c.LastCreateTime == nil && c.LastID == nil
+}
+
+// Advance returns the cursor moved to the record, if the record is after
+// the cursor.
+func (c RecordWatchCursor) Advance(record Record) RecordWatchCursor {
+	if !c.Precedes(record) {
+		return c
+	}
+
+	return RecordWatchCursor{
+		LastCreateTime: &record.CreateTime,
+		LastID:         &record.ID,
+	}
+}
+
+// Precedes reports whether the record is after the cursor. Empty cursor
+// precedes all records.
+func (c RecordWatchCursor) Precedes(record Record) bool {
+	if c.Empty() {
+		return true
+	}
+
+	if !record.CreateTime.Equal(*c.LastCreateTime) {
+		return record.CreateTime.After(*c.LastCreateTime)
+	}
+
+	return bytes.Compare(record.ID[:], c.LastID[:]) > 0
+}
+
 type RecordUpdate struct {
 	Labels       map[string]string
 	UpdateLabels bool
@@ -68,3 +164,20 @@ type RecordUpdate struct {
 	Actor       Actor
 	UpdateActor bool
 }
+
+// Apply returns the record with the update applied.
+func (u RecordUpdate) Apply(record Record) Record {
+	if u.UpdateLabels {
+		record.Labels = u.Labels
+	}
+	if u.UpdateResource {
+		record.Resource = u.Resource
+	}
+	if u.UpdateOperation {
+		record.Operation = u.Operation
+	}
+	if u.UpdateActor {
+		record.Actor = u.Actor
+	}
+	return record
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestRecordWatchCursor_Advance(t *testing.T) {
+	t1 := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Second)
+
+	id1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	id2 := aud.MustParseID("00000000-0000-0000-0000-000000000002")
+
+	record := func(createTime time.Time, id aud.ID) aud.Record {
+		return aud.Record{ID: id, CreateTime: createTime}
+	}
+
+	var cursor aud.RecordWatchCursor
+	assert.True(t, cursor.Empty())
+	assert.True(t, cursor.Precedes(record(t1, id1)))
+
+	cursor = cursor.Advance(record(t1, id2))
+	assert.Equal(t, t1, *cursor.LastCreateTime)
+	assert.Equal(t, id2, *cursor.LastID)
+
+	// Same create time is ordered by ID.
+	assert.False(t, cursor.Precedes(record(t1, id1)))
+	assert.False(t, cursor.Precedes(record(t1, id2)))
+	assert.True(t, cursor.Precedes(record(t2, id1)))
+
+	// Cursor does not move back.
+	assert.Equal(t, cursor, cursor.Advance(record(t1, id1)))
+
+	cursor = cursor.Advance(record(t2, id1))
+	assert.Equal(t, t2, *cursor.LastCreateTime)
+	assert.Equal(t, id1, *cursor.LastID)
+}
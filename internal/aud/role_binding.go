@@ -0,0 +1,83 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"fmt"
+	"time"
+)
+
+// Role grants access to a project. Each role includes all permissions of
+// the previous roles.
+type Role int
+
+const (
+	RoleUnspecified Role = iota
+	// RoleViewer allows getting the project and reading its records.
+	RoleViewer
+	// RoleWriter allows creating records.
+	RoleWriter
+	// RoleEditor allows updating and deleting records.
+	RoleEditor
+	// RoleAdmin allows updating the project and managing its role bindings.
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleWriter:
+		return "writer"
+	case RoleEditor:
+		return "editor"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "unspecified"
+	}
+}
+
+func ParseRole(s string) (Role, error) {
+	switch s {
+	case "viewer":
+		return RoleViewer, nil
+	case "writer":
+		return RoleWriter, nil
+	case "editor":
+		return RoleEditor, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleUnspecified, fmt.Errorf("unknown role %q", s)
+	}
+}
+
+// Includes reports whether the role includes the other role.
+func (r Role) Includes(other Role) bool {
+	return r != RoleUnspecified && r >= other
+}
+
+// RoleBinding grants the role in the project to the principal.
+// A principal has at most one role in a project.
+type RoleBinding struct {
+	ProjectID ID
+	// PrincipalID identifies the principal, e.g. "apikey:<id>",
+	// "jwt:<subject>" or "cert:<subject>".
+	PrincipalID string
+	Role        Role
+	CreateTime  time.Time
+	UpdateTime  time.Time
+}
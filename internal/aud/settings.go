@@ -58,6 +58,17 @@ func (r RecordsRestrictions) Validate() error {
 	)
 }
 
+// Merge returns restrictions with limits set in overrides replacing the
+// ones in r. Zero limits in overrides are not set and keep the values of r.
+func (r RecordsRestrictions) Merge(overrides RecordsRestrictions) RecordsRestrictions {
+	return RecordsRestrictions{
+		Labels:    r.Labels.Merge(overrides.Labels),
+		Resource:  r.Resource.Merge(overrides.Resource),
+		Operation: r.Operation.Merge(overrides.Operation),
+		Actor:     r.Actor.Merge(overrides.Actor),
+	}
+}
+
 type RecordsRestrictionsResource struct {
 	Type     RestrictionsString                 `yaml:"type" json:"type"`
 	ID       RestrictionsString                 `yaml:"id" json:"id"`
@@ -74,6 +85,15 @@ func (r RecordsRestrictionsResource) Validate() error {
 	)
 }
 
+func (r RecordsRestrictionsResource) Merge(overrides RecordsRestrictionsResource) RecordsRestrictionsResource {
+	return RecordsRestrictionsResource{
+		Type:     r.Type.Merge(overrides.Type),
+		ID:       r.ID.Merge(overrides.ID),
+		Metadata: r.Metadata.Merge(overrides.Metadata),
+		Changes:  r.Changes.Merge(overrides.Changes),
+	}
+}
+
 type RecordsRestrictionsResourceChanges struct {
 	TotalMaxCount int                `yaml:"totalMaxCount" json:"totalMaxCount"`
 	Name          RestrictionsString `yaml:"name" json:"name"`
@@ -99,6 +119,16 @@ func (r RecordsRestrictionsResourceChanges) Validate() error {
 	)
 }
 
+func (r RecordsRestrictionsResourceChanges) Merge(overrides RecordsRestrictionsResourceChanges) RecordsRestrictionsResourceChanges {
+	return RecordsRestrictionsResourceChanges{
+		TotalMaxCount: overrideLimit(r.TotalMaxCount, overrides.TotalMaxCount),
+		Name:          r.Name.Merge(overrides.Name),
+		Description:   r.Description.Merge(overrides.Description),
+		OldValue:      r.OldValue.Merge(overrides.OldValue),
+		NewValue:      r.NewValue.Merge(overrides.NewValue),
+	}
+}
+
 type RecordsRestrictionsOperation struct {
 	Type     RestrictionsString   `yaml:"type" json:"type"`
 	ID       RestrictionsString   `yaml:"id" json:"id"`
@@ -113,6 +143,14 @@ func (r RecordsRestrictionsOperation) Validate() error {
 	)
 }
 
+func (r RecordsRestrictionsOperation) Merge(overrides RecordsRestrictionsOperation) RecordsRestrictionsOperation {
+	return RecordsRestrictionsOperation{
+		Type:     r.Type.Merge(overrides.Type),
+		ID:       r.ID.Merge(overrides.ID),
+		Metadata: r.Metadata.Merge(overrides.Metadata),
+	}
+}
+
 type RecordsRestrictionsActor struct {
 	Type     RestrictionsString   `yaml:"type" json:"type"`
 	ID       RestrictionsString   `yaml:"id" json:"id"`
@@ -127,6 +165,14 @@ func (r RecordsRestrictionsActor) Validate() error {
 	)
 }
 
+func (r RecordsRestrictionsActor) Merge(overrides RecordsRestrictionsActor) RecordsRestrictionsActor {
+	return RecordsRestrictionsActor{
+		Type:     r.Type.Merge(overrides.Type),
+		ID:       r.ID.Merge(overrides.ID),
+		Metadata: r.Metadata.Merge(overrides.Metadata),
+	}
+}
+
 type RestrictionsKeyValue struct {
 	KeyMaxSizeBytes   int `yaml:"keyMaxSizeBytes" json:"keyMaxSizeBytes"`
 	ValueMaxSizeBytes int `yaml:"valueMaxSizeBytes" json:"valueMaxSizeBytes"`
@@ -150,6 +196,14 @@ func (r RestrictionsKeyValue) Validate() error {
 	)
 }
 
+func (r RestrictionsKeyValue) Merge(overrides RestrictionsKeyValue) RestrictionsKeyValue {
+	return RestrictionsKeyValue{
+		KeyMaxSizeBytes:   overrideLimit(r.KeyMaxSizeBytes, overrides.KeyMaxSizeBytes),
+		ValueMaxSizeBytes: overrideLimit(r.ValueMaxSizeBytes, overrides.ValueMaxSizeBytes),
+		TotalMaxSizeBytes: overrideLimit(r.TotalMaxSizeBytes, overrides.TotalMaxSizeBytes),
+	}
+}
+
 type RestrictionsString struct {
 	MaxSizeBytes int `yaml:"maxSizeBytes" json:"maxSizeBytes"`
 }
@@ -163,6 +217,12 @@ func (r RestrictionsString) Validate() error {
 	)
 }
 
+func (r RestrictionsString) Merge(overrides RestrictionsString) RestrictionsString {
+	return RestrictionsString{
+		MaxSizeBytes: overrideLimit(r.MaxSizeBytes, overrides.MaxSizeBytes),
+	}
+}
+
 type RestrictionsBytes struct {
 	MaxSizeBytes int `yaml:"maxSizeBytes" json:"maxSizeBytes"`
 }
@@ -176,6 +236,19 @@ func (r RestrictionsBytes) Validate() error {
 	)
 }
 
+func (r RestrictionsBytes) Merge(overrides RestrictionsBytes) RestrictionsBytes {
+	return RestrictionsBytes{
+		MaxSizeBytes: overrideLimit(r.MaxSizeBytes, overrides.MaxSizeBytes),
+	}
+}
+
+func overrideLimit(value, override int) int {
+	if override != 0 {
+		return override
+	}
+	return value
+}
+
 var DefaultSettings = Settings{
 	Records: RecordsSettings{
 		UpdateEnabled: false,
@@ -0,0 +1,60 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+func TestRecordsRestrictions_Merge(t *testing.T) {
+	global := aud.DefaultSettings.Records.Restrictions
+
+	t.Run("Empty overrides keep global restrictions", func(t *testing.T) {
+		got := global.Merge(aud.RecordsRestrictions{})
+		assert.Equal(t, global, got)
+	})
+
+	t.Run("Set limits override global restrictions", func(t *testing.T) {
+		overrides := aud.RecordsRestrictions{
+			Labels: aud.RestrictionsKeyValue{
+				TotalMaxSizeBytes: 8192,
+			},
+			Resource: aud.RecordsRestrictionsResource{
+				Changes: aud.RecordsRestrictionsResourceChanges{
+					TotalMaxCount: 50,
+					OldValue:      aud.RestrictionsBytes{MaxSizeBytes: 65536},
+					NewValue:      aud.RestrictionsBytes{MaxSizeBytes: 65536},
+				},
+			},
+			Actor: aud.RecordsRestrictionsActor{
+				ID: aud.RestrictionsString{MaxSizeBytes: 16},
+			},
+		}
+
+		want := global
+		want.Labels.TotalMaxSizeBytes = 8192
+		want.Resource.Changes.TotalMaxCount = 50
+		want.Resource.Changes.OldValue.MaxSizeBytes = 65536
+		want.Resource.Changes.NewValue.MaxSizeBytes = 65536
+		want.Actor.ID.MaxSizeBytes = 16
+
+		got := global.Merge(overrides)
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aud
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+const (
+	webhookSecretPrefix      = "whsec_"
+	webhookSecretRandomBytes = 32
+)
+
+// Webhook subscribes a URL to records created in the project.
+type Webhook struct {
+	ID          ID
+	ProjectID   ID
+	CreateTime  time.Time
+	DisplayName string
+	// URL is the HTTP(S) URL records are delivered to.
+	URL string
+	// Secret signs delivered payloads with HMAC-SHA256.
+	Secret string
+	// Filter is a filter expression of records to deliver. Delivers all
+	// records if empty.
+	Filter string
+}
+
+// NewWebhook generates a new secret and returns the webhook holding it. The
+// secret is shown to the user only once.
+func NewWebhook(
+	id ID,
+	projectID ID,
+	createTime time.Time,
+	displayName string,
+	url string,
+	filter string,
+) (Webhook, error) {
+	b := make([]byte, webhookSecretRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return Webhook{}, fmt.Errorf("generate random secret: %v", err)
+	}
+
+	return Webhook{
+		ID:          id,
+		ProjectID:   projectID,
+		CreateTime:  createTime,
+		DisplayName: displayName,
+		URL:         url,
+		Secret:      webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(b),
+		Filter:      filter,
+	}, nil
+}
+
+type WebhookDeliveryStatus int
+
+const (
+	WebhookDeliveryStatusUnspecified WebhookDeliveryStatus = iota
+	// WebhookDeliveryStatusPending is a delivery waiting for the next
+	// attempt.
+	WebhookDeliveryStatusPending
+	// WebhookDeliveryStatusSucceeded is a delivery acknowledged by the
+	// receiver.
+	WebhookDeliveryStatusSucceeded
+	// WebhookDeliveryStatusFailed is a delivery which ran out of attempts.
+	WebhookDeliveryStatusFailed
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	switch s {
+	case WebhookDeliveryStatusPending:
+		return "pending"
+	case WebhookDeliveryStatusSucceeded:
+		return "succeeded"
+	case WebhookDeliveryStatusFailed:
+		return "failed"
+	default:
+		return "unspecified"
+	}
+}
+
+func ParseWebhookDeliveryStatus(s string) (WebhookDeliveryStatus, error) {
+	switch s {
+	case "pending":
+		return WebhookDeliveryStatusPending, nil
+	case "succeeded":
+		return WebhookDeliveryStatusSucceeded, nil
+	case "failed":
+		return WebhookDeliveryStatusFailed, nil
+	default:
+		return WebhookDeliveryStatusUnspecified, fmt.Errorf("unknown webhook delivery status %q", s)
+	}
+}
+
+// WebhookDelivery is a record to deliver to a webhook. Deliveries are kept
+// after completion as a log for debugging.
+type WebhookDelivery struct {
+	ID         ID
+	ProjectID  ID
+	WebhookID  ID
+	RecordID   ID
+	CreateTime time.Time
+	Status     WebhookDeliveryStatus
+	// Attempts is the number of completed delivery attempts.
+	Attempts int
+	// NextAttemptTime is the time of the next attempt of a pending delivery.
+	NextAttemptTime time.Time
+	// LastAttemptTime is zero if there were no attempts yet.
+	LastAttemptTime time.Time
+	// LastResponseCode is the HTTP status code of the last attempt, zero if
+	// no response was received.
+	LastResponseCode int
+	// LastError describes why the last attempt failed.
+	LastError string
+}
+
+type WebhookDeliveryFilter struct {
+	Statuses []WebhookDeliveryStatus
+}
+
+type WebhookDeliveryCursor struct {
+	LastID *ID `json:"lid,omitempty"`
+}
+
+func (c WebhookDeliveryCursor) Empty() bool {
+	return c.LastID == nil
+}
+
+func NewWebhookDeliveryCursor(deliveries []WebhookDelivery, pageSize int32) WebhookDeliveryCursor {
+	var cursor WebhookDeliveryCursor
+
+	if len(deliveries) >= int(pageSize) {
+		last := deliveries[len(deliveries)-1]
+		cursor.LastID = &last.ID
+	}
+
+	return cursor
+}
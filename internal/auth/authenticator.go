@@ -0,0 +1,89 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// ErrInvalidCredentials is returned when credentials are not valid, e.g. the
+// token is unknown, expired or revoked.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator authenticates bearer tokens.
+type Authenticator interface {
+	// Authenticate returns the principal identified by the token.
+	// Returns ErrInvalidCredentials if the token is not valid.
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
+
+// ChainAuthenticator tries authenticators in order and returns the first
+// successfully authenticated principal.
+type ChainAuthenticator []Authenticator
+
+func (c ChainAuthenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if errors.Is(err, ErrInvalidCredentials) {
+			continue
+		}
+		return p, err
+	}
+	return Principal{}, ErrInvalidCredentials
+}
+
+type APIKeyStore interface {
+	// May return [aud.ErrAPIKeyNotFound].
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (aud.APIKey, error)
+}
+
+// APIKeyAuthenticator authenticates API keys.
+type APIKeyAuthenticator struct {
+	store APIKeyStore
+}
+
+func NewAPIKeyAuthenticator(store APIKeyStore) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		store: store,
+	}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if !aud.IsAPIKey(token) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	key, err := a.store.GetAPIKeyByHash(ctx, aud.HashAPIKey(token))
+	if errors.Is(err, aud.ErrAPIKeyNotFound) {
+		return Principal{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Principal{}, fmt.Errorf("get api key: %v", err)
+	}
+
+	if key.Revoked() {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{
+		ID:          "apikey:" + key.ID.String(),
+		ProjectIDs:  key.ProjectIDs,
+		Permissions: key.Permissions,
+	}, nil
+}
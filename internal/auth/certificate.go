@@ -0,0 +1,97 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/pkg/fragma/tlsx"
+)
+
+// ClientCertificateSubjectMetadataKey is the metadata key with the subject
+// of the client certificate verified by gRPC-Gateway. It is trusted only on
+// unix socket connections from the gateway.
+const ClientCertificateSubjectMetadataKey = "x-auditum-client-cert-subject"
+
+// ClientCertificate grants access to clients with the certificate subject.
+type ClientCertificate struct {
+	// Subject is the certificate subject in RFC 2253 format,
+	// e.g. "CN=blog,O=Example".
+	Subject     string
+	ProjectIDs  []aud.ID
+	Permissions []aud.Permission
+}
+
+// ClientCertificateAuthenticator authenticates clients by the subject of
+// the verified TLS client certificate. Clients with unknown subjects are
+// authenticated, but have no permissions.
+type ClientCertificateAuthenticator struct {
+	certs map[string]ClientCertificate
+}
+
+func NewClientCertificateAuthenticator(certs []ClientCertificate) *ClientCertificateAuthenticator {
+	m := make(map[string]ClientCertificate, len(certs))
+	for _, cert := range certs {
+		m[cert.Subject] = cert
+	}
+
+	return &ClientCertificateAuthenticator{
+		certs: m,
+	}
+}
+
+// Authenticate returns the principal of the certificate subject.
+func (a *ClientCertificateAuthenticator) Authenticate(_ context.Context, subject string) (Principal, error) {
+	if subject == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	cert := a.certs[subject]
+
+	return Principal{
+		ID:          "cert:" + subject,
+		ProjectIDs:  cert.ProjectIDs,
+		Permissions: cert.Permissions,
+	}, nil
+}
+
+// clientCertificateSubject returns the subject of the verified client
+// certificate of the request, either presented over gRPC or forwarded by
+// gRPC-Gateway.
+func clientCertificateSubject(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		return tlsx.ClientCertificateSubject(&info.State)
+	}
+
+	if _, ok := p.Addr.(*net.UnixAddr); ok {
+		values := metadata.ValueFromIncomingContext(ctx, ClientCertificateSubjectMetadataKey)
+		if len(values) == 1 && values[0] != "" {
+			return values[0], true
+		}
+	}
+
+	return "", false
+}
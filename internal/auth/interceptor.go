@@ -0,0 +1,279 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// Rule describes access to a gRPC method.
+//
+// A principal may call the method if it has the permission and access to
+// the project, or if it has the role in the project.
+type Rule struct {
+	// Permission required to call the method.
+	Permission aud.Permission
+	// Role in the project that allows to call the method. If unspecified,
+	// roles are not considered.
+	Role aud.Role
+	// ProjectID returns the project targeted by the request. If nil, the
+	// method does not target a single project and requires access to all
+	// projects.
+	ProjectID func(req any) string
+	// AnyPrincipal allows any authenticated principal to call the method.
+	// The method is responsible for authorization, e.g. filters results
+	// by the principal access.
+	AnyPrincipal bool
+}
+
+type RoleBindingStore interface {
+	// May return [aud.ErrRoleBindingNotFound].
+	GetRoleBinding(ctx context.Context, projectID aud.ID, principalID string) (aud.RoleBinding, error)
+}
+
+// publicServices are available without authentication.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// Interceptor authenticates and authorizes requests to gRPC methods
+// according to the rules. Methods without a rule are denied, except for
+// health checks and reflection.
+type Interceptor struct {
+	authenticator     Authenticator
+	certAuthenticator Authenticator
+	roleBindings      RoleBindingStore
+	rules             map[string]Rule
+	log               *zap.Logger
+}
+
+func NewInterceptor(
+	authenticator Authenticator,
+	rules map[string]Rule,
+	log *zap.Logger,
+	opts ...InterceptorOption,
+) *Interceptor {
+	i := &Interceptor{
+		authenticator: authenticator,
+		rules:         rules,
+		log:           log.Named("auth"),
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+type InterceptorOption func(*Interceptor)
+
+// InterceptorWithRoleBindings authorizes requests by roles of principals in
+// projects.
+func InterceptorWithRoleBindings(store RoleBindingStore) InterceptorOption {
+	return func(i *Interceptor) {
+		i.roleBindings = store
+	}
+}
+
+// InterceptorWithClientCertificates authenticates requests without bearer
+// token by the subject of the verified TLS client certificate.
+func InterceptorWithClientCertificates(authenticator Authenticator) InterceptorOption {
+	return func(i *Interceptor) {
+		i.certAuthenticator = authenticator
+	}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		principal, rule, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := i.authorize(ctx, principal, rule, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ContextWithPrincipal(ctx, principal), req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		principal, rule, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedServerStream{
+			ServerStream: ss,
+			ctx:          ContextWithPrincipal(ss.Context(), principal),
+			interceptor:  i,
+			principal:    principal,
+			rule:         rule,
+		})
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, method string) (Principal, Rule, error) {
+	rule, ok := i.rules[method]
+	if !ok {
+		i.log.Warn("No access rule for method", zap.String("method", method))
+		return Principal{}, Rule{}, status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+
+	authenticator := i.authenticator
+	token, ok := bearerToken(ctx)
+	if !ok && i.certAuthenticator != nil {
+		authenticator = i.certAuthenticator
+		token, ok = clientCertificateSubject(ctx)
+	}
+	if !ok {
+		return Principal{}, Rule{}, status.Error(
+			codes.Unauthenticated,
+			`Request is not authenticated. Provide "authorization: Bearer <token>" header.`,
+		)
+	}
+
+	principal, err := authenticator.Authenticate(ctx, token)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return Principal{}, Rule{}, status.Error(codes.Unauthenticated, "Invalid credentials.")
+	}
+	if err != nil {
+		i.log.Error("Authenticate request", zap.String("method", method), zap.Error(err))
+		return Principal{}, Rule{}, status.Error(codes.Internal, "")
+	}
+
+	return principal, rule, nil
+}
+
+// authorize checks that the principal may call the method with the request.
+func (i *Interceptor) authorize(ctx context.Context, principal Principal, rule Rule, req any) error {
+	if rule.AnyPrincipal {
+		return nil
+	}
+
+	if principal.HasPermission(rule.Permission) && principal.AllProjects() {
+		return nil
+	}
+
+	if rule.ProjectID == nil {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+
+	projectID, err := aud.ParseID(rule.ProjectID(req))
+	if err != nil {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+
+	if principal.HasPermission(rule.Permission) && principal.HasProject(projectID) {
+		return nil
+	}
+
+	if rule.Role == aud.RoleUnspecified || i.roleBindings == nil {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+
+	binding, err := i.roleBindings.GetRoleBinding(ctx, projectID, principal.ID)
+	if errors.Is(err, aud.ErrRoleBindingNotFound) {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+	if err != nil {
+		i.log.Error("Get role binding",
+			zap.String("project_id", projectID.String()),
+			zap.String("principal_id", principal.ID),
+			zap.Error(err),
+		)
+		return status.Error(codes.Internal, "")
+	}
+
+	if !binding.Role.Includes(rule.Role) {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+
+	return nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	const scheme = "bearer "
+
+	for _, value := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		if len(value) > len(scheme) && strings.EqualFold(value[:len(scheme)], scheme) {
+			return strings.TrimSpace(value[len(scheme):]), true
+		}
+	}
+
+	return "", false
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizedServerStream authorizes every received request.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	interceptor *Interceptor
+	principal   Principal
+	rule        Rule
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return s.interceptor.authorize(s.ctx, s.principal, s.rule, m)
+}
@@ -0,0 +1,532 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+)
+
+type fakeAuthenticator map[string]auth.Principal
+
+func (a fakeAuthenticator) Authenticate(_ context.Context, token string) (auth.Principal, error) {
+	p, ok := a[token]
+	if !ok {
+		return auth.Principal{}, auth.ErrInvalidCredentials
+	}
+	return p, nil
+}
+
+type projectRequest struct {
+	projectID string
+}
+
+func (r projectRequest) GetProjectId() string {
+	return r.projectID
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	project2 := aud.MustParseID("00000000-0000-0000-0000-000000000002")
+
+	authenticator := fakeAuthenticator{
+		"admin": {
+			ID:          "admin",
+			Permissions: []aud.Permission{aud.PermissionAdmin},
+		},
+		"reader": {
+			ID:          "reader",
+			Permissions: []aud.Permission{aud.PermissionRead},
+		},
+		"project1-writer": {
+			ID:          "project1-writer",
+			ProjectIDs:  []aud.ID{project1},
+			Permissions: []aud.Permission{aud.PermissionWrite},
+		},
+	}
+
+	rules := map[string]auth.Rule{
+		"/test.Service/Create": {
+			Permission: aud.PermissionAdmin,
+		},
+		"/test.Service/Get": {
+			Permission: aud.PermissionRead,
+			ProjectID:  func(req any) string { return req.(projectRequest).GetProjectId() },
+		},
+		"/test.Service/Write": {
+			Permission: aud.PermissionWrite,
+			ProjectID:  func(req any) string { return req.(projectRequest).GetProjectId() },
+		},
+	}
+
+	interceptor := auth.NewInterceptor(authenticator, rules, zap.NewNop()).Unary()
+
+	tests := []struct {
+		name     string
+		method   string
+		header   string
+		req      any
+		wantCode codes.Code
+		wantID   string
+	}{
+		{
+			name:     "public method without token",
+			method:   "/grpc.health.v1.Health/Check",
+			req:      nil,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "method without rule",
+			method:   "/test.Service/Unknown",
+			header:   "Bearer admin",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "no token",
+			method:   "/test.Service/Create",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "not bearer token",
+			method:   "/test.Service/Create",
+			header:   "Basic admin",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			method:   "/test.Service/Create",
+			header:   "Bearer unknown",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "admin is allowed everything",
+			method:   "/test.Service/Create",
+			header:   "bearer admin",
+			wantCode: codes.OK,
+			wantID:   "admin",
+		},
+		{
+			name:     "missing permission",
+			method:   "/test.Service/Write",
+			header:   "Bearer reader",
+			req:      projectRequest{projectID: project1.String()},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "reader of all projects",
+			method:   "/test.Service/Get",
+			header:   "Bearer reader",
+			req:      projectRequest{projectID: project2.String()},
+			wantCode: codes.OK,
+			wantID:   "reader",
+		},
+		{
+			name:     "write does not include read",
+			method:   "/test.Service/Get",
+			header:   "Bearer project1-writer",
+			req:      projectRequest{projectID: project1.String()},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "allowed project",
+			method:   "/test.Service/Write",
+			header:   "Bearer project1-writer",
+			req:      projectRequest{projectID: project1.String()},
+			wantCode: codes.OK,
+			wantID:   "project1-writer",
+		},
+		{
+			name:     "other project",
+			method:   "/test.Service/Write",
+			header:   "Bearer project1-writer",
+			req:      projectRequest{projectID: project2.String()},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "invalid project",
+			method:   "/test.Service/Write",
+			header:   "Bearer project1-writer",
+			req:      projectRequest{projectID: "invalid"},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", test.header))
+			}
+
+			var gotID string
+			handler := func(ctx context.Context, _ any) (any, error) {
+				p, _ := auth.PrincipalFromContext(ctx)
+				gotID = p.ID
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, test.req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+			assert.Equal(t, test.wantCode, status.Code(err))
+			assert.Equal(t, test.wantID, gotID)
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req projectRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	*m.(*projectRequest) = s.req
+	return nil
+}
+
+func TestInterceptor_Stream(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	project2 := aud.MustParseID("00000000-0000-0000-0000-000000000002")
+
+	authenticator := fakeAuthenticator{
+		"project1-reader": {
+			ID:          "project1-reader",
+			ProjectIDs:  []aud.ID{project1},
+			Permissions: []aud.Permission{aud.PermissionRead},
+		},
+	}
+
+	rules := map[string]auth.Rule{
+		"/test.Service/Export": {
+			Permission: aud.PermissionRead,
+			ProjectID:  func(req any) string { return req.(*projectRequest).GetProjectId() },
+		},
+	}
+
+	interceptor := auth.NewInterceptor(authenticator, rules, zap.NewNop()).Stream()
+
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Export", IsServerStream: true}
+
+	handler := func(_ any, stream grpc.ServerStream) error {
+		var req projectRequest
+		return stream.RecvMsg(&req)
+	}
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("authorization", "Bearer project1-reader"),
+	)
+
+	t.Run("allowed project", func(t *testing.T) {
+		err := interceptor(nil, &fakeServerStream{ctx: ctx, req: projectRequest{projectID: project1.String()}}, info, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("other project", func(t *testing.T) {
+		err := interceptor(nil, &fakeServerStream{ctx: ctx, req: projectRequest{projectID: project2.String()}}, info, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestInterceptor_ClientCertificate(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+
+	certAuthenticator := auth.NewClientCertificateAuthenticator([]auth.ClientCertificate{
+		{
+			Subject:     "CN=blog,O=Example",
+			ProjectIDs:  []aud.ID{project1},
+			Permissions: []aud.Permission{aud.PermissionWrite},
+		},
+	})
+
+	rules := map[string]auth.Rule{
+		"/test.Service/Write": {
+			Permission: aud.PermissionWrite,
+			ProjectID:  func(req any) string { return req.(projectRequest).GetProjectId() },
+		},
+	}
+
+	interceptor := auth.NewInterceptor(
+		fakeAuthenticator{"admin": {ID: "admin", Permissions: []aud.Permission{aud.PermissionAdmin}}},
+		rules,
+		zap.NewNop(),
+		auth.InterceptorWithClientCertificates(certAuthenticator),
+	).Unary()
+
+	tlsPeer := func(subject string) *peer.Peer {
+		var chains [][]*x509.Certificate
+		if subject != "" {
+			chains = [][]*x509.Certificate{{{Subject: pkix.Name{
+				CommonName:   subject,
+				Organization: []string{"Example"},
+			}}}}
+		}
+		return &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000},
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{VerifiedChains: chains},
+			},
+		}
+	}
+
+	unixPeer := &peer.Peer{
+		Addr: &net.UnixAddr{Net: "unix"},
+	}
+
+	tcpPeer := &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000},
+	}
+
+	forwarded := metadata.Pairs(auth.ClientCertificateSubjectMetadataKey, "CN=blog,O=Example")
+
+	tests := []struct {
+		name     string
+		peer     *peer.Peer
+		md       metadata.MD
+		wantCode codes.Code
+		wantID   string
+	}{
+		{
+			name:     "verified certificate",
+			peer:     tlsPeer("blog"),
+			wantCode: codes.OK,
+			wantID:   "cert:CN=blog,O=Example",
+		},
+		{
+			name:     "unknown certificate subject",
+			peer:     tlsPeer("other"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "no certificate",
+			peer:     tlsPeer(""),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "bearer token takes precedence",
+			peer:     tlsPeer("other"),
+			md:       metadata.Pairs("authorization", "Bearer admin"),
+			wantCode: codes.OK,
+			wantID:   "admin",
+		},
+		{
+			name:     "forwarded by gateway",
+			peer:     unixPeer,
+			md:       forwarded,
+			wantCode: codes.OK,
+			wantID:   "cert:CN=blog,O=Example",
+		},
+		{
+			name:     "forwarded over TCP is not trusted",
+			peer:     tcpPeer,
+			md:       forwarded,
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), test.peer)
+			if test.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, test.md)
+			}
+
+			var gotID string
+			handler := func(ctx context.Context, _ any) (any, error) {
+				p, _ := auth.PrincipalFromContext(ctx)
+				gotID = p.ID
+				return nil, nil
+			}
+
+			req := projectRequest{projectID: project1.String()}
+			_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Write"}, handler)
+			assert.Equal(t, test.wantCode, status.Code(err))
+			assert.Equal(t, test.wantID, gotID)
+		})
+	}
+}
+
+type fakeRoleBindingStore map[string]aud.Role
+
+func (s fakeRoleBindingStore) GetRoleBinding(_ context.Context, projectID aud.ID, principalID string) (aud.RoleBinding, error) {
+	role, ok := s[projectID.String()+"/"+principalID]
+	if !ok {
+		return aud.RoleBinding{}, aud.ErrRoleBindingNotFound
+	}
+	return aud.RoleBinding{ProjectID: projectID, PrincipalID: principalID, Role: role}, nil
+}
+
+func TestInterceptor_RoleBindings(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	project2 := aud.MustParseID("00000000-0000-0000-0000-000000000002")
+
+	authenticator := fakeAuthenticator{
+		"alice": {ID: "jwt:alice"},
+		"project2-reader": {
+			ID:          "apikey:project2-reader",
+			ProjectIDs:  []aud.ID{project2},
+			Permissions: []aud.Permission{aud.PermissionRead},
+		},
+	}
+
+	roleBindings := fakeRoleBindingStore{
+		project1.String() + "/jwt:alice":              aud.RoleWriter,
+		project1.String() + "/apikey:project2-reader": aud.RoleViewer,
+	}
+
+	projectID := func(req any) string { return req.(projectRequest).GetProjectId() }
+
+	rules := map[string]auth.Rule{
+		"/test.Service/Create": {
+			Permission: aud.PermissionAdmin,
+		},
+		"/test.Service/List": {
+			AnyPrincipal: true,
+		},
+		"/test.Service/Get": {
+			Permission: aud.PermissionRead,
+			Role:       aud.RoleViewer,
+			ProjectID:  projectID,
+		},
+		"/test.Service/Write": {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleWriter,
+			ProjectID:  projectID,
+		},
+		"/test.Service/Delete": {
+			Permission: aud.PermissionWrite,
+			Role:       aud.RoleEditor,
+			ProjectID:  projectID,
+		},
+	}
+
+	interceptor := auth.NewInterceptor(
+		authenticator,
+		rules,
+		zap.NewNop(),
+		auth.InterceptorWithRoleBindings(roleBindings),
+	).Unary()
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		project  aud.ID
+		wantCode codes.Code
+	}{
+		{
+			name:     "writer role includes viewer",
+			method:   "/test.Service/Get",
+			token:    "alice",
+			project:  project1,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "writer role",
+			method:   "/test.Service/Write",
+			token:    "alice",
+			project:  project1,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "writer role does not include editor",
+			method:   "/test.Service/Delete",
+			token:    "alice",
+			project:  project1,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "no role in project",
+			method:   "/test.Service/Get",
+			token:    "alice",
+			project:  project2,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "method without project",
+			method:   "/test.Service/Create",
+			token:    "alice",
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "any principal",
+			method:   "/test.Service/List",
+			token:    "alice",
+			wantCode: codes.OK,
+		},
+		{
+			name:     "permission in scoped project",
+			method:   "/test.Service/Get",
+			token:    "project2-reader",
+			project:  project2,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "role outside of scoped projects",
+			method:   "/test.Service/Get",
+			token:    "project2-reader",
+			project:  project1,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "role does not extend permissions",
+			method:   "/test.Service/Write",
+			token:    "project2-reader",
+			project:  project1,
+			wantCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(
+				context.Background(),
+				metadata.Pairs("authorization", "Bearer "+test.token),
+			)
+
+			handler := func(context.Context, any) (any, error) {
+				return nil, nil
+			}
+
+			req := projectRequest{projectID: test.project.String()}
+			_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+			assert.Equal(t, test.wantCode, status.Code(err))
+		})
+	}
+}
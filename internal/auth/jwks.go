@@ -0,0 +1,183 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	jwksFetchTimeout = 10 * time.Second
+	jwksMaxSize      = 1 << 20
+
+	// jwksMinRefreshInterval limits how often a token with unknown key ID
+	// can trigger key set refresh.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// JWKS is a JSON Web Key Set loaded from a local file or URL.
+// It is safe for concurrent use.
+type JWKS struct {
+	source string
+	client *http.Client
+	log    *zap.Logger
+
+	mu          sync.RWMutex
+	keys        jose.JSONWebKeySet
+	lastRefresh time.Time
+
+	refreshMu sync.Mutex
+}
+
+// NewJWKS returns a key set loaded from the source, which is either a file
+// path or an http(s) URL. Keys are not loaded until Refresh is called.
+func NewJWKS(source string, log *zap.Logger) *JWKS {
+	return &JWKS{
+		source: source,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+		log:    log,
+	}
+}
+
+// Refresh loads the key set from the source. On failure, previously loaded
+// keys are kept.
+func (s *JWKS) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("decode key set: %v", err)
+	}
+
+	keys := make([]jose.JSONWebKey, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		// Only public keys are used to verify signatures. Private and
+		// symmetric keys are not expected in the key set.
+		if !key.IsPublic() {
+			key = key.Public()
+		}
+		if !key.Valid() || key.Use == "enc" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("key set has no public signing keys")
+	}
+
+	s.mu.Lock()
+	s.keys = jose.JSONWebKeySet{Keys: keys}
+	s.lastRefresh = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Run refreshes the key set periodically until the context is cancelled.
+func (s *JWKS) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				s.log.Error("Failed to refresh JWKS", zap.String("source", s.source), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Keys returns keys matching the key ID, or all keys if key ID is empty.
+// If no key matches, the key set is refreshed, but not more often than
+// once in jwksMinRefreshInterval, to pick up rotated keys.
+func (s *JWKS) Keys(ctx context.Context, kid string) []jose.JSONWebKey {
+	keys, lastRefresh := s.lookup(kid)
+	if len(keys) > 0 || time.Since(lastRefresh) < jwksMinRefreshInterval {
+		return keys
+	}
+
+	if err := s.Refresh(ctx); err != nil {
+		s.log.Error("Failed to refresh JWKS", zap.String("source", s.source), zap.Error(err))
+		return nil
+	}
+
+	keys, _ = s.lookup(kid)
+	return keys
+}
+
+func (s *JWKS) lookup(kid string) ([]jose.JSONWebKey, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		return s.keys.Keys, s.lastRefresh
+	}
+	return s.keys.Key(kid), s.lastRefresh
+}
+
+func (s *JWKS) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.source, "http://") && !strings.HasPrefix(s.source, "https://") {
+		data, err := os.ReadFile(s.source)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %v", err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %v", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// AllProjectsClaimValue is the value of the projects claim that grants
+// access to all projects.
+const AllProjectsClaimValue = "*"
+
+// JWTConfig configures validation of JWT bearer tokens.
+type JWTConfig struct {
+	// Issuer must match "iss" claim, if set.
+	Issuer string
+	// Audience must intersect with "aud" claim, if set.
+	Audience []string
+	// Algorithms are the allowed signature algorithms.
+	Algorithms []jose.SignatureAlgorithm
+	// Leeway is the allowed clock skew for time based claims.
+	Leeway time.Duration
+
+	// PrincipalClaim is the claim with the principal identifier.
+	PrincipalClaim string
+	// ProjectsClaim is the claim with the project IDs the principal has
+	// access to. If empty, tokens grant access to all projects.
+	ProjectsClaim string
+	// PermissionsClaim is the claim with the principal permissions.
+	PermissionsClaim string
+}
+
+// JWTAuthenticator authenticates JWT bearer tokens signed with keys from
+// a JSON Web Key Set.
+//
+// Claims are mapped to the principal as follows:
+//   - principal claim is the principal ID, prefixed with "jwt:";
+//   - projects claim is a list of project IDs, or "*" for all projects;
+//     a token without the claim has no permissions;
+//   - permissions claim is a list of "read", "write" or "admin";
+//     unknown values are ignored.
+//
+// Claim names may be dot-separated paths to nested claims. List claims may
+// be either JSON arrays or space-separated strings.
+type JWTAuthenticator struct {
+	keys *JWKS
+	conf JWTConfig
+	now  func() time.Time
+}
+
+func NewJWTAuthenticator(keys *JWKS, conf JWTConfig) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		keys: keys,
+		conf: conf,
+		now:  time.Now,
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if strings.Count(token, ".") != 2 {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	tok, err := jwt.ParseSigned(token, a.conf.Algorithms)
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	var kid string
+	if len(tok.Headers) > 0 {
+		kid = tok.Headers[0].KeyID
+	}
+
+	var (
+		claims jwt.Claims
+		raw    map[string]any
+	)
+	verified := false
+	for _, key := range a.keys.Keys(ctx, kid) {
+		if err := tok.Claims(key, &claims, &raw); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if claims.Expiry == nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	expected := jwt.Expected{
+		Issuer:      a.conf.Issuer,
+		AnyAudience: a.conf.Audience,
+		Time:        a.now(),
+	}
+	if err := claims.ValidateWithLeeway(expected, a.conf.Leeway); err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return a.principal(raw)
+}
+
+func (a *JWTAuthenticator) principal(raw map[string]any) (Principal, error) {
+	id, ok := lookupClaim(raw, a.conf.PrincipalClaim).(string)
+	if !ok || id == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	p := Principal{
+		ID: "jwt:" + id,
+	}
+
+	if a.conf.ProjectsClaim != "" {
+		projects, ok := claimStrings(lookupClaim(raw, a.conf.ProjectsClaim))
+		if !ok || len(projects) == 0 {
+			// No access to any project.
+			return p, nil
+		}
+
+		if !slices.Contains(projects, AllProjectsClaimValue) {
+			for _, s := range projects {
+				id, err := aud.ParseID(s)
+				if err != nil {
+					return Principal{}, ErrInvalidCredentials
+				}
+				p.ProjectIDs = append(p.ProjectIDs, id)
+			}
+		}
+	}
+
+	permissions, _ := claimStrings(lookupClaim(raw, a.conf.PermissionsClaim))
+	for _, s := range permissions {
+		perm, err := aud.ParsePermission(s)
+		if err != nil {
+			continue
+		}
+		p.Permissions = append(p.Permissions, perm)
+	}
+
+	return p, nil
+}
+
+// lookupClaim returns the claim by dot-separated path, or nil if not found.
+func lookupClaim(raw map[string]any, path string) any {
+	if path == "" {
+		return nil
+	}
+
+	var v any = raw
+	for _, name := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+// claimStrings converts the claim value to a list of strings. The value may
+// be a JSON array of strings or a space-separated string.
+func claimStrings(v any) ([]string, bool) {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v), true
+	case []any:
+		res := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			res = append(res, s)
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
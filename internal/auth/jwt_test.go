@@ -0,0 +1,404 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "auditum"
+)
+
+type testKey struct {
+	kid     string
+	private *ecdsa.PrivateKey
+}
+
+func newTestKey(t *testing.T, kid string) testKey {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return testKey{kid: kid, private: private}
+}
+
+func (k testKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.ES256,
+			Key:       jose.JSONWebKey{Key: k.private, KeyID: k.kid},
+		},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+func writeJWKS(t *testing.T, path string, keys ...testKey) {
+	t.Helper()
+
+	var set jose.JSONWebKeySet
+	for _, k := range keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{
+			Key:       &k.private.PublicKey,
+			KeyID:     k.kid,
+			Algorithm: string(jose.ES256),
+			Use:       "sig",
+		})
+	}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func validClaims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":                 testIssuer,
+		"aud":                 []string{testAudience},
+		"sub":                 "alice",
+		"iat":                 now.Unix(),
+		"exp":                 now.Add(time.Hour).Unix(),
+		"auditum_projects":    []string{"00000000-0000-0000-0000-000000000001"},
+		"auditum_permissions": []string{"read", "write"},
+	}
+}
+
+func withClaims(overrides map[string]any) map[string]any {
+	claims := validClaims()
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+var testJWTConfig = auth.JWTConfig{
+	Issuer:           testIssuer,
+	Audience:         []string{testAudience},
+	Algorithms:       []jose.SignatureAlgorithm{jose.ES256},
+	Leeway:           time.Minute,
+	PrincipalClaim:   "sub",
+	ProjectsClaim:    "auditum_projects",
+	PermissionsClaim: "auditum_permissions",
+}
+
+func newTestJWKS(t *testing.T, keys ...testKey) (*auth.JWKS, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys...)
+
+	jwks := auth.NewJWKS(path, zap.NewNop())
+	require.NoError(t, jwks.Refresh(context.Background()))
+
+	return jwks, path
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+
+	key := newTestKey(t, "key1")
+	otherKey := newTestKey(t, "key1")
+
+	jwks, _ := newTestJWKS(t, key)
+	authenticator := auth.NewJWTAuthenticator(jwks, testJWTConfig)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    auth.Principal
+		wantErr error
+	}{
+		{
+			name:  "valid token",
+			token: key.sign(t, validClaims()),
+			want: auth.Principal{
+				ID:          "jwt:alice",
+				ProjectIDs:  []aud.ID{project1},
+				Permissions: []aud.Permission{aud.PermissionRead, aud.PermissionWrite},
+			},
+		},
+		{
+			name: "all projects and space-separated permissions",
+			token: key.sign(t, withClaims(map[string]any{
+				"auditum_projects":    "*",
+				"auditum_permissions": "admin unknown",
+			})),
+			want: auth.Principal{
+				ID:          "jwt:alice",
+				Permissions: []aud.Permission{aud.PermissionAdmin},
+			},
+		},
+		{
+			name: "no projects claim",
+			token: key.sign(t, withClaims(map[string]any{
+				"auditum_projects": nil,
+			})),
+			want: auth.Principal{
+				ID: "jwt:alice",
+			},
+		},
+		{
+			name: "invalid project",
+			token: key.sign(t, withClaims(map[string]any{
+				"auditum_projects": []string{"invalid"},
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name: "no principal claim",
+			token: key.sign(t, withClaims(map[string]any{
+				"sub": nil,
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name: "wrong issuer",
+			token: key.sign(t, withClaims(map[string]any{
+				"iss": "https://other.example.com",
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name: "wrong audience",
+			token: key.sign(t, withClaims(map[string]any{
+				"aud": "other",
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name: "expired",
+			token: key.sign(t, withClaims(map[string]any{
+				"exp": time.Now().Add(-2 * time.Minute).Unix(),
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name: "expired within leeway",
+			token: key.sign(t, withClaims(map[string]any{
+				"exp":                 time.Now().Add(-30 * time.Second).Unix(),
+				"auditum_projects":    nil,
+				"auditum_permissions": nil,
+			})),
+			want: auth.Principal{
+				ID: "jwt:alice",
+			},
+		},
+		{
+			name: "no expiration",
+			token: key.sign(t, withClaims(map[string]any{
+				"exp": nil,
+			})),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name:    "signed with unknown key",
+			token:   otherKey.sign(t, validClaims()),
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name:    "not a token",
+			token:   "auditum_abcdef",
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name:    "malformed token",
+			token:   "a.b.c",
+			wantErr: auth.ErrInvalidCredentials,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := authenticator.Authenticate(context.Background(), test.token)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestJWTAuthenticator_NestedClaims(t *testing.T) {
+	key := newTestKey(t, "key1")
+	jwks, _ := newTestJWKS(t, key)
+
+	conf := testJWTConfig
+	conf.PrincipalClaim = "email"
+	conf.ProjectsClaim = ""
+	conf.PermissionsClaim = "realm_access.roles"
+
+	authenticator := auth.NewJWTAuthenticator(jwks, conf)
+
+	token := key.sign(t, withClaims(map[string]any{
+		"email": "alice@example.com",
+		"realm_access": map[string]any{
+			"roles": []string{"offline_access", "read"},
+		},
+	}))
+
+	got, err := authenticator.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, auth.Principal{
+		ID:          "jwt:alice@example.com",
+		Permissions: []aud.Permission{aud.PermissionRead},
+	}, got)
+}
+
+func TestJWKS_Refresh(t *testing.T) {
+	key1 := newTestKey(t, "key1")
+	key2 := newTestKey(t, "key2")
+
+	jwks, path := newTestJWKS(t, key1)
+	authenticator := auth.NewJWTAuthenticator(jwks, testJWTConfig)
+
+	token2 := key2.sign(t, validClaims())
+
+	_, err := authenticator.Authenticate(context.Background(), token2)
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	// Rotate keys.
+	writeJWKS(t, path, key2)
+	require.NoError(t, jwks.Refresh(context.Background()))
+
+	_, err = authenticator.Authenticate(context.Background(), token2)
+	assert.NoError(t, err)
+
+	_, err = authenticator.Authenticate(context.Background(), key1.sign(t, validClaims()))
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	// Failed refresh keeps the loaded keys.
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	assert.Error(t, jwks.Refresh(context.Background()))
+
+	_, err = authenticator.Authenticate(context.Background(), token2)
+	assert.NoError(t, err)
+}
+
+func TestJWKS_URL(t *testing.T) {
+	key := newTestKey(t, "key1")
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, key)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path)
+	}))
+	defer srv.Close()
+
+	jwks := auth.NewJWKS(srv.URL+"/.well-known/jwks.json", zap.NewNop())
+	require.NoError(t, jwks.Refresh(context.Background()))
+
+	authenticator := auth.NewJWTAuthenticator(jwks, testJWTConfig)
+
+	_, err := authenticator.Authenticate(context.Background(), key.sign(t, validClaims()))
+	assert.NoError(t, err)
+}
+
+func TestInterceptor_JWT(t *testing.T) {
+	project1 := aud.MustParseID("00000000-0000-0000-0000-000000000001")
+	project2 := aud.MustParseID("00000000-0000-0000-0000-000000000002")
+
+	key := newTestKey(t, "key1")
+	jwks, _ := newTestJWKS(t, key)
+
+	authenticator := auth.ChainAuthenticator{
+		fakeAuthenticator{},
+		auth.NewJWTAuthenticator(jwks, testJWTConfig),
+	}
+
+	rules := map[string]auth.Rule{
+		"/test.Service/Get": {
+			Permission: aud.PermissionRead,
+			ProjectID:  func(req any) string { return req.(projectRequest).GetProjectId() },
+		},
+	}
+
+	interceptor := auth.NewInterceptor(authenticator, rules, zap.NewNop()).Unary()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+
+	handler := func(ctx context.Context, _ any) (any, error) {
+		p, _ := auth.PrincipalFromContext(ctx)
+		return p.ID, nil
+	}
+
+	token := key.sign(t, validClaims())
+
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs("authorization", "Bearer "+token),
+	)
+
+	t.Run("allowed project", func(t *testing.T) {
+		res, err := interceptor(ctx, projectRequest{projectID: project1.String()}, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "jwt:alice", res)
+	})
+
+	t.Run("other project", func(t *testing.T) {
+		_, err := interceptor(ctx, projectRequest{projectID: project2.String()}, info, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("signed with unknown key", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs("authorization", "Bearer "+newTestKey(t, "key2").sign(t, validClaims())),
+		)
+		_, err := interceptor(ctx, projectRequest{projectID: project1.String()}, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
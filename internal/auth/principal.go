@@ -0,0 +1,67 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth implements authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// ID identifies the principal, e.g. "apikey:<id>".
+	ID string
+	// ProjectIDs limits access to the projects. If empty, the principal has
+	// access to all projects.
+	ProjectIDs  []aud.ID
+	Permissions []aud.Permission
+}
+
+// HasPermission reports whether the principal has the permission.
+// Admin permission includes all other permissions.
+func (p Principal) HasPermission(perm aud.Permission) bool {
+	for _, have := range p.Permissions {
+		if have == perm || have == aud.PermissionAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// AllProjects reports whether the principal has access to all projects.
+func (p Principal) AllProjects() bool {
+	return len(p.ProjectIDs) == 0
+}
+
+// HasProject reports whether the principal has access to the project.
+func (p Principal) HasProject(id aud.ID) bool {
+	return p.AllProjects() || slices.Contains(p.ProjectIDs, id)
+}
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of the request. It returns
+// false if authentication is disabled.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
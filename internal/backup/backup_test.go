@@ -0,0 +1,403 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup_test
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/aud/types"
+	"github.com/auditumio/auditum/internal/backup"
+)
+
+func TestWriterReader(t *testing.T) {
+	projects := []aud.Project{
+		{
+			ID:                  aud.MustNewID(),
+			CreateTime:          time.Date(2023, 1, 1, 2, 3, 4, 5, time.UTC),
+			DisplayName:         "Blog",
+			Description:         "Posts and comments.",
+			Labels:              map[string]string{"team": "blog"},
+			UpdateRecordEnabled: types.BoolValue{Bool: true, Valid: true},
+			ExternalID:          "blog",
+			Quota:               aud.ProjectQuota{RecordsPerMinute: 100, MaxBytes: 1 << 20},
+			RecordsRestrictions: aud.RecordsRestrictions{
+				Resource: aud.RecordsRestrictionsResource{
+					Changes: aud.RecordsRestrictionsResourceChanges{
+						NewValue: aud.RestrictionsBytes{MaxSizeBytes: 65536},
+					},
+				},
+			},
+		},
+		{
+			ID:          aud.MustNewID(),
+			CreateTime:  time.Date(2023, 1, 2, 2, 3, 4, 5, time.UTC),
+			DisplayName: "Shop",
+		},
+	}
+
+	record := aud.Record{
+		ID:         aud.MustNewID(),
+		ProjectID:  projects[0].ID,
+		CreateTime: time.Date(2023, 1, 1, 3, 0, 0, 0, time.UTC),
+		Labels:     map[string]string{"env": "prod"},
+		Resource: aud.Resource{
+			Type: "POST",
+			ID:   "post-1",
+			Changes: []aud.ResourceChange{
+				{
+					Name:     "status",
+					OldValue: json.RawMessage(`null`),
+					NewValue: json.RawMessage(`{"value":"published"}`),
+				},
+			},
+		},
+		Operation: aud.Operation{
+			Type: "CREATE",
+			ID:   "example.v1.PostService/CreatePost",
+			Time: time.Date(2023, 1, 1, 2, 59, 0, 0, time.UTC),
+			TraceContext: aud.TraceContext{
+				Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			Status: aud.OperationStatusFailed,
+		},
+		Actor: aud.Actor{
+			Type:     "USER",
+			ID:       "user-82",
+			Metadata: map[string]string{"email": "user@example.com"},
+		},
+	}
+
+	apiKeys := []aud.APIKey{
+		{
+			ID:          aud.MustNewID(),
+			CreateTime:  time.Date(2023, 1, 1, 4, 0, 0, 0, time.UTC),
+			DisplayName: "Blog writer",
+			KeyPrefix:   "adm_abcd",
+			KeyHash:     "5e884898da28047151d0e56f8dc62927",
+			ProjectIDs:  []aud.ID{projects[0].ID},
+			Permissions: []aud.Permission{aud.PermissionWrite},
+			RevokeTime:  time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	roleBindings := []aud.RoleBinding{
+		{
+			ProjectID:   projects[0].ID,
+			PrincipalID: "jwt:user-82",
+			Role:        aud.RoleEditor,
+			CreateTime:  time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC),
+			UpdateTime:  time.Date(2023, 1, 2, 5, 0, 0, 0, time.UTC),
+		},
+	}
+
+	webhooks := []aud.Webhook{
+		{
+			ID:          aud.MustNewID(),
+			ProjectID:   projects[1].ID,
+			CreateTime:  time.Date(2023, 1, 2, 6, 0, 0, 0, time.UTC),
+			DisplayName: "Orders",
+			URL:         "https://example.com/hook",
+			Secret:      "whsec_secret",
+			Filter:      `resource.type = "ORDER"`,
+		},
+	}
+
+	alertRules := []aud.AlertRule{
+		{
+			ID:          aud.MustNewID(),
+			ProjectID:   projects[0].ID,
+			CreateTime:  time.Date(2023, 1, 1, 7, 0, 0, 0, time.UTC),
+			DisplayName: "Mass deletion",
+			Threshold:   &aud.AlertRuleThreshold{Filter: `operation.type = "DELETE"`, Count: 10},
+			GroupBy:     []string{"actor.id"},
+			Window:      5 * time.Minute,
+		},
+		{
+			ID:          aud.MustNewID(),
+			ProjectID:   projects[1].ID,
+			CreateTime:  time.Date(2023, 1, 2, 7, 0, 0, 0, time.UTC),
+			DisplayName: "Escalation",
+			Sequence:    &aud.AlertRuleSequence{Steps: []string{`operation.type = "GRANT"`, `operation.type = "EXPORT"`}},
+			Window:      time.Hour,
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.zip")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	w := backup.NewWriter(f, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, w.WriteProjects(projects))
+	require.NoError(t, w.WriteAPIKeys(apiKeys))
+	require.NoError(t, w.WriteRoleBindings(roleBindings))
+	require.NoError(t, w.WriteWebhooks(webhooks))
+	require.NoError(t, w.WriteAlertRules(alertRules))
+
+	rw, err := w.CreateRecords(projects[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, rw.Write(record))
+
+	_, err = w.CreateRecords(projects[1].ID)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	r, err := backup.OpenReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	manifest := r.Manifest()
+	assert.Equal(t, backup.FormatVersion, manifest.Version)
+	assert.Equal(t, []backup.ManifestProject{
+		{ID: projects[0].ID.String(), Records: 1},
+		{ID: projects[1].ID.String(), Records: 0},
+	}, manifest.Projects)
+	assert.Equal(t, 1, manifest.APIKeys)
+	assert.Equal(t, 1, manifest.RoleBindings)
+	assert.Equal(t, 1, manifest.Webhooks)
+	assert.Equal(t, 2, manifest.AlertRules)
+	assert.Len(t, manifest.Files, 7)
+
+	require.NoError(t, r.Verify())
+
+	gotProjects, err := r.Projects()
+	require.NoError(t, err)
+	assert.Equal(t, projects, gotProjects)
+
+	var gotRecords []aud.Record
+	err = r.ReadRecords(projects[0].ID, func(record aud.Record) error {
+		gotRecords = append(gotRecords, record)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []aud.Record{record}, gotRecords)
+
+	gotAPIKeys, err := r.APIKeys()
+	require.NoError(t, err)
+	assert.Equal(t, apiKeys, gotAPIKeys)
+
+	gotRoleBindings, err := r.RoleBindings()
+	require.NoError(t, err)
+	assert.Equal(t, roleBindings, gotRoleBindings)
+
+	gotWebhooks, err := r.Webhooks()
+	require.NoError(t, err)
+	assert.Equal(t, webhooks, gotWebhooks)
+
+	gotAlertRules, err := r.AlertRules()
+	require.NoError(t, err)
+	assert.Equal(t, alertRules, gotAlertRules)
+}
+
+func TestWriter_WriteSettings_UnknownProject(t *testing.T) {
+	w := backup.NewWriter(io.Discard, time.Now())
+	require.NoError(t, w.WriteProjects(nil))
+
+	err := w.WriteWebhooks([]aud.Webhook{{ID: aud.MustNewID(), ProjectID: aud.MustNewID()}})
+	assert.Error(t, err)
+}
+
+func TestReader_Version1(t *testing.T) {
+	path := writeZip(t, map[string]string{
+		"manifest.json": `{
+			"version": 1,
+			"files": [{
+				"name": "projects.ndjson",
+				"size": 0,
+				"sha256": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+			}]
+		}`,
+		"projects.ndjson": "",
+	})
+
+	r, err := backup.OpenReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.NoError(t, r.Verify())
+
+	apiKeys, err := r.APIKeys()
+	require.NoError(t, err)
+	assert.Empty(t, apiKeys)
+
+	alertRules, err := r.AlertRules()
+	require.NoError(t, err)
+	assert.Empty(t, alertRules)
+}
+
+func TestWriter_CreateRecords_UnknownProject(t *testing.T) {
+	w := backup.NewWriter(io.Discard, time.Now())
+	require.NoError(t, w.WriteProjects(nil))
+
+	_, err := w.CreateRecords(aud.MustNewID())
+	assert.Error(t, err)
+}
+
+func TestOpenReader_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "Missing manifest",
+			files:   map[string]string{"projects.ndjson": ""},
+			wantErr: "invalid archive: manifest.json is missing",
+		},
+		{
+			name: "Unsupported version",
+			files: map[string]string{
+				"manifest.json": `{"version": 3}`,
+			},
+			wantErr: "unsupported archive version 3, at most 2 is supported",
+		},
+		{
+			name: "Missing projects file",
+			files: map[string]string{
+				"manifest.json": `{"version": 1, "files": [{"name": "projects.ndjson"}]}`,
+			},
+			wantErr: "invalid archive: projects.ndjson is missing",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path := writeZip(t, test.files)
+
+			_, err := backup.OpenReader(path)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestReader_Verify_ChecksumMismatch(t *testing.T) {
+	path := writeZip(t, map[string]string{
+		"manifest.json": `{
+			"version": 1,
+			"files": [{"name": "projects.ndjson", "size": 3, "sha256": "0000"}]
+		}`,
+		"projects.ndjson": "{}\n",
+	})
+
+	r, err := backup.OpenReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	err = r.Verify()
+	assert.EqualError(t, err, "invalid archive: projects.ndjson checksum mismatch")
+}
+
+func writeZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "backup.zip")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return path
+}
+
+func TestChecksum(t *testing.T) {
+	newRecord := func() aud.Record {
+		return aud.Record{
+			ID:         aud.MustParseID("01886e86-1963-7f3c-b672-b5d93cec6c6e"),
+			ProjectID:  aud.MustParseID("01886e86-1963-7f3c-b672-b5d93cec6c6f"),
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 123456789, time.UTC),
+			Resource: aud.Resource{
+				Type: "POST",
+				ID:   "post-1",
+				Changes: []aud.ResourceChange{
+					{Name: "status", NewValue: json.RawMessage(`{"a": 1, "b": [true, null]}`)},
+					{Name: "author", OldValue: json.RawMessage(`null`), NewValue: json.RawMessage(`"user-1"`)},
+				},
+			},
+			Operation: aud.Operation{
+				Type: "CREATE",
+				ID:   "example.v1.PostService/CreatePost",
+				Time: time.Date(2023, 1, 1, 2, 3, 0, 0, time.UTC),
+			},
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   "user-1",
+			},
+		}
+	}
+
+	other := newRecord()
+	other.ID = aud.MustParseID("01886e86-1963-7f3c-b672-b5d93cec6c70")
+
+	var a backup.Checksum
+	a.Add(newRecord())
+	a.Add(other)
+
+	t.Run("Should not depend on record order and storage differences", func(t *testing.T) {
+		stored := newRecord()
+		stored.CreateTime = time.Date(2023, 1, 1, 2, 3, 4, 123456000, time.FixedZone("UTC+1", 3600)).Add(time.Hour)
+		stored.Resource.Changes = []aud.ResourceChange{
+			{Name: "author", NewValue: json.RawMessage(`"user-1"`)},
+			{Name: "status", NewValue: json.RawMessage(`{"b":[true,null],"a":1}`)},
+		}
+
+		var b backup.Checksum
+		b.Add(other)
+		b.Add(stored)
+
+		assert.True(t, a.Equal(&b))
+		assert.Equal(t, a.String(), b.String())
+		assert.Equal(t, 2, b.Count())
+	})
+
+	t.Run("Should detect changed record", func(t *testing.T) {
+		changed := newRecord()
+		changed.Actor.ID = "user-2"
+
+		var b backup.Checksum
+		b.Add(changed)
+		b.Add(other)
+
+		assert.False(t, a.Equal(&b))
+	})
+
+	t.Run("Should detect missing record", func(t *testing.T) {
+		var b backup.Checksum
+		b.Add(other)
+
+		assert.False(t, a.Equal(&b))
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// Checksum is a checksum of a set of records that does not depend on the
+// order of records and on the database engine they were read from.
+//
+// Databases store records differently: PostgreSQL truncates time to
+// microseconds, normalizes JSON values and does not keep the order of
+// resource changes. So records are canonicalized before hashing.
+type Checksum struct {
+	count int
+	sum   [sha256.Size]byte
+}
+
+// Add adds the record to the checksum.
+func (c *Checksum) Add(record aud.Record) {
+	entry := canonicalRecordEntry(record)
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		// This is exceptional.
+		panic("marshal record entry: " + err.Error())
+	}
+
+	// Sum up hashes of records, so that the order does not matter.
+	h := sha256.Sum256(b)
+	var carry uint16
+	for i := len(c.sum) - 1; i >= 0; i-- {
+		v := uint16(c.sum[i]) + uint16(h[i]) + carry
+		c.sum[i] = byte(v)
+		carry = v >> 8
+	}
+
+	c.count++
+}
+
+// Count returns the number of added records.
+func (c *Checksum) Count() int {
+	return c.count
+}
+
+// String returns the hex encoded checksum.
+func (c *Checksum) String() string {
+	return hex.EncodeToString(c.sum[:])
+}
+
+// Equal reports whether checksums are computed from the same records.
+func (c *Checksum) Equal(other *Checksum) bool {
+	return c.count == other.count && c.sum == other.sum
+}
+
+func canonicalRecordEntry(record aud.Record) recordEntry {
+	entry := toRecordEntry(record)
+
+	entry.CreateTime = canonicalTime(entry.CreateTime)
+	entry.Operation.Time = canonicalTime(entry.Operation.Time)
+
+	for i := range entry.Resource.Changes {
+		change := &entry.Resource.Changes[i]
+		change.OldValue = canonicalJSON(change.OldValue)
+		change.NewValue = canonicalJSON(change.NewValue)
+	}
+	sort.Slice(entry.Resource.Changes, func(i, j int) bool {
+		a, b := entry.Resource.Changes[i], entry.Resource.Changes[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Description != b.Description {
+			return a.Description < b.Description
+		}
+		if c := bytes.Compare(a.OldValue, b.OldValue); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.NewValue, b.NewValue) < 0
+	})
+
+	return entry
+}
+
+func canonicalTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// canonicalJSON re-encodes the value with sorted object keys and without
+// insignificant whitespace. Null is treated as no value.
+func canonicalJSON(src json.RawMessage) json.RawMessage {
+	if len(src) == 0 {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(src, &v); err != nil {
+		// Keep invalid values as is, they are compared byte by byte.
+		return src
+	}
+	if v == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return src
+	}
+
+	return b
+}
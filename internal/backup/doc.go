@@ -0,0 +1,37 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup implements the logical backup archive of projects, their
+// records and settings.
+//
+// The archive is a ZIP file that does not depend on the database engine:
+//
+//	manifest.json            format version, contents and checksums
+//	projects.ndjson          one project per line
+//	records/<project>.ndjson one record per line
+//	api_keys.ndjson          one API key per line
+//	role_bindings.ndjson     one role binding per line
+//	webhooks.ndjson          one webhook per line
+//	alert_rules.ndjson       one alert rule per line
+//
+// API keys are stored as hashes, but webhook secrets are stored as is, so
+// the archive must be kept as safe as the database. Alert states, fired
+// alerts and webhook deliveries are not backed up.
+//
+// Entries are JSON representations of domain types, so the archive is
+// restored as is, regardless of the API validation settings.
+//
+// The package also provides Checksum to verify that records are copied
+// between databases intact.
+package backup
@@ -0,0 +1,510 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/aud/types"
+)
+
+type projectEntry struct {
+	ID                  string            `json:"id"`
+	CreateTime          time.Time         `json:"create_time"`
+	DisplayName         string            `json:"display_name"`
+	Description         string            `json:"description,omitempty"`
+	Labels              map[string]string `json:"labels,omitempty"`
+	UpdateRecordEnabled *bool             `json:"update_record_enabled,omitempty"`
+	DeleteRecordEnabled *bool             `json:"delete_record_enabled,omitempty"`
+	ExternalID          string            `json:"external_id,omitempty"`
+	Quota               *quotaEntry       `json:"quota,omitempty"`
+	// Restrictions are stored in the same format as in configuration.
+	RecordsRestrictions *aud.RecordsRestrictions `json:"records_restrictions,omitempty"`
+}
+
+type quotaEntry struct {
+	RecordsPerMinute int64 `json:"records_per_minute,omitempty"`
+	RecordsPerDay    int64 `json:"records_per_day,omitempty"`
+	MaxRecords       int64 `json:"max_records,omitempty"`
+	MaxBytes         int64 `json:"max_bytes,omitempty"`
+}
+
+type recordEntry struct {
+	ID         string            `json:"id"`
+	ProjectID  string            `json:"project_id"`
+	CreateTime time.Time         `json:"create_time"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Resource   resourceEntry     `json:"resource"`
+	Operation  operationEntry    `json:"operation"`
+	Actor      actorEntry        `json:"actor"`
+}
+
+type resourceEntry struct {
+	Type     string                `json:"type"`
+	ID       string                `json:"id"`
+	Metadata map[string]string     `json:"metadata,omitempty"`
+	Changes  []resourceChangeEntry `json:"changes,omitempty"`
+}
+
+type resourceChangeEntry struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	OldValue    json.RawMessage `json:"old_value,omitempty"`
+	NewValue    json.RawMessage `json:"new_value,omitempty"`
+}
+
+type operationEntry struct {
+	Type        string            `json:"type"`
+	ID          string            `json:"id"`
+	Time        time.Time         `json:"time"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Traceparent string            `json:"traceparent,omitempty"`
+	Tracestate  string            `json:"tracestate,omitempty"`
+	Status      int               `json:"status,omitempty"`
+}
+
+type actorEntry struct {
+	Type     string            `json:"type"`
+	ID       string            `json:"id"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func toProjectEntry(src aud.Project) projectEntry {
+	return projectEntry{
+		ID:                  src.ID.String(),
+		CreateTime:          src.CreateTime,
+		DisplayName:         src.DisplayName,
+		Description:         src.Description,
+		Labels:              src.Labels,
+		UpdateRecordEnabled: toBoolEntry(src.UpdateRecordEnabled),
+		DeleteRecordEnabled: toBoolEntry(src.DeleteRecordEnabled),
+		ExternalID:          src.ExternalID,
+		Quota:               toQuotaEntry(src.Quota),
+		RecordsRestrictions: toRecordsRestrictionsEntry(src.RecordsRestrictions),
+	}
+}
+
+func fromProjectEntry(src projectEntry) (aud.Project, error) {
+	id, err := aud.ParseID(src.ID)
+	if err != nil {
+		return aud.Project{}, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	return aud.Project{
+		ID:                  id,
+		CreateTime:          src.CreateTime,
+		DisplayName:         src.DisplayName,
+		Description:         src.Description,
+		Labels:              src.Labels,
+		UpdateRecordEnabled: fromBoolEntry(src.UpdateRecordEnabled),
+		DeleteRecordEnabled: fromBoolEntry(src.DeleteRecordEnabled),
+		ExternalID:          src.ExternalID,
+		Quota:               fromQuotaEntry(src.Quota),
+		RecordsRestrictions: fromRecordsRestrictionsEntry(src.RecordsRestrictions),
+	}, nil
+}
+
+func toQuotaEntry(src aud.ProjectQuota) *quotaEntry {
+	if src == (aud.ProjectQuota{}) {
+		return nil
+	}
+	return &quotaEntry{
+		RecordsPerMinute: src.RecordsPerMinute,
+		RecordsPerDay:    src.RecordsPerDay,
+		MaxRecords:       src.MaxRecords,
+		MaxBytes:         src.MaxBytes,
+	}
+}
+
+func fromQuotaEntry(src *quotaEntry) aud.ProjectQuota {
+	if src == nil {
+		return aud.ProjectQuota{}
+	}
+	return aud.ProjectQuota{
+		RecordsPerMinute: src.RecordsPerMinute,
+		RecordsPerDay:    src.RecordsPerDay,
+		MaxRecords:       src.MaxRecords,
+		MaxBytes:         src.MaxBytes,
+	}
+}
+
+func toRecordsRestrictionsEntry(src aud.RecordsRestrictions) *aud.RecordsRestrictions {
+	if src == (aud.RecordsRestrictions{}) {
+		return nil
+	}
+	return &src
+}
+
+func fromRecordsRestrictionsEntry(src *aud.RecordsRestrictions) aud.RecordsRestrictions {
+	if src == nil {
+		return aud.RecordsRestrictions{}
+	}
+	return *src
+}
+
+func toBoolEntry(src types.BoolValue) *bool {
+	if !src.Valid {
+		return nil
+	}
+	return &src.Bool
+}
+
+func fromBoolEntry(src *bool) types.BoolValue {
+	if src == nil {
+		return types.BoolValue{}
+	}
+	return types.BoolValue{Bool: *src, Valid: true}
+}
+
+func toRecordEntry(src aud.Record) recordEntry {
+	var changes []resourceChangeEntry
+	for _, change := range src.Resource.Changes {
+		changes = append(changes, resourceChangeEntry{
+			Name:        change.Name,
+			Description: change.Description,
+			OldValue:    change.OldValue,
+			NewValue:    change.NewValue,
+		})
+	}
+
+	return recordEntry{
+		ID:         src.ID.String(),
+		ProjectID:  src.ProjectID.String(),
+		CreateTime: src.CreateTime,
+		Labels:     src.Labels,
+		Resource: resourceEntry{
+			Type:     src.Resource.Type,
+			ID:       src.Resource.ID,
+			Metadata: src.Resource.Metadata,
+			Changes:  changes,
+		},
+		Operation: operationEntry{
+			Type:        src.Operation.Type,
+			ID:          src.Operation.ID,
+			Time:        src.Operation.Time,
+			Metadata:    src.Operation.Metadata,
+			Traceparent: src.Operation.TraceContext.Traceparent,
+			Tracestate:  src.Operation.TraceContext.Tracestate,
+			Status:      src.Operation.Status.Int(),
+		},
+		Actor: actorEntry{
+			Type:     src.Actor.Type,
+			ID:       src.Actor.ID,
+			Metadata: src.Actor.Metadata,
+		},
+	}
+}
+
+func fromRecordEntry(src recordEntry) (aud.Record, error) {
+	id, err := aud.ParseID(src.ID)
+	if err != nil {
+		return aud.Record{}, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	projectID, err := aud.ParseID(src.ProjectID)
+	if err != nil {
+		return aud.Record{}, fmt.Errorf(`invalid "project_id": %v`, err)
+	}
+
+	var changes []aud.ResourceChange
+	for _, change := range src.Resource.Changes {
+		changes = append(changes, aud.ResourceChange{
+			Name:        change.Name,
+			Description: change.Description,
+			OldValue:    change.OldValue,
+			NewValue:    change.NewValue,
+		})
+	}
+
+	return aud.Record{
+		ID:         id,
+		ProjectID:  projectID,
+		CreateTime: src.CreateTime,
+		Labels:     src.Labels,
+		Resource: aud.Resource{
+			Type:     src.Resource.Type,
+			ID:       src.Resource.ID,
+			Metadata: src.Resource.Metadata,
+			Changes:  changes,
+		},
+		Operation: aud.Operation{
+			Type:     src.Operation.Type,
+			ID:       src.Operation.ID,
+			Time:     src.Operation.Time,
+			Metadata: src.Operation.Metadata,
+			TraceContext: aud.TraceContext{
+				Traceparent: src.Operation.Traceparent,
+				Tracestate:  src.Operation.Tracestate,
+			},
+			Status: aud.OperationStatus(src.Operation.Status),
+		},
+		Actor: aud.Actor{
+			Type:     src.Actor.Type,
+			ID:       src.Actor.ID,
+			Metadata: src.Actor.Metadata,
+		},
+	}, nil
+}
+
+type apiKeyEntry struct {
+	ID          string     `json:"id"`
+	CreateTime  time.Time  `json:"create_time"`
+	DisplayName string     `json:"display_name"`
+	KeyPrefix   string     `json:"key_prefix"`
+	KeyHash     string     `json:"key_hash"`
+	ProjectIDs  []string   `json:"project_ids,omitempty"`
+	Permissions []string   `json:"permissions"`
+	RevokeTime  *time.Time `json:"revoke_time,omitempty"`
+}
+
+type roleBindingEntry struct {
+	ProjectID   string    `json:"project_id"`
+	PrincipalID string    `json:"principal_id"`
+	Role        string    `json:"role"`
+	CreateTime  time.Time `json:"create_time"`
+	UpdateTime  time.Time `json:"update_time"`
+}
+
+type webhookEntry struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	CreateTime  time.Time `json:"create_time"`
+	DisplayName string    `json:"display_name"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret"`
+	Filter      string    `json:"filter,omitempty"`
+}
+
+type alertRuleEntry struct {
+	ID          string                   `json:"id"`
+	ProjectID   string                   `json:"project_id"`
+	CreateTime  time.Time                `json:"create_time"`
+	DisplayName string                   `json:"display_name"`
+	Threshold   *alertRuleThresholdEntry `json:"threshold,omitempty"`
+	Sequence    *alertRuleSequenceEntry  `json:"sequence,omitempty"`
+	GroupBy     []string                 `json:"group_by,omitempty"`
+	// Window is a Go duration string, e.g. "1h0m0s".
+	Window string `json:"window"`
+}
+
+type alertRuleThresholdEntry struct {
+	Filter string `json:"filter,omitempty"`
+	Count  int    `json:"count"`
+}
+
+type alertRuleSequenceEntry struct {
+	Steps []string `json:"steps"`
+}
+
+func toAPIKeyEntry(src aud.APIKey) apiKeyEntry {
+	var projectIDs []string
+	for _, id := range src.ProjectIDs {
+		projectIDs = append(projectIDs, id.String())
+	}
+
+	permissions := make([]string, 0, len(src.Permissions))
+	for _, p := range src.Permissions {
+		permissions = append(permissions, p.String())
+	}
+
+	var revokeTime *time.Time
+	if src.Revoked() {
+		revokeTime = &src.RevokeTime
+	}
+
+	return apiKeyEntry{
+		ID:          src.ID.String(),
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		KeyPrefix:   src.KeyPrefix,
+		KeyHash:     src.KeyHash,
+		ProjectIDs:  projectIDs,
+		Permissions: permissions,
+		RevokeTime:  revokeTime,
+	}
+}
+
+func fromAPIKeyEntry(src apiKeyEntry) (aud.APIKey, error) {
+	id, err := aud.ParseID(src.ID)
+	if err != nil {
+		return aud.APIKey{}, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	var projectIDs []aud.ID
+	for _, s := range src.ProjectIDs {
+		projectID, err := aud.ParseID(s)
+		if err != nil {
+			return aud.APIKey{}, fmt.Errorf(`invalid "project_ids": %v`, err)
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	permissions := make([]aud.Permission, 0, len(src.Permissions))
+	for _, s := range src.Permissions {
+		p, err := aud.ParsePermission(s)
+		if err != nil {
+			return aud.APIKey{}, fmt.Errorf(`invalid "permissions": %v`, err)
+		}
+		permissions = append(permissions, p)
+	}
+
+	var revokeTime time.Time
+	if src.RevokeTime != nil {
+		revokeTime = *src.RevokeTime
+	}
+
+	return aud.APIKey{
+		ID:          id,
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		KeyPrefix:   src.KeyPrefix,
+		KeyHash:     src.KeyHash,
+		ProjectIDs:  projectIDs,
+		Permissions: permissions,
+		RevokeTime:  revokeTime,
+	}, nil
+}
+
+func toRoleBindingEntry(src aud.RoleBinding) roleBindingEntry {
+	return roleBindingEntry{
+		ProjectID:   src.ProjectID.String(),
+		PrincipalID: src.PrincipalID,
+		Role:        src.Role.String(),
+		CreateTime:  src.CreateTime,
+		UpdateTime:  src.UpdateTime,
+	}
+}
+
+func fromRoleBindingEntry(src roleBindingEntry) (aud.RoleBinding, error) {
+	projectID, err := aud.ParseID(src.ProjectID)
+	if err != nil {
+		return aud.RoleBinding{}, fmt.Errorf(`invalid "project_id": %v`, err)
+	}
+
+	role, err := aud.ParseRole(src.Role)
+	if err != nil {
+		return aud.RoleBinding{}, fmt.Errorf(`invalid "role": %v`, err)
+	}
+
+	return aud.RoleBinding{
+		ProjectID:   projectID,
+		PrincipalID: src.PrincipalID,
+		Role:        role,
+		CreateTime:  src.CreateTime,
+		UpdateTime:  src.UpdateTime,
+	}, nil
+}
+
+func toWebhookEntry(src aud.Webhook) webhookEntry {
+	return webhookEntry{
+		ID:          src.ID.String(),
+		ProjectID:   src.ProjectID.String(),
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		URL:         src.URL,
+		Secret:      src.Secret,
+		Filter:      src.Filter,
+	}
+}
+
+func fromWebhookEntry(src webhookEntry) (aud.Webhook, error) {
+	id, err := aud.ParseID(src.ID)
+	if err != nil {
+		return aud.Webhook{}, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	projectID, err := aud.ParseID(src.ProjectID)
+	if err != nil {
+		return aud.Webhook{}, fmt.Errorf(`invalid "project_id": %v`, err)
+	}
+
+	return aud.Webhook{
+		ID:          id,
+		ProjectID:   projectID,
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		URL:         src.URL,
+		Secret:      src.Secret,
+		Filter:      src.Filter,
+	}, nil
+}
+
+func toAlertRuleEntry(src aud.AlertRule) alertRuleEntry {
+	dst := alertRuleEntry{
+		ID:          src.ID.String(),
+		ProjectID:   src.ProjectID.String(),
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		GroupBy:     src.GroupBy,
+		Window:      src.Window.String(),
+	}
+
+	if src.Threshold != nil {
+		dst.Threshold = &alertRuleThresholdEntry{
+			Filter: src.Threshold.Filter,
+			Count:  src.Threshold.Count,
+		}
+	}
+	if src.Sequence != nil {
+		dst.Sequence = &alertRuleSequenceEntry{
+			Steps: src.Sequence.Steps,
+		}
+	}
+
+	return dst
+}
+
+func fromAlertRuleEntry(src alertRuleEntry) (aud.AlertRule, error) {
+	id, err := aud.ParseID(src.ID)
+	if err != nil {
+		return aud.AlertRule{}, fmt.Errorf(`invalid "id": %v`, err)
+	}
+
+	projectID, err := aud.ParseID(src.ProjectID)
+	if err != nil {
+		return aud.AlertRule{}, fmt.Errorf(`invalid "project_id": %v`, err)
+	}
+
+	window, err := time.ParseDuration(src.Window)
+	if err != nil {
+		return aud.AlertRule{}, fmt.Errorf(`invalid "window": %v`, err)
+	}
+
+	dst := aud.AlertRule{
+		ID:          id,
+		ProjectID:   projectID,
+		CreateTime:  src.CreateTime,
+		DisplayName: src.DisplayName,
+		GroupBy:     src.GroupBy,
+		Window:      window,
+	}
+
+	if src.Threshold != nil {
+		dst.Threshold = &aud.AlertRuleThreshold{
+			Filter: src.Threshold.Filter,
+			Count:  src.Threshold.Count,
+		}
+	}
+	if src.Sequence != nil {
+		dst.Sequence = &aud.AlertRuleSequence{
+			Steps: src.Sequence.Steps,
+		}
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatVersion is the version of the archive format written by Writer.
+//
+// Version 2 adds API keys, role bindings, webhooks and alert rules.
+const FormatVersion = 2
+
+const (
+	manifestFileName     = "manifest.json"
+	projectsFileName     = "projects.ndjson"
+	recordsDirName       = "records"
+	apiKeysFileName      = "api_keys.ndjson"
+	roleBindingsFileName = "role_bindings.ndjson"
+	webhooksFileName     = "webhooks.ndjson"
+	alertRulesFileName   = "alert_rules.ndjson"
+)
+
+// settingsFileNames are files written since version 2.
+var settingsFileNames = []string{
+	apiKeysFileName,
+	roleBindingsFileName,
+	webhooksFileName,
+	alertRulesFileName,
+}
+
+// Manifest describes contents of the archive.
+type Manifest struct {
+	Version      int               `json:"version"`
+	CreateTime   time.Time         `json:"create_time"`
+	Projects     []ManifestProject `json:"projects"`
+	APIKeys      int               `json:"api_keys"`
+	RoleBindings int               `json:"role_bindings"`
+	Webhooks     int               `json:"webhooks"`
+	AlertRules   int               `json:"alert_rules"`
+	Files        []ManifestFile    `json:"files"`
+}
+
+// ManifestProject describes a project in the archive.
+type ManifestProject struct {
+	ID      string `json:"id"`
+	Records int    `json:"records"`
+}
+
+// ManifestFile describes a file in the archive.
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+func (m Manifest) file(name string) (ManifestFile, bool) {
+	for _, f := range m.Files {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+func recordsFileName(projectID string) string {
+	return fmt.Sprintf("%s/%s.ndjson", recordsDirName, projectID)
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// Reader reads the backup archive.
+type Reader struct {
+	zr       *zip.ReadCloser
+	files    map[string]*zip.File
+	manifest Manifest
+}
+
+// OpenReader opens the archive and reads its manifest.
+func OpenReader(path string) (*Reader, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %v", err)
+	}
+
+	r := &Reader{
+		zr:    zr,
+		files: make(map[string]*zip.File, len(zr.File)),
+	}
+	for _, f := range zr.File {
+		r.files[f.Name] = f
+	}
+
+	if err := r.readManifest(); err != nil {
+		_ = zr.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Manifest returns the manifest of the archive.
+func (r *Reader) Manifest() Manifest {
+	return r.manifest
+}
+
+// Close closes the archive.
+func (r *Reader) Close() error {
+	return r.zr.Close()
+}
+
+func (r *Reader) readManifest() error {
+	f, ok := r.files[manifestFileName]
+	if !ok {
+		return fmt.Errorf("invalid archive: %s is missing", manifestFileName)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %v", manifestFileName, err)
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(&r.manifest); err != nil {
+		return fmt.Errorf("invalid archive: read %s: %v", manifestFileName, err)
+	}
+
+	if r.manifest.Version < 1 || r.manifest.Version > FormatVersion {
+		return fmt.Errorf(
+			"unsupported archive version %d, at most %d is supported",
+			r.manifest.Version,
+			FormatVersion,
+		)
+	}
+
+	names := []string{projectsFileName}
+	for _, p := range r.manifest.Projects {
+		names = append(names, recordsFileName(p.ID))
+	}
+	if r.manifest.Version >= 2 {
+		names = append(names, settingsFileNames...)
+	}
+	for _, name := range names {
+		if _, ok := r.manifest.file(name); !ok {
+			return fmt.Errorf("invalid archive: %s is missing in manifest", name)
+		}
+		if _, ok := r.files[name]; !ok {
+			return fmt.Errorf("invalid archive: %s is missing", name)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks sizes and checksums of all files listed in the manifest.
+func (r *Reader) Verify() error {
+	for _, mf := range r.manifest.Files {
+		err := r.readFile(mf.Name, func(rd io.Reader) error {
+			_, err := io.Copy(io.Discard, rd)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Projects returns all projects in the archive, in the order of the
+// manifest.
+func (r *Reader) Projects() ([]aud.Project, error) {
+	var projects []aud.Project
+
+	err := r.readFile(projectsFileName, func(rd io.Reader) error {
+		dec := json.NewDecoder(rd)
+		for {
+			var entry projectEntry
+			err := dec.Decode(&entry)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			project, err := fromProjectEntry(entry)
+			if err != nil {
+				return fmt.Errorf("invalid project: %v", err)
+			}
+
+			projects = append(projects, project)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) != len(r.manifest.Projects) {
+		return nil, fmt.Errorf("invalid archive: projects do not match manifest")
+	}
+	for i, project := range projects {
+		if project.ID.String() != r.manifest.Projects[i].ID {
+			return nil, fmt.Errorf("invalid archive: projects do not match manifest")
+		}
+	}
+
+	return projects, nil
+}
+
+// ReadRecords reads records of the project, calling fn for each record.
+// Reading stops at the first error returned by fn.
+func (r *Reader) ReadRecords(projectID aud.ID, fn func(record aud.Record) error) error {
+	return r.readFile(recordsFileName(projectID.String()), func(rd io.Reader) error {
+		dec := json.NewDecoder(rd)
+		for {
+			var entry recordEntry
+			err := dec.Decode(&entry)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			record, err := fromRecordEntry(entry)
+			if err != nil {
+				return fmt.Errorf("invalid record: %v", err)
+			}
+			if record.ProjectID != projectID {
+				return fmt.Errorf("record %s belongs to another project", record.ID.String())
+			}
+
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// APIKeys returns all API keys in the archive. Archives of version 1 have
+// no API keys.
+func (r *Reader) APIKeys() ([]aud.APIKey, error) {
+	return readSettings(r, apiKeysFileName, r.manifest.APIKeys, fromAPIKeyEntry)
+}
+
+// RoleBindings returns role bindings of all projects in the archive.
+// Archives of version 1 have no role bindings.
+func (r *Reader) RoleBindings() ([]aud.RoleBinding, error) {
+	return readSettings(r, roleBindingsFileName, r.manifest.RoleBindings, fromRoleBindingEntry)
+}
+
+// Webhooks returns webhooks of all projects in the archive. Archives of
+// version 1 have no webhooks.
+func (r *Reader) Webhooks() ([]aud.Webhook, error) {
+	return readSettings(r, webhooksFileName, r.manifest.Webhooks, fromWebhookEntry)
+}
+
+// AlertRules returns alert rules of all projects in the archive. Archives
+// of version 1 have no alert rules.
+func (r *Reader) AlertRules() ([]aud.AlertRule, error) {
+	return readSettings(r, alertRulesFileName, r.manifest.AlertRules, fromAlertRuleEntry)
+}
+
+// readSettings reads all entries of the file and converts them with from.
+func readSettings[E, T any](r *Reader, name string, want int, from func(E) (T, error)) ([]T, error) {
+	if r.manifest.Version < 2 {
+		return nil, nil
+	}
+
+	var items []T
+
+	err := r.readFile(name, func(rd io.Reader) error {
+		dec := json.NewDecoder(rd)
+		for {
+			var entry E
+			err := dec.Decode(&entry)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			item, err := from(entry)
+			if err != nil {
+				return fmt.Errorf("invalid entry: %v", err)
+			}
+
+			items = append(items, item)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) != want {
+		return nil, fmt.Errorf("invalid archive: %s does not match manifest", name)
+	}
+
+	return items, nil
+}
+
+// readFile reads the file with fn and then checks its size and checksum
+// against the manifest.
+func (r *Reader) readFile(name string, fn func(rd io.Reader) error) error {
+	mf, ok := r.manifest.file(name)
+	if !ok {
+		return fmt.Errorf("invalid archive: %s is missing in manifest", name)
+	}
+
+	f, ok := r.files[name]
+	if !ok {
+		return fmt.Errorf("invalid archive: %s is missing", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open %s: %v", name, err)
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	cr := &countingReader{r: io.TeeReader(rc, h)}
+
+	if err := fn(cr); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+
+	// Consume the rest, e.g. trailing whitespace, to compute the checksum.
+	if _, err := io.Copy(io.Discard, cr); err != nil {
+		return fmt.Errorf("read %s: %v", name, err)
+	}
+
+	if cr.n != mf.Size || hex.EncodeToString(h.Sum(nil)) != mf.SHA256 {
+		return fmt.Errorf("invalid archive: %s checksum mismatch", name)
+	}
+
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
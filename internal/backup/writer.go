@@ -0,0 +1,305 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// Writer writes the backup archive. Projects must be written before their
+// records and settings. Close must be called to write the manifest.
+type Writer struct {
+	zw       *zip.Writer
+	manifest Manifest
+	file     *fileWriter
+}
+
+// NewWriter returns a new Writer writing the archive to w.
+func NewWriter(w io.Writer, createTime time.Time) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: Manifest{
+			Version:    FormatVersion,
+			CreateTime: createTime.UTC(),
+		},
+	}
+}
+
+// WriteProjects writes all projects to the archive.
+func (w *Writer) WriteProjects(projects []aud.Project) error {
+	if w.manifest.Projects != nil {
+		return fmt.Errorf("projects are already written")
+	}
+
+	fw, err := w.create(projectsFileName)
+	if err != nil {
+		return err
+	}
+
+	w.manifest.Projects = make([]ManifestProject, 0, len(projects))
+	for _, project := range projects {
+		if err := fw.encode(toProjectEntry(project)); err != nil {
+			return fmt.Errorf("write project: %v", err)
+		}
+
+		w.manifest.Projects = append(w.manifest.Projects, ManifestProject{
+			ID: project.ID.String(),
+		})
+	}
+
+	return nil
+}
+
+// WriteAPIKeys writes all API keys to the archive.
+func (w *Writer) WriteAPIKeys(keys []aud.APIKey) error {
+	return w.writeSettings(apiKeysFileName, &w.manifest.APIKeys, len(keys), func(fw *fileWriter, i int) error {
+		return fw.encode(toAPIKeyEntry(keys[i]))
+	})
+}
+
+// WriteRoleBindings writes role bindings of all projects to the archive.
+func (w *Writer) WriteRoleBindings(bindings []aud.RoleBinding) error {
+	return w.writeSettings(roleBindingsFileName, &w.manifest.RoleBindings, len(bindings), func(fw *fileWriter, i int) error {
+		if err := w.checkProject(bindings[i].ProjectID); err != nil {
+			return err
+		}
+		return fw.encode(toRoleBindingEntry(bindings[i]))
+	})
+}
+
+// WriteWebhooks writes webhooks of all projects to the archive.
+func (w *Writer) WriteWebhooks(webhooks []aud.Webhook) error {
+	return w.writeSettings(webhooksFileName, &w.manifest.Webhooks, len(webhooks), func(fw *fileWriter, i int) error {
+		if err := w.checkProject(webhooks[i].ProjectID); err != nil {
+			return err
+		}
+		return fw.encode(toWebhookEntry(webhooks[i]))
+	})
+}
+
+// WriteAlertRules writes alert rules of all projects to the archive.
+func (w *Writer) WriteAlertRules(rules []aud.AlertRule) error {
+	return w.writeSettings(alertRulesFileName, &w.manifest.AlertRules, len(rules), func(fw *fileWriter, i int) error {
+		if err := w.checkProject(rules[i].ProjectID); err != nil {
+			return err
+		}
+		return fw.encode(toAlertRuleEntry(rules[i]))
+	})
+}
+
+// writeSettings writes n entries to the file with encode and sets count.
+func (w *Writer) writeSettings(name string, count *int, n int, encode func(fw *fileWriter, i int) error) error {
+	if w.written(name) {
+		return fmt.Errorf("%s is already written", name)
+	}
+
+	fw, err := w.create(name)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if err := encode(fw, i); err != nil {
+			return fmt.Errorf("write %s: %v", name, err)
+		}
+	}
+
+	*count = n
+	return nil
+}
+
+func (w *Writer) checkProject(projectID aud.ID) error {
+	for _, p := range w.manifest.Projects {
+		if p.ID == projectID.String() {
+			return nil
+		}
+	}
+	return fmt.Errorf("project %s is not written", projectID.String())
+}
+
+func (w *Writer) written(name string) bool {
+	if w.file != nil && w.file.name == name {
+		return true
+	}
+	_, ok := w.manifest.file(name)
+	return ok
+}
+
+// CreateRecords starts writing records of the project. The returned writer
+// is valid until the next call to CreateRecords or Close.
+func (w *Writer) CreateRecords(projectID aud.ID) (*RecordsWriter, error) {
+	idx := -1
+	for i, p := range w.manifest.Projects {
+		if p.ID == projectID.String() {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil, fmt.Errorf("project %s is not written", projectID.String())
+	}
+
+	fw, err := w.create(recordsFileName(projectID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordsWriter{
+		fw:        fw,
+		projectID: projectID,
+		count:     &w.manifest.Projects[idx].Records,
+	}, nil
+}
+
+// Close writes the manifest and finishes the archive. Settings that are not
+// written are written empty. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	for _, name := range settingsFileNames {
+		if w.written(name) {
+			continue
+		}
+		if _, err := w.create(name); err != nil {
+			return err
+		}
+	}
+
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	fw, err := w.zw.CreateHeader(w.header(manifestFileName))
+	if err != nil {
+		return fmt.Errorf("create %s: %v", manifestFileName, err)
+	}
+
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(w.manifest); err != nil {
+		return fmt.Errorf("write %s: %v", manifestFileName, err)
+	}
+
+	if err := w.zw.Close(); err != nil {
+		return fmt.Errorf("close archive: %v", err)
+	}
+
+	return nil
+}
+
+// Manifest returns the manifest of written contents. It is complete after
+// Close.
+func (w *Writer) Manifest() Manifest {
+	return w.manifest
+}
+
+func (w *Writer) create(name string) (*fileWriter, error) {
+	if err := w.closeFile(); err != nil {
+		return nil, err
+	}
+
+	zf, err := w.zw.CreateHeader(w.header(name))
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %v", name, err)
+	}
+
+	w.file = newFileWriter(name, zf)
+	return w.file, nil
+}
+
+func (w *Writer) header(name string) *zip.FileHeader {
+	return &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: w.manifest.CreateTime,
+	}
+}
+
+func (w *Writer) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+
+	w.manifest.Files = append(w.manifest.Files, ManifestFile{
+		Name:   w.file.name,
+		Size:   w.file.size,
+		SHA256: hex.EncodeToString(w.file.hash.Sum(nil)),
+	})
+	w.file = nil
+
+	return nil
+}
+
+// RecordsWriter writes records of a single project.
+type RecordsWriter struct {
+	fw        *fileWriter
+	projectID aud.ID
+	count     *int
+}
+
+// Write writes the record to the archive.
+func (rw *RecordsWriter) Write(record aud.Record) error {
+	if record.ProjectID != rw.projectID {
+		return fmt.Errorf("record %s belongs to another project", record.ID.String())
+	}
+
+	if err := rw.fw.encode(toRecordEntry(record)); err != nil {
+		return fmt.Errorf("write record: %v", err)
+	}
+
+	*rw.count++
+	return nil
+}
+
+// fileWriter writes JSON lines to an archive file while computing its size
+// and checksum.
+type fileWriter struct {
+	name string
+	w    io.Writer
+	hash hash.Hash
+	size int64
+	enc  *json.Encoder
+}
+
+func newFileWriter(name string, w io.Writer) *fileWriter {
+	fw := &fileWriter{
+		name: name,
+		w:    w,
+		hash: sha256.New(),
+	}
+
+	fw.enc = json.NewEncoder(fw)
+	fw.enc.SetEscapeHTML(false)
+
+	return fw
+}
+
+func (fw *fileWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	fw.hash.Write(p[:n])
+	fw.size += int64(n)
+	return n, err
+}
+
+func (fw *fileWriter) encode(v any) error {
+	return fw.enc.Encode(v)
+}
@@ -0,0 +1,287 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	contentTypeJSON       = "application/json"
+	contentTypeStructured = "application/cloudevents+json"
+	contentTypeBatch      = "application/cloudevents-batch+json"
+)
+
+const specVersion = "1.0"
+
+// headerPrefix is the prefix of headers of event attributes in binary
+// content mode.
+const headerPrefix = "Ce-"
+
+// Event is a CloudEvent.
+type Event struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	// Extensions are extension attributes by name, formatted as strings.
+	Extensions map[string]string
+	Data       []byte
+}
+
+// isJSONData reports whether the data is JSON, i.e. the data content type is
+// absent, "application/json" or has "+json" suffix.
+func (e Event) isJSONData() bool {
+	if e.DataContentType == "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(e.DataContentType)
+	return mediaType == contentTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+func (e Event) validate() error {
+	var missing []string
+	for _, attr := range []struct {
+		name  string
+		value string
+	}{
+		{"id", e.ID},
+		{"source", e.Source},
+		{"specversion", e.SpecVersion},
+		{"type", e.Type},
+	} {
+		if attr.value == "" {
+			missing = append(missing, attr.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required attributes: %s", strings.Join(missing, ", "))
+	}
+
+	if e.SpecVersion != specVersion {
+		return fmt.Errorf("unsupported specversion %q", e.SpecVersion)
+	}
+
+	return nil
+}
+
+// readEvents reads events of the request in binary, structured or batched
+// content mode of the HTTP protocol binding.
+func readEvents(r *http.Request, body []byte) ([]Event, error) {
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch {
+	case contentType == contentTypeStructured:
+		event, err := decodeStructured(body)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{event}, nil
+	case contentType == contentTypeBatch:
+		return decodeBatch(body)
+	case r.Header.Get(headerPrefix+"Specversion") != "":
+		event, err := decodeBinary(r.Header, body)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{event}, nil
+	default:
+		return nil, fmt.Errorf(
+			"content type must be %q or %q, or attributes must be set in %q headers",
+			contentTypeStructured,
+			contentTypeBatch,
+			"ce-",
+		)
+	}
+}
+
+func decodeBinary(header http.Header, body []byte) (Event, error) {
+	event := Event{
+		DataContentType: header.Get("Content-Type"),
+		Data:            body,
+	}
+
+	for key, values := range header {
+		if !strings.HasPrefix(key, headerPrefix) || len(values) == 0 {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(key, headerPrefix))
+		value, err := url.PathUnescape(values[0])
+		if err != nil {
+			return Event{}, fmt.Errorf("invalid %q header: %v", key, err)
+		}
+
+		if err := event.setAttribute(name, value); err != nil {
+			return Event{}, err
+		}
+	}
+
+	if err := event.validate(); err != nil {
+		return Event{}, err
+	}
+
+	return event, nil
+}
+
+func decodeStructured(data []byte) (Event, error) {
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return Event{}, fmt.Errorf("invalid JSON event: %v", err)
+	}
+	return decodeAttributes(attrs)
+}
+
+func decodeBatch(data []byte) ([]Event, error) {
+	var batch []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("invalid JSON batch: %v", err)
+	}
+
+	events := make([]Event, 0, len(batch))
+	for i, attrs := range batch {
+		event, err := decodeAttributes(attrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %d: %v", i, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// decodeAttributes decodes the event in JSON format.
+func decodeAttributes(attrs map[string]json.RawMessage) (Event, error) {
+	var event Event
+
+	for name, raw := range attrs {
+		if name == "data" || name == "data_base64" {
+			continue
+		}
+
+		value, err := decodeAttributeValue(raw)
+		if err != nil {
+			return Event{}, fmt.Errorf("invalid %q attribute: %v", name, err)
+		}
+
+		if err := event.setAttribute(name, value); err != nil {
+			return Event{}, err
+		}
+	}
+
+	if err := event.validate(); err != nil {
+		return Event{}, err
+	}
+
+	data, hasData := attrs["data"]
+	dataBase64, hasDataBase64 := attrs["data_base64"]
+
+	switch {
+	case hasData && hasDataBase64:
+		return Event{}, errors.New(`only one of "data" and "data_base64" may be set`)
+	case hasDataBase64:
+		var s string
+		if err := json.Unmarshal(dataBase64, &s); err != nil {
+			return Event{}, fmt.Errorf(`invalid "data_base64": %v`, err)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return Event{}, fmt.Errorf(`invalid "data_base64": %v`, err)
+		}
+		event.Data = b
+	case hasData && !bytes.Equal(data, []byte("null")):
+		if event.isJSONData() {
+			event.Data = data
+			break
+		}
+		// Data of other content types is a JSON string.
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return Event{}, fmt.Errorf(`invalid "data": %v`, err)
+		}
+		event.Data = []byte(s)
+	}
+
+	return event, nil
+}
+
+// decodeAttributeValue returns the JSON attribute value as a string.
+func decodeAttributeValue(raw json.RawMessage) (string, error) {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, float64:
+		return string(bytes.TrimSpace(raw)), nil
+	default:
+		return "", errors.New("must be a string, number or boolean")
+	}
+}
+
+func (e *Event) setAttribute(name, value string) error {
+	switch name {
+	case "id":
+		e.ID = value
+	case "source":
+		e.Source = value
+	case "specversion":
+		e.SpecVersion = value
+	case "type":
+		e.Type = value
+	case "subject":
+		e.Subject = value
+	case "time":
+		if value == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf(`invalid "time" attribute: %v`, err)
+		}
+		e.Time = t
+	case "datacontenttype":
+		e.DataContentType = value
+	case "dataschema":
+		e.DataSchema = value
+	default:
+		if value == "" {
+			return nil
+		}
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]string)
+		}
+		e.Extensions[name] = value
+	}
+	return nil
+}
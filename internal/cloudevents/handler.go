@@ -0,0 +1,189 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudevents receives CloudEvents over HTTP and creates records
+// from them.
+package cloudevents
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+const receiveEventsPathPattern = "/cloudevents"
+
+// maxRequestSize is the maximum size of a request body.
+const maxRequestSize = 16 << 20
+
+const metricsSource = "cloudevents"
+
+// Config is the configuration of the handler.
+type Config struct {
+	Projects []ProjectMapping
+	Rules    []Rule
+}
+
+// Handler receives CloudEvents in binary, structured and batched content
+// modes of the HTTP protocol binding, and creates records from them.
+type Handler struct {
+	mapper *Mapper
+	log    *zap.Logger
+	now    func() time.Time
+}
+
+func NewHandler(conf Config, log *zap.Logger) (*Handler, error) {
+	mapper, err := NewMapper(conf.Projects, conf.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		mapper: mapper,
+		log:    log.Named("cloudevents"),
+		now:    time.Now,
+	}, nil
+}
+
+// RegisterGateway registers the endpoint receiving events at "/cloudevents".
+// Records are created with the record service over the connection, so that
+// requests are authenticated and rate limited like BatchCreateRecords.
+func (h *Handler) RegisterGateway(_ context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	receive := &receiveHandler{
+		mapper:  h.mapper,
+		mux:     mux,
+		records: ingest.NewRecordClient(auditumv1alpha1.NewRecordServiceClient(conn)),
+		log:     h.log,
+		now:     h.now,
+	}
+
+	return mux.HandlePath(http.MethodPost, receiveEventsPathPattern, receive.ServeHTTP)
+}
+
+type receiveHandler struct {
+	mapper  *Mapper
+	mux     *runtime.ServeMux
+	records ingest.RecordCreator
+	log     *zap.Logger
+	now     func() time.Time
+}
+
+func (h *receiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	receiveTime := h.now()
+
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		h.mux,
+		r,
+		auditumv1alpha1.RecordService_BatchCreateRecords_FullMethodName,
+		runtime.WithHTTPPathPattern(receiveEventsPathPattern),
+	)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+
+	body, err := ingest.ReadBody(w, r, maxRequestSize)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, invalidRequestError(err))
+		return
+	}
+
+	events, err := readEvents(r, body)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, invalidRequestError(err))
+		return
+	}
+
+	resp, err := h.receive(ctx, events, receiveTime)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ingest.WriteResults(w, resp)
+}
+
+func invalidRequestError(err error) error {
+	return status.Errorf(codes.InvalidArgument, `Request is invalid. %v.`, err.Error())
+}
+
+// receive creates records of the events. Events matching no project or
+// rule, or mapped to invalid records, are dropped. Records are created with
+// idempotency keys of the events, so when creation fails for some projects
+// the request can be retried without duplicating records of the others. The
+// returned error is a gRPC status.
+func (h *receiveHandler) receive(
+	ctx context.Context,
+	events []Event,
+	receiveTime time.Time,
+) (resp ingest.Results, err error) {
+	var (
+		projectIDs []string
+		records    = make(map[string][]*auditumv1alpha1.Record)
+	)
+
+	for _, event := range events {
+		record, err := h.mapper.Map(event, receiveTime)
+		if err != nil {
+			if errors.Is(err, errNoProject) || errors.Is(err, errNoRule) {
+				metrics.IngestedMessage(metricsSource, ingest.ResultUnmatched)
+				resp.Unmatched++
+			} else {
+				metrics.IngestedMessage(metricsSource, ingest.ResultInvalid)
+				resp.Invalid++
+			}
+			h.log.Debug("Dropped event",
+				zap.String("id", event.ID),
+				zap.String("source", event.Source),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if _, ok := records[record.ProjectId]; !ok {
+			projectIDs = append(projectIDs, record.ProjectId)
+		}
+		records[record.ProjectId] = append(records[record.ProjectId], record)
+	}
+
+	for _, projectID := range projectIDs {
+		invalid, err := ingest.CreateRecords(ctx, h.records, metricsSource, projectID, records[projectID])
+		if err != nil {
+			return resp, err
+		}
+		for _, err := range invalid {
+			h.log.Debug("Dropped event mapped to invalid record", zap.Error(err))
+		}
+		resp.Created += len(records[projectID]) - len(invalid)
+		resp.Invalid += len(invalid)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/cloudevents"
+)
+
+// fakeRecordServer creates records, rejecting records with actor id
+// "invalid" and records of the denied project.
+type fakeRecordServer struct {
+	auditumv1alpha1.UnimplementedRecordServiceServer
+
+	deniedProjectID string
+
+	mu      sync.Mutex
+	created []*auditumv1alpha1.Record
+}
+
+// create creates the records, skipping records with idempotency keys of
+// created records, like the store does.
+func (s *fakeRecordServer) create(records ...*auditumv1alpha1.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, record := range records {
+		if slices.ContainsFunc(s.created, func(created *auditumv1alpha1.Record) bool {
+			return created.GetIdempotencyKey() == record.GetIdempotencyKey()
+		}) {
+			continue
+		}
+		s.created = append(s.created, record)
+	}
+}
+
+func (s *fakeRecordServer) CreateRecord(
+	_ context.Context,
+	req *auditumv1alpha1.CreateRecordRequest,
+) (*auditumv1alpha1.CreateRecordResponse, error) {
+	if err := s.check(req.GetRecord().GetProjectId(), req.GetRecord()); err != nil {
+		return nil, err
+	}
+
+	s.create(req.GetRecord())
+
+	return &auditumv1alpha1.CreateRecordResponse{Record: req.GetRecord()}, nil
+}
+
+func (s *fakeRecordServer) BatchCreateRecords(
+	_ context.Context,
+	req *auditumv1alpha1.BatchCreateRecordsRequest,
+) (*auditumv1alpha1.BatchCreateRecordsResponse, error) {
+	if err := s.check(req.GetProjectId(), req.GetRecords()...); err != nil {
+		return nil, err
+	}
+
+	s.create(req.GetRecords()...)
+
+	return &auditumv1alpha1.BatchCreateRecordsResponse{Records: req.GetRecords()}, nil
+}
+
+func (s *fakeRecordServer) check(projectID string, records ...*auditumv1alpha1.Record) error {
+	if projectID == s.deniedProjectID {
+		return status.Error(codes.PermissionDenied, "Permission denied.")
+	}
+	for _, record := range records {
+		if record.GetActor().GetId() == "invalid" {
+			return status.Error(codes.InvalidArgument, "Record is invalid.")
+		}
+	}
+	return nil
+}
+
+func newGateway(t *testing.T, records *fakeRecordServer) http.Handler {
+	t.Helper()
+
+	handler, err := cloudevents.NewHandler(cloudevents.Config{
+		Projects: testProjects,
+		Rules:    testRules,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	auditumv1alpha1.RegisterRecordServiceServer(srv, records)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, handler.RegisterGateway(context.Background(), mux, conn))
+
+	return mux
+}
+
+func postEvents(handler http.Handler, header http.Header, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/cloudevents", strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Receive(t *testing.T) {
+	t.Run("Should receive event in binary mode", func(t *testing.T) {
+		records := &fakeRecordServer{}
+		handler := newGateway(t, records)
+
+		rec := postEvents(handler, http.Header{
+			"Content-Type":   {"application/json"},
+			"Ce-Specversion": {"1.0"},
+			"Ce-Id":          {"evt-1"},
+			"Ce-Source":      {"/orders/eu"},
+			"Ce-Type":        {"com.example.order.paid"},
+			"Ce-Subject":     {"order-42"},
+			"Ce-Time":        {"2026-10-19T11:00:00Z"},
+			"Ce-Region":      {"eu%20west"},
+		}, `{"user": {"id": "alice"}}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"created": 1, "unmatched": 0, "invalid": 0}`, rec.Body.String())
+		require.Len(t, records.created, 1)
+
+		got := records.created[0]
+		assert.Equal(t, testProjectID, got.GetProjectId())
+		assert.Equal(t, "order-42", got.GetResource().GetId())
+		assert.Equal(t, "alice", got.GetActor().GetId())
+		assert.Equal(t, "eu west", got.GetLabels()["region"])
+		assert.Equal(t, time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC), got.GetOperation().GetTime().AsTime())
+	})
+
+	t.Run("Should receive event in structured mode", func(t *testing.T) {
+		records := &fakeRecordServer{}
+		handler := newGateway(t, records)
+
+		data := base64.StdEncoding.EncodeToString([]byte(`{"user": {"id": "bob"}}`))
+
+		rec := postEvents(handler, http.Header{
+			"Content-Type": {"application/cloudevents+json; charset=utf-8"},
+		}, `{
+			"specversion": "1.0",
+			"id": "evt-1",
+			"source": "/orders/eu",
+			"type": "com.example.order.paid",
+			"subject": "order-42",
+			"sequence": 7,
+			"data_base64": "`+data+`"
+		}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Len(t, records.created, 1)
+		assert.Equal(t, "bob", records.created[0].GetActor().GetId())
+	})
+
+	t.Run("Should receive batch and drop unmatched and invalid events", func(t *testing.T) {
+		records := &fakeRecordServer{}
+		handler := newGateway(t, records)
+
+		rec := postEvents(handler, http.Header{
+			"Content-Type": {"application/cloudevents-batch+json"},
+		}, `[
+			{"specversion": "1.0", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid", "data": {"user": {"id": "alice"}}},
+			{"specversion": "1.0", "id": "evt-2", "source": "/orders/eu", "type": "com.example.order.paid", "data": {"user": {"id": "invalid"}}},
+			{"specversion": "1.0", "id": "evt-3", "source": "/billing", "type": "com.example.invoice.sent"},
+			{"specversion": "1.0", "id": "evt-4", "source": "/unknown", "type": "com.example.order.paid"},
+			{"specversion": "1.0", "id": "evt-5", "source": "/orders/eu", "type": "com.example.order.paid", "data": {"status": "pending"}}
+		]`)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"created": 2, "unmatched": 1, "invalid": 2}`, rec.Body.String())
+		require.Len(t, records.created, 2)
+		assert.Equal(t, testProjectID, records.created[0].GetProjectId())
+		assert.Equal(t, otherTestProjectID, records.created[1].GetProjectId())
+	})
+
+	t.Run("Should return error of record service", func(t *testing.T) {
+		records := &fakeRecordServer{deniedProjectID: testProjectID}
+		handler := newGateway(t, records)
+
+		rec := postEvents(handler, http.Header{
+			"Content-Type": {"application/cloudevents+json"},
+		}, `{"specversion": "1.0", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid"}`)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		assert.Empty(t, records.created)
+	})
+
+	t.Run("Should not duplicate records of retried request", func(t *testing.T) {
+		records := &fakeRecordServer{deniedProjectID: otherTestProjectID}
+		handler := newGateway(t, records)
+
+		header := http.Header{
+			"Content-Type": {"application/cloudevents-batch+json"},
+		}
+		body := `[
+			{"specversion": "1.0", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid"},
+			{"specversion": "1.0", "id": "evt-2", "source": "/billing", "type": "com.example.invoice.sent"}
+		]`
+
+		rec := postEvents(handler, header, body)
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		require.Len(t, records.created, 1)
+
+		records.deniedProjectID = ""
+
+		rec = postEvents(handler, header, body)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Len(t, records.created, 2)
+		assert.Equal(t, testProjectID, records.created[0].GetProjectId())
+		assert.Equal(t, otherTestProjectID, records.created[1].GetProjectId())
+	})
+
+	t.Run("Should reject invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			header http.Header
+			body   string
+		}{
+			{
+				name:   "not an event",
+				header: http.Header{"Content-Type": {"application/json"}},
+				body:   `{}`,
+			},
+			{
+				name:   "invalid JSON",
+				header: http.Header{"Content-Type": {"application/cloudevents+json"}},
+				body:   `{`,
+			},
+			{
+				name:   "missing required attributes",
+				header: http.Header{"Content-Type": {"application/cloudevents+json"}},
+				body:   `{"specversion": "1.0", "id": "evt-1"}`,
+			},
+			{
+				name:   "unsupported specversion",
+				header: http.Header{"Content-Type": {"application/cloudevents+json"}},
+				body:   `{"specversion": "0.3", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid"}`,
+			},
+			{
+				name:   "invalid time",
+				header: http.Header{"Content-Type": {"application/cloudevents+json"}},
+				body:   `{"specversion": "1.0", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid", "time": "yesterday"}`,
+			},
+			{
+				name:   "invalid event in batch",
+				header: http.Header{"Content-Type": {"application/cloudevents-batch+json"}},
+				body:   `[{"specversion": "1.0", "id": "evt-1", "source": "/orders/eu", "type": "com.example.order.paid"}, {}]`,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				records := &fakeRecordServer{}
+				handler := newGateway(t, records)
+
+				rec := postEvents(handler, test.header, test.body)
+
+				assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+				assert.Empty(t, records.created)
+			})
+		}
+	})
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+// ProjectMapping selects the project of events with source matching the
+// glob pattern. Empty pattern matches any source.
+type ProjectMapping struct {
+	Source    string
+	ProjectID string
+}
+
+// Rule maps events matching the source, type and subject glob patterns to
+// a record. Empty pattern matches any value.
+//
+// Record templates may reference id, source, type, subject, time,
+// datacontenttype, dataschema, extension attributes by name, data, and
+// data.<path> for values of JSON data, e.g. data.user.id or data.items.0.
+type Rule struct {
+	Source  string
+	Type    string
+	Subject string
+	Record  ingest.RecordTemplate
+}
+
+var (
+	errNoProject = errors.New("no project mapping matched")
+	errNoRule    = errors.New("no rule matched")
+)
+
+// Mapper maps events to records.
+type Mapper struct {
+	projects []ProjectMapping
+	rules    []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+
+	record *ingest.RecordMapper
+}
+
+// NewMapper returns a mapper of events. Projects and rules are matched in
+// order, the first match is used.
+func NewMapper(projects []ProjectMapping, rules []Rule) (*Mapper, error) {
+	m := &Mapper{
+		projects: projects,
+		rules:    make([]compiledRule, 0, len(rules)),
+	}
+
+	for i, p := range projects {
+		if err := ingest.ValidatePatterns(p.Source); err != nil {
+			return nil, fmt.Errorf("invalid project mapping %d: %v", i, err)
+		}
+	}
+
+	for i, r := range rules {
+		if err := ingest.ValidatePatterns(r.Source, r.Type, r.Subject); err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %v", i, err)
+		}
+		record, err := ingest.NewRecordMapper(r.Record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %v", i, err)
+		}
+		m.rules = append(m.rules, compiledRule{Rule: r, record: record})
+	}
+
+	return m, nil
+}
+
+// Map maps the event to a record of the mapped project. Operation time is
+// the event time, or the receive time when the event has none. Extension
+// attributes of distributed tracing fill the trace context.
+func (m *Mapper) Map(event Event, receiveTime time.Time) (*auditumv1alpha1.Record, error) {
+	projectID := ""
+	for _, p := range m.projects {
+		if ingest.Match(p.Source, event.Source) {
+			projectID = p.ProjectID
+			break
+		}
+	}
+	if projectID == "" {
+		return nil, errNoProject
+	}
+
+	for _, rule := range m.rules {
+		if !ingest.Match(rule.Source, event.Source) ||
+			!ingest.Match(rule.Type, event.Type) ||
+			!ingest.Match(rule.Subject, event.Subject) {
+			continue
+		}
+
+		operationTime := event.Time
+		if operationTime.IsZero() {
+			operationTime = receiveTime
+		}
+
+		record, err := rule.record.Record(projectID, eventLookup(event), operationTime)
+		if err != nil {
+			return nil, err
+		}
+
+		// Source and id identify the event, so retried deliveries of the
+		// event do not create the record again.
+		record.IdempotencyKey = ingest.IdempotencyKey(metricsSource, event.Source, event.ID)
+
+		if traceparent := event.Extensions["traceparent"]; traceparent != "" {
+			record.Operation.TraceContext = &auditumv1alpha1.TraceContext{
+				Traceparent: traceparent,
+				Tracestate:  event.Extensions["tracestate"],
+			}
+		}
+
+		return record, nil
+	}
+
+	return nil, errNoRule
+}
+
+// eventLookup looks fields of the event up. JSON data is decoded on first
+// lookup of a data path.
+func eventLookup(event Event) ingest.Lookup {
+	var (
+		data    any
+		decoded bool
+	)
+
+	return func(name string) string {
+		switch name {
+		case "id":
+			return event.ID
+		case "source":
+			return event.Source
+		case "specversion":
+			return event.SpecVersion
+		case "type":
+			return event.Type
+		case "subject":
+			return event.Subject
+		case "time":
+			if event.Time.IsZero() {
+				return ""
+			}
+			return event.Time.Format(time.RFC3339Nano)
+		case "datacontenttype":
+			return event.DataContentType
+		case "dataschema":
+			return event.DataSchema
+		case "data":
+			return string(event.Data)
+		}
+
+		path, ok := strings.CutPrefix(name, "data.")
+		if !ok {
+			return event.Extensions[name]
+		}
+
+		if !decoded {
+			decoded = true
+			if event.isJSONData() {
+				data = decodeJSON(event.Data)
+			}
+		}
+
+		return jsonPath(data, strings.Split(path, "."))
+	}
+}
+
+// decodeJSON returns the decoded JSON value, or nil if the data is not
+// valid JSON.
+func decodeJSON(data []byte) any {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// jsonPath returns the value at the path of object keys and array indexes,
+// or empty string if there is none. Values other than strings are formatted
+// as JSON.
+func jsonPath(value any, path []string) string {
+	for _, key := range path {
+		switch v := value.(type) {
+		case map[string]any:
+			value = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return ""
+			}
+			value = v[i]
+		default:
+			return ""
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
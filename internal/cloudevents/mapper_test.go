@@ -0,0 +1,239 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudevents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/cloudevents"
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+const (
+	testProjectID      = "0190dc5c-1b0d-7bd4-9b0e-5e3a1e1b1e1b"
+	otherTestProjectID = "0190dc5c-1b0d-7bd4-9b0e-5e3a1e1b1e1c"
+)
+
+var testProjects = []cloudevents.ProjectMapping{
+	{Source: "/orders/*", ProjectID: testProjectID},
+	{Source: "/billing", ProjectID: otherTestProjectID},
+}
+
+var testRules = []cloudevents.Rule{
+	{
+		Type: "com.example.order.*",
+		Record: ingest.RecordTemplate{
+			ResourceType:    "order",
+			ResourceID:      "${subject}",
+			OperationType:   "${type}",
+			OperationID:     "${id}",
+			OperationStatus: "${data.status}",
+			ActorType:       "user",
+			ActorID:         "${data.user.id:-unknown}",
+			Labels: map[string]string{
+				"region": "${region}",
+			},
+			Metadata: map[string]string{
+				"total":      "${data.total}",
+				"first_item": "${data.items.0.sku}",
+				"user":       "${data.user}",
+			},
+		},
+	},
+	{
+		Record: ingest.RecordTemplate{
+			ResourceType:  "${source}",
+			ResourceID:    "${subject:-unknown}",
+			OperationType: "${type}",
+			OperationID:   "${id}",
+			ActorType:     "${authtype:-unknown}",
+			ActorID:       "${authid:-unknown}",
+		},
+	},
+}
+
+func TestMapper_Map(t *testing.T) {
+	mapper, err := cloudevents.NewMapper(testProjects, testRules)
+	require.NoError(t, err)
+
+	receiveTime := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should map event with JSON data", func(t *testing.T) {
+		event := cloudevents.Event{
+			ID:          "evt-1",
+			Source:      "/orders/eu",
+			SpecVersion: "1.0",
+			Type:        "com.example.order.paid",
+			Subject:     "order-42",
+			Time:        time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+			Extensions: map[string]string{
+				"region":      "eu-west-1",
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			Data: []byte(`{"status": "succeeded", "total": 12.5, "user": {"id": "alice"}, "items": [{"sku": "A1"}]}`),
+		}
+
+		got, err := mapper.Map(event, receiveTime)
+		require.NoError(t, err)
+
+		want := &auditumv1alpha1.Record{
+			ProjectId: testProjectID,
+			Labels: map[string]string{
+				"region": "eu-west-1",
+			},
+			IdempotencyKey: ingest.IdempotencyKey("cloudevents", "/orders/eu", "evt-1"),
+			Resource: &auditumv1alpha1.Resource{
+				Type: "order",
+				Id:   "order-42",
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type: "com.example.order.paid",
+				Id:   "evt-1",
+				Time: timestamppb.New(time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)),
+				Metadata: map[string]string{
+					"total":      "12.5",
+					"first_item": "A1",
+					"user":       `{"id":"alice"}`,
+				},
+				TraceContext: &auditumv1alpha1.TraceContext{
+					Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+				},
+				Status: auditumv1alpha1.OperationStatus_SUCCEEDED,
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type: "user",
+				Id:   "alice",
+			},
+		}
+		assertRecord(t, want, got)
+	})
+
+	t.Run("Should not look data paths up in data other than JSON", func(t *testing.T) {
+		event := cloudevents.Event{
+			ID:              "evt-2",
+			Source:          "/orders/us",
+			SpecVersion:     "1.0",
+			Type:            "com.example.order.created",
+			Subject:         "order-43",
+			DataContentType: "text/plain",
+			Data:            []byte(`{"user": {"id": "alice"}}`),
+		}
+
+		got, err := mapper.Map(event, receiveTime)
+		require.NoError(t, err)
+		assert.Equal(t, "unknown", got.GetActor().GetId())
+		assert.Equal(t, receiveTime, got.GetOperation().GetTime().AsTime())
+	})
+
+	t.Run("Should fall through rules not matching event type", func(t *testing.T) {
+		event := cloudevents.Event{
+			ID:          "evt-3",
+			Source:      "/billing",
+			SpecVersion: "1.0",
+			Type:        "com.example.invoice.sent",
+			Extensions: map[string]string{
+				"authtype": "service_account",
+				"authid":   "mailer",
+			},
+		}
+
+		got, err := mapper.Map(event, receiveTime)
+		require.NoError(t, err)
+
+		want := &auditumv1alpha1.Record{
+			ProjectId:      otherTestProjectID,
+			IdempotencyKey: ingest.IdempotencyKey("cloudevents", "/billing", "evt-3"),
+			Resource: &auditumv1alpha1.Resource{
+				Type: "/billing",
+				Id:   "unknown",
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type: "com.example.invoice.sent",
+				Id:   "evt-3",
+				Time: timestamppb.New(receiveTime),
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type: "service_account",
+				Id:   "mailer",
+			},
+		}
+		assertRecord(t, want, got)
+	})
+
+	t.Run("Should not map event of unmapped source", func(t *testing.T) {
+		event := cloudevents.Event{
+			ID:          "evt-4",
+			Source:      "/orders/eu/archive",
+			SpecVersion: "1.0",
+			Type:        "com.example.order.paid",
+		}
+
+		_, err := mapper.Map(event, receiveTime)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should not map event with invalid status", func(t *testing.T) {
+		event := cloudevents.Event{
+			ID:          "evt-5",
+			Source:      "/orders/eu",
+			SpecVersion: "1.0",
+			Type:        "com.example.order.paid",
+			Data:        []byte(`{"status": "pending"}`),
+		}
+
+		_, err := mapper.Map(event, receiveTime)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewMapper_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []cloudevents.ProjectMapping
+		rule     cloudevents.Rule
+	}{
+		{
+			name:     "invalid project pattern",
+			projects: []cloudevents.ProjectMapping{{Source: "[orders", ProjectID: testProjectID}},
+		},
+		{
+			name: "invalid rule pattern",
+			rule: cloudevents.Rule{Type: "[com"},
+		},
+		{
+			name: "unterminated reference",
+			rule: cloudevents.Rule{Record: ingest.RecordTemplate{ActorID: "${data.user"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := cloudevents.NewMapper(test.projects, []cloudevents.Rule{test.rule})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func assertRecord(t *testing.T, want, got *auditumv1alpha1.Record) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want:\n%v\ngot:\n%v", want, got)
+}
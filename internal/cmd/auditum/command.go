@@ -17,6 +17,7 @@ package auditum
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
@@ -26,6 +27,11 @@ const (
 	appName             = "auditum"
 	commandNameServer   = "server"
 	commandNameMigrator = "migrator"
+	commandNameImport   = "import"
+	commandNameBackup   = "backup"
+	commandNameRestore  = "restore"
+	commandNameTransfer = "transfer"
+	commandNameAPIKey   = "apikey"
 )
 
 const (
@@ -34,31 +40,81 @@ const (
 	exitCodeRunFailure
 )
 
-func parseCommand() (command, configPath string, err error) {
-	flagset := flag.NewFlagSet(appName, flag.ContinueOnError)
+// parseCommand parses command line arguments. Besides the command name and
+// config path, it returns the flagset with command-specific flags and
+// positional arguments.
+func parseCommand() (command, configPath string, flagset *flag.FlagSet, err error) {
+	args := os.Args[1:]
+
+	command = commandArg(args)
+
+	flagset = flag.NewFlagSet(appName, flag.ContinueOnError)
 
 	flagset.String("config", "", "Path to config file.")
 
-	if err := flagset.Parse(os.Args[1:]); err != nil {
-		return "", "", fmt.Errorf("parse command line arguments: %v", err)
+	switch command {
+	case commandNameMigrator, "migrate":
+		addMigratorFlags(flagset)
+	case commandNameImport:
+		addImportFlags(flagset)
+	case commandNameRestore:
+		addRestoreFlags(flagset)
+	case commandNameTransfer:
+		addTransferFlags(flagset)
+	case commandNameAPIKey:
+		addAPIKeyFlags(flagset)
 	}
 
-	c := flagset.Arg(0)
+	if err := flagset.Parse(args); err != nil {
+		return "", "", nil, fmt.Errorf("parse command line arguments: %v", err)
+	}
 
 	fpath, err := flagset.GetString("config")
 	if err != nil {
-		return "", "", fmt.Errorf("get config argument: %v", err)
+		return "", "", nil, fmt.Errorf("get config argument: %v", err)
 	}
 
-	return c, fpath, nil
+	return command, fpath, flagset, nil
+}
+
+// commandArg returns the command name, which is the first positional
+// argument. Only the config flag may precede the command name.
+func commandArg(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+			return ""
+		case args[i] == "--config":
+			// Skip the flag value.
+			i++
+		case strings.HasPrefix(args[i], "-"):
+			continue
+		default:
+			return args[i]
+		}
+	}
+	return ""
 }
 
-func executeCommand(cmd string, config *Configuration, log *zap.Logger) int {
+func executeCommand(cmd string, flagset *flag.FlagSet, config *Configuration, log *zap.Logger) int {
 	switch cmd {
 	case "server", "serve", "":
 		return executeServer(config, log)
 	case "migrator", "migrate":
-		return executeMigrator(config, log)
+		return executeMigrator(config, flagset, log)
+	case commandNameImport:
+		return executeImport(config, flagset, log)
+	case commandNameBackup:
+		return executeBackup(config, flagset, log)
+	case commandNameRestore:
+		return executeRestore(config, flagset, log)
+	case commandNameTransfer:
+		return executeTransfer(config, flagset, log)
+	case commandNameAPIKey:
+		return executeAPIKey(config, flagset, log)
 	default:
 		log.Error("Unknown command", zap.String("command", cmd))
 		return exitCodeStartFailure
@@ -0,0 +1,132 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/sql"
+)
+
+const apiKeyActionCreate = "create"
+
+func addAPIKeyFlags(flagset *flag.FlagSet) {
+	flagset.String("name", "", "Display name of the API key.")
+	flagset.StringSlice("permission", nil, `Permissions of the API key: "read", "write" or "admin". May be repeated.`)
+	flagset.StringSlice("project", nil, "ID of the project the API key grants access to. May be repeated. Defaults to all projects.")
+}
+
+type apiKeyOptions struct {
+	action      string
+	name        string
+	permissions []aud.Permission
+	projectIDs  []aud.ID
+}
+
+func parseAPIKeyOptions(flagset *flag.FlagSet) (opts apiKeyOptions, err error) {
+	args := flagset.Args()[1:]
+	if len(args) != 1 || args[0] != apiKeyActionCreate {
+		return opts, fmt.Errorf("expected action %q, e.g. %s %s %s --name admin --permission admin",
+			apiKeyActionCreate, appName, commandNameAPIKey, apiKeyActionCreate)
+	}
+	opts.action = args[0]
+
+	opts.name, _ = flagset.GetString("name")
+	if opts.name == "" {
+		return opts, fmt.Errorf(`flag "name" is required`)
+	}
+
+	permissions, _ := flagset.GetStringSlice("permission")
+	if len(permissions) == 0 {
+		return opts, fmt.Errorf(`flag "permission" is required`)
+	}
+	for _, s := range permissions {
+		p, err := aud.ParsePermission(s)
+		if err != nil {
+			return opts, fmt.Errorf(`invalid flag "permission": %v`, err)
+		}
+		opts.permissions = append(opts.permissions, p)
+	}
+
+	projects, _ := flagset.GetStringSlice("project")
+	for _, s := range projects {
+		id, err := aud.ParseID(s)
+		if err != nil {
+			return opts, fmt.Errorf(`invalid flag "project": %v`, err)
+		}
+		opts.projectIDs = append(opts.projectIDs, id)
+	}
+
+	return opts, nil
+}
+
+// executeAPIKey creates an API key directly in the database. It is used to
+// create the first admin key, when the API requires authentication.
+func executeAPIKey(conf *Configuration, flagset *flag.FlagSet, log *zap.Logger) int {
+	ctx := context.Background()
+
+	opts, err := parseAPIKeyOptions(flagset)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	db, err := connectPersistentDatabase(ctx, conf.Store, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer db.Close()
+
+	store := sql.NewStore(db)
+
+	for _, projectID := range opts.projectIDs {
+		if _, err := store.GetProject(ctx, projectID); err != nil {
+			log.Error("Failed to get project", zap.String("project_id", projectID.String()), zap.Error(err))
+			return exitCodeRunFailure
+		}
+	}
+
+	key, secret, err := aud.NewAPIKey(
+		aud.MustNewID(),
+		time.Now().UTC(),
+		opts.name,
+		opts.projectIDs,
+		opts.permissions,
+	)
+	if err != nil {
+		log.Error("Failed to generate API key", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	if err := store.CreateAPIKey(ctx, key); err != nil {
+		log.Error("Failed to create API key", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	log.Info("Created API key", zap.String("api_key_id", key.ID.String()))
+
+	// The secret is printed to stdout only, so it can be captured by scripts.
+	fmt.Fprintln(os.Stdout, secret)
+
+	return exitCodeOK
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/backup"
+	"github.com/auditumio/auditum/internal/sql"
+)
+
+// backupProjectsPageSize is the number of projects to list at once.
+const backupProjectsPageSize = 100
+
+// backupAPIKeysPageSize is the number of API keys to list at once.
+const backupAPIKeysPageSize = 100
+
+func parseBackupFileArg(flagset *flag.FlagSet, command string) (string, error) {
+	if flagset.NArg() != 2 {
+		return "", fmt.Errorf("expected exactly one archive file argument, e.g. %s %s backup.zip", appName, command)
+	}
+	return flagset.Arg(1), nil
+}
+
+func executeBackup(conf *Configuration, flagset *flag.FlagSet, log *zap.Logger) (code int) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	slog := log.Sugar()
+	slog.Infof("%s %s started", appName, commandNameBackup)
+	defer func() {
+		if code == exitCodeOK {
+			slog.Infof("%s %s finished", appName, commandNameBackup)
+		} else {
+			slog.Errorf("%s %s failed", appName, commandNameBackup)
+		}
+	}()
+
+	fpath, err := parseBackupFileArg(flagset, commandNameBackup)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	if _, err := os.Stat(fpath); err == nil {
+		log.Error("Archive file already exists", zap.String("file", fpath))
+		return exitCodeStartFailure
+	}
+
+	db, err := connectPersistentDatabase(ctx, conf.Store, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer db.Close()
+
+	manifest, err := backupStore(ctx, sql.NewStore(db), fpath, log)
+	if err != nil {
+		log.Error("Failed to back up", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	var records int
+	for _, p := range manifest.Projects {
+		records += p.Records
+	}
+
+	log.Info("Backup summary",
+		zap.String("file", fpath),
+		zap.Int("projects", len(manifest.Projects)),
+		zap.Int("records", records),
+		zap.Int("api_keys", manifest.APIKeys),
+		zap.Int("role_bindings", manifest.RoleBindings),
+		zap.Int("webhooks", manifest.Webhooks),
+		zap.Int("alert_rules", manifest.AlertRules),
+	)
+
+	return exitCodeOK
+}
+
+// backupStore writes all projects with their records and settings, and all
+// API keys to the archive. The
+// archive is written to a temporary file first, so that an incomplete
+// archive is never left at fpath.
+func backupStore(ctx context.Context, store *sql.Store, fpath string, log *zap.Logger) (backup.Manifest, error) {
+	projects, err := listAllProjects(ctx, store)
+	if err != nil {
+		return backup.Manifest{}, fmt.Errorf("list projects: %v", err)
+	}
+
+	tmp := fpath + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return backup.Manifest{}, fmt.Errorf("create archive file: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+	}()
+
+	apiKeys, err := listAllAPIKeys(ctx, store)
+	if err != nil {
+		return backup.Manifest{}, fmt.Errorf("list api keys: %v", err)
+	}
+
+	var settings projectSettings
+	for _, project := range projects {
+		ps, err := listProjectSettings(ctx, store, project.ID)
+		if errors.Is(err, aud.ErrProjectNotFound) {
+			// The project has been deleted since listing, keep it empty.
+			continue
+		}
+		if err != nil {
+			return backup.Manifest{}, fmt.Errorf("list settings of project %s: %w", project.ID.String(), err)
+		}
+
+		settings.roleBindings = append(settings.roleBindings, ps.roleBindings...)
+		settings.webhooks = append(settings.webhooks, ps.webhooks...)
+		settings.alertRules = append(settings.alertRules, ps.alertRules...)
+	}
+
+	w := backup.NewWriter(f, time.Now())
+
+	if err := w.WriteProjects(projects); err != nil {
+		return backup.Manifest{}, err
+	}
+	if err := w.WriteAPIKeys(apiKeys); err != nil {
+		return backup.Manifest{}, err
+	}
+	if err := w.WriteRoleBindings(settings.roleBindings); err != nil {
+		return backup.Manifest{}, err
+	}
+	if err := w.WriteWebhooks(settings.webhooks); err != nil {
+		return backup.Manifest{}, err
+	}
+	if err := w.WriteAlertRules(settings.alertRules); err != nil {
+		return backup.Manifest{}, err
+	}
+
+	for _, project := range projects {
+		rw, err := w.CreateRecords(project.ID)
+		if err != nil {
+			return backup.Manifest{}, err
+		}
+
+		err = store.ExportRecords(ctx, project.ID, aud.RecordFilter{}, rw.Write)
+		if errors.Is(err, aud.ErrProjectNotFound) {
+			// The project has been deleted since listing, keep it empty.
+			log.Warn("Project not found, skip its records", zap.String("project_id", project.ID.String()))
+			continue
+		}
+		if err != nil {
+			return backup.Manifest{}, fmt.Errorf("back up records of project %s: %w", project.ID.String(), err)
+		}
+
+		log.Info("Backed up project", zap.String("project_id", project.ID.String()))
+	}
+
+	if err := w.Close(); err != nil {
+		return backup.Manifest{}, err
+	}
+
+	if err := f.Sync(); err != nil {
+		return backup.Manifest{}, fmt.Errorf("sync archive file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return backup.Manifest{}, fmt.Errorf("close archive file: %v", err)
+	}
+	if err := os.Rename(tmp, fpath); err != nil {
+		return backup.Manifest{}, fmt.Errorf("rename archive file: %v", err)
+	}
+
+	return w.Manifest(), nil
+}
+
+func listAllProjects(ctx context.Context, store *sql.Store) ([]aud.Project, error) {
+	var projects []aud.Project
+
+	var cursor aud.ProjectCursor
+	for {
+		page, err := store.ListProjects(ctx, aud.ProjectFilter{}, aud.ProjectOrderCreateTimeDesc, backupProjectsPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, page...)
+
+		cursor = aud.NewProjectCursor(page, backupProjectsPageSize)
+		if cursor.Empty() {
+			return projects, nil
+		}
+	}
+}
+
+func listAllAPIKeys(ctx context.Context, store *sql.Store) ([]aud.APIKey, error) {
+	var keys []aud.APIKey
+
+	var cursor aud.APIKeyCursor
+	for {
+		page, err := store.ListAPIKeys(ctx, backupAPIKeysPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, page...)
+
+		cursor = aud.NewAPIKeyCursor(page, backupAPIKeysPageSize)
+		if cursor.Empty() {
+			return keys, nil
+		}
+	}
+}
+
+// projectSettings are settings of projects copied along with them.
+// Alert states, fired alerts and webhook deliveries are not copied.
+type projectSettings struct {
+	roleBindings []aud.RoleBinding
+	webhooks     []aud.Webhook
+	alertRules   []aud.AlertRule
+}
+
+func listProjectSettings(ctx context.Context, store *sql.Store, projectID aud.ID) (ps projectSettings, err error) {
+	ps.roleBindings, err = store.ListRoleBindings(ctx, projectID)
+	if err != nil {
+		return ps, fmt.Errorf("list role bindings: %w", err)
+	}
+
+	ps.webhooks, err = store.ListWebhooks(ctx, projectID)
+	if err != nil {
+		return ps, fmt.Errorf("list webhooks: %w", err)
+	}
+
+	ps.alertRules, err = store.ListAlertRules(ctx, projectID)
+	if err != nil {
+		return ps, fmt.Errorf("list alert rules: %w", err)
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,471 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	auditumv1alpha1pb "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	auditumv1alpha1 "github.com/auditumio/auditum/internal/api/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/sql"
+)
+
+const (
+	importFormatNDJSON = "ndjson"
+	importFormatCSV    = "csv"
+)
+
+const (
+	defaultImportBatchSize = 1000
+	maxImportBatchSize     = 10000
+)
+
+func addImportFlags(flagset *flag.FlagSet) {
+	flagset.String("project", "", "ID of the project to import records into.")
+	flagset.String("format", "", `Format of the file: "ndjson" or "csv". Defaults to file extension, or "ndjson".`)
+	flagset.Int("batch-size", defaultImportBatchSize, "Number of records to write in a single transaction.")
+	flagset.String("progress-file", "", `Path to file to track progress for resuming import. Defaults to "<file>.progress".`)
+}
+
+type importOptions struct {
+	projectID    aud.ID
+	format       string
+	batchSize    int
+	filePath     string
+	progressPath string
+}
+
+func parseImportOptions(flagset *flag.FlagSet) (opts importOptions, err error) {
+	if flagset.NArg() != 2 {
+		return opts, fmt.Errorf("expected exactly one file argument, e.g. %s %s --project <id> records.ndjson", appName, commandNameImport)
+	}
+	opts.filePath = flagset.Arg(1)
+
+	project, _ := flagset.GetString("project")
+	if project == "" {
+		return opts, fmt.Errorf(`flag "project" is required`)
+	}
+	opts.projectID, err = aud.ParseID(project)
+	if err != nil {
+		return opts, fmt.Errorf(`invalid flag "project": %v`, err)
+	}
+
+	opts.format, _ = flagset.GetString("format")
+	switch opts.format {
+	case importFormatNDJSON, importFormatCSV:
+	case "":
+		opts.format = importFormatNDJSON
+		if strings.EqualFold(filepath.Ext(opts.filePath), ".csv") {
+			opts.format = importFormatCSV
+		}
+	default:
+		return opts, fmt.Errorf(`invalid flag "format": must be one of "%s", "%s"`, importFormatNDJSON, importFormatCSV)
+	}
+
+	opts.batchSize, _ = flagset.GetInt("batch-size")
+	if opts.batchSize < 1 || opts.batchSize > maxImportBatchSize {
+		return opts, fmt.Errorf(`invalid flag "batch-size": must be between 1 and %d`, maxImportBatchSize)
+	}
+
+	opts.progressPath, _ = flagset.GetString("progress-file")
+	if opts.progressPath == "" {
+		opts.progressPath = opts.filePath + ".progress"
+	}
+
+	return opts, nil
+}
+
+func executeImport(conf *Configuration, flagset *flag.FlagSet, log *zap.Logger) (code int) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	slog := log.Sugar()
+	slog.Infof("%s %s started", appName, commandNameImport)
+	defer func() {
+		if code == exitCodeOK {
+			slog.Infof("%s %s finished", appName, commandNameImport)
+		} else {
+			slog.Errorf("%s %s failed", appName, commandNameImport)
+		}
+	}()
+
+	opts, err := parseImportOptions(flagset)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	db, err := connectPersistentDatabase(ctx, conf.Store, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer db.Close()
+
+	imp := &importer{
+		store:        sql.NewStore(db),
+		log:          log,
+		restrictions: conf.Settings.Records.Restrictions,
+		opts:         opts,
+		now:          time.Now,
+	}
+
+	stats, err := imp.run(ctx)
+
+	log.Info("Import summary",
+		zap.Int("records_read", stats.read),
+		zap.Int("records_created", stats.created),
+		zap.Int("records_duplicate", stats.duplicate),
+		zap.Int("records_invalid", stats.invalid),
+	)
+
+	if errors.Is(err, aud.ErrProjectNotFound) {
+		log.Error("Project not found", zap.String("project_id", opts.projectID.String()))
+		return exitCodeStartFailure
+	}
+	if err != nil {
+		log.Error("Failed to import records. Run the same command to resume.", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	if stats.invalid > 0 {
+		log.Error("Some records are invalid and were not imported, see errors above")
+		return exitCodeRunFailure
+	}
+
+	return exitCodeOK
+}
+
+type importStats struct {
+	read      int
+	created   int
+	duplicate int
+	invalid   int
+}
+
+type importer struct {
+	store        *sql.Store
+	log          *zap.Logger
+	restrictions aud.RecordsRestrictions
+	opts         importOptions
+	now          func() time.Time
+}
+
+func (imp *importer) run(ctx context.Context) (stats importStats, err error) {
+	progress, err := loadImportProgress(imp.opts.progressPath)
+	if err != nil {
+		return stats, fmt.Errorf("load progress: %v", err)
+	}
+	if progress.Entries > 0 {
+		if progress.ProjectID != imp.opts.projectID.String() || progress.File != imp.opts.filePath {
+			return stats, fmt.Errorf(
+				"progress file %s belongs to another import of %s into project %s, remove it to start over",
+				imp.opts.progressPath,
+				progress.File,
+				progress.ProjectID,
+			)
+		}
+
+		imp.log.Info("Resuming import",
+			zap.String("progress_file", imp.opts.progressPath),
+			zap.Int("skip_entries", progress.Entries),
+		)
+	}
+
+	project, err := imp.store.GetProject(ctx, imp.opts.projectID)
+	if err != nil {
+		return stats, fmt.Errorf("get project: %w", err)
+	}
+	imp.restrictions = imp.restrictions.Merge(project.RecordsRestrictions)
+
+	f, err := os.Open(imp.opts.filePath)
+	if err != nil {
+		return stats, fmt.Errorf("open file: %v", err)
+	}
+	defer f.Close()
+
+	var source importSource
+	switch imp.opts.format {
+	case importFormatCSV:
+		source, err = newImportCSVSource(f)
+		if err != nil {
+			return stats, err
+		}
+	default:
+		source = newImportNDJSONSource(f)
+	}
+
+	skip := progress.Entries
+	entries := 0
+	line := 0
+	batch := make([]aud.Record, 0, imp.opts.batchSize)
+
+	flush := func() error {
+		if len(batch) > 0 {
+			created, err := imp.store.ImportRecords(ctx, batch)
+			if err != nil {
+				return fmt.Errorf("import records ending at line %d: %w", line, err)
+			}
+
+			stats.created += created
+			stats.duplicate += len(batch) - created
+			batch = batch[:0]
+		}
+
+		progress := importProgress{
+			ProjectID: imp.opts.projectID.String(),
+			File:      imp.opts.filePath,
+			Entries:   entries,
+		}
+		if err := saveImportProgress(imp.opts.progressPath, progress); err != nil {
+			return fmt.Errorf("save progress: %v", err)
+		}
+
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		entry, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read file: %v", err)
+		}
+
+		entries++
+		line = entry.line
+		if entries <= skip {
+			continue
+		}
+		stats.read++
+
+		record, err := imp.decode(entry)
+		if err != nil {
+			stats.invalid++
+			imp.log.Warn("Skip invalid record",
+				zap.Int("line", entry.line),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		batch = append(batch, record)
+		if len(batch) == imp.opts.batchSize {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+
+			imp.log.Info("Imported records",
+				zap.Int("line", line),
+				zap.Int("records_created", stats.created),
+			)
+		}
+	}
+
+	if err := flush(); err != nil {
+		return stats, err
+	}
+
+	if err := os.Remove(imp.opts.progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		imp.log.Warn("Failed to remove progress file", zap.Error(err))
+	}
+
+	return stats, nil
+}
+
+func (imp *importer) decode(entry importEntry) (aud.Record, error) {
+	if entry.err != nil {
+		return aud.Record{}, entry.err
+	}
+
+	entry.record.ProjectId = imp.opts.projectID.String()
+
+	record, err := auditumv1alpha1.DecodeImportRecord(entry.record, imp.restrictions)
+	if err != nil {
+		return aud.Record{}, err
+	}
+
+	if record.ID.IsEmpty() {
+		// The id is derived from the file and the line, so that records
+		// imported before an interruption but after the last saved progress
+		// are skipped as existing when the import is resumed.
+		record.ID = aud.NewKeyedID(
+			imp.opts.projectID,
+			fmt.Sprintf("import:%s:%d", imp.opts.filePath, entry.line),
+		)
+	}
+	if record.CreateTime.IsZero() {
+		record.CreateTime = imp.now().UTC()
+	}
+
+	return record, nil
+}
+
+// importEntry is a single record read from the file. If the record cannot
+// be parsed, err describes the problem.
+type importEntry struct {
+	line   int
+	record *auditumv1alpha1pb.Record
+	err    error
+}
+
+type importSource interface {
+	// Next returns the next entry. Returns io.EOF at the end of file.
+	Next() (importEntry, error)
+}
+
+type importNDJSONSource struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newImportNDJSONSource(r io.Reader) *importNDJSONSource {
+	return &importNDJSONSource{r: bufio.NewReader(r)}
+}
+
+func (s *importNDJSONSource) Next() (importEntry, error) {
+	for {
+		b, err := s.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return importEntry{}, err
+		}
+		s.line++
+
+		b = bytes.TrimSpace(b)
+		if len(b) == 0 {
+			continue
+		}
+
+		entry := importEntry{
+			line:   s.line,
+			record: &auditumv1alpha1pb.Record{},
+		}
+		if err := protojson.Unmarshal(b, entry.record); err != nil {
+			entry.err = fmt.Errorf("invalid json: %v", err)
+		}
+
+		return entry, nil
+	}
+}
+
+type importCSVSource struct {
+	r       *csv.Reader
+	columns []string
+}
+
+func newImportCSVSource(r io.Reader) (*importCSVSource, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %v", err)
+	}
+
+	if err := auditumv1alpha1.ValidateRecordCSVColumns(header); err != nil {
+		return nil, fmt.Errorf("invalid csv header: %v", err)
+	}
+
+	return &importCSVSource{
+		r:       cr,
+		columns: header,
+	}, nil
+}
+
+func (s *importCSVSource) Next() (importEntry, error) {
+	row, err := s.r.Read()
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return importEntry{
+			line: parseErr.StartLine,
+			err:  fmt.Errorf("invalid csv: %v", parseErr.Err),
+		}, nil
+	}
+	if err != nil {
+		return importEntry{}, err
+	}
+
+	line, _ := s.r.FieldPos(0)
+
+	entry := importEntry{line: line}
+	entry.record, entry.err = auditumv1alpha1.DecodeRecordCSV(s.columns, row)
+
+	return entry, nil
+}
+
+// importProgress is saved after every written batch to resume the import.
+type importProgress struct {
+	ProjectID string `json:"project_id"`
+	File      string `json:"file"`
+	// Entries is the number of entries of the file that have been processed.
+	Entries int `json:"entries"`
+}
+
+func loadImportProgress(fpath string) (importProgress, error) {
+	var progress importProgress
+
+	b, err := os.ReadFile(fpath)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(b, &progress); err != nil {
+		return progress, fmt.Errorf("invalid progress file %s: %v", fpath, err)
+	}
+
+	return progress, nil
+}
+
+func saveImportProgress(fpath string, progress importProgress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that progress is never corrupted.
+	tmp := fpath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, fpath)
+}
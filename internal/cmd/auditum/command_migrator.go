@@ -16,7 +16,12 @@ package auditum
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
 
+	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
 
 	"github.com/auditumio/auditum/internal/sql/postgres"
@@ -24,7 +29,71 @@ import (
 	"github.com/auditumio/auditum/pkg/fragma/bunx"
 )
 
-func executeMigrator(conf *Configuration, log *zap.Logger) (code int) {
+const (
+	migratorActionUp     = "up"
+	migratorActionStatus = "status"
+	migratorActionDown   = "down"
+	migratorActionGoto   = "goto"
+	migratorActionForce  = "force"
+)
+
+func addMigratorFlags(flagset *flag.FlagSet) {
+	flagset.Bool("dry-run", false, "Print SQL of migrations to run instead of running them.")
+}
+
+type migratorOptions struct {
+	action string
+	// arg is the number of migrations for down, or the version for goto and
+	// force.
+	arg    int64
+	dryRun bool
+}
+
+func parseMigratorOptions(flagset *flag.FlagSet) (opts migratorOptions, err error) {
+	args := flagset.Args()[1:]
+
+	opts.action = migratorActionUp
+	if len(args) > 0 {
+		opts.action = args[0]
+		args = args[1:]
+	}
+
+	opts.dryRun, _ = flagset.GetBool("dry-run")
+
+	switch opts.action {
+	case migratorActionUp, migratorActionStatus:
+		if len(args) != 0 {
+			return opts, fmt.Errorf("unexpected arguments: %v", args)
+		}
+	case migratorActionDown, migratorActionGoto, migratorActionForce:
+		if len(args) != 1 {
+			return opts, fmt.Errorf("expected exactly one argument for %q", opts.action)
+		}
+		opts.arg, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid argument for %q: must be integer", opts.action)
+		}
+	default:
+		return opts, fmt.Errorf("unknown action %q", opts.action)
+	}
+
+	switch {
+	case opts.action == migratorActionDown && opts.arg < 1:
+		return opts, fmt.Errorf("invalid argument for %q: must be positive", opts.action)
+	case opts.action == migratorActionGoto && opts.arg < 0:
+		return opts, fmt.Errorf("invalid argument for %q: must not be negative", opts.action)
+	case opts.action == migratorActionForce && opts.arg < -1:
+		return opts, fmt.Errorf("invalid argument for %q: must be at least -1", opts.action)
+	}
+
+	if opts.dryRun && (opts.action == migratorActionStatus || opts.action == migratorActionForce) {
+		return opts, fmt.Errorf("flag \"dry-run\" is not supported for %q", opts.action)
+	}
+
+	return opts, nil
+}
+
+func executeMigrator(conf *Configuration, flagset *flag.FlagSet, log *zap.Logger) (code int) {
 	ctx := context.Background()
 
 	slog := log.Sugar()
@@ -37,73 +106,156 @@ func executeMigrator(conf *Configuration, log *zap.Logger) (code int) {
 		}
 	}()
 
-	switch conf.Store.Type {
-	case storeTypeSQLite:
-		return migrateSQLite(ctx, conf, log)
-	case storeTypePostgres:
-		return migratePostgres(ctx, conf, log)
-	default:
-		log.Panic("Unreachable code: invalid store type", zap.String("store_type", conf.Store.Type))
+	opts, err := parseMigratorOptions(flagset)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
 		return exitCodeStartFailure
 	}
-}
 
-func migrateSQLite(ctx context.Context, conf *Configuration, log *zap.Logger) int {
-	if conf.Store.SQLite.DatabasePath == sqlite.FilepathMemory {
+	if conf.Store.Type == storeTypeSQLite && conf.Store.SQLite.DatabasePath == sqlite.FilepathMemory {
 		log.Info("Migrations for in-memory SQLite database are run automatically on server startup." +
 			" The migrator command is no-op.")
 		return exitCodeOK
 	}
 
-	db, err := sqlite.NewDatabase(
-		ctx,
-		conf.Store.SQLite.DatabasePath,
-		log,
-		bunx.LogQueriesFlagFromBool(conf.Store.SQLite.LogQueries),
-	)
+	m, err := newMigrator(ctx, conf.Store, log)
 	if err != nil {
-		log.Error("Failed to connect to database", zap.Error(err))
+		log.Error("Failed to initialize migrator", zap.Error(err))
 		return exitCodeStartFailure
 	}
+	defer m.Close()
 
-	if err := sqlite.RunMigrations(
-		db,
-		conf.Store.SQLite.DatabasePath,
-		conf.Store.SQLite.MigrationsPath,
-		log,
-	); err != nil {
-		log.Error("Failed to run migrations", zap.Error(err))
+	if err := runMigrator(m, opts, os.Stdout); err != nil {
+		log.Error("Failed to run migrator", zap.String("action", opts.action), zap.Error(err))
 		return exitCodeRunFailure
 	}
 
 	return exitCodeOK
 }
 
-func migratePostgres(ctx context.Context, conf *Configuration, log *zap.Logger) int {
-	db, err := postgres.NewDatabase(
-		ctx,
-		conf.Store.Postgres.Host,
-		conf.Store.Postgres.Port,
-		conf.Store.Postgres.Database,
-		conf.Store.Postgres.Username,
-		conf.Store.Postgres.Password,
-		conf.Store.Postgres.SSLMode,
-		log,
-		bunx.LogQueriesFlagFromBool(conf.Store.Postgres.LogQueries),
-	)
+func runMigrator(m *bunx.Migrator, opts migratorOptions, out io.Writer) error {
+	switch opts.action {
+	case migratorActionStatus:
+		status, err := m.Status()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(out, status)
+		return nil
+	case migratorActionForce:
+		return m.Force(int(opts.arg))
+	}
+
+	if opts.dryRun {
+		var planned []bunx.PlannedMigration
+		var err error
+		switch opts.action {
+		case migratorActionDown:
+			planned, err = m.PlanDown(int(opts.arg))
+		case migratorActionGoto:
+			planned, err = m.PlanGoto(uint(opts.arg))
+		default:
+			planned, err = m.PlanUp()
+		}
+		if err != nil {
+			return err
+		}
+		printPlannedMigrations(out, planned)
+		return nil
+	}
+
+	switch opts.action {
+	case migratorActionDown:
+		return m.Down(int(opts.arg))
+	case migratorActionGoto:
+		return m.Goto(uint(opts.arg))
+	default:
+		return m.Up()
+	}
+}
+
+func printMigrationStatus(out io.Writer, status bunx.MigrationStatus) {
+	if status.Version == 0 {
+		fmt.Fprintln(out, "Version: none")
+	} else {
+		fmt.Fprintf(out, "Version: %d\n", status.Version)
+	}
+	fmt.Fprintf(out, "Dirty: %t\n", status.Dirty)
+	fmt.Fprintf(out, "Pending: %d\n", len(status.Pending))
+	for _, migration := range status.Pending {
+		fmt.Fprintf(out, "  %d %s\n", migration.Version, migration.Identifier)
+	}
+}
+
+func printPlannedMigrations(out io.Writer, planned []bunx.PlannedMigration) {
+	if len(planned) == 0 {
+		fmt.Fprintln(out, "-- No migrations to apply")
+		return
+	}
+
+	for _, migration := range planned {
+		fmt.Fprintf(out, "-- %d %s (%s)\n", migration.Version, migration.Identifier, migration.Direction)
+		fmt.Fprintln(out, migration.SQL)
+	}
+}
+
+// newMigrator connects to the database of the configured store and returns
+// its migrator. Closing the migrator closes the connection.
+func newMigrator(ctx context.Context, conf StoreConfig, log *zap.Logger) (*bunx.Migrator, error) {
+	db, err := connectDatabase(ctx, conf, log)
 	if err != nil {
-		log.Error("Failed to connect to database", zap.Error(err))
-		return exitCodeStartFailure
+		return nil, fmt.Errorf("connect to database: %v", err)
 	}
 
-	if err := postgres.RunMigrations(
-		db,
-		conf.Store.Postgres.MigrationsPath,
-		log,
-	); err != nil {
-		log.Error("Failed to run migrations", zap.Error(err))
-		return exitCodeRunFailure
+	var m *bunx.Migrator
+	switch conf.Type {
+	case storeTypeSQLite:
+		m, err = sqlite.NewMigrator(db, conf.SQLite.DatabasePath, conf.SQLite.MigrationsPath, log)
+	case storeTypePostgres:
+		m, err = postgres.NewMigrator(db, conf.Postgres.MigrationsPath, log)
+	default:
+		err = fmt.Errorf("unknown store type: %s", conf.Type)
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, err
 	}
 
-	return exitCodeOK
+	return m, nil
+}
+
+// checkMigrations checks that the database has all migrations applied and
+// is not in a dirty state.
+func checkMigrations(ctx context.Context, conf StoreConfig, log *zap.Logger) error {
+	m, err := newMigrator(ctx, conf, log)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		return fmt.Errorf(
+			"database is dirty at version %d, fix it manually and run `%s %s %s <version>`",
+			status.Version,
+			appName,
+			commandNameMigrator,
+			migratorActionForce,
+		)
+	}
+
+	if len(status.Pending) > 0 {
+		return fmt.Errorf(
+			"database has %d pending migrations, run `%s %s`",
+			len(status.Pending),
+			appName,
+			commandNameMigrator,
+		)
+	}
+
+	return nil
 }
@@ -0,0 +1,148 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration && !postgres && sqlite
+
+package auditum
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/sql/sqlite"
+	"github.com/auditumio/auditum/pkg/fragma/bunx"
+)
+
+func TestIntegration_CheckMigrations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conf := newTestSQLiteStoreConfig(t)
+	versions := sqliteMigrationVersions(t)
+	latest := versions[len(versions)-1]
+
+	t.Run("Should refuse to start server with pending migrations", func(t *testing.T) {
+		err := checkMigrations(ctx, conf, zap.NewNop())
+		assert.ErrorContains(t, err, fmt.Sprintf("database has %d pending migrations", len(versions)))
+	})
+
+	t.Run("Should print migrations without applying them on dry run", func(t *testing.T) {
+		out := runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionUp, dryRun: true})
+		assert.Equal(t, len(versions), strings.Count(out, "(up)\n"))
+		assert.Contains(t, out, fmt.Sprintf("-- %d ", versions[0]))
+
+		out = runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionStatus})
+		assert.Contains(t, out, "Version: none\n")
+		assert.Contains(t, out, fmt.Sprintf("Pending: %d\n", len(versions)))
+	})
+
+	t.Run("Should start server with all migrations applied", func(t *testing.T) {
+		runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionUp})
+
+		assert.NoError(t, checkMigrations(ctx, conf, zap.NewNop()))
+	})
+
+	t.Run("Should refuse to start server after reverting migration", func(t *testing.T) {
+		runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionDown, arg: 1})
+
+		err := checkMigrations(ctx, conf, zap.NewNop())
+		assert.ErrorContains(t, err, "database has 1 pending migrations")
+
+		runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionGoto, arg: int64(latest)})
+	})
+
+	t.Run("Should refuse to start server with dirty database", func(t *testing.T) {
+		setTestMigrationDirty(ctx, t, conf)
+
+		err := checkMigrations(ctx, conf, zap.NewNop())
+		assert.ErrorContains(t, err, fmt.Sprintf("database is dirty at version %d", latest))
+
+		runTestMigrator(ctx, t, conf, migratorOptions{action: migratorActionForce, arg: int64(latest)})
+
+		assert.NoError(t, checkMigrations(ctx, conf, zap.NewNop()))
+	})
+}
+
+func newTestSQLiteStoreConfig(t *testing.T) StoreConfig {
+	t.Helper()
+
+	return StoreConfig{
+		Type: storeTypeSQLite,
+		SQLite: SQLiteConfig{
+			DatabasePath: filepath.Join(t.TempDir(), "auditum.db"),
+		},
+	}
+}
+
+// sqliteMigrationVersions returns versions of SQLite migrations in
+// ascending order.
+func sqliteMigrationVersions(t *testing.T) []uint {
+	t.Helper()
+
+	entries, err := os.ReadDir("../../sql/sqlite/migrations")
+	require.NoError(t, err)
+
+	var versions []uint
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".up.sql")
+		if !ok {
+			continue
+		}
+		version, _, _ := strings.Cut(name, "_")
+		v, err := strconv.ParseUint(version, 10, 64)
+		require.NoError(t, err)
+		versions = append(versions, uint(v))
+	}
+	require.NotEmpty(t, versions)
+
+	return versions
+}
+
+func runTestMigrator(ctx context.Context, t *testing.T, conf StoreConfig, opts migratorOptions) string {
+	t.Helper()
+
+	m, err := newMigrator(ctx, conf, zap.NewNop())
+	require.NoError(t, err)
+	defer m.Close()
+
+	var out bytes.Buffer
+	err = runMigrator(m, opts, &out)
+	require.NoError(t, err)
+
+	return out.String()
+}
+
+// setTestMigrationDirty marks the database dirty, as if the last migration
+// failed.
+func setTestMigrationDirty(ctx context.Context, t *testing.T, conf StoreConfig) {
+	t.Helper()
+
+	db, err := sqlite.NewDatabase(ctx, conf.SQLite.DatabasePath, zap.NewNop(), bunx.LogQueriesDisabled)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = TRUE")
+	require.NoError(t, err)
+}
@@ -0,0 +1,404 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/backup"
+	"github.com/auditumio/auditum/internal/sql"
+)
+
+const defaultRestoreBatchSize = 1000
+
+func addRestoreFlags(flagset *flag.FlagSet) {
+	flagset.Int("batch-size", defaultRestoreBatchSize, "Number of records to write in a single transaction.")
+}
+
+func executeRestore(conf *Configuration, flagset *flag.FlagSet, log *zap.Logger) (code int) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	slog := log.Sugar()
+	slog.Infof("%s %s started", appName, commandNameRestore)
+	defer func() {
+		if code == exitCodeOK {
+			slog.Infof("%s %s finished", appName, commandNameRestore)
+		} else {
+			slog.Errorf("%s %s failed", appName, commandNameRestore)
+		}
+	}()
+
+	fpath, err := parseBackupFileArg(flagset, commandNameRestore)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	batchSize, _ := flagset.GetInt("batch-size")
+	if batchSize < 1 || batchSize > maxImportBatchSize {
+		log.Error("Invalid command line arguments", zap.Error(
+			fmt.Errorf(`invalid flag "batch-size": must be between 1 and %d`, maxImportBatchSize),
+		))
+		return exitCodeStartFailure
+	}
+
+	r, err := backup.OpenReader(fpath)
+	if err != nil {
+		log.Error("Failed to open archive", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer r.Close()
+
+	// Verify the whole archive before writing anything to the database.
+	if err := r.Verify(); err != nil {
+		log.Error("Failed to verify archive", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	db, err := connectPersistentDatabase(ctx, conf.Store, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer db.Close()
+
+	rs := &restorer{
+		store:     sql.NewStore(db),
+		reader:    r,
+		log:       log,
+		batchSize: batchSize,
+	}
+
+	stats, err := rs.run(ctx)
+
+	log.Info("Restore summary",
+		zap.Int("projects_created", stats.projectsCreated),
+		zap.Int("projects_existing", stats.projectsExisting),
+		zap.Int("records_created", stats.recordsCreated),
+		zap.Int("records_existing", stats.recordsExisting),
+		zap.Int("api_keys_created", stats.apiKeysCreated),
+		zap.Int("role_bindings_created", stats.roleBindingsCreated),
+		zap.Int("webhooks_created", stats.webhooksCreated),
+		zap.Int("alert_rules_created", stats.alertRulesCreated),
+		zap.Int("settings_existing", stats.settingsExisting),
+	)
+
+	if err != nil {
+		log.Error("Failed to restore. Run the same command to resume.", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	return exitCodeOK
+}
+
+type restoreStats struct {
+	projectsCreated  int
+	projectsExisting int
+	recordsCreated   int
+	recordsExisting  int
+	settingsStats
+}
+
+// restorer restores the archive into the store. Projects, records and
+// settings that already exist are skipped, so restore can be safely
+// repeated.
+type restorer struct {
+	store     *sql.Store
+	reader    *backup.Reader
+	log       *zap.Logger
+	batchSize int
+}
+
+func (rs *restorer) run(ctx context.Context) (stats restoreStats, err error) {
+	projects, err := rs.reader.Projects()
+	if err != nil {
+		return stats, err
+	}
+
+	manifest := rs.reader.Manifest()
+
+	settings, err := rs.readSettings()
+	if err != nil {
+		return stats, err
+	}
+
+	apiKeys, err := rs.reader.APIKeys()
+	if err != nil {
+		return stats, err
+	}
+	if err := createAPIKeys(ctx, rs.store, apiKeys, &stats.settingsStats); err != nil {
+		return stats, fmt.Errorf("restore api keys: %w", err)
+	}
+
+	for i, project := range projects {
+		created, err := createProjectIfNotExists(ctx, rs.store, project)
+		if err != nil {
+			return stats, fmt.Errorf("restore project %s: %w", project.ID.String(), err)
+		}
+		if created {
+			stats.projectsCreated++
+		} else {
+			stats.projectsExisting++
+			rs.log.Info("Project already exists, restore its records only",
+				zap.String("project_id", project.ID.String()),
+			)
+		}
+
+		records, err := rs.restoreRecords(ctx, project.ID, &stats)
+		if err != nil {
+			return stats, fmt.Errorf("restore records of project %s: %w", project.ID.String(), err)
+		}
+
+		if want := manifest.Projects[i].Records; records != want {
+			return stats, fmt.Errorf(
+				"restore records of project %s: expected %d records, got %d",
+				project.ID.String(),
+				want,
+				records,
+			)
+		}
+
+		if err := createProjectSettings(ctx, rs.store, settings[project.ID], &stats.settingsStats); err != nil {
+			return stats, fmt.Errorf("restore settings of project %s: %w", project.ID.String(), err)
+		}
+
+		rs.log.Info("Restored project",
+			zap.String("project_id", project.ID.String()),
+			zap.Int("records", records),
+		)
+	}
+
+	return stats, nil
+}
+
+// readSettings reads settings of all projects in the archive, grouped by
+// project.
+func (rs *restorer) readSettings() (map[aud.ID]projectSettings, error) {
+	settings := make(map[aud.ID]projectSettings)
+
+	roleBindings, err := rs.reader.RoleBindings()
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range roleBindings {
+		ps := settings[binding.ProjectID]
+		ps.roleBindings = append(ps.roleBindings, binding)
+		settings[binding.ProjectID] = ps
+	}
+
+	webhooks, err := rs.reader.Webhooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		ps := settings[webhook.ProjectID]
+		ps.webhooks = append(ps.webhooks, webhook)
+		settings[webhook.ProjectID] = ps
+	}
+
+	alertRules, err := rs.reader.AlertRules()
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range alertRules {
+		ps := settings[rule.ProjectID]
+		ps.alertRules = append(ps.alertRules, rule)
+		settings[rule.ProjectID] = ps
+	}
+
+	return settings, nil
+}
+
+func (rs *restorer) restoreRecords(ctx context.Context, projectID aud.ID, stats *restoreStats) (int, error) {
+	var read int
+	batch := newRecordsBatch(rs.store, rs.batchSize)
+
+	err := rs.reader.ReadRecords(projectID, func(record aud.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		read++
+		return batch.Add(ctx, record)
+	})
+	if err == nil {
+		err = batch.Flush(ctx)
+	}
+
+	stats.recordsCreated += batch.created
+	stats.recordsExisting += batch.existing
+
+	return read, err
+}
+
+// createProjectIfNotExists creates the project keeping its id, unless the
+// project already exists.
+func createProjectIfNotExists(ctx context.Context, store *sql.Store, project aud.Project) (created bool, err error) {
+	_, err = store.GetProject(ctx, project.ID)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, aud.ErrProjectNotFound) {
+		return false, err
+	}
+
+	if err := store.CreateProject(ctx, project); err != nil {
+		if errors.Is(err, aud.ErrConflict) {
+			return false, fmt.Errorf("another project has the same external id %q", project.ExternalID)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recordsBatch imports records into the store in batches. Records that
+// already exist are skipped.
+type recordsBatch struct {
+	store    *sql.Store
+	records  []aud.Record
+	created  int
+	existing int
+}
+
+func newRecordsBatch(store *sql.Store, size int) *recordsBatch {
+	return &recordsBatch{
+		store:   store,
+		records: make([]aud.Record, 0, size),
+	}
+}
+
+// Add adds the record to the batch, importing the batch when it is full.
+func (b *recordsBatch) Add(ctx context.Context, record aud.Record) error {
+	b.records = append(b.records, record)
+	if len(b.records) == cap(b.records) {
+		return b.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush imports the records added to the batch.
+func (b *recordsBatch) Flush(ctx context.Context) error {
+	if len(b.records) == 0 {
+		return nil
+	}
+
+	created, err := b.store.ImportRecords(ctx, b.records)
+	if err != nil {
+		return err
+	}
+
+	b.created += created
+	b.existing += len(b.records) - created
+	b.records = b.records[:0]
+
+	return nil
+}
+
+// settingsStats counts API keys and project settings that are created or
+// already exist.
+type settingsStats struct {
+	apiKeysCreated      int
+	roleBindingsCreated int
+	webhooksCreated     int
+	alertRulesCreated   int
+	settingsExisting    int
+}
+
+// createAPIKeys creates the API keys keeping their ids and hashes. Keys
+// with a hash that already exists are skipped.
+func createAPIKeys(ctx context.Context, store *sql.Store, keys []aud.APIKey, stats *settingsStats) error {
+	for _, key := range keys {
+		_, err := store.GetAPIKeyByHash(ctx, key.KeyHash)
+		if err == nil {
+			stats.settingsExisting++
+			continue
+		}
+		if !errors.Is(err, aud.ErrAPIKeyNotFound) {
+			return fmt.Errorf("get api key %s: %w", key.ID.String(), err)
+		}
+
+		if err := store.CreateAPIKey(ctx, key); err != nil {
+			return fmt.Errorf("create api key %s: %w", key.ID.String(), err)
+		}
+		stats.apiKeysCreated++
+	}
+
+	return nil
+}
+
+// createProjectSettings creates settings of a project keeping their ids.
+// Settings that already exist are skipped, in particular the role of an
+// existing role binding is not changed.
+func createProjectSettings(ctx context.Context, store *sql.Store, ps projectSettings, stats *settingsStats) error {
+	for _, binding := range ps.roleBindings {
+		_, err := store.GetRoleBinding(ctx, binding.ProjectID, binding.PrincipalID)
+		if err == nil {
+			stats.settingsExisting++
+			continue
+		}
+		if !errors.Is(err, aud.ErrRoleBindingNotFound) {
+			return fmt.Errorf("get role binding of %s: %w", binding.PrincipalID, err)
+		}
+
+		if _, err := store.SetRoleBinding(ctx, binding); err != nil {
+			return fmt.Errorf("create role binding of %s: %w", binding.PrincipalID, err)
+		}
+		stats.roleBindingsCreated++
+	}
+
+	for _, webhook := range ps.webhooks {
+		_, err := store.GetWebhook(ctx, webhook.ProjectID, webhook.ID)
+		if err == nil {
+			stats.settingsExisting++
+			continue
+		}
+		if !errors.Is(err, aud.ErrWebhookNotFound) {
+			return fmt.Errorf("get webhook %s: %w", webhook.ID.String(), err)
+		}
+
+		if err := store.CreateWebhook(ctx, webhook); err != nil {
+			return fmt.Errorf("create webhook %s: %w", webhook.ID.String(), err)
+		}
+		stats.webhooksCreated++
+	}
+
+	for _, rule := range ps.alertRules {
+		_, err := store.GetAlertRule(ctx, rule.ProjectID, rule.ID)
+		if err == nil {
+			stats.settingsExisting++
+			continue
+		}
+		if !errors.Is(err, aud.ErrAlertRuleNotFound) {
+			return fmt.Errorf("get alert rule %s: %w", rule.ID.String(), err)
+		}
+
+		if err := store.CreateAlertRule(ctx, rule); err != nil {
+			return fmt.Errorf("create alert rule %s: %w", rule.ID.String(), err)
+		}
+		stats.alertRulesCreated++
+	}
+
+	return nil
+}
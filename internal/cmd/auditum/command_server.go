@@ -16,23 +16,35 @@ package auditum
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"maps"
 	"os/signal"
 	"syscall"
 
-	"github.com/uptrace/bun"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	auditumv1alpha1pb "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
 	auditumv1alpha1 "github.com/auditumio/auditum/internal/api/auditumio/auditum/v1alpha1"
 	healthv1 "github.com/auditumio/auditum/internal/api/health/v1"
+	logsv1 "github.com/auditumio/auditum/internal/api/opentelemetry/collector/logs/v1"
 	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+	"github.com/auditumio/auditum/internal/cloudevents"
 	"github.com/auditumio/auditum/internal/grpcgateway"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/kubeaudit"
+	"github.com/auditumio/auditum/internal/metrics"
+	"github.com/auditumio/auditum/internal/ratelimit"
 	"github.com/auditumio/auditum/internal/sql"
-	"github.com/auditumio/auditum/internal/sql/postgres"
 	"github.com/auditumio/auditum/internal/sql/sqlite"
-	"github.com/auditumio/auditum/pkg/fragma/bunx"
+	"github.com/auditumio/auditum/internal/syslog"
+	"github.com/auditumio/auditum/internal/webhook"
 	"github.com/auditumio/auditum/pkg/fragma/grpcx"
 	"github.com/auditumio/auditum/pkg/fragma/httpx"
 	"github.com/auditumio/auditum/pkg/fragma/otelx"
+	"github.com/auditumio/auditum/pkg/fragma/tlsx"
 	"github.com/auditumio/auditum/pkg/fragma/uds"
 )
 
@@ -55,57 +67,50 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 		Records: conf.Settings.Records,
 	}
 
-	var db *bun.DB
-	switch conf.Store.Type {
-	case storeTypeSQLite:
-		var err error
-		db, err = sqlite.NewDatabase(
-			ctx,
-			conf.Store.SQLite.DatabasePath,
-			log,
-			bunx.LogQueriesFlagFromBool(conf.Store.SQLite.LogQueries),
-		)
-		if err != nil {
-			log.Error("Failed to connect to database", zap.Error(err))
-			return exitCodeStartFailure
-		}
+	db, err := connectDatabase(ctx, conf.Store, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return exitCodeStartFailure
+	}
 
-		if conf.Store.SQLite.DatabasePath == sqlite.FilepathMemory {
-			log.Warn("Using in-memory SQLite database. All data will be lost on shutdown.")
+	if conf.Store.Type == storeTypeSQLite && conf.Store.SQLite.DatabasePath == sqlite.FilepathMemory {
+		log.Warn("Using in-memory SQLite database. All data will be lost on shutdown.")
 
-			if err := sqlite.RunMigrations(
-				db,
-				conf.Store.SQLite.DatabasePath,
-				conf.Store.SQLite.MigrationsPath,
-				log,
-			); err != nil {
-				log.Error("Failed to run migrations", zap.Error(err))
-				return exitCodeStartFailure
-			}
-		}
-	case storeTypePostgres:
-		var err error
-		db, err = postgres.NewDatabase(
-			ctx,
-			conf.Store.Postgres.Host,
-			conf.Store.Postgres.Port,
-			conf.Store.Postgres.Database,
-			conf.Store.Postgres.Username,
-			conf.Store.Postgres.Password,
-			conf.Store.Postgres.SSLMode,
+		if err := sqlite.RunMigrations(
+			db,
+			conf.Store.SQLite.DatabasePath,
+			conf.Store.SQLite.MigrationsPath,
 			log,
-			bunx.LogQueriesFlagFromBool(conf.Store.Postgres.LogQueries),
-		)
-		if err != nil {
-			log.Error("Failed to connect to database", zap.Error(err))
+		); err != nil {
+			log.Error("Failed to run migrations", zap.Error(err))
 			return exitCodeStartFailure
 		}
-	default:
-		log.Panic("Unreachable code: invalid store type", zap.String("store_type", conf.Store.Type))
+	} else if err := checkMigrations(ctx, conf.Store, log); err != nil {
+		log.Error("Failed to check migrations", zap.Error(err))
 		return exitCodeStartFailure
 	}
 
-	store := sql.NewStore(db)
+	store := sql.NewStore(db, sql.WithLogger(log))
+
+	// Watched records are received and expired alert rule states are
+	// deleted until shutdown.
+	recordsCtx, recordsCancel := context.WithCancel(ctx)
+	defer recordsCancel()
+
+	go store.RunRecordsListener(recordsCtx, log)
+	go store.RunAlertRuleStatesCleanup(recordsCtx, log)
+
+	// Webhook deliveries are dispatched until shutdown. Deliveries in
+	// progress are attempted again later.
+	webhooksCtx, webhooksCancel := context.WithCancel(ctx)
+	defer webhooksCancel()
+
+	go webhook.NewDispatcher(
+		store,
+		auditumv1alpha1.MarshalRecordJSON,
+		conf.Webhooks.DispatcherConfig(),
+		log,
+	).Run(webhooksCtx)
 
 	unixSocketAvailable := true
 	if err := uds.IsAvailable(); err != nil {
@@ -126,7 +131,80 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 		}
 	}
 
-	grpcServer := grpcx.NewServer(log)
+	// Background authentication tasks, e.g. JWKS refresh, are stopped on
+	// shutdown.
+	authCtx, authCancel := context.WithCancel(ctx)
+	defer authCancel()
+
+	var grpcServerOpts []grpcx.ServerOption
+	if conf.Auth.Enabled {
+		authenticator, err := initAuthenticator(authCtx, conf.Auth, store, log)
+		if err != nil {
+			log.Error("Failed to initialize authentication", zap.Error(err))
+			return exitCodeStartFailure
+		}
+
+		clientCerts := make([]auth.ClientCertificate, 0, len(conf.Auth.ClientCertificates))
+		for _, cert := range conf.Auth.ClientCertificates {
+			clientCerts = append(clientCerts, cert.ClientCertificate())
+		}
+
+		authRules := auditumv1alpha1.AuthRules()
+		maps.Copy(authRules, logsv1.AuthRules())
+
+		authInterceptor := auth.NewInterceptor(
+			authenticator,
+			authRules,
+			log,
+			auth.InterceptorWithClientCertificates(
+				auth.NewClientCertificateAuthenticator(clientCerts),
+			),
+			auth.InterceptorWithRoleBindings(store),
+		)
+		grpcServerOpts = append(
+			grpcServerOpts,
+			grpcx.ServerWithUnaryInterceptors(authInterceptor.Unary()),
+			grpcx.ServerWithStreamInterceptors(authInterceptor.Stream()),
+		)
+	} else {
+		log.Warn("Authentication is disabled. Anyone who can reach the server has full access.")
+	}
+
+	// Rate limiting follows authentication to limit by principal.
+	if conf.RateLimit.Enabled {
+		rateLimitRules := auditumv1alpha1.RateLimitRules()
+		maps.Copy(rateLimitRules, logsv1.RateLimitRules())
+
+		rateLimitInterceptor := ratelimit.NewInterceptor(
+			conf.RateLimit.InterceptorConfig(),
+			rateLimitRules,
+			log,
+		)
+		grpcServerOpts = append(
+			grpcServerOpts,
+			grpcx.ServerWithUnaryInterceptors(rateLimitInterceptor.Unary()),
+			grpcx.ServerWithStreamInterceptors(rateLimitInterceptor.Stream()),
+		)
+	}
+
+	if conf.GRPC.TLS.Enabled {
+		if !unixSocketAvailable {
+			// gRPC-Gateway connects to gRPC server without TLS over unix
+			// socket only.
+			log.Error("gRPC server TLS requires unix socket to be available")
+			return exitCodeStartFailure
+		}
+
+		tlsConfig, err := tlsx.NewServerTLSConfig(conf.GRPC.TLS.ServerConfig(), log)
+		if err != nil {
+			log.Error("Failed to initialize gRPC server TLS", zap.Error(err))
+			return exitCodeStartFailure
+		}
+
+		grpcServerOpts = append(grpcServerOpts, grpcx.ServerWithTLS(tlsConfig))
+	}
+
+	grpcServer := grpcx.NewServer(log, grpcServerOpts...)
 
 	healthServer := healthv1.NewHealthServer()
 	healthServer.Register(grpcServer)
@@ -144,9 +222,44 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 	)
 	recordServiceServer.RegisterServer(grpcServer)
 
+	apiKeyServiceServer := auditumv1alpha1.NewApiKeyServiceServer(
+		store,
+		log,
+	)
+	apiKeyServiceServer.RegisterServer(grpcServer)
+
+	roleBindingServiceServer := auditumv1alpha1.NewRoleBindingServiceServer(
+		store,
+		log,
+	)
+	roleBindingServiceServer.RegisterServer(grpcServer)
+
+	webhookServiceServer := auditumv1alpha1.NewWebhookServiceServer(
+		store,
+		log,
+	)
+	webhookServiceServer.RegisterServer(grpcServer)
+
+	alertServiceServer := auditumv1alpha1.NewAlertServiceServer(
+		store,
+		log,
+	)
+	alertServiceServer.RegisterServer(grpcServer)
+
+	logsServiceServer := logsv1.NewLogsServiceServer(
+		recordServiceServer,
+		log,
+	)
+	logsServiceServer.RegisterServer(grpcServer)
+
 	// NOTE: must be called after all services are registered.
 	grpcx.InitPrometheusMetrics(grpcServer)
 
+	if err := metrics.Register(prometheus.DefaultRegisterer, store, log); err != nil {
+		log.Error("Failed to register metrics", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
 	grpcServerAddr := ":" + conf.GRPC.Port
 
 	var grpcServerControllerOpts []grpcx.ServerControllerOption
@@ -169,14 +282,47 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 		return exitCodeStartFailure
 	}
 
-	grpcGateway := grpcgateway.NewGateway(
-		log,
+	grpcGatewayOpts := []grpcgateway.GatewayOption{
 		grpcgateway.WithRegistrableServices(
 			"/api/v1alpha1",
 			projectServiceServer,
 			recordServiceServer,
+			apiKeyServiceServer,
+			roleBindingServiceServer,
+			webhookServiceServer,
+			alertServiceServer,
 		),
-	)
+		grpcgateway.WithRegistrableServices(
+			"/otlp",
+			logsServiceServer,
+		),
+	}
+
+	if conf.CloudEvents.Enabled {
+		cloudEventsHandler, err := cloudevents.NewHandler(conf.CloudEvents.HandlerConfig(), log)
+		if err != nil {
+			log.Error("Failed to initialize CloudEvents handler", zap.Error(err))
+			return exitCodeStartFailure
+		}
+		grpcGatewayOpts = append(grpcGatewayOpts, grpcgateway.WithRegistrableServices(
+			"/api/v1alpha1",
+			cloudEventsHandler,
+		))
+	}
+
+	if conf.KubernetesAudit.Enabled {
+		kubeAuditHandler, err := kubeaudit.NewHandler(conf.KubernetesAudit.HandlerConfig(), log)
+		if err != nil {
+			log.Error("Failed to initialize Kubernetes audit handler", zap.Error(err))
+			return exitCodeStartFailure
+		}
+		grpcGatewayOpts = append(grpcGatewayOpts, grpcgateway.WithRegistrableServices(
+			"/api/v1alpha1",
+			kubeAuditHandler,
+		))
+	}
+
+	grpcGateway := grpcgateway.NewGateway(log, grpcGatewayOpts...)
 
 	grpcGatewayUpstreamAddr := grpcServerAddr
 	if unixSocketAvailable {
@@ -191,9 +337,60 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 	httpServerAddr := ":" + conf.HTTP.Port
 	httpserver := httpx.NewServer(httpServerAddr, grpcGateway.Handler(), log)
 
+	if conf.HTTP.TLS.Enabled {
+		httpserver.TLSConfig, err = tlsx.NewServerTLSConfig(
+			conf.HTTP.TLS.ServerConfig("h2", "http/1.1"),
+			log,
+		)
+		if err != nil {
+			log.Error("Failed to initialize HTTP server TLS", zap.Error(err))
+			return exitCodeStartFailure
+		}
+	}
+
 	httpServerController := httpx.NewServerController(httpserver, log)
 	httpServerController.Start()
 
+	var syslogServer *syslog.Server
+	if conf.Syslog.Enabled {
+		var tlsConfig *tls.Config
+		if conf.Syslog.TCP.TLS.Enabled {
+			tlsConfig, err = tlsx.NewServerTLSConfig(conf.Syslog.TCP.TLS.ServerConfig(), log)
+			if err != nil {
+				log.Error("Failed to initialize syslog server TLS", zap.Error(err))
+				return exitCodeStartFailure
+			}
+		}
+
+		// Records are created over a connection to the gRPC server, so
+		// that they are authenticated, authorized and rate limited as of
+		// any other client.
+		syslogConn, err := grpcx.NewClientConnection(
+			grpcGatewayUpstreamAddr,
+			ingest.WithAPIKey(conf.Syslog.APIKey),
+		)
+		if err != nil {
+			log.Error("Failed to connect syslog server to gRPC server", zap.Error(err))
+			return exitCodeStartFailure
+		}
+		defer syslogConn.Close()
+
+		syslogServer, err = syslog.NewServer(
+			conf.Syslog.ServerConfig(tlsConfig),
+			ingest.NewRecordClient(auditumv1alpha1pb.NewRecordServiceClient(syslogConn)),
+			log,
+		)
+		if err != nil {
+			log.Error("Failed to initialize syslog server", zap.Error(err))
+			return exitCodeStartFailure
+		}
+
+		if err := syslogServer.Start(ctx); err != nil {
+			log.Error("Syslog server start error", zap.Error(err))
+			return exitCodeStartFailure
+		}
+	}
+
 	// --- Running phase ---
 
 	slog.Infof("%s %s is started and running", appName, commandNameServer)
@@ -218,6 +415,15 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 
 	slog.Infof("%s %s is stopping...", appName, commandNameServer)
 
+	// Close watches, which otherwise keep servers from stopping gracefully.
+	recordsCancel()
+	webhooksCancel()
+
+	// Received syslog messages are processed before the store is closed.
+	if syslogServer != nil {
+		syslogServer.Stop()
+	}
+
 	if err := httpServerController.Stop(ctx); err != nil {
 		log.Error("HTTP Server stop error", zap.Error(err))
 		exitCode = exitCodeRunFailure
@@ -244,6 +450,43 @@ func executeServer(conf *Configuration, log *zap.Logger) int {
 	return exitCode
 }
 
+func initAuthenticator(
+	ctx context.Context,
+	conf AuthConfig,
+	store *sql.Store,
+	log *zap.Logger,
+) (auth.Authenticator, error) {
+	authenticators := auth.ChainAuthenticator{
+		auth.NewAPIKeyAuthenticator(store),
+	}
+
+	if !conf.JWT.Enabled {
+		return authenticators, nil
+	}
+
+	jwks := auth.NewJWKS(conf.JWT.JWKS, log)
+	if err := jwks.Refresh(ctx); err != nil {
+		return nil, fmt.Errorf("load jwks: %v", err)
+	}
+	go jwks.Run(ctx, conf.JWT.RefreshInterval)
+
+	if conf.JWT.Issuer == "" && len(conf.JWT.Audience) == 0 {
+		log.Warn("JWT issuer and audience are not configured. Any token signed with JWKS keys is accepted.")
+	}
+
+	authenticators = append(authenticators, auth.NewJWTAuthenticator(jwks, auth.JWTConfig{
+		Issuer:           conf.JWT.Issuer,
+		Audience:         conf.JWT.Audience,
+		Algorithms:       conf.JWT.SignatureAlgorithms(),
+		Leeway:           conf.JWT.Leeway,
+		PrincipalClaim:   conf.JWT.Claims.Principal,
+		ProjectsClaim:    conf.JWT.Claims.Projects,
+		PermissionsClaim: conf.JWT.Claims.Permissions,
+	}))
+
+	return authenticators, nil
+}
+
 func initTracing(conf TracingConfig, log *zap.Logger) (*otelx.Provider, error) {
 	otelx.SetupErrorHandler(log)
 
@@ -0,0 +1,308 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/backup"
+	"github.com/auditumio/auditum/internal/sql"
+)
+
+const defaultTransferBatchSize = 1000
+
+func addTransferFlags(flagset *flag.FlagSet) {
+	flagset.String("from-config", "", "Path to config file of the source store.")
+	flagset.String("to-config", "", "Path to config file of the target store.")
+	flagset.Int("batch-size", defaultTransferBatchSize, "Number of records to write in a single transaction.")
+}
+
+type transferOptions struct {
+	from      StoreConfig
+	to        StoreConfig
+	batchSize int
+}
+
+func parseTransferOptions(flagset *flag.FlagSet) (opts transferOptions, err error) {
+	if flagset.NArg() != 1 {
+		return opts, fmt.Errorf("unexpected arguments: %v", flagset.Args()[1:])
+	}
+
+	for _, v := range []struct {
+		flag string
+		dst  *StoreConfig
+	}{
+		{flag: "from-config", dst: &opts.from},
+		{flag: "to-config", dst: &opts.to},
+	} {
+		fpath, _ := flagset.GetString(v.flag)
+		if fpath == "" {
+			return opts, fmt.Errorf("flag %q is required", v.flag)
+		}
+
+		// Environment variables are ignored, as they would apply to both
+		// configurations.
+		conf, err := loadFileConfiguration(fpath)
+		if err != nil {
+			return opts, fmt.Errorf("invalid flag %q: %v", v.flag, err)
+		}
+
+		*v.dst = conf.Store
+	}
+
+	if sameStore(opts.from, opts.to) {
+		return opts, fmt.Errorf("source and target stores must be different")
+	}
+
+	opts.batchSize, _ = flagset.GetInt("batch-size")
+	if opts.batchSize < 1 || opts.batchSize > maxImportBatchSize {
+		return opts, fmt.Errorf(`invalid flag "batch-size": must be between 1 and %d`, maxImportBatchSize)
+	}
+
+	return opts, nil
+}
+
+func sameStore(a, b StoreConfig) bool {
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case storeTypeSQLite:
+		return a.SQLite.DatabasePath == b.SQLite.DatabasePath
+	case storeTypePostgres:
+		return a.Postgres.Host == b.Postgres.Host &&
+			a.Postgres.Port == b.Postgres.Port &&
+			a.Postgres.Database == b.Postgres.Database
+	default:
+		return false
+	}
+}
+
+func executeTransfer(_ *Configuration, flagset *flag.FlagSet, log *zap.Logger) (code int) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	slog := log.Sugar()
+	slog.Infof("%s %s started", appName, commandNameTransfer)
+	defer func() {
+		if code == exitCodeOK {
+			slog.Infof("%s %s finished", appName, commandNameTransfer)
+		} else {
+			slog.Errorf("%s %s failed", appName, commandNameTransfer)
+		}
+	}()
+
+	opts, err := parseTransferOptions(flagset)
+	if err != nil {
+		log.Error("Invalid command line arguments", zap.Error(err))
+		return exitCodeStartFailure
+	}
+
+	fromDB, err := connectPersistentDatabase(ctx, opts.from, log)
+	if err != nil {
+		log.Error("Failed to connect to source database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer fromDB.Close()
+
+	toDB, err := connectPersistentDatabase(ctx, opts.to, log)
+	if err != nil {
+		log.Error("Failed to connect to target database", zap.Error(err))
+		return exitCodeStartFailure
+	}
+	defer toDB.Close()
+
+	tr := &transferer{
+		from:      sql.NewStore(fromDB),
+		to:        sql.NewStore(toDB),
+		log:       log,
+		batchSize: opts.batchSize,
+	}
+
+	stats, err := tr.run(ctx)
+
+	log.Info("Transfer summary",
+		zap.Int("projects_created", stats.projectsCreated),
+		zap.Int("projects_existing", stats.projectsExisting),
+		zap.Int("records_created", stats.recordsCreated),
+		zap.Int("records_existing", stats.recordsExisting),
+		zap.Int("api_keys_created", stats.apiKeysCreated),
+		zap.Int("role_bindings_created", stats.roleBindingsCreated),
+		zap.Int("webhooks_created", stats.webhooksCreated),
+		zap.Int("alert_rules_created", stats.alertRulesCreated),
+		zap.Int("settings_existing", stats.settingsExisting),
+	)
+
+	if err != nil {
+		log.Error("Failed to transfer. Run the same command to resume.", zap.Error(err))
+		return exitCodeRunFailure
+	}
+
+	return exitCodeOK
+}
+
+type transferStats struct {
+	projectsCreated  int
+	projectsExisting int
+	recordsCreated   int
+	recordsExisting  int
+	settingsStats
+}
+
+// transferer copies projects with their records and settings, and API keys
+// from one store to another. Entries that already exist in the target store
+// are skipped, so transfer can be safely repeated to resume.
+type transferer struct {
+	from      *sql.Store
+	to        *sql.Store
+	log       *zap.Logger
+	batchSize int
+}
+
+func (tr *transferer) run(ctx context.Context) (stats transferStats, err error) {
+	projects, err := listAllProjects(ctx, tr.from)
+	if err != nil {
+		return stats, fmt.Errorf("list projects of source store: %v", err)
+	}
+
+	apiKeys, err := listAllAPIKeys(ctx, tr.from)
+	if err != nil {
+		return stats, fmt.Errorf("list api keys of source store: %v", err)
+	}
+	if err := createAPIKeys(ctx, tr.to, apiKeys, &stats.settingsStats); err != nil {
+		return stats, fmt.Errorf("transfer api keys: %w", err)
+	}
+
+	for _, project := range projects {
+		created, err := createProjectIfNotExists(ctx, tr.to, project)
+		if err != nil {
+			return stats, fmt.Errorf("transfer project %s: %w", project.ID.String(), err)
+		}
+		if created {
+			stats.projectsCreated++
+		} else {
+			stats.projectsExisting++
+		}
+
+		if err := tr.verifyProject(ctx, project); err != nil {
+			return stats, fmt.Errorf("verify project %s: %w", project.ID.String(), err)
+		}
+
+		sum, err := tr.transferRecords(ctx, project.ID, &stats)
+		if err != nil {
+			return stats, fmt.Errorf("transfer records of project %s: %w", project.ID.String(), err)
+		}
+
+		if err := tr.verifyRecords(ctx, project.ID, sum); err != nil {
+			return stats, fmt.Errorf("verify records of project %s: %w", project.ID.String(), err)
+		}
+
+		settings, err := listProjectSettings(ctx, tr.from, project.ID)
+		if err != nil {
+			return stats, fmt.Errorf("list settings of project %s: %w", project.ID.String(), err)
+		}
+		if err := createProjectSettings(ctx, tr.to, settings, &stats.settingsStats); err != nil {
+			return stats, fmt.Errorf("transfer settings of project %s: %w", project.ID.String(), err)
+		}
+
+		tr.log.Info("Transferred project",
+			zap.String("project_id", project.ID.String()),
+			zap.Int("records", sum.Count()),
+			zap.String("checksum", sum.String()),
+		)
+	}
+
+	return stats, nil
+}
+
+// transferRecords copies records of the project and returns the checksum
+// of the source records.
+func (tr *transferer) transferRecords(
+	ctx context.Context,
+	projectID aud.ID,
+	stats *transferStats,
+) (*backup.Checksum, error) {
+	var sum backup.Checksum
+	batch := newRecordsBatch(tr.to, tr.batchSize)
+
+	err := tr.from.ExportRecords(ctx, projectID, aud.RecordFilter{}, func(record aud.Record) error {
+		sum.Add(record)
+		return batch.Add(ctx, record)
+	})
+	if err == nil {
+		err = batch.Flush(ctx)
+	}
+
+	stats.recordsCreated += batch.created
+	stats.recordsExisting += batch.existing
+
+	return &sum, err
+}
+
+// verifyRecords checks that records of the project in the target store match
+// the source records by count and checksum.
+func (tr *transferer) verifyRecords(ctx context.Context, projectID aud.ID, want *backup.Checksum) error {
+	var got backup.Checksum
+
+	err := tr.to.ExportRecords(ctx, projectID, aud.RecordFilter{}, func(record aud.Record) error {
+		got.Add(record)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("read records of target store: %w", err)
+	}
+
+	if got.Count() != want.Count() {
+		return fmt.Errorf("expected %d records in target store, got %d", want.Count(), got.Count())
+	}
+	if !got.Equal(want) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", want.String(), got.String())
+	}
+
+	return nil
+}
+
+// verifyProject checks that the project in the target store matches the
+// source project.
+func (tr *transferer) verifyProject(ctx context.Context, want aud.Project) error {
+	got, err := tr.to.GetProject(ctx, want.ID)
+	if err != nil {
+		return fmt.Errorf("get project of target store: %w", err)
+	}
+
+	if got.DisplayName != want.DisplayName ||
+		got.Description != want.Description ||
+		!maps.Equal(got.Labels, want.Labels) ||
+		got.ExternalID != want.ExternalID ||
+		got.UpdateRecordEnabled != want.UpdateRecordEnabled ||
+		got.DeleteRecordEnabled != want.DeleteRecordEnabled ||
+		got.Quota != want.Quota ||
+		got.RecordsRestrictions != want.RecordsRestrictions ||
+		!got.CreateTime.Truncate(time.Microsecond).Equal(want.CreateTime.Truncate(time.Microsecond)) {
+		return fmt.Errorf("project in target store differs from source")
+	}
+
+	return nil
+}
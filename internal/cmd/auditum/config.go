@@ -34,12 +34,18 @@ const (
 )
 
 type Configuration struct {
-	Log      LogConfig     `yaml:"log" json:"log"`
-	Tracing  TracingConfig `yaml:"tracing" json:"tracing"`
-	HTTP     HTTPConfig    `yaml:"http" json:"http"`
-	GRPC     GRPCConfig    `yaml:"grpc" json:"grpc"`
-	Store    StoreConfig   `yaml:"store" json:"store"`
-	Settings aud.Settings  `yaml:"settings" json:"settings"`
+	Log             LogConfig             `yaml:"log" json:"log"`
+	Tracing         TracingConfig         `yaml:"tracing" json:"tracing"`
+	HTTP            HTTPConfig            `yaml:"http" json:"http"`
+	GRPC            GRPCConfig            `yaml:"grpc" json:"grpc"`
+	Auth            AuthConfig            `yaml:"auth" json:"auth"`
+	RateLimit       RateLimitConfig       `yaml:"rateLimit" json:"rateLimit"`
+	Webhooks        WebhooksConfig        `yaml:"webhooks" json:"webhooks"`
+	Syslog          SyslogConfig          `yaml:"syslog" json:"syslog"`
+	CloudEvents     CloudEventsConfig     `yaml:"cloudEvents" json:"cloudEvents"`
+	KubernetesAudit KubernetesAuditConfig `yaml:"kubernetesAudit" json:"kubernetesAudit"`
+	Store           StoreConfig           `yaml:"store" json:"store"`
+	Settings        aud.Settings          `yaml:"settings" json:"settings"`
 
 	// Note: json tag in structs is used by validation package.
 }
@@ -61,6 +67,34 @@ func (c Configuration) Validate() error {
 		return fmt.Errorf("invalid 'grpc': %v", err)
 	}
 
+	if err := c.Auth.Validate(); err != nil {
+		return fmt.Errorf("invalid 'auth': %v", err)
+	}
+
+	if err := c.RateLimit.Validate(); err != nil {
+		return fmt.Errorf("invalid 'rateLimit': %v", err)
+	}
+
+	if err := c.Webhooks.Validate(); err != nil {
+		return fmt.Errorf("invalid 'webhooks': %v", err)
+	}
+
+	if err := c.Syslog.Validate(); err != nil {
+		return fmt.Errorf("invalid 'syslog': %v", err)
+	}
+
+	if c.Syslog.Enabled && c.Auth.Enabled && c.Syslog.APIKey == "" {
+		return fmt.Errorf("invalid 'syslog': 'apiKey' is required when authentication is enabled")
+	}
+
+	if err := c.CloudEvents.Validate(); err != nil {
+		return fmt.Errorf("invalid 'cloudEvents': %v", err)
+	}
+
+	if err := c.KubernetesAudit.Validate(); err != nil {
+		return fmt.Errorf("invalid 'kubernetesAudit': %v", err)
+	}
+
 	if err := c.Store.Validate(); err != nil {
 		return fmt.Errorf("invalid 'store': %v", err)
 	}
@@ -74,12 +108,18 @@ func (c Configuration) Validate() error {
 
 // NOTE: must be in sync with config/auditum.yaml
 var defaultConfig = Configuration{
-	Log:      defaultLogConfig,
-	Tracing:  defaultTracingConfig,
-	HTTP:     defaultHTTPConfig,
-	GRPC:     defaultGRPCConfig,
-	Store:    defaultStoreConfig,
-	Settings: aud.DefaultSettings,
+	Log:             defaultLogConfig,
+	Tracing:         defaultTracingConfig,
+	HTTP:            defaultHTTPConfig,
+	GRPC:            defaultGRPCConfig,
+	Auth:            defaultAuthConfig,
+	RateLimit:       defaultRateLimitConfig,
+	Webhooks:        defaultWebhooksConfig,
+	Syslog:          defaultSyslogConfig,
+	CloudEvents:     defaultCloudEventsConfig,
+	KubernetesAudit: defaultKubernetesAuditConfig,
+	Store:           defaultStoreConfig,
+	Settings:        aud.DefaultSettings,
 }
 
 func loadConfiguration(fpath string) (*Configuration, error) {
@@ -87,6 +127,17 @@ func loadConfiguration(fpath string) (*Configuration, error) {
 		fpath = os.Getenv(envConfigFilepath)
 	}
 
+	return loadConfigurationSources(fpath, true)
+}
+
+// loadFileConfiguration loads configuration from the file only, ignoring
+// environment variables. This allows to load multiple configurations at
+// once, e.g. of source and target stores.
+func loadFileConfiguration(fpath string) (*Configuration, error) {
+	return loadConfigurationSources(fpath, false)
+}
+
+func loadConfigurationSources(fpath string, withEnv bool) (*Configuration, error) {
 	// Load configuration from sources, considering default configuration.
 	// Then save it into the structure.
 
@@ -100,14 +151,16 @@ func loadConfiguration(fpath string) (*Configuration, error) {
 			return nil, fmt.Errorf("load yaml file configuration: %v", err)
 		}
 	}
-	if err := k.Load(env.Provider(envPrefix, ".", func(s string) string {
-		return strings.ReplaceAll(
-			strings.TrimPrefix(s, envPrefix),
-			"_",
-			".",
-		)
-	}), nil); err != nil {
-		return nil, fmt.Errorf("load environment variables configuration: %v", err)
+	if withEnv {
+		if err := k.Load(env.Provider(envPrefix, ".", func(s string) string {
+			return strings.ReplaceAll(
+				strings.TrimPrefix(s, envPrefix),
+				"_",
+				".",
+			)
+		}), nil); err != nil {
+			return nil, fmt.Errorf("load environment variables configuration: %v", err)
+		}
 	}
 
 	var conf Configuration
@@ -0,0 +1,176 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/auth"
+)
+
+type AuthConfig struct {
+	// Enabled requires requests to be authenticated.
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	JWT     AuthJWTConfig `yaml:"jwt" json:"jwt"`
+	// ClientCertificates grant access to clients authenticated with
+	// TLS client certificates.
+	ClientCertificates []AuthClientCertificateConfig `yaml:"clientCertificates" json:"clientCertificates"`
+}
+
+func (c AuthConfig) Validate() error {
+	if c.JWT.Enabled {
+		if err := c.JWT.Validate(); err != nil {
+			return fmt.Errorf("invalid 'jwt': %v", err)
+		}
+	}
+
+	for i, cert := range c.ClientCertificates {
+		if err := cert.Validate(); err != nil {
+			return fmt.Errorf("invalid 'clientCertificates[%d]': %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+type AuthClientCertificateConfig struct {
+	// Subject is the certificate subject, e.g. "CN=blog,O=Example".
+	Subject     string   `yaml:"subject" json:"subject"`
+	Projects    []string `yaml:"projects" json:"projects"`
+	Permissions []string `yaml:"permissions" json:"permissions"`
+}
+
+func (c AuthClientCertificateConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Subject, validation.Required),
+		validation.Field(&c.Projects, validation.Each(validation.By(validateProjectID))),
+		validation.Field(&c.Permissions, validation.Each(validation.By(validatePermission))),
+	)
+}
+
+func (c AuthClientCertificateConfig) ClientCertificate() auth.ClientCertificate {
+	// Validated in Validate.
+	cert := auth.ClientCertificate{
+		Subject: c.Subject,
+	}
+	for _, s := range c.Projects {
+		cert.ProjectIDs = append(cert.ProjectIDs, aud.MustParseID(s))
+	}
+	for _, s := range c.Permissions {
+		perm, _ := aud.ParsePermission(s)
+		cert.Permissions = append(cert.Permissions, perm)
+	}
+	return cert
+}
+
+func validateProjectID(value any) error {
+	_, err := aud.ParseID(value.(string))
+	return err
+}
+
+func validatePermission(value any) error {
+	_, err := aud.ParsePermission(value.(string))
+	return err
+}
+
+type AuthJWTConfig struct {
+	// Enabled allows requests to be authenticated with JWT bearer tokens.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// JWKS is a file path or URL of JSON Web Key Set.
+	JWKS            string              `yaml:"jwks" json:"jwks"`
+	RefreshInterval time.Duration       `yaml:"refreshInterval" json:"refreshInterval"`
+	Issuer          string              `yaml:"issuer" json:"issuer"`
+	Audience        []string            `yaml:"audience" json:"audience"`
+	Algorithms      []string            `yaml:"algorithms" json:"algorithms"`
+	Leeway          time.Duration       `yaml:"leeway" json:"leeway"`
+	Claims          AuthJWTClaimsConfig `yaml:"claims" json:"claims"`
+}
+
+func (c AuthJWTConfig) Validate() error {
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.JWKS, validation.Required),
+		validation.Field(&c.RefreshInterval, validation.Required, validation.Min(time.Second)),
+		validation.Field(&c.Algorithms, validation.Required, validation.Each(validation.In(jwtAlgorithms...))),
+		validation.Field(&c.Leeway, validation.Min(time.Duration(0))),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Claims.Validate(); err != nil {
+		return fmt.Errorf("invalid 'claims': %v", err)
+	}
+
+	return nil
+}
+
+func (c AuthJWTConfig) SignatureAlgorithms() []jose.SignatureAlgorithm {
+	algs := make([]jose.SignatureAlgorithm, 0, len(c.Algorithms))
+	for _, alg := range c.Algorithms {
+		algs = append(algs, jose.SignatureAlgorithm(alg))
+	}
+	return algs
+}
+
+// jwtAlgorithms are the supported asymmetric signature algorithms.
+var jwtAlgorithms = []any{
+	string(jose.RS256),
+	string(jose.RS384),
+	string(jose.RS512),
+	string(jose.PS256),
+	string(jose.PS384),
+	string(jose.PS512),
+	string(jose.ES256),
+	string(jose.ES384),
+	string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+type AuthJWTClaimsConfig struct {
+	Principal   string `yaml:"principal" json:"principal"`
+	Projects    string `yaml:"projects" json:"projects"`
+	Permissions string `yaml:"permissions" json:"permissions"`
+}
+
+func (c AuthJWTClaimsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Principal, validation.Required),
+		validation.Field(&c.Permissions, validation.Required),
+	)
+}
+
+var defaultAuthConfig = AuthConfig{
+	Enabled:            false,
+	ClientCertificates: nil,
+	JWT: AuthJWTConfig{
+		Enabled:         false,
+		JWKS:            "",
+		RefreshInterval: 15 * time.Minute,
+		Issuer:          "",
+		Audience:        nil,
+		Algorithms:      []string{string(jose.RS256), string(jose.ES256)},
+		Leeway:          time.Minute,
+		Claims: AuthJWTClaimsConfig{
+			Principal:   "sub",
+			Projects:    "auditum_projects",
+			Permissions: "auditum_permissions",
+		},
+	},
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"fmt"
+
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/cloudevents"
+)
+
+type CloudEventsConfig struct {
+	Enabled  bool                       `yaml:"enabled" json:"enabled"`
+	Projects []CloudEventsProjectConfig `yaml:"projects" json:"projects"`
+	Rules    []CloudEventsRuleConfig    `yaml:"rules" json:"rules"`
+}
+
+func (c CloudEventsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	for i, project := range c.Projects {
+		if err := project.Validate(); err != nil {
+			return fmt.Errorf("invalid 'projects[%d]': %v", i, err)
+		}
+	}
+
+	for i, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid 'rules[%d]': %v", i, err)
+		}
+	}
+
+	// Patterns and templates are validated by the mapper.
+	if _, err := cloudevents.NewMapper(c.projectMappings(), c.rules()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HandlerConfig returns configuration of the CloudEvents handler.
+func (c CloudEventsConfig) HandlerConfig() cloudevents.Config {
+	return cloudevents.Config{
+		Projects: c.projectMappings(),
+		Rules:    c.rules(),
+	}
+}
+
+func (c CloudEventsConfig) projectMappings() []cloudevents.ProjectMapping {
+	projects := make([]cloudevents.ProjectMapping, 0, len(c.Projects))
+	for _, p := range c.Projects {
+		projects = append(projects, cloudevents.ProjectMapping{
+			Source:    p.Source,
+			ProjectID: p.ProjectID,
+		})
+	}
+	return projects
+}
+
+func (c CloudEventsConfig) rules() []cloudevents.Rule {
+	rules := make([]cloudevents.Rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		rules = append(rules, cloudevents.Rule{
+			Source:  r.Match.Source,
+			Type:    r.Match.Type,
+			Subject: r.Match.Subject,
+			Record:  r.Record.RecordTemplate(),
+		})
+	}
+	return rules
+}
+
+type CloudEventsProjectConfig struct {
+	// Source is a glob pattern, empty matches any source.
+	Source    string `yaml:"source" json:"source"`
+	ProjectID string `yaml:"projectId" json:"projectId"`
+}
+
+func (c CloudEventsProjectConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ProjectID, validation.Required, validation.By(validateProjectID)),
+	)
+}
+
+type CloudEventsRuleConfig struct {
+	Match  CloudEventsRuleMatchConfig `yaml:"match" json:"match"`
+	Record IngestRecordConfig         `yaml:"record" json:"record"`
+}
+
+func (c CloudEventsRuleConfig) Validate() error {
+	if err := c.Record.Validate(); err != nil {
+		return fmt.Errorf("invalid 'record': %v", err)
+	}
+
+	return nil
+}
+
+type CloudEventsRuleMatchConfig struct {
+	// Source, Type and Subject are glob patterns, empty matches any value.
+	Source  string `yaml:"source" json:"source"`
+	Type    string `yaml:"type" json:"type"`
+	Subject string `yaml:"subject" json:"subject"`
+}
+
+var defaultCloudEventsConfig = CloudEventsConfig{
+	Enabled:  false,
+	Projects: nil,
+	Rules: []CloudEventsRuleConfig{
+		{
+			Record: IngestRecordConfig{
+				ResourceType:  "${source}",
+				ResourceID:    "${subject:-unknown}",
+				OperationType: "${type}",
+				OperationID:   "${id}",
+				ActorType:     "${authtype:-unknown}",
+				ActorID:       "${authid:-unknown}",
+			},
+		},
+	},
+}
@@ -15,20 +15,33 @@
 package auditum
 
 import (
+	"fmt"
+
 	"github.com/invopop/validation"
 	"github.com/invopop/validation/is"
 )
 
 type HTTPConfig struct {
-	Port string `yaml:"port" json:"port"`
+	Port string    `yaml:"port" json:"port"`
+	TLS  TLSConfig `yaml:"tls" json:"tls"`
 }
 
 func (c HTTPConfig) Validate() error {
-	return validation.ValidateStruct(&c,
+	err := validation.ValidateStruct(&c,
 		validation.Field(&c.Port, validation.Required, is.Port),
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid 'tls': %v", err)
+	}
+
+	return nil
 }
 
 var defaultHTTPConfig = HTTPConfig{
 	Port: "8080",
+	TLS:  defaultTLSConfig,
 }
@@ -0,0 +1,60 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+// IngestRecordConfig is a template of a record created by an ingestion
+// source, see ingest.RecordTemplate.
+type IngestRecordConfig struct {
+	ResourceType    string            `yaml:"resourceType" json:"resourceType"`
+	ResourceID      string            `yaml:"resourceId" json:"resourceId"`
+	OperationType   string            `yaml:"operationType" json:"operationType"`
+	OperationID     string            `yaml:"operationId" json:"operationId"`
+	OperationStatus string            `yaml:"operationStatus" json:"operationStatus"`
+	ActorType       string            `yaml:"actorType" json:"actorType"`
+	ActorID         string            `yaml:"actorId" json:"actorId"`
+	Labels          map[string]string `yaml:"labels" json:"labels"`
+	Metadata        map[string]string `yaml:"metadata" json:"metadata"`
+}
+
+func (c IngestRecordConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ResourceType, validation.Required),
+		validation.Field(&c.ResourceID, validation.Required),
+		validation.Field(&c.OperationType, validation.Required),
+		validation.Field(&c.OperationID, validation.Required),
+		validation.Field(&c.ActorType, validation.Required),
+		validation.Field(&c.ActorID, validation.Required),
+	)
+}
+
+func (c IngestRecordConfig) RecordTemplate() ingest.RecordTemplate {
+	return ingest.RecordTemplate{
+		ResourceType:    c.ResourceType,
+		ResourceID:      c.ResourceID,
+		OperationType:   c.OperationType,
+		OperationID:     c.OperationID,
+		OperationStatus: c.OperationStatus,
+		ActorType:       c.ActorType,
+		ActorID:         c.ActorID,
+		Labels:          c.Labels,
+		Metadata:        c.Metadata,
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"fmt"
+
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/kubeaudit"
+)
+
+type KubernetesAuditConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Stages are stages of audit events records are created from.
+	Stages []string                  `yaml:"stages" json:"stages"`
+	Diff   KubernetesAuditDiffConfig `yaml:"diff" json:"diff"`
+}
+
+func (c KubernetesAuditConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.Stages, validation.Required, validation.Each(validation.In(kubernetesAuditStages...))),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Diff.Validate(); err != nil {
+		return fmt.Errorf("invalid 'diff': %v", err)
+	}
+
+	return nil
+}
+
+// HandlerConfig returns configuration of the Kubernetes audit handler.
+func (c KubernetesAuditConfig) HandlerConfig() kubeaudit.Config {
+	return kubeaudit.Config{
+		Stages: c.Stages,
+		Diff: kubeaudit.DiffConfig{
+			Enabled:      c.Diff.Enabled,
+			IgnoreFields: c.Diff.IgnoreFields,
+			MaxChanges:   c.Diff.MaxChanges,
+		},
+	}
+}
+
+// kubernetesAuditStages are the stages of audit events.
+var kubernetesAuditStages = []any{
+	kubeaudit.StageRequestReceived,
+	kubeaudit.StageResponseStarted,
+	kubeaudit.StageResponseComplete,
+	kubeaudit.StagePanic,
+}
+
+type KubernetesAuditDiffConfig struct {
+	// Enabled adds differences of request and response objects to changes
+	// of records.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// IgnoreFields are paths of fields not compared.
+	IgnoreFields []string `yaml:"ignoreFields" json:"ignoreFields"`
+	// MaxChanges is the maximum number of changes of a record.
+	MaxChanges int `yaml:"maxChanges" json:"maxChanges"`
+}
+
+func (c KubernetesAuditDiffConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxChanges, validation.Required, validation.Min(1)),
+	)
+}
+
+var defaultKubernetesAuditConfig = KubernetesAuditConfig{
+	Enabled: false,
+	Stages: []string{
+		kubeaudit.StageResponseComplete,
+		kubeaudit.StagePanic,
+	},
+	Diff: KubernetesAuditDiffConfig{
+		Enabled: false,
+		IgnoreFields: []string{
+			"metadata.annotations.kubectl.kubernetes.io/last-applied-configuration",
+			"metadata.creationTimestamp",
+			"metadata.generation",
+			"metadata.managedFields",
+			"metadata.resourceVersion",
+			"metadata.uid",
+			"status",
+		},
+		MaxChanges: 20,
+	},
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"fmt"
+
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+type RateLimitConfig struct {
+	// Enabled limits the rate of API requests.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Key of the bucket of a request, any of: principal, project, method,
+	// ip. Requests with the same values share the bucket.
+	Key   []string             `yaml:"key" json:"key"`
+	Read  RateLimitLimitConfig `yaml:"read" json:"read"`
+	Write RateLimitLimitConfig `yaml:"write" json:"write"`
+}
+
+func (c RateLimitConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.Key, validation.Required, validation.Each(validation.In(rateLimitKeys...))),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Read.Validate(); err != nil {
+		return fmt.Errorf("invalid 'read': %v", err)
+	}
+
+	if err := c.Write.Validate(); err != nil {
+		return fmt.Errorf("invalid 'write': %v", err)
+	}
+
+	return nil
+}
+
+func (c RateLimitConfig) InterceptorConfig() ratelimit.Config {
+	keys := make([]ratelimit.Key, 0, len(c.Key))
+	for _, key := range c.Key {
+		keys = append(keys, ratelimit.Key(key))
+	}
+
+	return ratelimit.Config{
+		Keys:  keys,
+		Read:  c.Read.Limit(),
+		Write: c.Write.Limit(),
+	}
+}
+
+var rateLimitKeys = []any{
+	string(ratelimit.KeyPrincipal),
+	string(ratelimit.KeyProject),
+	string(ratelimit.KeyMethod),
+	string(ratelimit.KeyIP),
+}
+
+type RateLimitLimitConfig struct {
+	// Rate is the number of requests per second.
+	Rate float64 `yaml:"rate" json:"rate"`
+	// Burst is the number of requests allowed at once.
+	Burst int `yaml:"burst" json:"burst"`
+}
+
+func (c RateLimitLimitConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Rate, validation.Required, validation.Min(0.0)),
+		validation.Field(&c.Burst, validation.Required, validation.Min(1)),
+	)
+}
+
+func (c RateLimitLimitConfig) Limit() ratelimit.Limit {
+	return ratelimit.Limit{
+		Rate:  c.Rate,
+		Burst: c.Burst,
+	}
+}
+
+var defaultRateLimitConfig = RateLimitConfig{
+	Enabled: false,
+	Key:     []string{string(ratelimit.KeyPrincipal)},
+	Read: RateLimitLimitConfig{
+		Rate:  50,
+		Burst: 100,
+	},
+	Write: RateLimitLimitConfig{
+		Rate:  100,
+		Burst: 200,
+	},
+}
@@ -69,7 +69,6 @@ type SQLiteConfig struct {
 func (c SQLiteConfig) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.DatabasePath, validation.Required),
-		validation.Field(&c.MigrationsPath, validation.Required),
 	)
 }
 
@@ -92,7 +91,6 @@ func (c PostgresConfig) Validate() error {
 		validation.Field(&c.Username, validation.Required),
 		validation.Field(&c.Password, validation.Required),
 		validation.Field(&c.SSLMode, validation.Required),
-		validation.Field(&c.MigrationsPath, validation.Required),
 	)
 }
 
@@ -100,7 +98,7 @@ var defaultStoreConfig = StoreConfig{
 	Type: storeTypeSQLite,
 	SQLite: SQLiteConfig{
 		DatabasePath:   ":memory:",
-		MigrationsPath: "./internal/sql/sqlite/migrations",
+		MigrationsPath: "",
 		LogQueries:     false,
 	},
 	Postgres: PostgresConfig{
@@ -110,7 +108,7 @@ var defaultStoreConfig = StoreConfig{
 		Username:       "",
 		Password:       "",
 		SSLMode:        "require",
-		MigrationsPath: "./internal/sql/postgres/migrations",
+		MigrationsPath: "",
 		LogQueries:     false,
 	},
 }
@@ -0,0 +1,241 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/invopop/validation"
+	"github.com/invopop/validation/is"
+
+	"github.com/auditumio/auditum/internal/syslog"
+)
+
+type SyslogConfig struct {
+	Enabled bool            `yaml:"enabled" json:"enabled"`
+	UDP     SyslogUDPConfig `yaml:"udp" json:"udp"`
+	TCP     SyslogTCPConfig `yaml:"tcp" json:"tcp"`
+	// MaxMessageSize is the maximum size of a message in bytes.
+	MaxMessageSize int `yaml:"maxMessageSize" json:"maxMessageSize"`
+	// Concurrency is the number of messages processed at once.
+	Concurrency int `yaml:"concurrency" json:"concurrency"`
+	// APIKey authenticates creation of records when authentication is
+	// enabled. Its permissions limit the projects records are created in.
+	APIKey   string                `yaml:"apiKey" json:"apiKey"`
+	Projects []SyslogProjectConfig `yaml:"projects" json:"projects"`
+	Rules    []SyslogRuleConfig    `yaml:"rules" json:"rules"`
+}
+
+func (c SyslogConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.MaxMessageSize, validation.Required, validation.Min(480), validation.Max(65507)),
+		validation.Field(&c.Concurrency, validation.Required, validation.Min(1)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !c.UDP.Enabled && !c.TCP.Enabled {
+		return fmt.Errorf("either 'udp' or 'tcp' must be enabled")
+	}
+
+	if err := c.UDP.Validate(); err != nil {
+		return fmt.Errorf("invalid 'udp': %v", err)
+	}
+
+	if err := c.TCP.Validate(); err != nil {
+		return fmt.Errorf("invalid 'tcp': %v", err)
+	}
+
+	for i, project := range c.Projects {
+		if err := project.Validate(); err != nil {
+			return fmt.Errorf("invalid 'projects[%d]': %v", i, err)
+		}
+	}
+
+	for i, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid 'rules[%d]': %v", i, err)
+		}
+	}
+
+	// Patterns, regular expressions and templates are validated by the
+	// mapper.
+	if _, err := syslog.NewMapper(c.projectMappings(), c.rules()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ServerConfig returns configuration of the syslog server, with TLS
+// configuration of the TCP listener if enabled.
+func (c SyslogConfig) ServerConfig(tlsConfig *tls.Config) syslog.Config {
+	conf := syslog.Config{
+		MaxMessageSize: c.MaxMessageSize,
+		Concurrency:    c.Concurrency,
+		Projects:       c.projectMappings(),
+		Rules:          c.rules(),
+	}
+
+	if c.UDP.Enabled {
+		conf.UDPAddr = ":" + c.UDP.Port
+	}
+
+	if c.TCP.Enabled {
+		conf.TCPAddr = ":" + c.TCP.Port
+		conf.TLS = tlsConfig
+	}
+
+	return conf
+}
+
+func (c SyslogConfig) projectMappings() []syslog.ProjectMapping {
+	projects := make([]syslog.ProjectMapping, 0, len(c.Projects))
+	for _, p := range c.Projects {
+		projects = append(projects, syslog.ProjectMapping{
+			Hostname:  p.Hostname,
+			AppName:   p.AppName,
+			ProjectID: p.ProjectID,
+		})
+	}
+	return projects
+}
+
+func (c SyslogConfig) rules() []syslog.Rule {
+	rules := make([]syslog.Rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		rules = append(rules, syslog.Rule{
+			Hostname: r.Match.Hostname,
+			AppName:  r.Match.AppName,
+			MsgID:    r.Match.MsgID,
+			Message:  r.Match.Message,
+			Record:   r.Record.RecordTemplate(),
+		})
+	}
+	return rules
+}
+
+type SyslogUDPConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Port    string `yaml:"port" json:"port"`
+}
+
+func (c SyslogUDPConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Port, validation.Required, is.Port),
+	)
+}
+
+type SyslogTCPConfig struct {
+	Enabled bool      `yaml:"enabled" json:"enabled"`
+	Port    string    `yaml:"port" json:"port"`
+	TLS     TLSConfig `yaml:"tls" json:"tls"`
+}
+
+func (c SyslogTCPConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	err := validation.ValidateStruct(&c,
+		validation.Field(&c.Port, validation.Required, is.Port),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid 'tls': %v", err)
+	}
+
+	return nil
+}
+
+type SyslogProjectConfig struct {
+	// Hostname and AppName are glob patterns, empty matches any value.
+	Hostname  string `yaml:"hostname" json:"hostname"`
+	AppName   string `yaml:"appName" json:"appName"`
+	ProjectID string `yaml:"projectId" json:"projectId"`
+}
+
+func (c SyslogProjectConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ProjectID, validation.Required, validation.By(validateProjectID)),
+	)
+}
+
+type SyslogRuleConfig struct {
+	Match  SyslogRuleMatchConfig `yaml:"match" json:"match"`
+	Record IngestRecordConfig    `yaml:"record" json:"record"`
+}
+
+func (c SyslogRuleConfig) Validate() error {
+	if err := c.Record.Validate(); err != nil {
+		return fmt.Errorf("invalid 'record': %v", err)
+	}
+
+	return nil
+}
+
+type SyslogRuleMatchConfig struct {
+	// Hostname, AppName and MsgID are glob patterns, empty matches any
+	// value.
+	Hostname string `yaml:"hostname" json:"hostname"`
+	AppName  string `yaml:"appName" json:"appName"`
+	MsgID    string `yaml:"msgId" json:"msgId"`
+	// Message is a regular expression, empty matches any message.
+	Message string `yaml:"message" json:"message"`
+}
+
+var defaultSyslogConfig = SyslogConfig{
+	Enabled: false,
+	UDP: SyslogUDPConfig{
+		Enabled: true,
+		Port:    "5514",
+	},
+	TCP: SyslogTCPConfig{
+		Enabled: true,
+		Port:    "5514",
+		TLS:     defaultTLSConfig,
+	},
+	MaxMessageSize: 8192,
+	Concurrency:    4,
+	Projects:       nil,
+	Rules: []SyslogRuleConfig{
+		{
+			Record: IngestRecordConfig{
+				ResourceType:  "host",
+				ResourceID:    "${hostname:-unknown}",
+				OperationType: "${msg_id:-log}",
+				OperationID:   "${proc_id:-unknown}",
+				ActorType:     "application",
+				ActorID:       "${app_name:-unknown}",
+				Metadata: map[string]string{
+					"message": "${message}",
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/pkg/fragma/tlsx"
+)
+
+type TLSConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// CertFile and KeyFile are PEM encoded server certificate and key.
+	CertFile string `yaml:"certFile" json:"certFile"`
+	KeyFile  string `yaml:"keyFile" json:"keyFile"`
+	// ClientCAFile enables mutual TLS: clients must present a certificate
+	// signed by one of the CA certificates.
+	ClientCAFile string `yaml:"clientCAFile" json:"clientCAFile"`
+	MinVersion   string `yaml:"minVersion" json:"minVersion"`
+}
+
+func (c TLSConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CertFile, validation.Required),
+		validation.Field(&c.KeyFile, validation.Required),
+		validation.Field(&c.MinVersion, validation.Required, validation.In("1.2", "1.3")),
+	)
+}
+
+func (c TLSConfig) ServerConfig(nextProtos ...string) tlsx.ServerConfig {
+	// Validated in Validate.
+	minVersion, _ := tlsx.ParseVersion(c.MinVersion)
+
+	return tlsx.ServerConfig{
+		CertFile:     c.CertFile,
+		KeyFile:      c.KeyFile,
+		ClientCAFile: c.ClientCAFile,
+		MinVersion:   minVersion,
+		NextProtos:   nextProtos,
+	}
+}
+
+var defaultTLSConfig = TLSConfig{
+	Enabled:      false,
+	CertFile:     "",
+	KeyFile:      "",
+	ClientCAFile: "",
+	MinVersion:   "1.2",
+}
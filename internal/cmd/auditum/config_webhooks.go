@@ -0,0 +1,81 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"time"
+
+	"github.com/invopop/validation"
+
+	"github.com/auditumio/auditum/internal/webhook"
+)
+
+type WebhooksConfig struct {
+	// PollInterval is how often deliveries due are checked.
+	PollInterval time.Duration `yaml:"pollInterval" json:"pollInterval"`
+	// Timeout of a delivery request.
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+	// MaxAttempts is the number of attempts before a delivery fails.
+	MaxAttempts int `yaml:"maxAttempts" json:"maxAttempts"`
+	// MinBackoff is the delay before the second attempt, doubled with every
+	// attempt up to MaxBackoff.
+	MinBackoff time.Duration `yaml:"minBackoff" json:"minBackoff"`
+	MaxBackoff time.Duration `yaml:"maxBackoff" json:"maxBackoff"`
+	// Retention is how long completed deliveries are kept.
+	Retention time.Duration `yaml:"retention" json:"retention"`
+	// Concurrency is the number of deliveries attempted at once.
+	Concurrency int `yaml:"concurrency" json:"concurrency"`
+	// AllowPrivateNetworks allows delivering to loopback, private and
+	// link-local addresses, e.g. to webhooks in the same cluster.
+	AllowPrivateNetworks bool `yaml:"allowPrivateNetworks" json:"allowPrivateNetworks"`
+}
+
+func (c WebhooksConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.PollInterval, validation.Required, validation.Min(100*time.Millisecond)),
+		validation.Field(&c.Timeout, validation.Required, validation.Min(time.Second)),
+		validation.Field(&c.MaxAttempts, validation.Required, validation.Min(1)),
+		validation.Field(&c.MinBackoff, validation.Required, validation.Min(time.Second)),
+		validation.Field(&c.MaxBackoff, validation.Required, validation.Min(c.MinBackoff)),
+		validation.Field(&c.Retention, validation.Required, validation.Min(time.Hour)),
+		validation.Field(&c.Concurrency, validation.Required, validation.Min(1)),
+	)
+}
+
+func (c WebhooksConfig) DispatcherConfig() webhook.Config {
+	return webhook.Config{
+		PollInterval: c.PollInterval,
+		Timeout:      c.Timeout,
+		MaxAttempts:  c.MaxAttempts,
+		MinBackoff:   c.MinBackoff,
+		MaxBackoff:   c.MaxBackoff,
+		Retention:    c.Retention,
+		Concurrency:  c.Concurrency,
+
+		AllowPrivateNetworks: c.AllowPrivateNetworks,
+	}
+}
+
+var defaultWebhooksConfig = WebhooksConfig{
+	PollInterval: 5 * time.Second,
+	Timeout:      10 * time.Second,
+	MaxAttempts:  10,
+	MinBackoff:   10 * time.Second,
+	MaxBackoff:   time.Hour,
+	Retention:    7 * 24 * time.Hour,
+	Concurrency:  10,
+
+	AllowPrivateNetworks: false,
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/sql/postgres"
+	"github.com/auditumio/auditum/internal/sql/sqlite"
+	"github.com/auditumio/auditum/pkg/fragma/bunx"
+)
+
+// connectDatabase connects to the database of the configured store.
+func connectDatabase(ctx context.Context, conf StoreConfig, log *zap.Logger) (*bun.DB, error) {
+	switch conf.Type {
+	case storeTypeSQLite:
+		return sqlite.NewDatabase(
+			ctx,
+			conf.SQLite.DatabasePath,
+			log,
+			bunx.LogQueriesFlagFromBool(conf.SQLite.LogQueries),
+		)
+	case storeTypePostgres:
+		return postgres.NewDatabase(
+			ctx,
+			conf.Postgres.Host,
+			conf.Postgres.Port,
+			conf.Postgres.Database,
+			conf.Postgres.Username,
+			conf.Postgres.Password,
+			conf.Postgres.SSLMode,
+			log,
+			bunx.LogQueriesFlagFromBool(conf.Postgres.LogQueries),
+		)
+	default:
+		return nil, fmt.Errorf("unknown store type: %s", conf.Type)
+	}
+}
+
+// connectPersistentDatabase connects to the database of the configured
+// store, refusing in-memory SQLite database, which is only available to the
+// server process.
+func connectPersistentDatabase(ctx context.Context, conf StoreConfig, log *zap.Logger) (*bun.DB, error) {
+	if conf.Type == storeTypeSQLite && conf.SQLite.DatabasePath == sqlite.FilepathMemory {
+		return nil, fmt.Errorf("in-memory SQLite database is not supported, configure a database file")
+	}
+
+	return connectDatabase(ctx, conf, log)
+}
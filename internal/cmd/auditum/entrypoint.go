@@ -26,7 +26,7 @@ func Execute() int {
 		_ = dlog.Sync()
 	}()
 
-	cmd, configPath, err := parseCommand()
+	cmd, configPath, flagset, err := parseCommand()
 	if err != nil {
 		dlog.Error("Failed to parse command", zap.Error(err))
 		return exitCodeStartFailure
@@ -50,5 +50,5 @@ func Execute() int {
 		_ = log.Sync()
 	}()
 
-	return executeCommand(cmd, conf, log)
+	return executeCommand(cmd, flagset, conf, log)
 }
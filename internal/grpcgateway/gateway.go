@@ -29,7 +29,9 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/auditumio/auditum/internal/auth"
 	"github.com/auditumio/auditum/pkg/fragma/grpcx"
+	"github.com/auditumio/auditum/pkg/fragma/tlsx"
 )
 
 // RegistrableService describes a service implementation that can be registered
@@ -135,13 +137,22 @@ func newGatewayMux(log *zap.Logger) *runtime.ServeMux {
 		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher()),
 		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher(log)),
 		runtime.WithUnescapingMode(runtime.UnescapingModeAllExceptReserved),
-		runtime.WithMetadata(func(ctx context.Context, _ *http.Request) metadata.MD {
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			md := metadata.MD{}
+
 			pattern, ok := runtime.HTTPPathPattern(ctx)
 			if ok {
-				return metadata.Pairs("http-path-pattern", pattern)
+				md.Set("http-path-pattern", pattern)
 			}
 
-			return nil
+			// Forward the verified client certificate, so the request can be
+			// authenticated with it.
+			subject, ok := tlsx.ClientCertificateSubject(r.TLS)
+			if ok {
+				md.Set(auth.ClientCertificateSubjectMetadataKey, subject)
+			}
+
+			return md
 		}),
 	}
 
@@ -188,7 +199,13 @@ func incomingHeaderMatcher() runtime.HeaderMatcherFunc {
 			return key, true
 		}
 
-		return runtime.DefaultHeaderMatcher(key)
+		mdKey, ok := runtime.DefaultHeaderMatcher(key)
+		if ok && strings.EqualFold(mdKey, auth.ClientCertificateSubjectMetadataKey) {
+			// Must be set by the gateway only.
+			return "", false
+		}
+
+		return mdKey, ok
 	}
 }
 
@@ -199,6 +216,10 @@ func outgoingHeaderMatcher(log *zap.Logger) runtime.HeaderMatcherFunc {
 			// Drop "Grpc-Metadata-Content-Type: application/grpc".
 			return "", false
 		}
+		switch key {
+		case "Retry-After", "X-Ratelimit-Limit", "X-Ratelimit-Remaining", "X-Ratelimit-Reset":
+			return key, true
+		}
 
 		log.Warn("Drop unknown outgoing header",
 			zap.String("header_name", key),
@@ -0,0 +1,164 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+// RecordCreator creates records, e.g. the record service server.
+type RecordCreator interface {
+	CreateRecord(
+		ctx context.Context,
+		req *auditumv1alpha1.CreateRecordRequest,
+	) (*auditumv1alpha1.CreateRecordResponse, error)
+	BatchCreateRecords(
+		ctx context.Context,
+		req *auditumv1alpha1.BatchCreateRecordsRequest,
+	) (*auditumv1alpha1.BatchCreateRecordsResponse, error)
+}
+
+// NewRecordClient returns a record creator of the record service client,
+// e.g. to create records over the gateway connection, so that requests are
+// authenticated and rate limited.
+func NewRecordClient(client auditumv1alpha1.RecordServiceClient) RecordCreator {
+	return recordClient{client: client}
+}
+
+type recordClient struct {
+	client auditumv1alpha1.RecordServiceClient
+}
+
+func (c recordClient) CreateRecord(
+	ctx context.Context,
+	req *auditumv1alpha1.CreateRecordRequest,
+) (*auditumv1alpha1.CreateRecordResponse, error) {
+	return c.client.CreateRecord(ctx, req)
+}
+
+func (c recordClient) BatchCreateRecords(
+	ctx context.Context,
+	req *auditumv1alpha1.BatchCreateRecordsRequest,
+) (*auditumv1alpha1.BatchCreateRecordsResponse, error) {
+	return c.client.BatchCreateRecords(ctx, req)
+}
+
+// WithAPIKey returns a dial option authenticating calls of the client
+// connection with the API key, for receivers which are not HTTP requests
+// themselves, e.g. syslog. Empty key leaves calls unauthenticated.
+func WithAPIKey(key string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(apiKeyCredentials(key))
+}
+
+type apiKeyCredentials string
+
+func (c apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if c == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+// RequireTransportSecurity returns false, as the connection is local: over
+// unix socket or to the gRPC server of the same process.
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// maxBatchSize is the maximum number of records created in a batch.
+const maxBatchSize = 100
+
+// CreateRecords creates records of the project in batches, counting them as
+// messages of the source. If any record of a batch is invalid, its records
+// are created one by one to drop only the invalid ones, which are returned
+// as errors. The returned error is a gRPC status.
+func CreateRecords(
+	ctx context.Context,
+	creator RecordCreator,
+	source string,
+	projectID string,
+	records []*auditumv1alpha1.Record,
+) ([]error, error) {
+	var invalid []error
+
+	for start := 0; start < len(records); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(records))
+
+		batchInvalid, err := createBatch(ctx, creator, source, projectID, records[start:end])
+		if err != nil {
+			for range records[end:] {
+				metrics.IngestedMessage(source, ResultFailed)
+			}
+			return nil, err
+		}
+		invalid = append(invalid, batchInvalid...)
+	}
+
+	return invalid, nil
+}
+
+func createBatch(
+	ctx context.Context,
+	creator RecordCreator,
+	source string,
+	projectID string,
+	records []*auditumv1alpha1.Record,
+) ([]error, error) {
+	_, err := creator.BatchCreateRecords(ctx, &auditumv1alpha1.BatchCreateRecordsRequest{
+		ProjectId: projectID,
+		Records:   records,
+	})
+	if err == nil {
+		for range records {
+			metrics.IngestedMessage(source, ResultCreated)
+		}
+		return nil, nil
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		for range records {
+			metrics.IngestedMessage(source, ResultFailed)
+		}
+		return nil, err
+	}
+
+	var invalid []error
+	for i, record := range records {
+		_, err := creator.CreateRecord(ctx, &auditumv1alpha1.CreateRecordRequest{
+			Record: record,
+		})
+		if status.Code(err) == codes.InvalidArgument {
+			metrics.IngestedMessage(source, ResultInvalid)
+			invalid = append(invalid, errors.New(status.Convert(err).Message()))
+			continue
+		}
+		if err != nil {
+			for range records[i:] {
+				metrics.IngestedMessage(source, ResultFailed)
+			}
+			return nil, err
+		}
+		metrics.IngestedMessage(source, ResultCreated)
+	}
+
+	return invalid, nil
+}
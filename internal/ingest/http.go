@@ -0,0 +1,57 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Results are numbers of messages received in a request by result, as
+// returned by HTTP ingestion endpoints.
+type Results struct {
+	Created   int `json:"created"`
+	Unmatched int `json:"unmatched"`
+	Invalid   int `json:"invalid"`
+}
+
+// ReadBody reads the request body of at most maxSize bytes.
+func ReadBody(w http.ResponseWriter, r *http.Request, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, fmt.Errorf("body must be at most %d bytes", maxSize)
+		}
+		return nil, fmt.Errorf("read body: %v", err)
+	}
+	return data, nil
+}
+
+// WriteResults writes the results as JSON response.
+func WriteResults(w http.ResponseWriter, results Results) {
+	data, err := json.Marshal(results)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
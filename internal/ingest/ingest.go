@@ -0,0 +1,70 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ingest contains what ingestion sources, which create records from
+// messages of other systems, have in common.
+package ingest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+)
+
+// Results of ingested messages, counted by metrics.IngestedMessage.
+const (
+	// ResultCreated is a message a record is created from.
+	ResultCreated = "created"
+	// ResultUnmatched is a message matching no project or rule.
+	ResultUnmatched = "unmatched"
+	// ResultInvalid is an invalid message, or a message mapped to an
+	// invalid record.
+	ResultInvalid = "invalid"
+	// ResultFailed is a message the record of which failed to be created.
+	ResultFailed = "failed"
+)
+
+// IdempotencyKey returns the idempotency key of a record created from the
+// message of the source identified by the ids, so that the record is created
+// once however many times the message is received. Ids are hashed to keep
+// the key short.
+func IdempotencyKey(source string, ids ...string) string {
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+	}
+	return source + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// ValidatePatterns validates glob patterns of path.Match.
+func ValidatePatterns(patterns ...string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the value matches the glob pattern. Empty pattern
+// matches any value. Patterns are validated beforehand.
+func Match(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
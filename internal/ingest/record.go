@@ -0,0 +1,149 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+)
+
+// RecordTemplate is a template of a record created from a message. Values
+// are templates referencing fields of the message.
+type RecordTemplate struct {
+	ResourceType string
+	ResourceID   string
+	// OperationType and OperationID are required.
+	OperationType string
+	OperationID   string
+	// OperationStatus is either empty, "SUCCEEDED" or "FAILED".
+	OperationStatus string
+	ActorType       string
+	ActorID         string
+	Labels          map[string]string
+	// Metadata is operation metadata. Empty values are omitted.
+	Metadata map[string]string
+}
+
+// RecordMapper renders records from a record template.
+type RecordMapper struct {
+	resourceType    Template
+	resourceID      Template
+	operationType   Template
+	operationID     Template
+	operationStatus Template
+	actorType       Template
+	actorID         Template
+	labels          map[string]Template
+	metadata        map[string]Template
+}
+
+// NewRecordMapper returns a mapper of the record template.
+func NewRecordMapper(t RecordTemplate) (*RecordMapper, error) {
+	m := &RecordMapper{}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *Template
+	}{
+		{"resource type", t.ResourceType, &m.resourceType},
+		{"resource id", t.ResourceID, &m.resourceID},
+		{"operation type", t.OperationType, &m.operationType},
+		{"operation id", t.OperationID, &m.operationID},
+		{"operation status", t.OperationStatus, &m.operationStatus},
+		{"actor type", t.ActorType, &m.actorType},
+		{"actor id", t.ActorID, &m.actorID},
+	}
+	for _, f := range fields {
+		var err error
+		*f.dst, err = ParseTemplate(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template: %v", f.name, err)
+		}
+	}
+
+	if m.operationStatus.Static() {
+		if _, err := ParseOperationStatus(t.OperationStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+
+	m.labels, err = parseTemplates(t.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels: %v", err)
+	}
+
+	m.metadata, err = parseTemplates(t.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+
+	return m, nil
+}
+
+// Record renders a record of the project. The record is validated when
+// created.
+func (m *RecordMapper) Record(
+	projectID string,
+	lookup Lookup,
+	operationTime time.Time,
+) (*auditumv1alpha1.Record, error) {
+	status, err := ParseOperationStatus(m.operationStatus.Render(lookup))
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditumv1alpha1.Record{
+		ProjectId: projectID,
+		Labels:    renderTemplates(m.labels, lookup),
+		Resource: &auditumv1alpha1.Resource{
+			Type: m.resourceType.Render(lookup),
+			Id:   m.resourceID.Render(lookup),
+		},
+		Operation: &auditumv1alpha1.Operation{
+			Type:     m.operationType.Render(lookup),
+			Id:       m.operationID.Render(lookup),
+			Time:     timestamppb.New(operationTime),
+			Metadata: renderTemplates(m.metadata, lookup),
+			Status:   status,
+		},
+		Actor: &auditumv1alpha1.Actor{
+			Type: m.actorType.Render(lookup),
+			Id:   m.actorID.Render(lookup),
+		},
+	}, nil
+}
+
+// ParseOperationStatus parses the operation status by name, case
+// insensitive. Empty status is unspecified.
+func ParseOperationStatus(s string) (auditumv1alpha1.OperationStatus_Enum, error) {
+	switch strings.ToUpper(s) {
+	case "", "UNSPECIFIED":
+		return auditumv1alpha1.OperationStatus_UNSPECIFIED, nil
+	case "SUCCEEDED":
+		return auditumv1alpha1.OperationStatus_SUCCEEDED, nil
+	case "FAILED":
+		return auditumv1alpha1.OperationStatus_FAILED, nil
+	default:
+		return 0, fmt.Errorf("invalid operation status %q", s)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lookup returns the value of a field referenced in a template, or empty
+// string if there is none.
+type Lookup func(name string) string
+
+// MapLookup looks fields up in the map.
+func MapLookup(m map[string]string) Lookup {
+	return func(name string) string {
+		return m[name]
+	}
+}
+
+// Template is a value with ${name} and ${name:-fallback} references to
+// fields of a message. The fallback is used when the field is empty.
+type Template []templatePart
+
+type templatePart struct {
+	text     string
+	name     string
+	fallback string
+}
+
+func ParseTemplate(s string) (Template, error) {
+	var t Template
+
+	for s != "" {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			t = append(t, templatePart{text: s})
+			break
+		}
+		if start > 0 {
+			t = append(t, templatePart{text: s[:start]})
+		}
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated reference at %d", start)
+		}
+
+		ref := s[start+2 : start+end]
+		name, fallback, _ := strings.Cut(ref, ":-")
+		if name == "" {
+			return nil, fmt.Errorf("empty reference at %d", start)
+		}
+		t = append(t, templatePart{name: name, fallback: fallback})
+
+		s = s[start+end+1:]
+	}
+
+	return t, nil
+}
+
+func parseTemplates(src map[string]string) (map[string]Template, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	dst := make(map[string]Template, len(src))
+	for key, value := range src {
+		t, err := ParseTemplate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %q template: %v", key, err)
+		}
+		dst[key] = t
+	}
+	return dst, nil
+}
+
+// Static reports whether the template has no references.
+func (t Template) Static() bool {
+	for _, p := range t {
+		if p.name != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Template) Render(lookup Lookup) string {
+	var b strings.Builder
+	for _, p := range t {
+		if p.name == "" {
+			b.WriteString(p.text)
+			continue
+		}
+		if v := lookup(p.name); v != "" {
+			b.WriteString(v)
+		} else {
+			b.WriteString(p.fallback)
+		}
+	}
+	return b.String()
+}
+
+// renderTemplates renders templates, omitting empty values.
+func renderTemplates(src map[string]Template, lookup Lookup) map[string]string {
+	var dst map[string]string
+	for key, t := range src {
+		value := t.Render(lookup)
+		if value == "" {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string, len(src))
+		}
+		dst[key] = value
+	}
+	return dst
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeaudit
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+)
+
+// Maximum sizes of changes, as of default record restrictions. Changes
+// exceeding them are omitted rather than make the record invalid.
+const (
+	maxChangeNameSize  = 256
+	maxChangeValueSize = 4096
+)
+
+// DiffConfig is the configuration of changes of records, from differences
+// of request and response objects.
+type DiffConfig struct {
+	Enabled bool
+	// IgnoreFields are paths of fields not compared, with their nested
+	// fields, e.g. "metadata.managedFields".
+	IgnoreFields []string
+	// MaxChanges is the maximum number of changes of a record. Further
+	// changes are omitted.
+	MaxChanges int
+}
+
+// diffObjects returns changes of fields of the response object differing
+// from the request object, by field path, e.g. "spec.replicas" or
+// "spec.containers[0].image". Old value is the value of the request object,
+// new value is the value of the response object. Absent values are null.
+func diffObjects(
+	requestObject json.RawMessage,
+	responseObject json.RawMessage,
+	conf DiffConfig,
+) []*auditumv1alpha1.ResourceChange {
+	oldFields := flattenObject(requestObject)
+	newFields := flattenObject(responseObject)
+	if oldFields == nil || newFields == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(newFields))
+	for path := range newFields {
+		paths = append(paths, path)
+	}
+	for path := range oldFields {
+		if _, ok := newFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []*auditumv1alpha1.ResourceChange
+
+	for _, path := range paths {
+		if len(changes) >= conf.MaxChanges {
+			break
+		}
+		if len(path) > maxChangeNameSize || ignoredField(path, conf.IgnoreFields) {
+			continue
+		}
+
+		oldValue, newValue := oldFields[path], newFields[path]
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		if len(oldValue) > maxChangeValueSize || len(newValue) > maxChangeValueSize {
+			continue
+		}
+
+		changes = append(changes, &auditumv1alpha1.ResourceChange{
+			Name:     path,
+			OldValue: jsonValue(oldValue),
+			NewValue: jsonValue(newValue),
+		})
+	}
+
+	return changes
+}
+
+// flattenObject returns JSON encoded values of the object by field path, or
+// nil if the object is not a valid JSON object. Empty objects and arrays are
+// values.
+func flattenObject(object json.RawMessage) map[string][]byte {
+	decoder := json.NewDecoder(bytes.NewReader(object))
+	decoder.UseNumber()
+
+	var value map[string]any
+	if err := decoder.Decode(&value); err != nil || value == nil {
+		return nil
+	}
+
+	fields := make(map[string][]byte)
+	flattenValue(fields, "", value)
+	return fields
+}
+
+func flattenValue(fields map[string][]byte, path string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for key, nested := range v {
+				if path == "" {
+					flattenValue(fields, key, nested)
+				} else {
+					flattenValue(fields, path+"."+key, nested)
+				}
+			}
+			return
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, nested := range v {
+				flattenValue(fields, path+"["+strconv.Itoa(i)+"]", nested)
+			}
+			return
+		}
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	fields[path] = b
+}
+
+func ignoredField(path string, ignoreFields []string) bool {
+	for _, field := range ignoreFields {
+		if path == field ||
+			strings.HasPrefix(path, field+".") ||
+			strings.HasPrefix(path, field+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonValue returns the JSON encoded value, or null value if there is none.
+func jsonValue(b []byte) *structpb.Value {
+	if b == nil {
+		return structpb.NewNullValue()
+	}
+
+	var value structpb.Value
+	if err := value.UnmarshalJSON(b); err != nil {
+		return structpb.NewNullValue()
+	}
+	return &value
+}
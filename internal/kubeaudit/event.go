@@ -0,0 +1,114 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubeaudit receives Kubernetes audit events from the audit webhook
+// backend of kube-apiserver, and creates records from them.
+package kubeaudit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	eventListAPIVersion = "audit.k8s.io/v1"
+	eventListKind       = "EventList"
+)
+
+// Stages of audit events, in order of request handling.
+const (
+	StageRequestReceived  = "RequestReceived"
+	StageResponseStarted  = "ResponseStarted"
+	StageResponseComplete = "ResponseComplete"
+	StagePanic            = "Panic"
+)
+
+// Stages are valid stages of audit events, in order of request handling.
+var Stages = []string{
+	StageRequestReceived,
+	StageResponseStarted,
+	StageResponseComplete,
+	StagePanic,
+}
+
+// EventList is a list of audit events, as sent by the audit webhook backend.
+// Only fields mapped to records are decoded.
+type EventList struct {
+	APIVersion string  `json:"apiVersion"`
+	Kind       string  `json:"kind"`
+	Items      []Event `json:"items"`
+}
+
+// Event is an audit event of audit.k8s.io/v1 API.
+type Event struct {
+	Level                    string           `json:"level"`
+	AuditID                  string           `json:"auditID"`
+	Stage                    string           `json:"stage"`
+	RequestURI               string           `json:"requestURI"`
+	Verb                     string           `json:"verb"`
+	User                     UserInfo         `json:"user"`
+	ImpersonatedUser         *UserInfo        `json:"impersonatedUser,omitempty"`
+	SourceIPs                []string         `json:"sourceIPs,omitempty"`
+	UserAgent                string           `json:"userAgent,omitempty"`
+	ObjectRef                *ObjectReference `json:"objectRef,omitempty"`
+	ResponseStatus           *Status          `json:"responseStatus,omitempty"`
+	RequestObject            json.RawMessage  `json:"requestObject,omitempty"`
+	ResponseObject           json.RawMessage  `json:"responseObject,omitempty"`
+	RequestReceivedTimestamp time.Time        `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time        `json:"stageTimestamp"`
+}
+
+type UserInfo struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+type ObjectReference struct {
+	Resource        string `json:"resource,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	UID             string `json:"uid,omitempty"`
+	APIGroup        string `json:"apiGroup,omitempty"`
+	APIVersion      string `json:"apiVersion,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Subresource     string `json:"subresource,omitempty"`
+}
+
+type Status struct {
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Code    int32  `json:"code,omitempty"`
+}
+
+func decodeEventList(data []byte) (EventList, error) {
+	var list EventList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return EventList{}, fmt.Errorf("invalid JSON event list: %v", err)
+	}
+
+	if list.APIVersion != eventListAPIVersion || list.Kind != eventListKind {
+		return EventList{}, fmt.Errorf(
+			"must be %s %s, got %q %q",
+			eventListAPIVersion,
+			eventListKind,
+			list.APIVersion,
+			list.Kind,
+		)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeaudit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+const receiveEventsPathPattern = "/projects/{project_id}/kubernetes:audit"
+
+// maxRequestSize is the maximum size of a request body.
+const maxRequestSize = 16 << 20
+
+const metricsSource = "kubernetes"
+
+// Config is the configuration of the handler.
+type Config struct {
+	// Stages are stages of events records are created from. Events of
+	// other stages are dropped.
+	Stages []string
+	Diff   DiffConfig
+}
+
+// Handler receives audit event lists from the audit webhook backend of
+// kube-apiserver, and creates records from them.
+type Handler struct {
+	mapper *Mapper
+	log    *zap.Logger
+	now    func() time.Time
+}
+
+func NewHandler(conf Config, log *zap.Logger) (*Handler, error) {
+	mapper, err := NewMapper(conf.Stages, conf.Diff)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		mapper: mapper,
+		log:    log.Named("kubeaudit"),
+		now:    time.Now,
+	}, nil
+}
+
+// RegisterGateway registers the endpoint receiving events of the project at
+// "/projects/{project_id}/kubernetes:audit". Records are created with the
+// record service over the connection, so that requests are authenticated and
+// rate limited like BatchCreateRecords.
+func (h *Handler) RegisterGateway(_ context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	receive := &receiveHandler{
+		mapper:  h.mapper,
+		mux:     mux,
+		records: ingest.NewRecordClient(auditumv1alpha1.NewRecordServiceClient(conn)),
+		log:     h.log,
+		now:     h.now,
+	}
+
+	return mux.HandlePath(http.MethodPost, receiveEventsPathPattern, receive.ServeHTTP)
+}
+
+type receiveHandler struct {
+	mapper  *Mapper
+	mux     *runtime.ServeMux
+	records ingest.RecordCreator
+	log     *zap.Logger
+	now     func() time.Time
+}
+
+func (h *receiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	receiveTime := h.now()
+
+	_, outboundMarshaler := runtime.MarshalerForRequest(h.mux, r)
+
+	ctx, err := runtime.AnnotateContext(
+		r.Context(),
+		h.mux,
+		r,
+		auditumv1alpha1.RecordService_BatchCreateRecords_FullMethodName,
+		runtime.WithHTTPPathPattern(receiveEventsPathPattern),
+	)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+
+	projectID := pathParams["project_id"]
+	if _, err := aud.ParseID(projectID); err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, status.Errorf(
+			codes.InvalidArgument,
+			`Request is invalid. Invalid "project_id": %v.`,
+			err.Error(),
+		))
+		return
+	}
+
+	body, err := ingest.ReadBody(w, r, maxRequestSize)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, invalidRequestError(err))
+		return
+	}
+
+	list, err := decodeEventList(body)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, invalidRequestError(err))
+		return
+	}
+
+	resp, err := h.receive(ctx, projectID, list.Items, receiveTime)
+	if err != nil {
+		runtime.HTTPError(ctx, h.mux, outboundMarshaler, w, r, err)
+		return
+	}
+
+	ingest.WriteResults(w, resp)
+}
+
+func invalidRequestError(err error) error {
+	return status.Errorf(codes.InvalidArgument, `Request is invalid. %v.`, err.Error())
+}
+
+// receive creates records of the events in the project. Events of stages
+// not recorded, of requests already recorded, or mapped to invalid records,
+// are dropped. The returned error is a gRPC status.
+func (h *receiveHandler) receive(
+	ctx context.Context,
+	projectID string,
+	events []Event,
+	receiveTime time.Time,
+) (resp ingest.Results, err error) {
+	events, resp.Unmatched = h.mapper.dedupe(events)
+	for range resp.Unmatched {
+		metrics.IngestedMessage(metricsSource, ingest.ResultUnmatched)
+	}
+
+	records := make([]*auditumv1alpha1.Record, 0, len(events))
+	for _, event := range events {
+		record, err := h.mapper.Map(projectID, event, receiveTime)
+		if err != nil {
+			metrics.IngestedMessage(metricsSource, ingest.ResultInvalid)
+			resp.Invalid++
+			h.log.Debug("Dropped event", zap.String("audit_id", event.AuditID), zap.Error(err))
+			continue
+		}
+		records = append(records, record)
+	}
+
+	invalid, err := ingest.CreateRecords(ctx, h.records, metricsSource, projectID, records)
+	if err != nil {
+		return resp, err
+	}
+	for _, err := range invalid {
+		h.log.Debug("Dropped event mapped to invalid record", zap.Error(err))
+	}
+	resp.Created = len(records) - len(invalid)
+	resp.Invalid += len(invalid)
+
+	return resp, nil
+}
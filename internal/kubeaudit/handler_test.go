@@ -0,0 +1,243 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeaudit_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/kubeaudit"
+)
+
+// fakeRecordServer creates records, rejecting records with actor id
+// "invalid".
+type fakeRecordServer struct {
+	auditumv1alpha1.UnimplementedRecordServiceServer
+
+	mu      sync.Mutex
+	created []*auditumv1alpha1.Record
+}
+
+// create creates the records, skipping records with idempotency keys of
+// created records, like the store does.
+func (s *fakeRecordServer) create(records ...*auditumv1alpha1.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, record := range records {
+		if slices.ContainsFunc(s.created, func(created *auditumv1alpha1.Record) bool {
+			return created.GetIdempotencyKey() == record.GetIdempotencyKey()
+		}) {
+			continue
+		}
+		s.created = append(s.created, record)
+	}
+}
+
+func (s *fakeRecordServer) CreateRecord(
+	_ context.Context,
+	req *auditumv1alpha1.CreateRecordRequest,
+) (*auditumv1alpha1.CreateRecordResponse, error) {
+	if err := checkRecords(req.GetRecord()); err != nil {
+		return nil, err
+	}
+
+	s.create(req.GetRecord())
+
+	return &auditumv1alpha1.CreateRecordResponse{Record: req.GetRecord()}, nil
+}
+
+func (s *fakeRecordServer) BatchCreateRecords(
+	_ context.Context,
+	req *auditumv1alpha1.BatchCreateRecordsRequest,
+) (*auditumv1alpha1.BatchCreateRecordsResponse, error) {
+	if err := checkRecords(req.GetRecords()...); err != nil {
+		return nil, err
+	}
+
+	s.create(req.GetRecords()...)
+
+	return &auditumv1alpha1.BatchCreateRecordsResponse{Records: req.GetRecords()}, nil
+}
+
+func checkRecords(records ...*auditumv1alpha1.Record) error {
+	for _, record := range records {
+		if record.GetActor().GetId() == "invalid" {
+			return status.Error(codes.InvalidArgument, "Record is invalid.")
+		}
+	}
+	return nil
+}
+
+func newGateway(t *testing.T, records *fakeRecordServer) http.Handler {
+	t.Helper()
+
+	handler, err := kubeaudit.NewHandler(kubeaudit.Config{
+		Stages: testStages,
+		Diff:   testDiff,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	auditumv1alpha1.RegisterRecordServiceServer(srv, records)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, handler.RegisterGateway(context.Background(), mux, conn))
+
+	return mux
+}
+
+func postEventList(handler http.Handler, projectID string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/projects/"+projectID+"/kubernetes:audit",
+		strings.NewReader(body),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Receive(t *testing.T) {
+	t.Run("Should create records of first received stages of requests", func(t *testing.T) {
+		records := &fakeRecordServer{}
+		handler := newGateway(t, records)
+
+		rec := postEventList(handler, testProjectID, `{
+			"kind": "EventList",
+			"apiVersion": "audit.k8s.io/v1",
+			"items": [
+				{"auditID": "a1", "stage": "RequestReceived", "verb": "get", "user": {"username": "alice"}, "objectRef": {"resource": "pods", "namespace": "default", "name": "web"}},
+				{"auditID": "a2", "stage": "ResponseComplete", "verb": "list", "user": {"username": "bob"}, "objectRef": {"resource": "pods", "namespace": "default"}, "responseStatus": {"code": 200}},
+				{"auditID": "a1", "stage": "ResponseComplete", "verb": "get", "user": {"username": "alice"}, "objectRef": {"resource": "pods", "namespace": "default", "name": "web"}, "responseStatus": {"code": 200}},
+				{"auditID": "a2", "stage": "Panic", "verb": "list", "user": {"username": "bob"}, "objectRef": {"resource": "pods", "namespace": "default"}},
+				{"auditID": "a3", "stage": "ResponseComplete", "verb": "get", "user": {"username": "invalid"}, "objectRef": {"resource": "pods", "namespace": "default", "name": "db"}},
+				{"auditID": "a4", "stage": "ResponseComplete", "verb": "get", "user": {"username": "carol"}, "requestURI": "/version", "stageTimestamp": "2026-10-19T11:00:00.123456Z"}
+			]
+		}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.JSONEq(t, `{"created": 3, "unmatched": 2, "invalid": 1}`, rec.Body.String())
+		require.Len(t, records.created, 3)
+
+		assert.Equal(t, "a2", records.created[0].GetOperation().GetId())
+		assert.Equal(t, "ResponseComplete", records.created[0].GetOperation().GetMetadata()["stage"])
+		assert.Equal(t, "a1", records.created[1].GetOperation().GetId())
+		assert.Equal(t, "default/web", records.created[1].GetResource().GetId())
+		assert.Equal(t, "a4", records.created[2].GetOperation().GetId())
+		assert.Equal(t, testProjectID, records.created[2].GetProjectId())
+		assert.Equal(t, testTime.Add(123456*time.Microsecond), records.created[2].GetOperation().GetTime().AsTime())
+	})
+
+	t.Run("Should create one record of stages of request sent separately", func(t *testing.T) {
+		records := &fakeRecordServer{}
+		handler := newGateway(t, records)
+
+		rec := postEventList(handler, testProjectID, `{
+			"kind": "EventList",
+			"apiVersion": "audit.k8s.io/v1",
+			"items": [
+				{"auditID": "b1", "stage": "ResponseComplete", "verb": "get", "user": {"username": "alice"}, "objectRef": {"resource": "pods", "namespace": "default", "name": "web"}, "responseStatus": {"code": 200}}
+			]
+		}`)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = postEventList(handler, testProjectID, `{
+			"kind": "EventList",
+			"apiVersion": "audit.k8s.io/v1",
+			"items": [
+				{"auditID": "b1", "stage": "Panic", "verb": "get", "user": {"username": "alice"}, "objectRef": {"resource": "pods", "namespace": "default", "name": "web"}}
+			]
+		}`)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		require.Len(t, records.created, 1)
+		assert.Equal(t, "b1", records.created[0].GetOperation().GetId())
+		assert.Equal(t, "ResponseComplete", records.created[0].GetOperation().GetMetadata()["stage"])
+	})
+
+	t.Run("Should reject invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			projectID string
+			body      string
+		}{
+			{
+				name:      "invalid project id",
+				projectID: "default",
+				body:      `{"kind": "EventList", "apiVersion": "audit.k8s.io/v1", "items": []}`,
+			},
+			{
+				name:      "invalid JSON",
+				projectID: testProjectID,
+				body:      `{`,
+			},
+			{
+				name:      "unsupported version",
+				projectID: testProjectID,
+				body:      `{"kind": "EventList", "apiVersion": "audit.k8s.io/v1beta1", "items": []}`,
+			},
+			{
+				name:      "not an event list",
+				projectID: testProjectID,
+				body:      `{"kind": "Event", "apiVersion": "audit.k8s.io/v1"}`,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				records := &fakeRecordServer{}
+				handler := newGateway(t, records)
+
+				rec := postEventList(handler, test.projectID, test.body)
+
+				assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+				assert.Empty(t, records.created)
+			})
+		}
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeaudit
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+// Actor types of users, by username.
+const (
+	actorTypeUser           = "user"
+	actorTypeServiceAccount = "serviceaccount"
+	actorTypeNode           = "node"
+)
+
+// resourceTypeNonResourceURL is the resource type of requests to
+// non-resource URLs, e.g. "/healthz".
+const resourceTypeNonResourceURL = "nonResourceURL"
+
+// maxValueSize is the maximum size of values of metadata and resource id,
+// as of default record restrictions. Longer values are truncated rather than
+// make the record invalid.
+const maxValueSize = 256
+
+// diffVerbs are verbs of requests the request object of which is the full
+// object, compared with the response object.
+var diffVerbs = []string{"create", "update"}
+
+var errStageSkipped = errors.New("stage is not recorded")
+
+// Mapper maps audit events to records.
+type Mapper struct {
+	stages []string
+	diff   DiffConfig
+}
+
+// NewMapper returns a mapper of audit events of the stages.
+func NewMapper(stages []string, diff DiffConfig) (*Mapper, error) {
+	for _, stage := range stages {
+		if !slices.Contains(Stages, stage) {
+			return nil, fmt.Errorf("invalid stage %q", stage)
+		}
+	}
+
+	return &Mapper{
+		stages: stages,
+		diff:   diff,
+	}, nil
+}
+
+// Map maps the audit event to a record of the project:
+//   - auditID is the operation id, verb is the operation type;
+//   - objectRef is the resource, of type "<resource>[.<apiGroup>][/<subresource>]"
+//     and id "[<namespace>/]<name>", with "*" name for collections;
+//   - user is the actor;
+//   - responseStatus code is the operation status;
+//   - stageTimestamp is the operation time.
+//
+// Records of events of the same request have the idempotency key of
+// auditID, so that only the first received event of a request is recorded,
+// even if events of other stages come in other event lists.
+//
+// Changes of the resource are differences of request and response objects
+// of create and update requests, if enabled.
+func (m *Mapper) Map(projectID string, event Event, receiveTime time.Time) (*auditumv1alpha1.Record, error) {
+	if !slices.Contains(m.stages, event.Stage) {
+		return nil, errStageSkipped
+	}
+
+	operationTime := event.StageTimestamp
+	if operationTime.IsZero() {
+		operationTime = event.RequestReceivedTimestamp
+	}
+	if operationTime.IsZero() {
+		operationTime = receiveTime
+	}
+
+	resource := eventResource(event)
+	if m.diff.Enabled && slices.Contains(diffVerbs, event.Verb) {
+		resource.Changes = diffObjects(event.RequestObject, event.ResponseObject, m.diff)
+	}
+
+	var idempotencyKey string
+	if event.AuditID != "" {
+		idempotencyKey = ingest.IdempotencyKey(metricsSource, event.AuditID)
+	}
+
+	return &auditumv1alpha1.Record{
+		ProjectId:      projectID,
+		IdempotencyKey: idempotencyKey,
+		Resource:       resource,
+		Operation: &auditumv1alpha1.Operation{
+			Type:     event.Verb,
+			Id:       event.AuditID,
+			Time:     timestamppb.New(operationTime),
+			Metadata: operationMetadata(event),
+			Status:   operationStatus(event),
+		},
+		Actor: &auditumv1alpha1.Actor{
+			Type:     actorType(event.User.Username),
+			Id:       event.User.Username,
+			Metadata: metadata("uid", event.User.UID),
+		},
+	}, nil
+}
+
+// dedupe returns events of the recorded stages, one of each request in the
+// list: the first received one, as for requests recorded from earlier lists,
+// which are skipped on creation by idempotency key. Order of events is
+// preserved. Dropped events are counted.
+func (m *Mapper) dedupe(events []Event) (deduped []Event, dropped int) {
+	seen := make(map[string]bool, len(events))
+
+	for _, event := range events {
+		if !slices.Contains(m.stages, event.Stage) || seen[event.AuditID] {
+			dropped++
+			continue
+		}
+
+		if event.AuditID != "" {
+			seen[event.AuditID] = true
+		}
+		deduped = append(deduped, event)
+	}
+
+	return deduped, dropped
+}
+
+func eventResource(event Event) *auditumv1alpha1.Resource {
+	ref := event.ObjectRef
+	if ref == nil || ref.Resource == "" {
+		path := event.RequestURI
+		if u, err := url.ParseRequestURI(event.RequestURI); err == nil {
+			path = u.Path
+		}
+		return &auditumv1alpha1.Resource{
+			Type: resourceTypeNonResourceURL,
+			Id:   truncate(path, maxValueSize),
+		}
+	}
+
+	typ := ref.Resource
+	if ref.APIGroup != "" {
+		typ += "." + ref.APIGroup
+	}
+	if ref.Subresource != "" {
+		typ += "/" + ref.Subresource
+	}
+
+	id := ref.Name
+	if id == "" {
+		id = "*"
+	}
+	if ref.Namespace != "" {
+		id = ref.Namespace + "/" + id
+	}
+
+	return &auditumv1alpha1.Resource{
+		Type: typ,
+		Id:   truncate(id, maxValueSize),
+		Metadata: metadata(
+			"api_group", ref.APIGroup,
+			"api_version", ref.APIVersion,
+			"namespace", ref.Namespace,
+			"name", ref.Name,
+			"uid", ref.UID,
+			"resource_version", ref.ResourceVersion,
+			"subresource", ref.Subresource,
+		),
+	}
+}
+
+func operationMetadata(event Event) map[string]string {
+	var code, reason, impersonatedUser string
+	if event.ResponseStatus != nil {
+		if event.ResponseStatus.Code != 0 {
+			code = strconv.Itoa(int(event.ResponseStatus.Code))
+		}
+		reason = event.ResponseStatus.Reason
+	}
+	if event.ImpersonatedUser != nil {
+		impersonatedUser = event.ImpersonatedUser.Username
+	}
+
+	return metadata(
+		"stage", event.Stage,
+		"level", event.Level,
+		"request_uri", event.RequestURI,
+		"user_agent", event.UserAgent,
+		"source_ips", strings.Join(event.SourceIPs, ","),
+		"response_code", code,
+		"response_reason", reason,
+		"impersonated_user", impersonatedUser,
+	)
+}
+
+// operationStatus returns the status of the response code, or failed if the
+// request panicked.
+func operationStatus(event Event) auditumv1alpha1.OperationStatus_Enum {
+	switch {
+	case event.Stage == StagePanic:
+		return auditumv1alpha1.OperationStatus_FAILED
+	case event.ResponseStatus == nil || event.ResponseStatus.Code == 0:
+		return auditumv1alpha1.OperationStatus_UNSPECIFIED
+	case event.ResponseStatus.Code < 400:
+		return auditumv1alpha1.OperationStatus_SUCCEEDED
+	default:
+		return auditumv1alpha1.OperationStatus_FAILED
+	}
+}
+
+func actorType(username string) string {
+	switch {
+	case strings.HasPrefix(username, "system:serviceaccount:"):
+		return actorTypeServiceAccount
+	case strings.HasPrefix(username, "system:node:"):
+		return actorTypeNode
+	default:
+		return actorTypeUser
+	}
+}
+
+// metadata returns metadata of key and value pairs, omitting empty values.
+func metadata(keyValues ...string) map[string]string {
+	var dst map[string]string
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		key, value := keyValues[i], keyValues[i+1]
+		if value == "" {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string)
+		}
+		dst[key] = truncate(value, maxValueSize)
+	}
+	return dst
+}
+
+// truncate returns at most n bytes of the string, not splitting runes.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeaudit_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/kubeaudit"
+)
+
+const testProjectID = "0190dc5c-1b0d-7bd4-9b0e-5e3a1e1b1e1b"
+
+var testStages = []string{kubeaudit.StageResponseComplete, kubeaudit.StagePanic}
+
+var testDiff = kubeaudit.DiffConfig{
+	Enabled:      true,
+	IgnoreFields: []string{"metadata.managedFields", "metadata.resourceVersion", "status"},
+	MaxChanges:   20,
+}
+
+var testTime = time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+
+func TestMapper_Map(t *testing.T) {
+	mapper, err := kubeaudit.NewMapper(testStages, testDiff)
+	require.NoError(t, err)
+
+	receiveTime := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should map event of namespaced resource", func(t *testing.T) {
+		event := kubeaudit.Event{
+			Level:      "Metadata",
+			AuditID:    "6f2c1a0e-4d7b-4c1e-9a55-0d0b7a3e2f10",
+			Stage:      kubeaudit.StageResponseComplete,
+			RequestURI: "/apis/apps/v1/namespaces/default/deployments/web",
+			Verb:       "delete",
+			User: kubeaudit.UserInfo{
+				Username: "alice@example.com",
+				UID:      "u-1",
+				Groups:   []string{"system:authenticated"},
+			},
+			SourceIPs: []string{"10.0.0.1", "10.0.0.2"},
+			UserAgent: "kubectl/v1.31.0",
+			ObjectRef: &kubeaudit.ObjectReference{
+				Resource:   "deployments",
+				Namespace:  "default",
+				Name:       "web",
+				APIGroup:   "apps",
+				APIVersion: "v1",
+			},
+			ResponseStatus: &kubeaudit.Status{
+				Code: 200,
+			},
+			RequestReceivedTimestamp: testTime.Add(-time.Second),
+			StageTimestamp:           testTime,
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+
+		want := &auditumv1alpha1.Record{
+			ProjectId:      testProjectID,
+			IdempotencyKey: ingest.IdempotencyKey("kubernetes", "6f2c1a0e-4d7b-4c1e-9a55-0d0b7a3e2f10"),
+			Resource: &auditumv1alpha1.Resource{
+				Type: "deployments.apps",
+				Id:   "default/web",
+				Metadata: map[string]string{
+					"api_group":   "apps",
+					"api_version": "v1",
+					"namespace":   "default",
+					"name":        "web",
+				},
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type: "delete",
+				Id:   "6f2c1a0e-4d7b-4c1e-9a55-0d0b7a3e2f10",
+				Time: timestamppb.New(testTime),
+				Metadata: map[string]string{
+					"stage":         "ResponseComplete",
+					"level":         "Metadata",
+					"request_uri":   "/apis/apps/v1/namespaces/default/deployments/web",
+					"user_agent":    "kubectl/v1.31.0",
+					"source_ips":    "10.0.0.1,10.0.0.2",
+					"response_code": "200",
+				},
+				Status: auditumv1alpha1.OperationStatus_SUCCEEDED,
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type: "user",
+				Id:   "alice@example.com",
+				Metadata: map[string]string{
+					"uid": "u-1",
+				},
+			},
+		}
+		assertRecord(t, want, got)
+	})
+
+	t.Run("Should map event of collection", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID: "a1",
+			Stage:   kubeaudit.StageResponseComplete,
+			Verb:    "list",
+			User:    kubeaudit.UserInfo{Username: "system:serviceaccount:kube-system:replicaset-controller"},
+			ObjectRef: &kubeaudit.ObjectReference{
+				Resource:  "pods",
+				Namespace: "default",
+			},
+			ResponseStatus: &kubeaudit.Status{Code: 403, Reason: "Forbidden"},
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+		assert.Equal(t, "pods", got.GetResource().GetType())
+		assert.Equal(t, "default/*", got.GetResource().GetId())
+		assert.Equal(t, "serviceaccount", got.GetActor().GetType())
+		assert.Equal(t, auditumv1alpha1.OperationStatus_FAILED, got.GetOperation().GetStatus())
+		assert.Equal(t, "Forbidden", got.GetOperation().GetMetadata()["response_reason"])
+		assert.Equal(t, receiveTime, got.GetOperation().GetTime().AsTime())
+	})
+
+	t.Run("Should map event of subresource of cluster scoped resource", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID: "a2",
+			Stage:   kubeaudit.StagePanic,
+			Verb:    "update",
+			User:    kubeaudit.UserInfo{Username: "system:node:worker-1"},
+			ObjectRef: &kubeaudit.ObjectReference{
+				Resource:    "nodes",
+				Name:        "worker-1",
+				Subresource: "status",
+			},
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+		assert.Equal(t, "nodes/status", got.GetResource().GetType())
+		assert.Equal(t, "worker-1", got.GetResource().GetId())
+		assert.Equal(t, "node", got.GetActor().GetType())
+		assert.Equal(t, auditumv1alpha1.OperationStatus_FAILED, got.GetOperation().GetStatus())
+	})
+
+	t.Run("Should map event of non-resource URL", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID:    "a3",
+			Stage:      kubeaudit.StageResponseComplete,
+			Verb:       "get",
+			RequestURI: "/healthz?verbose=1",
+			User:       kubeaudit.UserInfo{Username: "system:anonymous"},
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+		assert.Equal(t, "nonResourceURL", got.GetResource().GetType())
+		assert.Equal(t, "/healthz", got.GetResource().GetId())
+	})
+
+	t.Run("Should diff request and response objects", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID: "a4",
+			Stage:   kubeaudit.StageResponseComplete,
+			Verb:    "update",
+			User:    kubeaudit.UserInfo{Username: "alice@example.com"},
+			ObjectRef: &kubeaudit.ObjectReference{
+				Resource: "deployments",
+				APIGroup: "apps",
+			},
+			RequestObject: json.RawMessage(`{
+				"metadata": {"name": "web", "resourceVersion": "1", "labels": {"tier": "web"}},
+				"spec": {"replicas": 3, "template": {"spec": {"containers": [{"image": "web:1"}]}}}
+			}`),
+			ResponseObject: json.RawMessage(`{
+				"metadata": {"name": "web", "resourceVersion": "2", "managedFields": [{"manager": "kubectl"}]},
+				"spec": {"replicas": 3, "template": {"spec": {"containers": [{"image": "web:2"}]}}},
+				"status": {"replicas": 3}
+			}`),
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+
+		want := []*auditumv1alpha1.ResourceChange{
+			{
+				Name:     "metadata.labels.tier",
+				OldValue: structpb.NewStringValue("web"),
+				NewValue: structpb.NewNullValue(),
+			},
+			{
+				Name:     "spec.template.spec.containers[0].image",
+				OldValue: structpb.NewStringValue("web:1"),
+				NewValue: structpb.NewStringValue("web:2"),
+			},
+		}
+		require.Len(t, got.GetResource().GetChanges(), len(want))
+		for i := range want {
+			assert.True(t, proto.Equal(want[i], got.GetResource().GetChanges()[i]), "change %d: %v", i, got.GetResource().GetChanges()[i])
+		}
+	})
+
+	t.Run("Should not diff objects of patch requests", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID:        "a5",
+			Stage:          kubeaudit.StageResponseComplete,
+			Verb:           "patch",
+			User:           kubeaudit.UserInfo{Username: "alice@example.com"},
+			ObjectRef:      &kubeaudit.ObjectReference{Resource: "configmaps", Namespace: "default", Name: "app"},
+			RequestObject:  json.RawMessage(`{"data": {"key": "new"}}`),
+			ResponseObject: json.RawMessage(`{"metadata": {"name": "app"}, "data": {"key": "new"}}`),
+		}
+
+		got, err := mapper.Map(testProjectID, event, receiveTime)
+		require.NoError(t, err)
+		assert.Empty(t, got.GetResource().GetChanges())
+	})
+
+	t.Run("Should not map event of stage not recorded", func(t *testing.T) {
+		event := kubeaudit.Event{
+			AuditID: "a6",
+			Stage:   kubeaudit.StageRequestReceived,
+			Verb:    "get",
+			User:    kubeaudit.UserInfo{Username: "alice@example.com"},
+		}
+
+		_, err := mapper.Map(testProjectID, event, receiveTime)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewMapper_Invalid(t *testing.T) {
+	_, err := kubeaudit.NewMapper([]string{"ResponseFinished"}, kubeaudit.DiffConfig{})
+	assert.Error(t, err)
+}
+
+func assertRecord(t *testing.T, want, got *auditumv1alpha1.Record) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want:\n%v\ngot:\n%v", want, got)
+}
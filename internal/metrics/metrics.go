@@ -0,0 +1,82 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics contains Prometheus metrics of auditum.
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const namespace = "auditum"
+
+// Register registers all auditum metrics in the registerer.
+func Register(reg prometheus.Registerer, store UsageStore, log *zap.Logger) error {
+	return errors.Join(
+		reg.Register(NewProjectUsageCollector(store, log)),
+		reg.Register(quotaExceededTotal),
+		reg.Register(rateLimitedTotal),
+		reg.Register(webhookDeliveryAttemptsTotal),
+		reg.Register(ingestedMessagesTotal),
+	)
+}
+
+var rateLimitedTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "rate_limited_total",
+		Help:      "Number of requests rejected because the rate limit was exceeded.",
+	},
+	[]string{"grpc_method", "class"},
+)
+
+// RateLimited counts a request rejected because of the rate limit.
+func RateLimited(method string, class string) {
+	rateLimitedTotal.WithLabelValues(method, class).Inc()
+}
+
+var webhookDeliveryAttemptsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "delivery_attempts_total",
+		Help:      "Number of webhook delivery attempts by result: succeeded, retried or failed.",
+	},
+	[]string{"result"},
+)
+
+// WebhookDeliveryAttempt counts a webhook delivery attempt.
+func WebhookDeliveryAttempt(result string) {
+	webhookDeliveryAttemptsTotal.WithLabelValues(result).Inc()
+}
+
+var ingestedMessagesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "messages_total",
+		Help:      "Number of messages received by ingestion sources by result: created, unmatched, invalid or failed.",
+	},
+	[]string{"source", "result"},
+)
+
+// IngestedMessage counts a message received by an ingestion source, e.g.
+// syslog.
+func IngestedMessage(source string, result string) {
+	ingestedMessagesTotal.WithLabelValues(source, result).Inc()
+}
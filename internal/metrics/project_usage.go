@@ -0,0 +1,122 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type UsageStore interface {
+	ListProjectUsage(ctx context.Context) ([]aud.ProjectUsage, error)
+}
+
+// ProjectUsageCollector exposes usage counters of all projects. The counters
+// are read from the store on every scrape, so they are consistent across
+// all instances.
+type ProjectUsageCollector struct {
+	store UsageStore
+	log   *zap.Logger
+
+	now     func() time.Time
+	timeout time.Duration
+
+	records       *prometheus.Desc
+	bytes         *prometheus.Desc
+	minuteRecords *prometheus.Desc
+	dayRecords    *prometheus.Desc
+}
+
+func NewProjectUsageCollector(store UsageStore, log *zap.Logger) *ProjectUsageCollector {
+	labels := []string{"project_id"}
+
+	return &ProjectUsageCollector{
+		store:   store,
+		log:     log.Named("project_usage_collector"),
+		now:     time.Now,
+		timeout: 10 * time.Second,
+		records: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "project", "records"),
+			"Number of records stored in the project.",
+			labels, nil,
+		),
+		bytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "project", "bytes"),
+			"Size of records stored in the project, in bytes.",
+			labels, nil,
+		),
+		minuteRecords: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "project", "minute_records"),
+			"Number of records created in the project within the current UTC minute.",
+			labels, nil,
+		),
+		dayRecords: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "project", "day_records"),
+			"Number of records created in the project within the current UTC day.",
+			labels, nil,
+		),
+	}
+}
+
+func (c *ProjectUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.records
+	ch <- c.bytes
+	ch <- c.minuteRecords
+	ch <- c.dayRecords
+}
+
+func (c *ProjectUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	usages, err := c.store.ListProjectUsage(ctx)
+	if err != nil {
+		c.log.Error("List project usage in store", zap.Error(err))
+		ch <- prometheus.NewInvalidMetric(c.records, err)
+		return
+	}
+
+	now := c.now()
+
+	for _, usage := range usages {
+		usage = usage.At(now)
+		projectID := usage.ProjectID.String()
+
+		ch <- prometheus.MustNewConstMetric(c.records, prometheus.GaugeValue, float64(usage.Records), projectID)
+		ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.GaugeValue, float64(usage.Bytes), projectID)
+		ch <- prometheus.MustNewConstMetric(c.minuteRecords, prometheus.GaugeValue, float64(usage.MinuteRecords), projectID)
+		ch <- prometheus.MustNewConstMetric(c.dayRecords, prometheus.GaugeValue, float64(usage.DayRecords), projectID)
+	}
+}
+
+var quotaExceededTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "project",
+		Name:      "quota_exceeded_total",
+		Help:      "Number of requests rejected because the project quota was exceeded.",
+	},
+	[]string{"project_id", "quota"},
+)
+
+// QuotaExceeded counts a request rejected because of the project quota.
+func QuotaExceeded(projectID aud.ID, quota aud.QuotaName) {
+	quotaExceededTotal.WithLabelValues(projectID.String(), string(quota)).Inc()
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/auditumio/auditum/internal/auth"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+// Class of a method, limited separately.
+type Class string
+
+const (
+	ClassRead  Class = "read"
+	ClassWrite Class = "write"
+)
+
+// Rule describes rate limiting of a gRPC method.
+type Rule struct {
+	Class Class
+	// ProjectID returns the project targeted by the request. If nil, the
+	// method does not target a single project.
+	ProjectID func(req any) string
+}
+
+// Key is a part of the bucket key of a request.
+type Key string
+
+const (
+	// KeyPrincipal limits requests of each authenticated principal. When
+	// authentication is disabled, the peer IP is used instead.
+	KeyPrincipal Key = "principal"
+	// KeyProject limits requests to each project.
+	KeyProject Key = "project"
+	// KeyMethod limits requests to each method.
+	KeyMethod Key = "method"
+	// KeyIP limits requests from each peer IP.
+	KeyIP Key = "ip"
+)
+
+// Rate limit headers sent in response metadata. gRPC-Gateway forwards them
+// to HTTP clients.
+const (
+	HeaderLimit     = "x-ratelimit-limit"
+	HeaderRemaining = "x-ratelimit-remaining"
+	HeaderReset     = "x-ratelimit-reset"
+	HeaderRetry     = "retry-after"
+)
+
+type Config struct {
+	// Keys of the bucket of a request. Requests with the same values of
+	// all keys share the bucket.
+	Keys  []Key
+	Read  Limit
+	Write Limit
+}
+
+// Interceptor limits the rate of requests to gRPC methods according to the
+// rules. Methods without a rule, e.g. health checks, are not limited.
+//
+// It must be chained after the authentication interceptor to limit by
+// principal.
+type Interceptor struct {
+	keys     []Key
+	limiters map[Class]*Limiter
+	rules    map[string]Rule
+	log      *zap.Logger
+
+	now func() time.Time
+}
+
+func NewInterceptor(conf Config, rules map[string]Rule, log *zap.Logger) *Interceptor {
+	return &Interceptor{
+		keys: conf.Keys,
+		limiters: map[Class]*Limiter{
+			ClassRead:  NewLimiter(conf.Read),
+			ClassWrite: NewLimiter(conf.Write),
+		},
+		rules: rules,
+		log:   log.Named("ratelimit"),
+		now:   time.Now,
+	}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		res, ok := i.allow(ctx, info.FullMethod, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		_ = grpc.SetHeader(ctx, resultHeaders(res))
+
+		if !res.Allowed {
+			return nil, rateLimitedError(info.FullMethod, res)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream limits opening of streams. Messages received in the stream are not
+// limited.
+//
+// When limiting by project, streams of methods targeting a project are
+// limited on receiving the first message, which sets the project of the
+// stream, rather than on opening.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if rule, ok := i.rules[info.FullMethod]; ok &&
+			rule.ProjectID != nil && slices.Contains(i.keys, KeyProject) {
+			return handler(srv, &limitedServerStream{
+				ServerStream: ss,
+				interceptor:  i,
+				method:       info.FullMethod,
+			})
+		}
+
+		res, ok := i.allow(ss.Context(), info.FullMethod, nil)
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		_ = ss.SetHeader(resultHeaders(res))
+
+		if !res.Allowed {
+			return rateLimitedError(info.FullMethod, res)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// limitedServerStream limits the stream by the first received request.
+type limitedServerStream struct {
+	grpc.ServerStream
+	interceptor *Interceptor
+	method      string
+	received    bool
+}
+
+func (s *limitedServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.received {
+		return err
+	}
+	s.received = true
+
+	res, ok := s.interceptor.allow(s.Context(), s.method, m)
+	if !ok {
+		return nil
+	}
+
+	_ = s.SetHeader(resultHeaders(res))
+
+	if !res.Allowed {
+		return rateLimitedError(s.method, res)
+	}
+
+	return nil
+}
+
+// allow takes a token for the request. It returns false if the method is
+// not limited.
+func (i *Interceptor) allow(ctx context.Context, method string, req any) (Result, bool) {
+	rule, ok := i.rules[method]
+	if !ok {
+		return Result{}, false
+	}
+
+	limiter, ok := i.limiters[rule.Class]
+	if !ok {
+		i.log.Warn("Unknown method class",
+			zap.String("method", method),
+			zap.String("class", string(rule.Class)),
+		)
+		return Result{}, false
+	}
+
+	res := limiter.Allow(i.key(ctx, method, rule, req), i.now())
+	if !res.Allowed {
+		metrics.RateLimited(method, string(rule.Class))
+	}
+
+	return res, true
+}
+
+// key returns the bucket key of the request.
+func (i *Interceptor) key(ctx context.Context, method string, rule Rule, req any) string {
+	parts := make([]string, 0, len(i.keys))
+
+	for _, key := range i.keys {
+		var value string
+		switch key {
+		case KeyPrincipal:
+			if principal, ok := auth.PrincipalFromContext(ctx); ok {
+				value = principal.ID
+			} else {
+				value = "ip:" + peerIP(ctx)
+			}
+		case KeyProject:
+			if rule.ProjectID != nil && req != nil {
+				value = rule.ProjectID(req)
+			}
+		case KeyMethod:
+			value = method
+		case KeyIP:
+			value = peerIP(ctx)
+		}
+
+		parts = append(parts, string(key)+"="+value)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// peerIP returns the IP of the client. For requests forwarded by
+// gRPC-Gateway over unix socket, it is the last address in
+// "x-forwarded-for", which is set by the gateway from the HTTP connection.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if _, ok := p.Addr.(*net.UnixAddr); ok {
+		values := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for")
+		if len(values) == 0 {
+			return ""
+		}
+		forwarded := strings.Split(values[len(values)-1], ",")
+		return strings.TrimSpace(forwarded[len(forwarded)-1])
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func resultHeaders(res Result) metadata.MD {
+	md := metadata.Pairs(
+		HeaderLimit, strconv.Itoa(res.Limit),
+		HeaderRemaining, strconv.Itoa(res.Remaining),
+		HeaderReset, strconv.FormatInt(seconds(res.Reset), 10),
+	)
+	if !res.Allowed {
+		md.Set(HeaderRetry, strconv.FormatInt(seconds(res.RetryAfter), 10))
+	}
+	return md
+}
+
+// rateLimitedError returns RESOURCE_EXHAUSTED status with the delay before
+// the next request is allowed.
+func rateLimitedError(method string, res Result) error {
+	st := status.New(codes.ResourceExhausted, "Rate limit exceeded. Retry later.")
+
+	withDetails, err := st.WithDetails(
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     "method:" + method,
+					Description: "request rate limit exceeded",
+				},
+			},
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(res.RetryAfter),
+		},
+	)
+	if err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// seconds rounds the duration up to whole seconds.
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,336 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/auditumio/auditum/internal/auth"
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+type projectRequest struct {
+	projectID string
+}
+
+func (r projectRequest) GetProjectId() string {
+	return r.projectID
+}
+
+// headerStream captures headers set by the interceptor.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+var testRules = map[string]ratelimit.Rule{
+	"/test.Service/Get": {
+		Class:     ratelimit.ClassRead,
+		ProjectID: func(req any) string { return req.(interface{ GetProjectId() string }).GetProjectId() },
+	},
+	"/test.Service/List": {
+		Class: ratelimit.ClassRead,
+	},
+	"/test.Service/Create": {
+		Class:     ratelimit.ClassWrite,
+		ProjectID: func(req any) string { return req.(interface{ GetProjectId() string }).GetProjectId() },
+	},
+}
+
+type call struct {
+	method    string
+	principal string
+	ip        string
+	projectID string
+}
+
+func (c call) invoke(interceptor grpc.UnaryServerInterceptor) (metadata.MD, error) {
+	ctx := context.Background()
+	if c.principal != "" {
+		ctx = auth.ContextWithPrincipal(ctx, auth.Principal{ID: c.principal})
+	}
+	ctx = peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(c.ip), Port: 50000},
+	})
+
+	stream := &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	_, err := interceptor(
+		ctx,
+		projectRequest{projectID: c.projectID},
+		&grpc.UnaryServerInfo{FullMethod: c.method},
+		func(context.Context, any) (any, error) { return nil, nil },
+	)
+
+	return stream.header, err
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []ratelimit.Key
+		first   call
+		second  call
+		limited bool
+	}{
+		{
+			name:    "same principal",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1", ip: "10.0.0.1"},
+			second:  call{method: "/test.Service/List", principal: "apikey:1", ip: "10.0.0.2"},
+			limited: true,
+		},
+		{
+			name:    "different principals",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1"},
+			second:  call{method: "/test.Service/Get", principal: "apikey:2"},
+			limited: false,
+		},
+		{
+			name:    "principal falls back to ip without authentication",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal},
+			first:   call{method: "/test.Service/Get", ip: "10.0.0.1"},
+			second:  call{method: "/test.Service/Get", ip: "10.0.0.2"},
+			limited: false,
+		},
+		{
+			name:    "same project",
+			keys:    []ratelimit.Key{ratelimit.KeyProject},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1", projectID: "p1"},
+			second:  call{method: "/test.Service/Get", principal: "apikey:2", projectID: "p1"},
+			limited: true,
+		},
+		{
+			name:    "different projects",
+			keys:    []ratelimit.Key{ratelimit.KeyProject},
+			first:   call{method: "/test.Service/Get", projectID: "p1"},
+			second:  call{method: "/test.Service/Get", projectID: "p2"},
+			limited: false,
+		},
+		{
+			name:    "different methods",
+			keys:    []ratelimit.Key{ratelimit.KeyMethod},
+			first:   call{method: "/test.Service/Get"},
+			second:  call{method: "/test.Service/List"},
+			limited: false,
+		},
+		{
+			name:    "same ip",
+			keys:    []ratelimit.Key{ratelimit.KeyIP},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1", ip: "10.0.0.1"},
+			second:  call{method: "/test.Service/Get", principal: "apikey:2", ip: "10.0.0.1"},
+			limited: true,
+		},
+		{
+			name:    "combined keys",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal, ratelimit.KeyMethod},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1"},
+			second:  call{method: "/test.Service/List", principal: "apikey:1"},
+			limited: false,
+		},
+		{
+			name:    "read and write are limited separately",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal},
+			first:   call{method: "/test.Service/Get", principal: "apikey:1"},
+			second:  call{method: "/test.Service/Create", principal: "apikey:1"},
+			limited: false,
+		},
+		{
+			name:    "method without rule",
+			keys:    []ratelimit.Key{ratelimit.KeyPrincipal},
+			first:   call{method: "/grpc.health.v1.Health/Check"},
+			second:  call{method: "/grpc.health.v1.Health/Check"},
+			limited: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interceptor := ratelimit.NewInterceptor(
+				ratelimit.Config{
+					Keys:  test.keys,
+					Read:  ratelimit.Limit{Rate: 0.001, Burst: 1},
+					Write: ratelimit.Limit{Rate: 0.001, Burst: 1},
+				},
+				testRules,
+				zap.NewNop(),
+			).Unary()
+
+			_, err := test.first.invoke(interceptor)
+			require.NoError(t, err)
+
+			_, err = test.second.invoke(interceptor)
+			if test.limited {
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInterceptor_Unary_Headers(t *testing.T) {
+	interceptor := ratelimit.NewInterceptor(
+		ratelimit.Config{
+			Keys:  []ratelimit.Key{ratelimit.KeyPrincipal},
+			Read:  ratelimit.Limit{Rate: 0.5, Burst: 2},
+			Write: ratelimit.Limit{Rate: 0.5, Burst: 2},
+		},
+		testRules,
+		zap.NewNop(),
+	).Unary()
+
+	c := call{method: "/test.Service/List", principal: "apikey:1"}
+
+	header, err := c.invoke(interceptor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, header.Get(ratelimit.HeaderLimit))
+	assert.Equal(t, []string{"1"}, header.Get(ratelimit.HeaderRemaining))
+	assert.Equal(t, []string{"2"}, header.Get(ratelimit.HeaderReset))
+	assert.Empty(t, header.Get(ratelimit.HeaderRetry))
+
+	_, err = c.invoke(interceptor)
+	require.NoError(t, err)
+
+	header, err = c.invoke(interceptor)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"0"}, header.Get(ratelimit.HeaderRemaining))
+	assert.Equal(t, []string{"2"}, header.Get(ratelimit.HeaderRetry))
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	require.NotNil(t, retryInfo)
+	assert.Positive(t, retryInfo.GetRetryDelay().AsDuration())
+}
+
+func TestInterceptor_Unary_GatewayPeerIP(t *testing.T) {
+	interceptor := ratelimit.NewInterceptor(
+		ratelimit.Config{
+			Keys:  []ratelimit.Key{ratelimit.KeyIP},
+			Read:  ratelimit.Limit{Rate: 0.001, Burst: 1},
+			Write: ratelimit.Limit{Rate: 0.001, Burst: 1},
+		},
+		testRules,
+		zap.NewNop(),
+	).Unary()
+
+	invoke := func(forwardedFor string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.UnixAddr{Name: "/tmp/auditum.sock", Net: "unix"},
+		})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &headerStream{})
+
+		_, err := interceptor(
+			ctx,
+			projectRequest{},
+			&grpc.UnaryServerInfo{FullMethod: "/test.Service/List"},
+			func(context.Context, any) (any, error) { return nil, nil },
+		)
+		return err
+	}
+
+	require.NoError(t, invoke("10.0.0.1"))
+	// Client provided addresses are ignored, the last one is set by the
+	// gateway.
+	assert.Equal(t, codes.ResourceExhausted, status.Code(invoke("10.0.0.2, 10.0.0.1")))
+	assert.NoError(t, invoke("10.0.0.1, 10.0.0.2"))
+}
+
+// projectStream receives requests of the project.
+type projectStream struct {
+	grpc.ServerStream
+	projectID string
+	header    metadata.MD
+}
+
+func (s *projectStream) Context() context.Context {
+	return auth.ContextWithPrincipal(context.Background(), auth.Principal{ID: "apikey:1"})
+}
+
+func (s *projectStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *projectStream) RecvMsg(m any) error {
+	*m.(*projectRequest) = projectRequest{projectID: s.projectID}
+	return nil
+}
+
+func TestInterceptor_Stream_Project(t *testing.T) {
+	interceptor := ratelimit.NewInterceptor(
+		ratelimit.Config{
+			Keys:  []ratelimit.Key{ratelimit.KeyProject},
+			Read:  ratelimit.Limit{Rate: 0.001, Burst: 1},
+			Write: ratelimit.Limit{Rate: 0.001, Burst: 1},
+		},
+		testRules,
+		zap.NewNop(),
+	).Stream()
+
+	invoke := func(projectID string) (*projectStream, error) {
+		stream := &projectStream{projectID: projectID}
+		err := interceptor(
+			nil,
+			stream,
+			&grpc.StreamServerInfo{FullMethod: "/test.Service/Create"},
+			func(_ any, stream grpc.ServerStream) error {
+				// Only the first message is limited.
+				for range 3 {
+					var req projectRequest
+					if err := stream.RecvMsg(&req); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		)
+		return stream, err
+	}
+
+	stream, err := invoke("project-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0"}, stream.header.Get(ratelimit.HeaderRemaining))
+
+	_, err = invoke("project-1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = invoke("project-2")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/auditumio/auditum/internal/ratelimit"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{Rate: 2, Burst: 3})
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	// Burst is allowed at once.
+	for i := 2; i >= 0; i-- {
+		res := limiter.Allow("a", start)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res := limiter.Allow("a", start)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, res.Reset)
+
+	// Other keys have own buckets.
+	res = limiter.Allow("b", start)
+	assert.True(t, res.Allowed)
+
+	// Tokens are added over time.
+	res = limiter.Allow("a", start.Add(500*time.Millisecond))
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res = limiter.Allow("a", start.Add(750*time.Millisecond))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 250*time.Millisecond, res.RetryAfter)
+
+	// Bucket is not filled over burst.
+	res = limiter.Allow("a", start.Add(time.Hour))
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Limit{Rate: 1, Burst: 10})
+
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	limiter.Allow("idle", start)
+	limiter.Allow("active", start)
+	assert.Equal(t, 2, limiter.Len())
+
+	// Buckets are swept at most once a minute, so the first call does
+	// not remove anything.
+	limiter.Allow("active", start.Add(5*time.Second))
+	assert.Equal(t, 2, limiter.Len())
+
+	limiter.Allow("active", start.Add(61*time.Second))
+	assert.Equal(t, 1, limiter.Len())
+
+	// Removed bucket is full again.
+	res := limiter.Allow("idle", start.Add(62*time.Second))
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 9, res.Remaining)
+}
@@ -0,0 +1,589 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+const (
+	alertRuleTypeThreshold = "threshold"
+	alertRuleTypeSequence  = "sequence"
+
+	// alertRuleStatesCleanupInterval is how often expired alert rule states
+	// are deleted.
+	alertRuleStatesCleanupInterval = 10 * time.Minute
+)
+
+type alertRuleModel struct {
+	bun.BaseModel `bun:"table:alert_rules,alias:alert_rules"`
+
+	ID              aud.ID    `bun:"id,pk"`
+	ProjectID       aud.ID    `bun:"project_id,notnull"`
+	CreateTime      time.Time `bun:"create_time,notnull"`
+	DisplayName     string    `bun:"display_name,notnull"`
+	Type            string    `bun:"type,notnull"`
+	ThresholdFilter string    `bun:"threshold_filter,notnull"`
+	ThresholdCount  int       `bun:"threshold_count,notnull"`
+	SequenceSteps   []string  `bun:"sequence_steps,type:jsonb"`
+	GroupBy         []string  `bun:"group_by,type:jsonb"`
+	WindowSeconds   int64     `bun:"window_seconds,notnull"`
+}
+
+func toAlertRuleModel(rule aud.AlertRule) alertRuleModel {
+	model := alertRuleModel{
+		ID:            rule.ID,
+		ProjectID:     rule.ProjectID,
+		CreateTime:    rule.CreateTime,
+		DisplayName:   rule.DisplayName,
+		GroupBy:       rule.GroupBy,
+		WindowSeconds: int64(rule.Window / time.Second),
+	}
+
+	switch {
+	case rule.Threshold != nil:
+		model.Type = alertRuleTypeThreshold
+		model.ThresholdFilter = rule.Threshold.Filter
+		model.ThresholdCount = rule.Threshold.Count
+	case rule.Sequence != nil:
+		model.Type = alertRuleTypeSequence
+		model.SequenceSteps = rule.Sequence.Steps
+	}
+
+	return model
+}
+
+func fromAlertRuleModel(model alertRuleModel) (aud.AlertRule, error) {
+	rule := aud.AlertRule{
+		ID:          model.ID,
+		ProjectID:   model.ProjectID,
+		CreateTime:  model.CreateTime.UTC(),
+		DisplayName: model.DisplayName,
+		GroupBy:     model.GroupBy,
+		Window:      time.Duration(model.WindowSeconds) * time.Second,
+	}
+
+	switch model.Type {
+	case alertRuleTypeThreshold:
+		rule.Threshold = &aud.AlertRuleThreshold{
+			Filter: model.ThresholdFilter,
+			Count:  model.ThresholdCount,
+		}
+	case alertRuleTypeSequence:
+		rule.Sequence = &aud.AlertRuleSequence{
+			Steps: model.SequenceSteps,
+		}
+	default:
+		return aud.AlertRule{}, fmt.Errorf("unknown alert rule type %q", model.Type)
+	}
+
+	return rule, nil
+}
+
+func fromAlertRuleModels(models []alertRuleModel) ([]aud.AlertRule, error) {
+	rules := make([]aud.AlertRule, len(models))
+	for i, model := range models {
+		rule, err := fromAlertRuleModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %s: %v", model.ID, err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+type alertRuleStateModel struct {
+	bun.BaseModel `bun:"table:alert_rule_states,alias:alert_rule_states"`
+
+	RuleID     aud.ID             `bun:"rule_id,pk"`
+	GroupKey   string             `bun:"group_key,pk"`
+	State      alertRuleStateData `bun:"state,type:jsonb"`
+	ExpireTime time.Time          `bun:"expire_time,notnull"`
+}
+
+type alertRuleStateData struct {
+	Events    []alertEventData   `json:"events,omitempty"`
+	Sequences [][]alertEventData `json:"sequences,omitempty"`
+}
+
+type alertEventData struct {
+	RecordID string    `json:"id"`
+	Time     time.Time `json:"t"`
+}
+
+func toAlertRuleStateData(state aud.AlertRuleState) alertRuleStateData {
+	data := alertRuleStateData{
+		Events: toAlertEventsData(state.Events),
+	}
+	if len(state.Sequences) > 0 {
+		data.Sequences = make([][]alertEventData, len(state.Sequences))
+		for i, seq := range state.Sequences {
+			data.Sequences[i] = toAlertEventsData(seq)
+		}
+	}
+	return data
+}
+
+func toAlertEventsData(events []aud.AlertEvent) []alertEventData {
+	if len(events) == 0 {
+		return nil
+	}
+
+	data := make([]alertEventData, len(events))
+	for i, event := range events {
+		data[i] = alertEventData{
+			RecordID: event.RecordID.String(),
+			Time:     event.Time,
+		}
+	}
+	return data
+}
+
+func fromAlertRuleStateData(data alertRuleStateData) (aud.AlertRuleState, error) {
+	var state aud.AlertRuleState
+
+	events, err := fromAlertEventsData(data.Events)
+	if err != nil {
+		return aud.AlertRuleState{}, err
+	}
+	state.Events = events
+
+	if len(data.Sequences) > 0 {
+		state.Sequences = make([][]aud.AlertEvent, len(data.Sequences))
+		for i, seq := range data.Sequences {
+			events, err := fromAlertEventsData(seq)
+			if err != nil {
+				return aud.AlertRuleState{}, err
+			}
+			state.Sequences[i] = events
+		}
+	}
+
+	return state, nil
+}
+
+func fromAlertEventsData(data []alertEventData) ([]aud.AlertEvent, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	events := make([]aud.AlertEvent, len(data))
+	for i, event := range data {
+		id, err := aud.ParseID(event.RecordID)
+		if err != nil {
+			return nil, fmt.Errorf("parse record id: %v", err)
+		}
+		events[i] = aud.AlertEvent{
+			RecordID: id,
+			Time:     event.Time.UTC(),
+		}
+	}
+	return events, nil
+}
+
+type alertModel struct {
+	bun.BaseModel `bun:"table:alerts,alias:alerts"`
+
+	ID                 aud.ID            `bun:"id,pk"`
+	ProjectID          aud.ID            `bun:"project_id,notnull"`
+	RuleID             aud.ID            `bun:"rule_id,notnull"`
+	CreateTime         time.Time         `bun:"create_time,notnull"`
+	GroupValues        map[string]string `bun:"group_values,type:jsonb"`
+	RecordIDs          []string          `bun:"record_ids,type:jsonb"`
+	FirstOperationTime time.Time         `bun:"first_operation_time,notnull"`
+	LastOperationTime  time.Time         `bun:"last_operation_time,notnull"`
+}
+
+func toAlertModel(alert aud.Alert) alertModel {
+	recordIDs := make([]string, len(alert.RecordIDs))
+	for i, id := range alert.RecordIDs {
+		recordIDs[i] = id.String()
+	}
+
+	return alertModel{
+		ID:                 alert.ID,
+		ProjectID:          alert.ProjectID,
+		RuleID:             alert.RuleID,
+		CreateTime:         alert.CreateTime,
+		GroupValues:        alert.Group,
+		RecordIDs:          recordIDs,
+		FirstOperationTime: alert.FirstOperationTime,
+		LastOperationTime:  alert.LastOperationTime,
+	}
+}
+
+func fromAlertModel(model alertModel) (aud.Alert, error) {
+	recordIDs := make([]aud.ID, len(model.RecordIDs))
+	for i, s := range model.RecordIDs {
+		id, err := aud.ParseID(s)
+		if err != nil {
+			return aud.Alert{}, fmt.Errorf("parse record id: %v", err)
+		}
+		recordIDs[i] = id
+	}
+
+	return aud.Alert{
+		ID:                 model.ID,
+		ProjectID:          model.ProjectID,
+		RuleID:             model.RuleID,
+		CreateTime:         model.CreateTime.UTC(),
+		Group:              model.GroupValues,
+		RecordIDs:          recordIDs,
+		FirstOperationTime: model.FirstOperationTime.UTC(),
+		LastOperationTime:  model.LastOperationTime.UTC(),
+	}, nil
+}
+
+func fromAlertModels(models []alertModel) ([]aud.Alert, error) {
+	alerts := make([]aud.Alert, len(models))
+	for i, model := range models {
+		alert, err := fromAlertModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("alert %s: %v", model.ID, err)
+		}
+		alerts[i] = alert
+	}
+	return alerts, nil
+}
+
+// evaluateRecordsAlerts evaluates the created records against alert rules
+// of the project. It runs after the records are committed, in a separate
+// transaction, so that alert evaluation does not fail record creation, but
+// still before creation returns, so that alerts of the records are saved
+// once the records are created. Errors are logged, as the records are
+// already created.
+func (s *Store) evaluateRecordsAlerts(ctx context.Context, projectID aud.ID, records []aud.Record) {
+	// The records are created, so let's not abandon evaluation when the
+	// request is canceled.
+	ctx = context.WithoutCancel(ctx)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockProjectAlerts(ctx, tx, projectID); err != nil {
+			return err
+		}
+		return evaluateAlertRules(ctx, tx, projectID, records)
+	})
+	if err != nil {
+		s.log.Error("Evaluate alert rules",
+			zap.String("project_id", projectID.String()),
+			zap.Int("records", len(records)),
+			zap.Error(err),
+		)
+	}
+}
+
+// lockProjectAlerts serializes concurrent evaluation of alert rules for the
+// project until the end of the transaction. On PostgreSQL the project row is
+// locked with FOR NO KEY UPDATE, which does not block inserting records
+// referencing the project. SQLite serializes writes on its own.
+func lockProjectAlerts(ctx context.Context, tx bun.Tx, projectID aud.ID) error {
+	if tx.Dialect().Name() != dialect.PG {
+		return nil
+	}
+
+	_, err := tx.NewSelect().
+		Model((*projectModel)(nil)).
+		Column("id").
+		Where("id = ?", projectID).
+		For("NO KEY UPDATE").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("lock project in db: %v", err)
+	}
+
+	return nil
+}
+
+// evaluateAlertRules evaluates the records against alert rules of the
+// project, saving rule states and fired alerts. States expire when the
+// window passes since the latest event they hold.
+func evaluateAlertRules(ctx context.Context, tx bun.Tx, projectID aud.ID, records []aud.Record) error {
+	var ruleMods []alertRuleModel
+
+	err := tx.NewSelect().
+		Model(&ruleMods).
+		Where("project_id = ?", projectID).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select alert rules from db: %v", err)
+	}
+
+	if len(ruleMods) == 0 {
+		return nil
+	}
+
+	type groupRecord struct {
+		evaluator *aud.AlertRuleEvaluator
+		key       string
+		group     map[string]string
+		record    aud.Record
+	}
+
+	var (
+		groupRecords []groupRecord
+		ruleIDs      []aud.ID
+		groupKeys    []string
+		seenRuleIDs  = make(map[aud.ID]bool)
+		seenKeys     = make(map[string]bool)
+	)
+	for _, ruleMod := range ruleMods {
+		rule, err := fromAlertRuleModel(ruleMod)
+		if err != nil {
+			return fmt.Errorf("alert rule %s: %v", ruleMod.ID, err)
+		}
+
+		evaluator, err := aud.NewAlertRuleEvaluator(rule)
+		if err != nil {
+			return fmt.Errorf("alert rule %s: %v", rule.ID, err)
+		}
+
+		for _, record := range records {
+			key, group, ok := evaluator.Group(record)
+			if !ok {
+				continue
+			}
+
+			groupRecords = append(groupRecords, groupRecord{
+				evaluator: evaluator,
+				key:       key,
+				group:     group,
+				record:    record,
+			})
+			if !seenRuleIDs[rule.ID] {
+				seenRuleIDs[rule.ID] = true
+				ruleIDs = append(ruleIDs, rule.ID)
+			}
+			if !seenKeys[key] {
+				seenKeys[key] = true
+				groupKeys = append(groupKeys, key)
+			}
+		}
+	}
+
+	if len(groupRecords) == 0 {
+		return nil
+	}
+
+	var stateMods []alertRuleStateModel
+
+	err = tx.NewSelect().
+		Model(&stateMods).
+		Where("rule_id IN (?)", bun.In(ruleIDs)).
+		Where("group_key IN (?)", bun.In(groupKeys)).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select alert rule states from db: %v", err)
+	}
+
+	type stateKey struct {
+		ruleID aud.ID
+		key    string
+	}
+
+	states := make(map[stateKey]*aud.AlertRuleState, len(stateMods))
+	for _, stateMod := range stateMods {
+		state, err := fromAlertRuleStateData(stateMod.State)
+		if err != nil {
+			return fmt.Errorf("alert rule %s state: %v", stateMod.RuleID, err)
+		}
+		states[stateKey{stateMod.RuleID, stateMod.GroupKey}] = &state
+	}
+
+	var (
+		alertMods []alertModel
+		changed   = make(map[stateKey]time.Duration)
+		order     []stateKey
+	)
+	for _, gr := range groupRecords {
+		rule := gr.evaluator.Rule()
+		sk := stateKey{rule.ID, gr.key}
+
+		state, ok := states[sk]
+		if !ok {
+			state = &aud.AlertRuleState{}
+			states[sk] = state
+		}
+
+		if _, ok := changed[sk]; !ok {
+			order = append(order, sk)
+		}
+		changed[sk] = rule.Window
+
+		events := gr.evaluator.Evaluate(state, gr.record)
+		if len(events) == 0 {
+			continue
+		}
+
+		id, err := aud.NewID()
+		if err != nil {
+			return fmt.Errorf("generate alert id: %v", err)
+		}
+
+		recordIDs := make([]aud.ID, len(events))
+		for i, event := range events {
+			recordIDs[i] = event.RecordID
+		}
+
+		alertMods = append(alertMods, toAlertModel(aud.Alert{
+			ID:                 id,
+			ProjectID:          projectID,
+			RuleID:             rule.ID,
+			CreateTime:         gr.record.CreateTime,
+			Group:              gr.group,
+			RecordIDs:          recordIDs,
+			FirstOperationTime: events[0].Time,
+			LastOperationTime:  events[len(events)-1].Time,
+		}))
+	}
+
+	var (
+		upsertMods []alertRuleStateModel
+		deleteMods []alertRuleStateModel
+	)
+	for _, sk := range order {
+		model := alertRuleStateModel{
+			RuleID:     sk.ruleID,
+			GroupKey:   sk.key,
+			State:      toAlertRuleStateData(*states[sk]),
+			ExpireTime: states[sk].LastTime().Add(changed[sk]),
+		}
+		if states[sk].Empty() {
+			deleteMods = append(deleteMods, model)
+		} else {
+			upsertMods = append(upsertMods, model)
+		}
+	}
+
+	if len(upsertMods) > 0 {
+		_, err := tx.NewInsert().
+			Model(&upsertMods).
+			On("CONFLICT (rule_id, group_key) DO UPDATE").
+			Set("state = EXCLUDED.state").
+			Set("expire_time = EXCLUDED.expire_time").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("upsert alert rule states into db: %v", err)
+		}
+	}
+
+	if len(deleteMods) > 0 {
+		_, err := tx.NewDelete().
+			Model(&deleteMods).
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete alert rule states from db: %v", err)
+		}
+	}
+
+	if len(alertMods) > 0 {
+		_, err := tx.NewInsert().
+			Model(&alertMods).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("insert alerts into db: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// RunAlertRuleStatesCleanup periodically deletes expired states of alert
+// rules, until ctx is done. See deleteExpiredAlertRuleStates.
+func (s *Store) RunAlertRuleStatesCleanup(ctx context.Context, log *zap.Logger) {
+	log = log.Named("alert_rule_states_cleanup")
+
+	ticker := time.NewTicker(alertRuleStatesCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := deleteExpiredAlertRuleStates(ctx, s.db)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Delete expired alert rule states", zap.Error(err))
+		}
+	}
+}
+
+// deleteExpiredAlertRuleStates deletes states of alert rules whose latest
+// event is older than the rule window before the latest event of the rule.
+// Such states are of groups which stopped receiving records. Like state
+// expire times, it goes by operation time of records rather than current
+// time, so that states of records with past operation times, e.g. imported
+// ones, are kept while the rule still receives records within the window.
+func deleteExpiredAlertRuleStates(ctx context.Context, idb bun.IDB) error {
+	var ruleMods []alertRuleModel
+
+	err := idb.NewSelect().
+		Model(&ruleMods).
+		Column("id", "window_seconds").
+		Where("EXISTS (?)", idb.NewSelect().
+			Model((*alertRuleStateModel)(nil)).
+			ColumnExpr("1").
+			Where("alert_rule_states.rule_id = alert_rules.id"),
+		).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select alert rules from db: %v", err)
+	}
+
+	for _, ruleMod := range ruleMods {
+		var latest alertRuleStateModel
+
+		err := idb.NewSelect().
+			Model(&latest).
+			Column("expire_time").
+			Where("rule_id = ?", ruleMod.ID).
+			Order("expire_time DESC").
+			Limit(1).
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("select latest alert rule %s state from db: %v", ruleMod.ID, err)
+		}
+
+		// Expire time of a state is the time of its latest event plus the
+		// window, so this is the time of the latest event of the rule.
+		latestTime := latest.ExpireTime.Add(-time.Duration(ruleMod.WindowSeconds) * time.Second)
+
+		_, err = idb.NewDelete().
+			Model((*alertRuleStateModel)(nil)).
+			Where("rule_id = ?", ruleMod.ID).
+			Where("expire_time < ?", latestTime).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete expired alert rule %s states from db: %v", ruleMod.ID, err)
+		}
+	}
+
+	return nil
+}
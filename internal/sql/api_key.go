@@ -0,0 +1,108 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type apiKeyModel struct {
+	bun.BaseModel `bun:"table:api_keys,alias:api_keys"`
+
+	ID          aud.ID       `bun:"id,pk"`
+	CreateTime  time.Time    `bun:"create_time,notnull"`
+	DisplayName string       `bun:"display_name,notnull"`
+	KeyPrefix   string       `bun:"key_prefix,notnull"`
+	KeyHash     string       `bun:"key_hash,notnull"`
+	ProjectIDs  []string     `bun:"project_ids,type:jsonb"`
+	Permissions []string     `bun:"permissions,type:jsonb,notnull"`
+	RevokeTime  bun.NullTime `bun:"revoke_time"`
+}
+
+func toAPIKeyModel(key aud.APIKey) apiKeyModel {
+	var projectIDs []string
+	for _, id := range key.ProjectIDs {
+		projectIDs = append(projectIDs, id.String())
+	}
+
+	permissions := make([]string, len(key.Permissions))
+	for i, p := range key.Permissions {
+		permissions[i] = p.String()
+	}
+
+	var revokeTime bun.NullTime
+	if !key.RevokeTime.IsZero() {
+		revokeTime = bun.NullTime{Time: key.RevokeTime}
+	}
+
+	return apiKeyModel{
+		ID:          key.ID,
+		CreateTime:  key.CreateTime,
+		DisplayName: key.DisplayName,
+		KeyPrefix:   key.KeyPrefix,
+		KeyHash:     key.KeyHash,
+		ProjectIDs:  projectIDs,
+		Permissions: permissions,
+		RevokeTime:  revokeTime,
+	}
+}
+
+func fromAPIKeyModel(model apiKeyModel) (aud.APIKey, error) {
+	var projectIDs []aud.ID
+	for _, s := range model.ProjectIDs {
+		id, err := aud.ParseID(s)
+		if err != nil {
+			return aud.APIKey{}, fmt.Errorf("parse project id %q: %v", s, err)
+		}
+		projectIDs = append(projectIDs, id)
+	}
+
+	permissions := make([]aud.Permission, len(model.Permissions))
+	for i, s := range model.Permissions {
+		p, err := aud.ParsePermission(s)
+		if err != nil {
+			return aud.APIKey{}, err
+		}
+		permissions[i] = p
+	}
+
+	return aud.APIKey{
+		ID:          model.ID,
+		CreateTime:  model.CreateTime.UTC(),
+		DisplayName: model.DisplayName,
+		KeyPrefix:   model.KeyPrefix,
+		KeyHash:     model.KeyHash,
+		ProjectIDs:  projectIDs,
+		Permissions: permissions,
+		RevokeTime:  fromNullTime(model.RevokeTime),
+	}, nil
+}
+
+func fromAPIKeyModels(models []apiKeyModel) ([]aud.APIKey, error) {
+	keys := make([]aud.APIKey, len(models))
+	for i, model := range models {
+		key, err := fromAPIKeyModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("api key %s: %v", model.ID, err)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
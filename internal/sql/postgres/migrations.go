@@ -15,30 +15,51 @@
 package postgres
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
 
-	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file" // init driver for fs
 	"github.com/uptrace/bun"
 
 	"github.com/auditumio/auditum/pkg/fragma/bunx"
 )
 
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// embeddedMigrations returns migrations embedded into the binary.
+func embeddedMigrations() fs.FS {
+	sub, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		// This is exceptional.
+		panic("sub migrations fs: " + err.Error())
+	}
+	return sub
+}
+
 func RunMigrations(db *bun.DB, migrationsDir string, log any) error {
+	m, err := NewMigrator(db, migrationsDir, log)
+	if err != nil {
+		return err
+	}
+
+	return m.Up()
+}
+
+// NewMigrator returns a migrator of the database. Migrations are read from
+// migrationsDir if it is set, or embedded migrations are used otherwise.
+// Closing the migrator closes the database.
+func NewMigrator(db *bun.DB, migrationsDir string, log any) (*bunx.Migrator, error) {
 	driver, err := postgres.WithInstance(db.DB, &postgres.Config{})
 	if err != nil {
-		return fmt.Errorf("create driver: %v", err)
+		return nil, fmt.Errorf("create driver: %v", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://"+migrationsDir, // e.g. "file://./postgres/migrations",
-		"postgres",
-		driver,
-	)
+	sourceName, src, err := bunx.OpenMigrationsSource(embeddedMigrations(), migrationsDir)
 	if err != nil {
-		return fmt.Errorf("create migrate instance: %v", err)
+		return nil, err
 	}
 
-	return bunx.RunMigrations(m, log)
+	return bunx.NewMigrator(sourceName, src, "postgres", driver, log)
 }
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE project_usage;
+
+ALTER TABLE projects DROP COLUMN quota_max_bytes;
+ALTER TABLE projects DROP COLUMN quota_max_records;
+ALTER TABLE projects DROP COLUMN quota_records_per_day;
+ALTER TABLE projects DROP COLUMN quota_records_per_minute;
+
+COMMIT;
@@ -0,0 +1,60 @@
+BEGIN;
+
+ALTER TABLE projects ADD COLUMN quota_records_per_minute BIGINT;
+ALTER TABLE projects ADD COLUMN quota_records_per_day BIGINT;
+ALTER TABLE projects ADD COLUMN quota_max_records BIGINT;
+ALTER TABLE projects ADD COLUMN quota_max_bytes BIGINT;
+
+CREATE TABLE project_usage
+(
+    project_id        UUID,
+    records           BIGINT NOT NULL DEFAULT 0,
+    bytes             BIGINT NOT NULL DEFAULT 0,
+    minute_start_time TIMESTAMPTZ,
+    minute_records    BIGINT NOT NULL DEFAULT 0,
+    day_start_time    TIMESTAMPTZ,
+    day_records       BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (project_id),
+    FOREIGN KEY (project_id)
+        REFERENCES projects (id)
+        ON DELETE CASCADE
+);
+
+-- Backfill totals of existing projects. Bytes are calculated the same way
+-- as by the application: the length of all text and JSON values.
+INSERT INTO project_usage (project_id, records, bytes)
+SELECT projects.id,
+       COALESCE(r.records, 0),
+       COALESCE(r.bytes, 0) + COALESCE(c.bytes, 0)
+FROM projects
+         LEFT JOIN (SELECT project_id,
+                           COUNT(*) AS records,
+                           SUM(
+                               octet_length(resource_type) + octet_length(resource_id) +
+                               octet_length(operation_type) + octet_length(operation_id) +
+                               COALESCE(octet_length(operation_traceparent), 0) +
+                               COALESCE(octet_length(operation_tracestate), 0) +
+                               octet_length(actor_type) + octet_length(actor_id) +
+                               (SELECT COALESCE(SUM(octet_length(key) + octet_length(value)), 0)
+                                FROM jsonb_each_text(CASE WHEN jsonb_typeof(labels) = 'object' THEN labels END)) +
+                               (SELECT COALESCE(SUM(octet_length(key) + octet_length(value)), 0)
+                                FROM jsonb_each_text(CASE WHEN jsonb_typeof(resource_metadata) = 'object' THEN resource_metadata END)) +
+                               (SELECT COALESCE(SUM(octet_length(key) + octet_length(value)), 0)
+                                FROM jsonb_each_text(CASE WHEN jsonb_typeof(operation_metadata) = 'object' THEN operation_metadata END)) +
+                               (SELECT COALESCE(SUM(octet_length(key) + octet_length(value)), 0)
+                                FROM jsonb_each_text(CASE WHEN jsonb_typeof(actor_metadata) = 'object' THEN actor_metadata END))
+                           ) AS bytes
+                    FROM records
+                    GROUP BY project_id) r ON r.project_id = projects.id
+         LEFT JOIN (SELECT project_id,
+                           SUM(
+                               octet_length(name) +
+                               COALESCE(octet_length(description), 0) +
+                               COALESCE(octet_length(old_value::TEXT), 0) +
+                               COALESCE(octet_length(new_value::TEXT), 0)
+                           ) AS bytes
+                    FROM records_resource_changes
+                    GROUP BY project_id) c ON c.project_id = projects.id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE projects ADD COLUMN records_restrictions JSONB;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE projects ADD COLUMN description TEXT;
+ALTER TABLE projects ADD COLUMN labels JSONB;
+
+COMMIT;
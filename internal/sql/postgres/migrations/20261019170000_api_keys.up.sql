@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE api_keys
+(
+    id           UUID,
+    create_time  TIMESTAMPTZ NOT NULL,
+    display_name TEXT        NOT NULL,
+    key_prefix   TEXT        NOT NULL,
+    key_hash     TEXT        NOT NULL,
+    project_ids  JSONB,
+    permissions  JSONB       NOT NULL,
+    revoke_time  TIMESTAMPTZ,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX ON api_keys (key_hash);
+
+COMMIT;
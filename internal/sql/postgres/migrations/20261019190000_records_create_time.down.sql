@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX records_project_id_create_time_id_idx;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE INDEX ON records USING btree (project_id, create_time, id);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE alerts;
+DROP TABLE alert_rule_states;
+DROP TABLE alert_rules;
+
+COMMIT;
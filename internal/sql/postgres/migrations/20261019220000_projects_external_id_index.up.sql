@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Same as in SQLite, where the index of external_id must be dropped by the
+-- down migration of this one before the column can be dropped.
+DROP INDEX IF EXISTS idx_projects_external_id;
+
+CREATE UNIQUE INDEX idx_projects_external_id ON projects (external_id);
+
+COMMIT;
@@ -26,13 +26,22 @@ import (
 type projectModel struct {
 	bun.BaseModel `bun:"table:projects,alias:projects"`
 
-	ID                  aud.ID         `bun:"id,pk"`
-	PartitionNumber     int32          `bun:"partition_number,autoincrement"`
-	CreateTime          time.Time      `bun:"create_time,notnull"`
-	DisplayName         string         `bun:"display_name,notnull,nullzero"`
-	UpdateRecordEnabled sql.NullBool   `bun:"update_record_enabled"`
-	DeleteRecordEnabled sql.NullBool   `bun:"delete_record_enabled"`
-	ExternalID          sql.NullString `bun:"external_id"`
+	ID                  aud.ID            `bun:"id,pk"`
+	PartitionNumber     int32             `bun:"partition_number,autoincrement"`
+	CreateTime          time.Time         `bun:"create_time,notnull"`
+	DisplayName         string            `bun:"display_name,notnull,nullzero"`
+	Description         string            `bun:"description,nullzero"`
+	Labels              map[string]string `bun:"labels,type:jsonb"`
+	UpdateRecordEnabled sql.NullBool      `bun:"update_record_enabled"`
+	DeleteRecordEnabled sql.NullBool      `bun:"delete_record_enabled"`
+	ExternalID          sql.NullString    `bun:"external_id"`
+
+	QuotaRecordsPerMinute int64 `bun:"quota_records_per_minute,nullzero"`
+	QuotaRecordsPerDay    int64 `bun:"quota_records_per_day,nullzero"`
+	QuotaMaxRecords       int64 `bun:"quota_max_records,nullzero"`
+	QuotaMaxBytes         int64 `bun:"quota_max_bytes,nullzero"`
+
+	RecordsRestrictions *aud.RecordsRestrictions `bun:"records_restrictions,type:jsonb"`
 }
 
 func normalizeProjectModel(model *projectModel) {
@@ -44,9 +53,18 @@ func toProjectModel(project aud.Project) projectModel {
 		ID:                  project.ID,
 		CreateTime:          project.CreateTime,
 		DisplayName:         project.DisplayName,
+		Description:         project.Description,
+		Labels:              project.Labels,
 		UpdateRecordEnabled: toBoolValueModel(project.UpdateRecordEnabled),
 		DeleteRecordEnabled: toBoolValueModel(project.DeleteRecordEnabled),
 		ExternalID:          toNullString(project.ExternalID),
+
+		QuotaRecordsPerMinute: project.Quota.RecordsPerMinute,
+		QuotaRecordsPerDay:    project.Quota.RecordsPerDay,
+		QuotaMaxRecords:       project.Quota.MaxRecords,
+		QuotaMaxBytes:         project.Quota.MaxBytes,
+
+		RecordsRestrictions: toRecordsRestrictionsModel(project.RecordsRestrictions),
 	}
 }
 
@@ -57,10 +75,33 @@ func fromProjectModel(model projectModel) aud.Project {
 		ID:                  model.ID,
 		CreateTime:          model.CreateTime,
 		DisplayName:         model.DisplayName,
+		Description:         model.Description,
+		Labels:              model.Labels,
 		UpdateRecordEnabled: fromBoolValueModel(model.UpdateRecordEnabled),
 		DeleteRecordEnabled: fromBoolValueModel(model.DeleteRecordEnabled),
 		ExternalID:          fromNullString(model.ExternalID),
+		Quota: aud.ProjectQuota{
+			RecordsPerMinute: model.QuotaRecordsPerMinute,
+			RecordsPerDay:    model.QuotaRecordsPerDay,
+			MaxRecords:       model.QuotaMaxRecords,
+			MaxBytes:         model.QuotaMaxBytes,
+		},
+		RecordsRestrictions: fromRecordsRestrictionsModel(model.RecordsRestrictions),
+	}
+}
+
+func toRecordsRestrictionsModel(src aud.RecordsRestrictions) *aud.RecordsRestrictions {
+	if src == (aud.RecordsRestrictions{}) {
+		return nil
+	}
+	return &src
+}
+
+func fromRecordsRestrictionsModel(src *aud.RecordsRestrictions) aud.RecordsRestrictions {
+	if src == nil {
+		return aud.RecordsRestrictions{}
 	}
+	return *src
 }
 
 func fromProjectModels(models []projectModel) []aud.Project {
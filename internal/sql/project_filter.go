@@ -0,0 +1,104 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// applyProjectFilter adds conditions of the filter to the projects query.
+func applyProjectFilter(q *bun.SelectQuery, filter aud.ProjectFilter) error {
+	d := q.Dialect().Name()
+	if d != dialect.PG && d != dialect.SQLite {
+		return fmt.Errorf("unsupported dialect: %s", d.String())
+	}
+
+	if len(filter.IDs) > 0 {
+		q.Where("id IN (?)", bun.In(filter.IDs))
+	}
+
+	whereIn(q, "external_id", filter.ExternalIDs)
+
+	if len(filter.Labels) > 0 {
+		switch d {
+		case dialect.PG:
+			q.Where("labels @> ?", filter.Labels)
+		case dialect.SQLite:
+			for k, v := range filter.Labels {
+				q.Where("json_extract(labels, ?) = ?", "$."+k, v)
+			}
+		}
+	}
+
+	if filter.DisplayNameContains != "" {
+		pattern := "%" + escapeLike(filter.DisplayNameContains) + "%"
+		switch d {
+		case dialect.PG:
+			q.Where(`display_name ILIKE ? ESCAPE '\'`, pattern)
+		case dialect.SQLite:
+			// LIKE is case-insensitive in SQLite for ASCII characters.
+			q.Where(`display_name LIKE ? ESCAPE '\'`, pattern)
+		}
+	}
+
+	return nil
+}
+
+// applyProjectOrder adds ordering and the cursor condition to the projects
+// query. Project IDs are time-ordered, so they are used to order projects
+// by create time.
+func applyProjectOrder(q *bun.SelectQuery, order aud.ProjectOrder, cursor aud.ProjectCursor) error {
+	switch order {
+	case aud.ProjectOrderCreateTimeDesc:
+		if cursor.LastID != nil {
+			q.Where("id < ?", cursor.LastID)
+		}
+		q.Order("id DESC")
+	case aud.ProjectOrderCreateTimeAsc:
+		if cursor.LastID != nil {
+			q.Where("id > ?", cursor.LastID)
+		}
+		q.Order("id ASC")
+	case aud.ProjectOrderDisplayNameAsc:
+		if cursor.LastID != nil {
+			q.Where(
+				"(display_name > ? OR (display_name = ? AND id > ?))",
+				cursor.LastDisplayName,
+				cursor.LastDisplayName,
+				cursor.LastID,
+			)
+		}
+		q.Order("display_name ASC", "id ASC")
+	case aud.ProjectOrderDisplayNameDesc:
+		if cursor.LastID != nil {
+			q.Where(
+				"(display_name < ? OR (display_name = ? AND id < ?))",
+				cursor.LastDisplayName,
+				cursor.LastDisplayName,
+				cursor.LastID,
+			)
+		}
+		q.Order("display_name DESC", "id DESC")
+	default:
+		return fmt.Errorf("unsupported order: %d", order)
+	}
+
+	return nil
+}
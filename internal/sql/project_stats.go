@@ -0,0 +1,119 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type projectStatsModel struct {
+	Records            int64        `bun:"records"`
+	FirstOperationTime bun.NullTime `bun:"first_operation_time"`
+	LastOperationTime  bun.NullTime `bun:"last_operation_time"`
+	LastCreateTime     bun.NullTime `bun:"last_create_time"`
+	ResourceTypes      int64        `bun:"resource_types"`
+	OperationTypes     int64        `bun:"operation_types"`
+	ActorTypes         int64        `bun:"actor_types"`
+}
+
+func fromProjectStatsModel(projectID aud.ID, model projectStatsModel, sizeBytes int64) aud.ProjectStats {
+	return aud.ProjectStats{
+		ProjectID:          projectID,
+		Records:            model.Records,
+		SizeBytes:          sizeBytes,
+		FirstOperationTime: fromNullTime(model.FirstOperationTime),
+		LastOperationTime:  fromNullTime(model.LastOperationTime),
+		LastCreateTime:     fromNullTime(model.LastCreateTime),
+		ResourceTypes:      model.ResourceTypes,
+		OperationTypes:     model.OperationTypes,
+		ActorTypes:         model.ActorTypes,
+	}
+}
+
+func selectProjectStats(ctx context.Context, idb bun.IDB, projectID aud.ID) (projectStatsModel, error) {
+	var model projectStatsModel
+
+	err := idb.NewSelect().
+		Model((*recordModel)(nil)).
+		ColumnExpr("COUNT(*) AS records").
+		ColumnExpr("MIN(operation_time) AS first_operation_time").
+		ColumnExpr("MAX(operation_time) AS last_operation_time").
+		ColumnExpr("MAX(create_time) AS last_create_time").
+		ColumnExpr("COUNT(DISTINCT resource_type) AS resource_types").
+		ColumnExpr("COUNT(DISTINCT operation_type) AS operation_types").
+		ColumnExpr("COUNT(DISTINCT actor_type) AS actor_types").
+		Where("project_id = ?", projectID).
+		Scan(ctx, &model)
+	if err != nil {
+		return projectStatsModel{}, fmt.Errorf("select records stats from db: %v", err)
+	}
+
+	return model, nil
+}
+
+// selectProjectSizeBytes returns the approximate storage size of project
+// records. On PostgreSQL it is the total size of the project partitions.
+// Other dialects do not store projects separately, so the size of record
+// data accounted in project usage is returned.
+func selectProjectSizeBytes(ctx context.Context, idb bun.IDB, projectID aud.ID) (int64, error) {
+	var size int64
+
+	if idb.Dialect().Name() != dialect.PG {
+		err := idb.NewSelect().
+			Model((*projectUsageModel)(nil)).
+			Column("bytes").
+			Where("project_id = ?", projectID).
+			Scan(ctx, &size)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Usage is not accounted yet.
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("select project usage from db: %v", err)
+		}
+
+		return size, nil
+	}
+
+	var partitionNumber int32
+
+	err := idb.NewSelect().
+		Model((*projectModel)(nil)).
+		Column("partition_number").
+		Where("id = ?", projectID).
+		Scan(ctx, &partitionNumber)
+	if err != nil {
+		return 0, fmt.Errorf("select project from db: %v", err)
+	}
+
+	err = idb.NewRaw(
+		"SELECT pg_total_relation_size(?::regclass) + pg_total_relation_size(?::regclass)",
+		partitionForProjectTableName(tableNameRecords, partitionNumber),
+		partitionForProjectTableName(tableNameRecordsResourceChanges, partitionNumber),
+	).Scan(ctx, &size)
+	if err != nil {
+		return 0, fmt.Errorf("select partitions size from db: %v", err)
+	}
+
+	return size, nil
+}
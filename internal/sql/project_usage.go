@@ -0,0 +1,242 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type projectUsageModel struct {
+	bun.BaseModel `bun:"table:project_usage,alias:project_usage"`
+
+	ProjectID       aud.ID    `bun:"project_id,pk"`
+	Records         int64     `bun:"records,notnull"`
+	Bytes           int64     `bun:"bytes,notnull"`
+	MinuteStartTime time.Time `bun:"minute_start_time,nullzero"`
+	MinuteRecords   int64     `bun:"minute_records,notnull"`
+	DayStartTime    time.Time `bun:"day_start_time,nullzero"`
+	DayRecords      int64     `bun:"day_records,notnull"`
+}
+
+func normalizeProjectUsageModel(model *projectUsageModel) {
+	model.MinuteStartTime = model.MinuteStartTime.UTC()
+	model.DayStartTime = model.DayStartTime.UTC()
+}
+
+func toProjectUsageModel(usage aud.ProjectUsage) projectUsageModel {
+	return projectUsageModel{
+		ProjectID:       usage.ProjectID,
+		Records:         usage.Records,
+		Bytes:           usage.Bytes,
+		MinuteStartTime: usage.MinuteStartTime,
+		MinuteRecords:   usage.MinuteRecords,
+		DayStartTime:    usage.DayStartTime,
+		DayRecords:      usage.DayRecords,
+	}
+}
+
+func fromProjectUsageModel(model projectUsageModel) aud.ProjectUsage {
+	normalizeProjectUsageModel(&model)
+
+	return aud.ProjectUsage{
+		ProjectID:       model.ProjectID,
+		Records:         model.Records,
+		Bytes:           model.Bytes,
+		MinuteStartTime: model.MinuteStartTime,
+		MinuteRecords:   model.MinuteRecords,
+		DayStartTime:    model.DayStartTime,
+		DayRecords:      model.DayRecords,
+	}
+}
+
+func fromProjectUsageModels(models []projectUsageModel) []aud.ProjectUsage {
+	usages := make([]aud.ProjectUsage, len(models))
+	for i, model := range models {
+		usages[i] = fromProjectUsageModel(model)
+	}
+	return usages
+}
+
+func createProjectUsage(ctx context.Context, idb bun.IDB, projectID aud.ID) error {
+	model := projectUsageModel{ProjectID: projectID}
+
+	_, err := idb.NewInsert().
+		Model(&model).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("insert project usage into db: %v", err)
+	}
+
+	return nil
+}
+
+// updateProjectUsage applies fn to the usage of the project and saves the
+// result. On PostgreSQL the usage row is locked until the end of the
+// transaction, so concurrent writes to the project are accounted correctly.
+// It is only needed when fn has to see the current usage, e.g. to check a
+// quota, otherwise use [adjustProjectUsage].
+func updateProjectUsage(
+	ctx context.Context,
+	tx bun.Tx,
+	projectID aud.ID,
+	fn func(aud.ProjectUsage) (aud.ProjectUsage, error),
+) error {
+	var model projectUsageModel
+
+	selectForUpdate := func() error {
+		q := tx.NewSelect().
+			Model(&model).
+			Where("project_id = ?", projectID)
+
+		if tx.Dialect().Name() == dialect.PG {
+			q.For("UPDATE")
+		}
+
+		return q.Scan(ctx)
+	}
+
+	err := selectForUpdate()
+	if errors.Is(err, sql.ErrNoRows) {
+		// Usage is created along with the project, but let's not fail
+		// if it is missing for some reason.
+		if err := createProjectUsage(ctx, tx, projectID); err != nil {
+			return err
+		}
+		err = selectForUpdate()
+	}
+	if err != nil {
+		return fmt.Errorf("select project usage from db: %v", err)
+	}
+
+	usage, err := fn(fromProjectUsageModel(model))
+	if err != nil {
+		return err
+	}
+
+	model = toProjectUsageModel(usage)
+
+	_, err = tx.NewUpdate().
+		Model(&model).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("update project usage in db: %v", err)
+	}
+
+	return nil
+}
+
+// adjustProjectUsage changes usage totals of the project by the given
+// number of records and bytes, which may be negative. If t is not zero,
+// the records are also counted in the windows containing t.
+//
+// Usage is changed with a single UPDATE without reading it first, so
+// concurrent writes to the project do not wait for each other. Use it
+// when no quota has to be checked.
+func adjustProjectUsage(
+	ctx context.Context,
+	idb bun.IDB,
+	projectID aud.ID,
+	t time.Time,
+	n int64,
+	bytes int64,
+) error {
+	greatest := "GREATEST"
+	if idb.Dialect().Name() == dialect.SQLite {
+		greatest = "MAX"
+	}
+
+	update := func() (int64, error) {
+		q := idb.NewUpdate().
+			Model((*projectUsageModel)(nil)).
+			Set("records = "+greatest+"(records + ?, 0)", n).
+			Set("bytes = "+greatest+"(bytes + ?, 0)", bytes).
+			Where("project_id = ?", projectID)
+
+		if !t.IsZero() {
+			// Windows never move backwards, see [aud.ProjectUsage.At].
+			minute := t.UTC().Truncate(time.Minute)
+			day := t.UTC().Truncate(24 * time.Hour)
+			q.
+				Set("minute_records = CASE WHEN minute_start_time IS NULL OR minute_start_time < ? THEN ? ELSE minute_records + ? END", minute, n, n).
+				Set("minute_start_time = CASE WHEN minute_start_time IS NULL OR minute_start_time < ? THEN ? ELSE minute_start_time END", minute, minute).
+				Set("day_records = CASE WHEN day_start_time IS NULL OR day_start_time < ? THEN ? ELSE day_records + ? END", day, n, n).
+				Set("day_start_time = CASE WHEN day_start_time IS NULL OR day_start_time < ? THEN ? ELSE day_start_time END", day, day)
+		}
+
+		result, err := q.Exec(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	}
+
+	affected, err := update()
+	if err == nil && affected == 0 {
+		// Usage is created along with the project, but let's not fail
+		// if it is missing for some reason.
+		if err := createProjectUsage(ctx, idb, projectID); err != nil {
+			return err
+		}
+		_, err = update()
+	}
+	if err != nil {
+		return fmt.Errorf("update project usage in db: %v", err)
+	}
+
+	return nil
+}
+
+// addRecordsUsage counts n records of the given total size created at
+// time t in the usage of the project. If the project has a quota, the usage
+// row is locked to check it, otherwise usage is updated atomically.
+func addRecordsUsage(
+	ctx context.Context,
+	tx bun.Tx,
+	project aud.Project,
+	t time.Time,
+	n int64,
+	bytes int64,
+) error {
+	if project.Quota == (aud.ProjectQuota{}) {
+		return adjustProjectUsage(ctx, tx, project.ID, t, n, bytes)
+	}
+
+	return updateProjectUsage(ctx, tx, project.ID, func(usage aud.ProjectUsage) (aud.ProjectUsage, error) {
+		if err := project.Quota.Check(usage, t, n, bytes); err != nil {
+			return usage, err
+		}
+
+		return usage.Add(t, n, bytes), nil
+	})
+}
+
+func recordsSize(records []aud.Record) int64 {
+	var size int64
+	for _, record := range records {
+		size += record.Size()
+	}
+	return size
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// applyRecordFilter adds conditions of the filter to the records query.
+func applyRecordFilter(q *bun.SelectQuery, filter aud.RecordFilter) error {
+	d := q.Dialect().Name()
+	if d != dialect.PG && d != dialect.SQLite {
+		return fmt.Errorf("unsupported dialect: %s", d.String())
+	}
+
+	if len(filter.IDs) > 0 {
+		q.Where("id IN (?)", bun.In(filter.IDs))
+	}
+
+	if len(filter.Labels) > 0 {
+		switch d {
+		case dialect.PG:
+			q.Where("labels @> ?", filter.Labels)
+		case dialect.SQLite:
+			for k, v := range filter.Labels {
+				q.Where("json_extract(labels, ?) = ?", "$."+k, v)
+			}
+		}
+	}
+
+	whereIn(q, "resource_type", filter.ResourceTypes)
+	whereIn(q, "resource_id", filter.ResourceIDs)
+
+	if filter.ResourceIDPrefix != "" {
+		switch d {
+		case dialect.PG:
+			q.Where(`resource_id LIKE ? ESCAPE '\'`, escapeLike(filter.ResourceIDPrefix)+"%")
+		case dialect.SQLite:
+			// LIKE is case-insensitive in SQLite, while GLOB is not.
+			q.Where("resource_id GLOB ?", escapeGlob(filter.ResourceIDPrefix)+"*")
+		}
+	}
+
+	whereIn(q, "operation_type", filter.OperationTypes)
+	whereIn(q, "operation_id", filter.OperationIDs)
+
+	if len(filter.OperationStatuses) > 0 {
+		q.Where("COALESCE(operation_status, 0) IN (?)", bun.In(operationStatusInts(filter.OperationStatuses)))
+	}
+
+	if !filter.OperationTimeFrom.IsZero() {
+		q.Where("operation_time >= ?", filter.OperationTimeFrom)
+	}
+	if !filter.OperationTimeTo.IsZero() {
+		q.Where("operation_time < ?", filter.OperationTimeTo)
+	}
+
+	whereIn(q, "actor_type", filter.ActorTypes)
+	whereIn(q, "actor_id", filter.ActorIDs)
+
+	applyRecordFilterNot(q, d, filter.Not)
+
+	if filter.Expression != nil {
+		where, args, err := buildRecordFilterExpr(d, filter.Expression)
+		if err != nil {
+			return fmt.Errorf("build filter expression: %v", err)
+		}
+		q.Where(where, args...)
+	}
+
+	return nil
+}
+
+func applyRecordFilterNot(q *bun.SelectQuery, d dialect.Name, filter aud.RecordFilterNot) {
+	for k, v := range filter.Labels {
+		switch d {
+		case dialect.PG:
+			// Labels may be NULL, so the containment check must not be NULL.
+			q.Where("NOT COALESCE(labels @> ?, FALSE)", map[string]string{k: v})
+		case dialect.SQLite:
+			q.Where("json_extract(labels, ?) IS NOT ?", "$."+k, v)
+		}
+	}
+
+	whereNotIn(q, "resource_type", filter.ResourceTypes)
+	whereNotIn(q, "resource_id", filter.ResourceIDs)
+
+	whereNotIn(q, "operation_type", filter.OperationTypes)
+	whereNotIn(q, "operation_id", filter.OperationIDs)
+
+	if len(filter.OperationStatuses) > 0 {
+		q.Where("COALESCE(operation_status, 0) NOT IN (?)", bun.In(operationStatusInts(filter.OperationStatuses)))
+	}
+
+	whereNotIn(q, "actor_type", filter.ActorTypes)
+	whereNotIn(q, "actor_id", filter.ActorIDs)
+}
+
+func whereIn(q *bun.SelectQuery, column string, values []string) {
+	switch len(values) {
+	case 0:
+		return
+	case 1:
+		q.Where("? = ?", bun.Ident(column), values[0])
+	default:
+		q.Where("? IN (?)", bun.Ident(column), bun.In(values))
+	}
+}
+
+func whereNotIn(q *bun.SelectQuery, column string, values []string) {
+	switch len(values) {
+	case 0:
+		return
+	case 1:
+		q.Where("? != ?", bun.Ident(column), values[0])
+	default:
+		q.Where("? NOT IN (?)", bun.Ident(column), bun.In(values))
+	}
+}
+
+func operationStatusInts(src []aud.OperationStatus) []int {
+	dst := make([]int, len(src))
+	for i, s := range src {
+		dst[i] = s.Int()
+	}
+	return dst
+}
+
+var likeReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`%`, `\%`,
+	`_`, `\_`,
+)
+
+// escapeLike escapes LIKE wildcards, assuming backslash is the escape
+// character.
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+var globReplacer = strings.NewReplacer(
+	`[`, `[[]`,
+	`*`, `[*]`,
+	`?`, `[?]`,
+)
+
+// escapeGlob escapes GLOB wildcards by wrapping them in character classes.
+func escapeGlob(s string) string {
+	return globReplacer.Replace(s)
+}
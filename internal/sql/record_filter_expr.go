@@ -0,0 +1,194 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/auditumio/auditum/internal/aud/expr"
+)
+
+// recordFilterColumns maps fields of aud.RecordFilterSchema to columns.
+var recordFilterColumns = map[string]string{
+	"create_time":        "create_time",
+	"labels":             "labels",
+	"resource.type":      "resource_type",
+	"resource.id":        "resource_id",
+	"resource.metadata":  "resource_metadata",
+	"operation.type":     "operation_type",
+	"operation.id":       "operation_id",
+	"operation.time":     "operation_time",
+	"operation.metadata": "operation_metadata",
+	"operation.status":   "operation_status",
+	"actor.type":         "actor_type",
+	"actor.id":           "actor_id",
+	"actor.metadata":     "actor_metadata",
+}
+
+// exprBuilder translates a checked filter expression into a WHERE condition
+// with placeholders.
+type exprBuilder struct {
+	dialect dialect.Name
+	b       strings.Builder
+	args    []any
+}
+
+func buildRecordFilterExpr(d dialect.Name, e expr.Expr) (string, []any, error) {
+	eb := &exprBuilder{dialect: d}
+	if err := eb.build(e); err != nil {
+		return "", nil, err
+	}
+	return eb.b.String(), eb.args, nil
+}
+
+func (eb *exprBuilder) build(e expr.Expr) error {
+	switch e := e.(type) {
+	case expr.And:
+		return eb.buildJoin(e.Args, " AND ")
+	case expr.Or:
+		return eb.buildJoin(e.Args, " OR ")
+	case expr.Not:
+		eb.b.WriteString("NOT (")
+		if err := eb.build(e.Arg); err != nil {
+			return err
+		}
+		eb.b.WriteString(")")
+		return nil
+	case *expr.Restriction:
+		return eb.buildRestriction(e)
+	default:
+		return fmt.Errorf("unsupported expression %T", e)
+	}
+}
+
+func (eb *exprBuilder) buildJoin(args []expr.Expr, sep string) error {
+	eb.b.WriteString("(")
+	for i, arg := range args {
+		if i > 0 {
+			eb.b.WriteString(sep)
+		}
+		if err := eb.build(arg); err != nil {
+			return err
+		}
+	}
+	eb.b.WriteString(")")
+	return nil
+}
+
+func (eb *exprBuilder) buildRestriction(r *expr.Restriction) error {
+	column, ok := recordFilterColumns[r.Field.Name]
+	if !ok {
+		return fmt.Errorf("unsupported field %q", r.Field.Name)
+	}
+
+	switch r.Field.Type {
+	case expr.FieldTypeString:
+		if r.Prefix {
+			eb.write(eb.prefixCondition("?", r.Op), bun.Ident(column), eb.prefixArg(r.Arg))
+			return nil
+		}
+		eb.write("? "+r.Op.String()+" ?", bun.Ident(column), r.Arg)
+		return nil
+	case expr.FieldTypeTimestamp:
+		eb.write("? "+r.Op.String()+" ?", bun.Ident(column), r.Arg)
+		return nil
+	case expr.FieldTypeEnum:
+		// Zero status is stored as NULL.
+		eb.write("COALESCE(?, 0) "+r.Op.String()+" ?", bun.Ident(column), r.Arg)
+		return nil
+	case expr.FieldTypeMap:
+		return eb.buildMapRestriction(column, r)
+	default:
+		return fmt.Errorf("unsupported type of field %q", r.Field.Name)
+	}
+}
+
+// buildMapRestriction compares a map value by key. A missing key is not equal
+// to any value, so `labels.env != "prod"` matches records without "env".
+func (eb *exprBuilder) buildMapRestriction(column string, r *expr.Restriction) error {
+	var value string
+	var key any
+	switch eb.dialect {
+	case dialect.PG:
+		value = "? ->> ?"
+		key = r.Key
+	case dialect.SQLite:
+		value = "json_extract(?, ?)"
+		key = sqliteJSONPath(r.Key)
+	default:
+		return fmt.Errorf("unsupported dialect: %s", eb.dialect.String())
+	}
+
+	switch {
+	case r.Op == expr.OpHas:
+		eb.write(value+" IS NOT NULL", bun.Ident(column), key)
+	case r.Prefix:
+		eb.write(eb.prefixCondition(value, r.Op), bun.Ident(column), key, eb.prefixArg(r.Arg))
+	case eb.dialect == dialect.PG && r.Op == expr.OpEquals:
+		eb.write(value+" IS NOT DISTINCT FROM ?", bun.Ident(column), key, r.Arg)
+	case eb.dialect == dialect.PG && r.Op == expr.OpNotEquals:
+		eb.write(value+" IS DISTINCT FROM ?", bun.Ident(column), key, r.Arg)
+	case r.Op == expr.OpEquals:
+		eb.write(value+" IS ?", bun.Ident(column), key, r.Arg)
+	case r.Op == expr.OpNotEquals:
+		eb.write(value+" IS NOT ?", bun.Ident(column), key, r.Arg)
+	default:
+		return fmt.Errorf("unsupported operator %q for field %q", r.Op.String(), r.Field.Name)
+	}
+
+	return nil
+}
+
+// prefixCondition returns a null-safe prefix match of the value.
+func (eb *exprBuilder) prefixCondition(value string, op expr.Operator) string {
+	var match string
+	if eb.dialect == dialect.PG {
+		match = "COALESCE(" + value + ` LIKE ? ESCAPE '\', FALSE)`
+	} else {
+		// LIKE is case-insensitive in SQLite, while GLOB is not.
+		match = "COALESCE(" + value + " GLOB ?, FALSE)"
+	}
+
+	if op == expr.OpNotEquals {
+		return "NOT " + match
+	}
+	return match
+}
+
+func (eb *exprBuilder) prefixArg(arg any) string {
+	prefix, _ := arg.(string)
+	if eb.dialect == dialect.PG {
+		return escapeLike(prefix) + "%"
+	}
+	return escapeGlob(prefix) + "*"
+}
+
+func (eb *exprBuilder) write(query string, args ...any) {
+	eb.b.WriteString(query)
+	eb.args = append(eb.args, args...)
+}
+
+// sqliteJSONPathKeyReplacer escapes a key in a quoted label of a JSON path
+// of SQLite, which decodes JSON escapes in labels. A quote cannot be
+// escaped with backslash, as it ends the label regardless.
+var sqliteJSONPathKeyReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\u0022`)
+
+func sqliteJSONPath(key string) string {
+	return `$."` + sqliteJSONPathKeyReplacer.Replace(key) + `"`
+}
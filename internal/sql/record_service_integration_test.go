@@ -0,0 +1,120 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1pb "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	auditumv1alpha1 "github.com/auditumio/auditum/internal/api/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/sql/sqltest"
+)
+
+func TestIntegration_RecordServiceServer_CreateRecord(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+	setCleanupRecords(t, db)
+
+	// Test
+
+	server := auditumv1alpha1.NewRecordServiceServer(
+		NewStore(db),
+		zap.NewNop(),
+		aud.DefaultSettings,
+	)
+
+	newRecord := func(idempotencyKey, actorID string) *auditumv1alpha1pb.Record {
+		return &auditumv1alpha1pb.Record{
+			ProjectId: testProjectID.String(),
+			Resource: &auditumv1alpha1pb.Resource{
+				Type: "POST",
+				Id:   "post-1",
+			},
+			Operation: &auditumv1alpha1pb.Operation{
+				Type: "CREATE",
+				Id:   "example.v1.PostService/CreatePost",
+				Time: timestamppb.New(time.Date(2023, 1, 1, 2, 1, 0, 0, time.UTC)),
+			},
+			Actor: &auditumv1alpha1pb.Actor{
+				Type: "USER",
+				Id:   actorID,
+			},
+			IdempotencyKey: idempotencyKey,
+		}
+	}
+
+	t.Run("Should return existing record of retried request", func(t *testing.T) {
+		first, err := server.CreateRecord(ctx, &auditumv1alpha1pb.CreateRecordRequest{
+			Record: newRecord("request-1", "user-1"),
+		})
+		require.NoError(t, err)
+
+		retried, err := server.CreateRecord(ctx, &auditumv1alpha1pb.CreateRecordRequest{
+			Record: newRecord("request-1", "user-2"),
+		})
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(first.GetRecord(), retried.GetRecord()))
+		assert.Equal(t, "user-1", retried.GetRecord().GetActor().GetId())
+
+		batch, err := server.BatchCreateRecords(ctx, &auditumv1alpha1pb.BatchCreateRecordsRequest{
+			ProjectId: testProjectID.String(),
+			Records: []*auditumv1alpha1pb.Record{
+				newRecord("request-1", "user-3"),
+				newRecord("request-2", "user-4"),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, batch.GetRecords(), 2)
+		assert.True(t, proto.Equal(first.GetRecord(), batch.GetRecords()[0]))
+		assert.Equal(t, "user-4", batch.GetRecords()[1].GetActor().GetId())
+
+		list, err := server.ListRecords(ctx, &auditumv1alpha1pb.ListRecordsRequest{
+			ProjectId: testProjectID.String(),
+		})
+		require.NoError(t, err)
+		assert.Len(t, list.GetRecords(), 2)
+	})
+
+	t.Run("Should create records without idempotency key", func(t *testing.T) {
+		first, err := server.CreateRecord(ctx, &auditumv1alpha1pb.CreateRecordRequest{
+			Record: newRecord("", "user-5"),
+		})
+		require.NoError(t, err)
+
+		second, err := server.CreateRecord(ctx, &auditumv1alpha1pb.CreateRecordRequest{
+			Record: newRecord("", "user-5"),
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.GetRecord().GetId(), second.GetRecord().GetId())
+	})
+}
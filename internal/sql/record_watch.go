@@ -0,0 +1,306 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+const (
+	// recordsChannel is the Postgres notification channel of created
+	// records.
+	recordsChannel = "auditum_records"
+	// recordsNotificationSize is the maximum number of record identifiers in
+	// a notification, so the payload is under the Postgres limit of 8000
+	// bytes.
+	recordsNotificationSize = 100
+	// recordsSubscriptionBuffer is the number of notifications buffered for
+	// a subscriber. Subscribers that fall behind are closed.
+	recordsSubscriptionBuffer = 256
+	// recordsListenerRetryDelay is the delay before listening again after
+	// the listener connection failed.
+	recordsListenerRetryDelay = 5 * time.Second
+)
+
+// SubscribeRecords subscribes to records created in the project. Identifiers
+// of created records are sent to the returned channel, in batches as they
+// were created. The channel is closed when ctx is done, when the subscriber
+// falls behind, or when the listener connection fails, since some records
+// may be missed.
+//
+// With Postgres, records created by all instances sharing the database are
+// received, and [Store.RunRecordsListener] must be running. Otherwise,
+// records created by this store are received.
+//
+// May return [aud.ErrWatchUnavailable], if the Postgres listener is not
+// connected.
+func (s *Store) SubscribeRecords(ctx context.Context, projectID aud.ID) (<-chan []aud.ID, error) {
+	return s.records.subscribe(ctx, projectID)
+}
+
+// RunRecordsListener listens to Postgres notifications of records created
+// by all instances sharing the database, and sends them to subscribers
+// until ctx is done. It reconnects when the connection fails. For other
+// databases, it only waits for ctx.
+//
+// Subscriptions are closed when it returns, so that watching clients
+// disconnect on shutdown.
+func (s *Store) RunRecordsListener(ctx context.Context, log *zap.Logger) {
+	if s.db.Dialect().Name() != dialect.PG {
+		<-ctx.Done()
+		s.records.setListening(false)
+		return
+	}
+
+	log = log.Named("records_listener")
+
+	for {
+		err := s.listenRecords(ctx, log)
+		s.records.setListening(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error("Listen to created records", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(recordsListenerRetryDelay):
+		}
+	}
+}
+
+func (s *Store) listenRecords(ctx context.Context, log *zap.Logger) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %v", err)
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(interface{ Conn() *pgx.Conn })
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		c := pgxConn.Conn()
+
+		if _, err := c.Exec(ctx, "LISTEN "+recordsChannel); err != nil {
+			return fmt.Errorf("listen: %v", err)
+		}
+		defer func() {
+			// The connection is returned to the pool, unless closed.
+			if !c.IsClosed() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_, _ = c.Exec(ctx, "UNLISTEN *")
+			}
+		}()
+
+		s.records.setListening(true)
+
+		for {
+			n, err := c.WaitForNotification(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for notification: %v", err)
+			}
+
+			projectID, ids, err := decodeRecordsNotification(n.Payload)
+			if err != nil {
+				log.Warn("Decode records notification", zap.Error(err))
+				continue
+			}
+
+			s.records.publish(projectID, ids)
+		}
+	})
+}
+
+// notifyRecordsCreated notifies other instances about created records. For
+// Postgres, the notification is delivered when the transaction commits.
+// Otherwise, subscribers are notified by publishRecordsCreated after commit.
+func notifyRecordsCreated(ctx context.Context, tx bun.Tx, projectID aud.ID, ids []aud.ID) error {
+	if tx.Dialect().Name() != dialect.PG {
+		return nil
+	}
+
+	for start := 0; start < len(ids); start += recordsNotificationSize {
+		end := min(start+recordsNotificationSize, len(ids))
+
+		_, err := tx.ExecContext(
+			ctx,
+			"SELECT pg_notify(?, ?)",
+			recordsChannel,
+			encodeRecordsNotification(projectID, ids[start:end]),
+		)
+		if err != nil {
+			return fmt.Errorf("notify created records: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// publishRecordsCreated notifies subscribers of this store about created
+// records, unless notifications are delivered by Postgres.
+func (s *Store) publishRecordsCreated(projectID aud.ID, ids []aud.ID) {
+	if s.db.Dialect().Name() == dialect.PG {
+		return
+	}
+
+	s.records.publish(projectID, ids)
+}
+
+// encodeRecordsNotification encodes the notification payload as
+// "<project_id>:<record_id>,<record_id>,...".
+func encodeRecordsNotification(projectID aud.ID, ids []aud.ID) string {
+	var b strings.Builder
+	b.WriteString(projectID.String())
+	b.WriteByte(':')
+	for i, id := range ids {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(id.String())
+	}
+	return b.String()
+}
+
+func decodeRecordsNotification(payload string) (aud.ID, []aud.ID, error) {
+	project, records, ok := strings.Cut(payload, ":")
+	if !ok {
+		return aud.ID{}, nil, fmt.Errorf("invalid payload %q", payload)
+	}
+
+	projectID, err := aud.ParseID(project)
+	if err != nil {
+		return aud.ID{}, nil, fmt.Errorf("invalid project id: %v", err)
+	}
+
+	var ids []aud.ID
+	for _, s := range strings.Split(records, ",") {
+		id, err := aud.ParseID(s)
+		if err != nil {
+			return aud.ID{}, nil, fmt.Errorf("invalid record id: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return projectID, ids, nil
+}
+
+// recordsBroadcaster sends identifiers of created records to subscribers of
+// the project.
+type recordsBroadcaster struct {
+	mu          sync.Mutex
+	listening   bool
+	subscribers map[aud.ID]map[*recordsSubscriber]struct{}
+}
+
+type recordsSubscriber struct {
+	c      chan []aud.ID
+	closed bool
+}
+
+func newRecordsBroadcaster(listening bool) *recordsBroadcaster {
+	return &recordsBroadcaster{
+		listening:   listening,
+		subscribers: make(map[aud.ID]map[*recordsSubscriber]struct{}),
+	}
+}
+
+func (b *recordsBroadcaster) subscribe(ctx context.Context, projectID aud.ID) (<-chan []aud.ID, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.listening {
+		return nil, aud.ErrWatchUnavailable
+	}
+
+	sub := &recordsSubscriber{
+		c: make(chan []aud.ID, recordsSubscriptionBuffer),
+	}
+
+	if b.subscribers[projectID] == nil {
+		b.subscribers[projectID] = make(map[*recordsSubscriber]struct{})
+	}
+	b.subscribers[projectID][sub] = struct{}{}
+
+	context.AfterFunc(ctx, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.remove(projectID, sub)
+	})
+
+	return sub.c, nil
+}
+
+func (b *recordsBroadcaster) publish(projectID aud.ID, ids []aud.ID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[projectID] {
+		select {
+		case sub.c <- ids:
+		default:
+			// The subscriber fell behind.
+			b.remove(projectID, sub)
+		}
+	}
+}
+
+// setListening marks whether notifications are received. When listening
+// stops, all subscribers are closed, since notifications may be missed.
+func (b *recordsBroadcaster) setListening(listening bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listening = listening
+	if listening {
+		return
+	}
+
+	for projectID, subs := range b.subscribers {
+		for sub := range subs {
+			b.remove(projectID, sub)
+		}
+	}
+}
+
+// remove closes the subscriber. Must be called with the lock held.
+func (b *recordsBroadcaster) remove(projectID aud.ID, sub *recordsSubscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.c)
+
+	delete(b.subscribers[projectID], sub)
+	if len(b.subscribers[projectID]) == 0 {
+		delete(b.subscribers, projectID)
+	}
+}
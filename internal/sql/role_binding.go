@@ -0,0 +1,71 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+type roleBindingModel struct {
+	bun.BaseModel `bun:"table:role_bindings,alias:role_bindings"`
+
+	ProjectID   aud.ID    `bun:"project_id,pk"`
+	PrincipalID string    `bun:"principal_id,pk"`
+	Role        string    `bun:"role,notnull"`
+	CreateTime  time.Time `bun:"create_time,notnull"`
+	UpdateTime  time.Time `bun:"update_time,notnull"`
+}
+
+func toRoleBindingModel(binding aud.RoleBinding) roleBindingModel {
+	return roleBindingModel{
+		ProjectID:   binding.ProjectID,
+		PrincipalID: binding.PrincipalID,
+		Role:        binding.Role.String(),
+		CreateTime:  binding.CreateTime,
+		UpdateTime:  binding.UpdateTime,
+	}
+}
+
+func fromRoleBindingModel(model roleBindingModel) (aud.RoleBinding, error) {
+	role, err := aud.ParseRole(model.Role)
+	if err != nil {
+		return aud.RoleBinding{}, err
+	}
+
+	return aud.RoleBinding{
+		ProjectID:   model.ProjectID,
+		PrincipalID: model.PrincipalID,
+		Role:        role,
+		CreateTime:  model.CreateTime.UTC(),
+		UpdateTime:  model.UpdateTime.UTC(),
+	}, nil
+}
+
+func fromRoleBindingModels(models []roleBindingModel) ([]aud.RoleBinding, error) {
+	bindings := make([]aud.RoleBinding, len(models))
+	for i, model := range models {
+		binding, err := fromRoleBindingModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("role binding of %q in project %s: %v", model.PrincipalID, model.ProjectID, err)
+		}
+		bindings[i] = binding
+	}
+	return bindings, nil
+}
@@ -0,0 +1,58 @@
+BEGIN;
+
+ALTER TABLE projects ADD COLUMN quota_records_per_minute BIGINT;
+ALTER TABLE projects ADD COLUMN quota_records_per_day BIGINT;
+ALTER TABLE projects ADD COLUMN quota_max_records BIGINT;
+ALTER TABLE projects ADD COLUMN quota_max_bytes BIGINT;
+
+CREATE TABLE project_usage
+(
+    project_id        UUID,
+    records           BIGINT NOT NULL DEFAULT 0,
+    bytes             BIGINT NOT NULL DEFAULT 0,
+    minute_start_time TIMESTAMPTZ,
+    minute_records    BIGINT NOT NULL DEFAULT 0,
+    day_start_time    TIMESTAMPTZ,
+    day_records       BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (project_id),
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+-- Backfill totals of existing projects. Bytes are calculated the same way
+-- as by the application: the length of all text and JSON values.
+INSERT INTO project_usage (project_id, records, bytes)
+SELECT projects.id,
+       COALESCE(r.records, 0),
+       COALESCE(r.bytes, 0) + COALESCE(c.bytes, 0)
+FROM projects
+         LEFT JOIN (SELECT project_id,
+                           COUNT(*) AS records,
+                           SUM(
+                               length(CAST(resource_type AS BLOB)) + length(CAST(resource_id AS BLOB)) +
+                               length(CAST(operation_type AS BLOB)) + length(CAST(operation_id AS BLOB)) +
+                               COALESCE(length(CAST(operation_traceparent AS BLOB)), 0) +
+                               COALESCE(length(CAST(operation_tracestate AS BLOB)), 0) +
+                               length(CAST(actor_type AS BLOB)) + length(CAST(actor_id AS BLOB)) +
+                               (SELECT COALESCE(SUM(length(CAST(key AS BLOB)) + length(CAST(value AS BLOB))), 0)
+                                FROM json_each(CASE WHEN json_type(labels) = 'object' THEN labels END)) +
+                               (SELECT COALESCE(SUM(length(CAST(key AS BLOB)) + length(CAST(value AS BLOB))), 0)
+                                FROM json_each(CASE WHEN json_type(resource_metadata) = 'object' THEN resource_metadata END)) +
+                               (SELECT COALESCE(SUM(length(CAST(key AS BLOB)) + length(CAST(value AS BLOB))), 0)
+                                FROM json_each(CASE WHEN json_type(operation_metadata) = 'object' THEN operation_metadata END)) +
+                               (SELECT COALESCE(SUM(length(CAST(key AS BLOB)) + length(CAST(value AS BLOB))), 0)
+                                FROM json_each(CASE WHEN json_type(actor_metadata) = 'object' THEN actor_metadata END))
+                           ) AS bytes
+                    FROM records
+                    GROUP BY project_id) r ON r.project_id = projects.id
+         LEFT JOIN (SELECT project_id,
+                           SUM(
+                               length(CAST(name AS BLOB)) +
+                               COALESCE(length(CAST(description AS BLOB)), 0) +
+                               COALESCE(length(CAST(old_value AS BLOB)), 0) +
+                               COALESCE(length(CAST(new_value AS BLOB)), 0)
+                           ) AS bytes
+                    FROM records_resource_changes
+                    GROUP BY project_id) c ON c.project_id = projects.id;
+
+COMMIT;
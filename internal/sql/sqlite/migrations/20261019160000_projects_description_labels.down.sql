@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE projects DROP COLUMN labels;
+ALTER TABLE projects DROP COLUMN description;
+
+COMMIT;
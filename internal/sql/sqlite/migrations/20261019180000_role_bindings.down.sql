@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE role_bindings;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE role_bindings
+(
+    project_id   UUID        NOT NULL,
+    principal_id TEXT        NOT NULL,
+    role         TEXT        NOT NULL,
+    create_time  TIMESTAMPTZ NOT NULL,
+    update_time  TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (project_id, principal_id),
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_role_bindings_principal_id ON role_bindings (principal_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX idx_records_project_id_create_time_id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE INDEX idx_records_project_id_create_time_id ON records (project_id, create_time, id);
+
+COMMIT;
@@ -0,0 +1,41 @@
+BEGIN;
+
+CREATE TABLE webhooks
+(
+    id           UUID,
+    project_id   UUID        NOT NULL,
+    create_time  TIMESTAMPTZ NOT NULL,
+    display_name TEXT        NOT NULL,
+    url          TEXT        NOT NULL,
+    secret       TEXT        NOT NULL,
+    filter       TEXT        NOT NULL,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhooks_project_id ON webhooks (project_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id                 UUID,
+    project_id         UUID        NOT NULL,
+    webhook_id         UUID        NOT NULL,
+    record_id          UUID        NOT NULL,
+    create_time        TIMESTAMPTZ NOT NULL,
+    status             TEXT        NOT NULL,
+    attempts           INTEGER     NOT NULL,
+    next_attempt_time  TIMESTAMPTZ,
+    last_attempt_time  TIMESTAMPTZ,
+    last_response_code INTEGER     NOT NULL,
+    last_error         TEXT        NOT NULL,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id_id ON webhook_deliveries (webhook_id, id);
+CREATE INDEX idx_webhook_deliveries_next_attempt_time ON webhook_deliveries (next_attempt_time) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_last_attempt_time ON webhook_deliveries (last_attempt_time) WHERE status <> 'pending';
+
+COMMIT;
@@ -0,0 +1,53 @@
+BEGIN;
+
+CREATE TABLE alert_rules
+(
+    id               UUID,
+    project_id       UUID        NOT NULL,
+    create_time      TIMESTAMPTZ NOT NULL,
+    display_name     TEXT        NOT NULL,
+    type             TEXT        NOT NULL,
+    threshold_filter TEXT        NOT NULL,
+    threshold_count  INTEGER     NOT NULL,
+    sequence_steps   JSONB,
+    group_by         JSONB,
+    window_seconds   BIGINT      NOT NULL,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alert_rules_project_id ON alert_rules (project_id);
+
+CREATE TABLE alert_rule_states
+(
+    rule_id     UUID        NOT NULL,
+    group_key   TEXT        NOT NULL,
+    state       JSONB,
+    expire_time TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (rule_id, group_key),
+    FOREIGN KEY (rule_id) REFERENCES alert_rules (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alert_rule_states_expire_time ON alert_rule_states (expire_time);
+
+CREATE TABLE alerts
+(
+    id                   UUID,
+    project_id           UUID        NOT NULL,
+    rule_id              UUID        NOT NULL,
+    create_time          TIMESTAMPTZ NOT NULL,
+    group_values         JSONB,
+    record_ids           JSONB,
+    first_operation_time TIMESTAMPTZ NOT NULL,
+    last_operation_time  TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alerts_project_id_id ON alerts (project_id, id);
+CREATE INDEX idx_alerts_rule_id_id ON alerts (rule_id, id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX idx_projects_external_id;
+
+COMMIT;
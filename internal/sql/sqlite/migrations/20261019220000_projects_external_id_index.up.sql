@@ -0,0 +1,12 @@
+BEGIN;
+
+-- SQLite cannot drop a column referenced by an index, so the index of
+-- external_id is dropped by the down migration of this one, right before
+-- the column is dropped by 20240704220029_projects_external_id. The index
+-- is recreated here to make this migration own it, or created if this
+-- migration was reverted before.
+DROP INDEX IF EXISTS idx_projects_external_id;
+
+CREATE UNIQUE INDEX idx_projects_external_id ON projects (external_id);
+
+COMMIT;
@@ -0,0 +1,253 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration && !postgres && sqlite
+
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/sql/sqlite"
+	"github.com/auditumio/auditum/pkg/fragma/bunx"
+)
+
+func TestIntegration_Migrator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fpath := filepath.Join(t.TempDir(), "auditum.db")
+	versions := migrationVersions(t)
+	latest := versions[len(versions)-1]
+
+	t.Run("Should return status without applied migrations", func(t *testing.T) {
+		status := migrationStatus(ctx, t, fpath)
+		assert.Zero(t, status.Version)
+		assert.False(t, status.Dirty)
+		require.Len(t, status.Pending, len(versions))
+		assert.Equal(t, versions[0], status.Pending[0].Version)
+	})
+
+	t.Run("Should plan migrations to apply", func(t *testing.T) {
+		m := newMigrator(ctx, t, fpath)
+
+		planned, err := m.PlanUp()
+		require.NoError(t, err)
+		require.Len(t, planned, len(versions))
+		assert.Equal(t, versions[0], planned[0].Version)
+		assert.Equal(t, source.Up, planned[0].Direction)
+		assert.NotEmpty(t, planned[0].SQL)
+
+		assert.Zero(t, migrationStatus(ctx, t, fpath).Version)
+	})
+
+	t.Run("Should apply all migrations", func(t *testing.T) {
+		require.NoError(t, newMigrator(ctx, t, fpath).Up())
+
+		status := migrationStatus(ctx, t, fpath)
+		assert.Equal(t, latest, status.Version)
+		assert.Empty(t, status.Pending)
+	})
+
+	t.Run("Should plan migrations to revert", func(t *testing.T) {
+		planned, err := newMigrator(ctx, t, fpath).PlanDown(2)
+		require.NoError(t, err)
+		require.Len(t, planned, 2)
+		assert.Equal(t, latest, planned[0].Version)
+		assert.Equal(t, versions[len(versions)-2], planned[1].Version)
+		assert.Equal(t, source.Down, planned[0].Direction)
+
+		_, err = newMigrator(ctx, t, fpath).PlanDown(len(versions) + 1)
+		assert.Error(t, err)
+
+		assert.Equal(t, latest, migrationStatus(ctx, t, fpath).Version)
+	})
+
+	t.Run("Should revert migrations", func(t *testing.T) {
+		require.NoError(t, newMigrator(ctx, t, fpath).Down(2))
+
+		status := migrationStatus(ctx, t, fpath)
+		assert.Equal(t, versions[len(versions)-3], status.Version)
+		assert.Len(t, status.Pending, 2)
+	})
+
+	t.Run("Should plan and migrate to version", func(t *testing.T) {
+		planned, err := newMigrator(ctx, t, fpath).PlanGoto(latest)
+		require.NoError(t, err)
+		assert.Len(t, planned, 2)
+
+		require.NoError(t, newMigrator(ctx, t, fpath).Goto(latest))
+		assert.Equal(t, latest, migrationStatus(ctx, t, fpath).Version)
+
+		planned, err = newMigrator(ctx, t, fpath).PlanGoto(versions[0])
+		require.NoError(t, err)
+		assert.Len(t, planned, len(versions)-1)
+
+		require.NoError(t, newMigrator(ctx, t, fpath).Goto(versions[0]))
+		assert.Equal(t, versions[0], migrationStatus(ctx, t, fpath).Version)
+
+		_, err = newMigrator(ctx, t, fpath).PlanGoto(versions[0] + 1)
+		assert.ErrorContains(t, err, "unknown version")
+	})
+
+	t.Run("Should revert all migrations", func(t *testing.T) {
+		require.NoError(t, newMigrator(ctx, t, fpath).Up())
+		require.NoError(t, newMigrator(ctx, t, fpath).Down(len(versions)))
+
+		status := migrationStatus(ctx, t, fpath)
+		assert.Zero(t, status.Version)
+		assert.Len(t, status.Pending, len(versions))
+	})
+
+	t.Run("Should force version of dirty database", func(t *testing.T) {
+		require.NoError(t, newMigrator(ctx, t, fpath).Up())
+		setMigrationDirty(ctx, t, fpath)
+
+		status := migrationStatus(ctx, t, fpath)
+		assert.Equal(t, latest, status.Version)
+		assert.True(t, status.Dirty)
+
+		_, err := newMigrator(ctx, t, fpath).PlanUp()
+		assert.Error(t, err)
+
+		require.NoError(t, newMigrator(ctx, t, fpath).Force(int(versions[len(versions)-2])))
+
+		status = migrationStatus(ctx, t, fpath)
+		assert.Equal(t, versions[len(versions)-2], status.Version)
+		assert.False(t, status.Dirty)
+		assert.Len(t, status.Pending, 1)
+	})
+}
+
+func TestIntegration_Migrator_EmbeddedMigrations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fpath := filepath.Join(t.TempDir(), "auditum.db")
+
+	t.Run("Should use embedded migrations without migrations path", func(t *testing.T) {
+		planned, err := newMigrator(ctx, t, fpath).PlanUp()
+		require.NoError(t, err)
+
+		var (
+			versions []uint
+			files    []string
+		)
+		for _, migration := range planned {
+			versions = append(versions, migration.Version)
+			files = append(files, strconv.FormatUint(uint64(migration.Version), 10)+"_"+migration.Identifier+".up.sql")
+
+			b, err := os.ReadFile(filepath.Join("migrations", files[len(files)-1]))
+			require.NoError(t, err)
+			assert.Equal(t, string(b), migration.SQL)
+		}
+		assert.Equal(t, migrationVersions(t), versions)
+	})
+
+	t.Run("Should use migrations path instead of embedded migrations", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(
+			filepath.Join(dir, "1_custom.up.sql"),
+			[]byte("CREATE TABLE custom (id TEXT);"),
+			0o600,
+		)
+		require.NoError(t, err)
+
+		db, err := sqlite.NewDatabase(ctx, fpath, zap.NewNop(), bunx.LogQueriesDisabled)
+		require.NoError(t, err)
+
+		m, err := sqlite.NewMigrator(db, fpath, dir, nil)
+		require.NoError(t, err)
+		defer m.Close()
+
+		status, err := m.Status()
+		require.NoError(t, err)
+		require.Len(t, status.Pending, 1)
+		assert.Equal(t, bunx.Migration{Version: 1, Identifier: "custom"}, status.Pending[0])
+	})
+}
+
+// migrationVersions returns versions of migrations in ascending order.
+func migrationVersions(t *testing.T) []uint {
+	t.Helper()
+
+	entries, err := os.ReadDir("migrations")
+	require.NoError(t, err)
+
+	var versions []uint
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".up.sql")
+		if !ok {
+			continue
+		}
+		version, _, _ := strings.Cut(name, "_")
+		v, err := strconv.ParseUint(version, 10, 64)
+		require.NoError(t, err)
+		versions = append(versions, uint(v))
+	}
+	require.NotEmpty(t, versions)
+
+	return versions
+}
+
+// newMigrator returns a migrator of embedded migrations, closed at the end
+// of the test.
+func newMigrator(ctx context.Context, t *testing.T, fpath string) *bunx.Migrator {
+	t.Helper()
+
+	db, err := sqlite.NewDatabase(ctx, fpath, zap.NewNop(), bunx.LogQueriesDisabled)
+	require.NoError(t, err)
+
+	m, err := sqlite.NewMigrator(db, fpath, "", nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, m.Close())
+	})
+
+	return m
+}
+
+func migrationStatus(ctx context.Context, t *testing.T, fpath string) bunx.MigrationStatus {
+	t.Helper()
+
+	status, err := newMigrator(ctx, t, fpath).Status()
+	require.NoError(t, err)
+
+	return status
+}
+
+// setMigrationDirty marks the database dirty, as if the last migration
+// failed.
+func setMigrationDirty(ctx context.Context, t *testing.T, fpath string) {
+	t.Helper()
+
+	db, err := sqlite.NewDatabase(ctx, fpath, zap.NewNop(), bunx.LogQueriesDisabled)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, "UPDATE schema_migrations SET dirty = TRUE")
+	require.NoError(t, err)
+}
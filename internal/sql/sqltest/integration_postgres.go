@@ -107,6 +107,6 @@ func runMigrations(t *testing.T, db *bun.DB, logQueries bool) {
 		log = migrateLogger{t: t}
 	}
 
-	err := postgres.RunMigrations(db, "./postgres/migrations", log)
+	err := postgres.RunMigrations(db, "", log)
 	require.NoError(t, err)
 }
@@ -105,6 +105,6 @@ func runMigrations(t *testing.T, db *bun.DB, fpath string, logQueries bool) {
 		log = migrateLogger{t: t}
 	}
 
-	err := sqlite.RunMigrations(db, fpath, "./sqlite/migrations", log)
+	err := sqlite.RunMigrations(db, fpath, "", log)
 	require.NoError(t, err)
 }
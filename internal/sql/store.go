@@ -19,19 +19,45 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
+	"go.uber.org/zap"
 
 	"github.com/auditumio/auditum/internal/aud"
 )
 
 type Store struct {
-	db *bun.DB
+	db      *bun.DB
+	records *recordsBroadcaster
+	log     *zap.Logger
 }
 
-func NewStore(db *bun.DB) *Store {
-	return &Store{db: db}
+type StoreOption func(*Store)
+
+// WithLogger sets the logger for errors of work done in the background of
+// store calls, e.g. evaluation of alert rules. Defaults to no-op logger.
+func WithLogger(log *zap.Logger) StoreOption {
+	return func(s *Store) {
+		s.log = log
+	}
+}
+
+func NewStore(db *bun.DB, opts ...StoreOption) *Store {
+	s := &Store{
+		db: db,
+		// Postgres notifications are received by the listener, while other
+		// databases notify subscribers in process.
+		records: newRecordsBroadcaster(db.Dialect().Name() != dialect.PG),
+		log:     zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *Store) CreateProject(ctx context.Context, project aud.Project) error {
@@ -51,6 +77,10 @@ func (s *Store) CreateProject(ctx context.Context, project aud.Project) error {
 			return aud.ErrConflict
 		}
 
+		if err := createProjectUsage(ctx, tx, model.ID); err != nil {
+			return err
+		}
+
 		if tx.Dialect().Name() == dialect.PG {
 			if err := createTablePartitionForProject(
 				ctx,
@@ -89,6 +119,7 @@ func (s *Store) GetProject(ctx context.Context, id aud.ID) (aud.Project, error)
 func (s *Store) ListProjects(
 	ctx context.Context,
 	filter aud.ProjectFilter,
+	order aud.ProjectOrder,
 	limit int32,
 	cursor aud.ProjectCursor,
 ) ([]aud.Project, error) {
@@ -97,15 +128,14 @@ func (s *Store) ListProjects(
 	q := s.db.NewSelect().
 		Model(&models)
 
-	if len(filter.ExternalIDs) > 0 {
-		q.Where("external_id IN (?)", bun.In(filter.ExternalIDs))
+	if err := applyProjectFilter(q, filter); err != nil {
+		return nil, fmt.Errorf("apply filter: %v", err)
 	}
 
-	if cursor.LastID != nil {
-		q.Where("id < ?", cursor.LastID)
+	if err := applyProjectOrder(q, order, cursor); err != nil {
+		return nil, fmt.Errorf("apply order: %v", err)
 	}
 
-	q.Order("id DESC")
 	q.Limit(int(limit))
 
 	err := q.Scan(ctx)
@@ -132,6 +162,27 @@ func (s *Store) UpdateProject(
 	if update.UpdateDeleteRecordEnabled {
 		columns = append(columns, "delete_record_enabled")
 	}
+	if update.UpdateDescription {
+		columns = append(columns, "description")
+	}
+	if update.UpdateLabels {
+		columns = append(columns, "labels")
+	}
+	if update.UpdateExternalID {
+		columns = append(columns, "external_id")
+	}
+	if update.UpdateQuota {
+		columns = append(
+			columns,
+			"quota_records_per_minute",
+			"quota_records_per_day",
+			"quota_max_records",
+			"quota_max_bytes",
+		)
+	}
+	if update.UpdateRecordsRestrictions {
+		columns = append(columns, "records_restrictions")
+	}
 	if len(columns) == 0 {
 		return aud.Project{}, fmt.Errorf("nothing to update")
 	}
@@ -141,10 +192,29 @@ func (s *Store) UpdateProject(
 		DisplayName:         update.DisplayName,
 		UpdateRecordEnabled: update.UpdateRecordEnabled,
 		DeleteRecordEnabled: update.DeleteRecordEnabled,
+		Description:         update.Description,
+		Labels:              update.Labels,
+		ExternalID:          update.ExternalID,
+		Quota:               update.Quota,
+		RecordsRestrictions: update.RecordsRestrictions,
 	}
 	model := toProjectModel(proj)
 
 	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if update.UpdateExternalID && update.ExternalID != "" {
+			taken, err := tx.NewSelect().
+				Model((*projectModel)(nil)).
+				Where("external_id = ?", update.ExternalID).
+				Where("id != ?", id).
+				Exists(ctx)
+			if err != nil {
+				return fmt.Errorf("check external id in db: %v", err)
+			}
+			if taken {
+				return aud.ErrConflict
+			}
+		}
+
 		result, err := tx.NewUpdate().
 			Model(&model).
 			Column(columns...).
@@ -169,27 +239,209 @@ func (s *Store) UpdateProject(
 	return project, nil
 }
 
-func (s *Store) CreateRecord(ctx context.Context, record aud.Record) error {
-	model := toRecordModel(record)
+func (s *Store) GetProjectUsage(ctx context.Context, projectID aud.ID) (aud.ProjectUsage, error) {
+	var model projectUsageModel
 
 	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		if err := projectExists(ctx, tx, record.ProjectID); err != nil {
+		if err := projectExists(ctx, tx, projectID); err != nil {
 			return err
 		}
 
-		_, err := tx.NewInsert().
+		err := tx.NewSelect().
 			Model(&model).
-			Exec(ctx)
+			Where("project_id = ?", projectID).
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			model.ProjectID = projectID
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("select project usage from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return aud.ProjectUsage{}, fmt.Errorf("run transaction: %w", err)
+	}
+
+	usage := fromProjectUsageModel(model)
+	return usage, nil
+}
+
+func (s *Store) GetProjectStats(ctx context.Context, projectID aud.ID) (aud.ProjectStats, error) {
+	var (
+		model     projectStatsModel
+		sizeBytes int64
+	)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := projectExists(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		var err error
+
+		model, err = selectProjectStats(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+
+		sizeBytes, err = selectProjectSizeBytes(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return aud.ProjectStats{}, fmt.Errorf("run transaction: %w", err)
+	}
+
+	stats := fromProjectStatsModel(projectID, model, sizeBytes)
+	return stats, nil
+}
+
+// ListProjectUsage returns usage of all projects.
+func (s *Store) ListProjectUsage(ctx context.Context) ([]aud.ProjectUsage, error) {
+	var models []projectUsageModel
+
+	err := s.db.NewSelect().
+		Model(&models).
+		Order("project_id").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("select project usage from db: %v", err)
+	}
+
+	usages := fromProjectUsageModels(models)
+	return usages, nil
+}
+
+// CreateRecord creates the record. See CreateRecords.
+func (s *Store) CreateRecord(ctx context.Context, record aud.Record) (aud.Record, error) {
+	stored, err := s.CreateRecords(ctx, []aud.Record{record})
+	if err != nil {
+		return aud.Record{}, err
+	}
+	return stored[0], nil
+}
+
+// CreateRecords creates the records of a project. Records with ids of
+// existing records are skipped, as well as duplicates within records, so
+// that creation of records with ids derived from idempotency keys can be
+// retried. Only created records are added to the project usage, notified
+// to webhooks and watches, and evaluated against alert rules.
+//
+// Returns the records as stored, in order of records: created records, and
+// existing records in place of skipped ones.
+func (s *Store) CreateRecords(ctx context.Context, records []aud.Record) ([]aud.Record, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no records to create")
+	}
+
+	projectID := records[0].ProjectID
+	for i := 1; i < len(records); i++ {
+		if records[i].ProjectID != projectID {
+			return nil, fmt.Errorf("records must have the same project id")
+		}
+	}
+
+	recordMods := toRecordModels(records)
+
+	createTime := records[0].CreateTime
+
+	var (
+		created []aud.Record
+		stored  = make(map[aud.ID]aud.Record, len(records))
+	)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		proj, err := getProject(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+
+		var ids []aud.ID
+
+		_, err = tx.NewInsert().
+			Model(&recordMods).
+			On("CONFLICT DO NOTHING").
+			Returning("id").
+			Exec(ctx, &ids)
+		if err != nil {
+			return fmt.Errorf("insert records into db: %v", err)
+		}
+
+		inserted := make(map[aud.ID]bool, len(ids))
+		for _, id := range ids {
+			inserted[id] = true
+		}
+
+		var (
+			changeMods []recordResourceChangeModel
+			skippedIDs []aud.ID
+		)
+		for i, recordMod := range recordMods {
+			if _, ok := stored[recordMod.ID]; ok {
+				// Duplicate of a preceding record.
+				continue
+			}
+			if !inserted[recordMod.ID] {
+				stored[recordMod.ID] = aud.Record{}
+				skippedIDs = append(skippedIDs, recordMod.ID)
+				continue
+			}
+
+			stored[recordMod.ID] = records[i]
+			created = append(created, records[i])
+			changeMods = append(changeMods, recordMod.ResourceChanges...)
+		}
+
+		if len(skippedIDs) > 0 {
+			var existingMods []recordModel
+
+			err := tx.NewSelect().
+				Model(&existingMods).
+				Relation(relationResourceChanges).
+				Where("project_id = ?", projectID).
+				Where("id IN (?)", bun.In(skippedIDs)).
+				Scan(ctx)
+			if err != nil {
+				return fmt.Errorf("select existing records from db: %v", err)
+			}
+			if len(existingMods) != len(skippedIDs) {
+				return fmt.Errorf("records exist in other projects")
+			}
+
+			for _, existingMod := range existingMods {
+				stored[existingMod.ID] = fromRecordModel(existingMod)
+			}
+		}
+
+		if len(created) == 0 {
+			return nil
+		}
+
+		err = addRecordsUsage(ctx, tx, proj, createTime, int64(len(created)), recordsSize(created))
 		if err != nil {
-			return fmt.Errorf("insert record into db: %v", err)
+			return err
+		}
+
+		if err := notifyRecordsCreated(ctx, tx, proj.ID, recordsIDs(created)); err != nil {
+			return err
+		}
+
+		if err := createWebhookDeliveries(ctx, tx, proj.ID, created); err != nil {
+			return err
 		}
 
-		if len(model.ResourceChanges) == 0 {
+		if len(changeMods) == 0 {
 			return nil
 		}
 
 		_, err = tx.NewInsert().
-			Model(&model.ResourceChanges).
+			Model(&changeMods).
 			Exec(ctx)
 		if err != nil {
 			return fmt.Errorf("insert record resource changes into db: %v", err)
@@ -198,43 +450,88 @@ func (s *Store) CreateRecord(ctx context.Context, record aud.Record) error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("run transaction: %w", err)
+		return nil, fmt.Errorf("run transaction: %w", err)
 	}
 
-	return nil
+	if len(created) > 0 {
+		s.publishRecordsCreated(projectID, recordsIDs(created))
+		s.evaluateRecordsAlerts(ctx, projectID, created)
+	}
+
+	result := make([]aud.Record, len(records))
+	for i, record := range records {
+		result[i] = stored[record.ID]
+	}
+
+	return result, nil
 }
 
-func (s *Store) CreateRecords(ctx context.Context, records []aud.Record) error {
+func recordsIDs(records []aud.Record) []aud.ID {
+	ids := make([]aud.ID, len(records))
+	for i, record := range records {
+		ids[i] = record.ID
+	}
+	return ids
+}
+
+// ImportRecords creates records keeping their ids and create time. Records
+// that already exist are skipped. Returns the number of created records.
+// Created records are added to the project usage, but quotas are not enforced.
+func (s *Store) ImportRecords(ctx context.Context, records []aud.Record) (int, error) {
 	if len(records) == 0 {
-		return fmt.Errorf("no records to create")
+		return 0, fmt.Errorf("no records to import")
 	}
 
 	projectID := records[0].ProjectID
 	for i := 1; i < len(records); i++ {
 		if records[i].ProjectID != projectID {
-			return fmt.Errorf("records must have the same project id")
+			return 0, fmt.Errorf("records must have the same project id")
 		}
 	}
 
 	recordMods := toRecordModels(records)
 
-	var changeMods []recordResourceChangeModel
-	for _, recordMod := range recordMods {
-		changeMods = append(changeMods, recordMod.ResourceChanges...)
-	}
+	var created int
 
 	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		if err := projectExists(ctx, tx, projectID); err != nil {
 			return err
 		}
 
+		var ids []aud.ID
+
 		_, err := tx.NewInsert().
 			Model(&recordMods).
-			Exec(ctx)
+			On("CONFLICT DO NOTHING").
+			Returning("id").
+			Exec(ctx, &ids)
 		if err != nil {
 			return fmt.Errorf("insert records into db: %v", err)
 		}
 
+		created = len(ids)
+
+		inserted := make(map[aud.ID]bool, len(ids))
+		for _, id := range ids {
+			inserted[id] = true
+		}
+
+		var (
+			changeMods []recordResourceChangeModel
+			size       int64
+		)
+		for i, recordMod := range recordMods {
+			if inserted[recordMod.ID] {
+				changeMods = append(changeMods, recordMod.ResourceChanges...)
+				size += records[i].Size()
+			}
+		}
+
+		err = adjustProjectUsage(ctx, tx, projectID, time.Time{}, int64(created), size)
+		if err != nil {
+			return err
+		}
+
 		if len(changeMods) == 0 {
 			return nil
 		}
@@ -249,10 +546,10 @@ func (s *Store) CreateRecords(ctx context.Context, records []aud.Record) error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("run transaction: %w", err)
+		return 0, fmt.Errorf("run transaction: %w", err)
 	}
 
-	return nil
+	return created, nil
 }
 
 func (s *Store) GetRecord(
@@ -268,20 +565,9 @@ func (s *Store) GetRecord(
 			return err
 		}
 
-		err := tx.NewSelect().
-			Model(&model).
-			Relation(relationResourceChanges).
-			Where("project_id = ?", projectID).
-			Where("id = ?", id).
-			Scan(ctx)
-		if errors.Is(err, sql.ErrNoRows) {
-			return aud.ErrRecordNotFound
-		}
-		if err != nil {
-			return fmt.Errorf("select record from db: %v", err)
-		}
-
-		return nil
+		var err error
+		model, err = selectRecord(ctx, tx, projectID, id)
+		return err
 	})
 	if err != nil {
 		return aud.Record{}, fmt.Errorf("run transaction: %w", err)
@@ -312,44 +598,8 @@ func (s *Store) ListRecords(
 
 		q.Where("project_id = ?", projectID)
 
-		if len(filter.Labels) > 0 {
-			switch tx.Dialect().Name() {
-			case dialect.PG:
-				q.Where("labels @> ?", filter.Labels)
-			case dialect.SQLite:
-				for k, v := range filter.Labels {
-					q.Where("json_extract(labels, ?) = ?", "$."+k, v)
-				}
-			default:
-				return fmt.Errorf("unsupported dialect: %s", tx.Dialect().Name().String())
-			}
-		}
-
-		if filter.ResourceType != "" {
-			q.Where("resource_type = ?", filter.ResourceType)
-		}
-		if filter.ResourceID != "" {
-			q.Where("resource_id = ?", filter.ResourceID)
-		}
-
-		if filter.OperationType != "" {
-			q.Where("operation_type = ?", filter.OperationType)
-		}
-		if filter.OperationID != "" {
-			q.Where("operation_id = ?", filter.OperationID)
-		}
-		if !filter.OperationTimeFrom.IsZero() {
-			q.Where("operation_time >= ?", filter.OperationTimeFrom)
-		}
-		if !filter.OperationTimeTo.IsZero() {
-			q.Where("operation_time < ?", filter.OperationTimeTo)
-		}
-
-		if filter.ActorType != "" {
-			q.Where("actor_type = ?", filter.ActorType)
-		}
-		if filter.ActorID != "" {
-			q.Where("actor_id = ?", filter.ActorID)
+		if err := applyRecordFilter(q, filter); err != nil {
+			return err
 		}
 
 		if !cursor.Empty() {
@@ -375,74 +625,311 @@ func (s *Store) ListRecords(
 	return records, nil
 }
 
-func (s *Store) UpdateRecord(
+// ListRecordsCreatedAfter returns records created after the cursor, ordered
+// by create time and identifier. It is used to watch records.
+func (s *Store) ListRecordsCreatedAfter(
 	ctx context.Context,
 	projectID aud.ID,
-	id aud.ID,
-	update aud.RecordUpdate,
-) (aud.Record, error) {
-	var columns []string
-	if update.UpdateLabels {
-		columns = append(
-			columns,
-			"labels",
-		)
-	}
-	if update.UpdateResource {
-		columns = append(
-			columns,
-			"resource_type",
-			"resource_id",
-			"resource_metadata",
-		)
-	}
-	if update.UpdateOperation {
-		columns = append(
-			columns,
-			"operation_type",
-			"operation_id",
-			"operation_time",
-			"operation_metadata",
-			"operation_traceparent",
-			"operation_tracestate",
-			"operation_status",
-		)
-	}
-	if update.UpdateActor {
-		columns = append(
-			columns,
-			"actor_type",
-			"actor_id",
-			"actor_metadata",
-		)
-	}
-	if len(columns) == 0 {
-		return aud.Record{}, fmt.Errorf("nothing to update")
-	}
-
-	rec := aud.Record{
-		ID:        id,
-		ProjectID: projectID,
-		Labels:    update.Labels,
-		Resource:  update.Resource,
-		Operation: update.Operation,
-		Actor:     update.Actor,
-	}
-	model := toRecordModel(rec)
+	filter aud.RecordFilter,
+	limit int32,
+	cursor aud.RecordWatchCursor,
+) ([]aud.Record, error) {
+	var models []recordModel
 
+	// Transaction is used since the query contains relation.
 	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		proj, err := getProject(ctx, tx, projectID)
-		if err != nil {
+		if err := projectExists(ctx, tx, projectID); err != nil {
 			return err
 		}
 
-		if proj.UpdateRecordEnabled.False() {
-			return aud.ErrDisabled
+		q := tx.NewSelect().
+			Model(&models).
+			Relation(relationResourceChanges)
+
+		q.Where("project_id = ?", projectID)
+
+		if err := applyRecordFilter(q, filter); err != nil {
+			return err
 		}
 
-		result, err := tx.NewUpdate().
-			Model(&model).
-			Column(columns...).
+		if !cursor.Empty() {
+			q.Where("(create_time, id) > (?, ?)", cursor.LastCreateTime, cursor.LastID)
+		}
+
+		q.Order("create_time ASC", "id ASC")
+		q.Limit(int(limit))
+
+		err := q.Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select records from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	records := fromRecordModels(models)
+	return records, nil
+}
+
+// exportBatchSize is the number of records read from the database at once
+// during export.
+const exportBatchSize = 500
+
+// ExportRecords calls fn for each record matching the filter, in the same
+// order as ListRecords. Records are read in batches, so the result is never
+// loaded into memory as a whole. If fn returns an error, export stops and the
+// error is returned.
+func (s *Store) ExportRecords(
+	ctx context.Context,
+	projectID aud.ID,
+	filter aud.RecordFilter,
+	fn func(aud.Record) error,
+) error {
+	if s.db.Dialect().Name() == dialect.PG {
+		return s.exportRecordsWithCursor(ctx, projectID, filter, fn)
+	}
+
+	return s.exportRecordsWithKeyset(ctx, projectID, filter, fn)
+}
+
+// exportRecordsWithCursor reads records with a server-side cursor, which
+// provides a consistent snapshot of the records.
+func (s *Store) exportRecordsWithCursor(
+	ctx context.Context,
+	projectID aud.ID,
+	filter aud.RecordFilter,
+	fn func(aud.Record) error,
+) error {
+	opts := &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}
+
+	err := s.db.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
+		if err := projectExists(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		q := tx.NewSelect().
+			Model((*recordModel)(nil))
+
+		q.Where("project_id = ?", projectID)
+
+		if err := applyRecordFilter(q, filter); err != nil {
+			return err
+		}
+
+		q.Order("operation_time DESC", "id DESC")
+
+		// The query is already formatted, so it is executed directly to avoid
+		// formatting placeholders in filter values again.
+		_, err := tx.Tx.ExecContext(ctx, "DECLARE export_records NO SCROLL CURSOR FOR "+q.String())
+		if err != nil {
+			return fmt.Errorf("declare cursor: %v", err)
+		}
+
+		for {
+			var models []recordModel
+
+			err := tx.NewRaw("FETCH FORWARD ? FROM export_records", exportBatchSize).
+				Scan(ctx, &models)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("fetch records from cursor: %v", err)
+			}
+			if len(models) == 0 {
+				return nil
+			}
+
+			if err := exportRecordModels(ctx, tx, projectID, models, fn); err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+// exportRecordsWithKeyset reads records in batches, continuing each batch
+// after the last record of the previous one. Every batch is read in its own
+// transaction, so the database is not locked for the whole export.
+func (s *Store) exportRecordsWithKeyset(
+	ctx context.Context,
+	projectID aud.ID,
+	filter aud.RecordFilter,
+	fn func(aud.Record) error,
+) error {
+	if err := projectExists(ctx, s.db, projectID); err != nil {
+		return fmt.Errorf("check project: %w", err)
+	}
+
+	var last *recordModel
+
+	for {
+		var models []recordModel
+
+		q := s.db.NewSelect().
+			Model(&models)
+
+		q.Where("project_id = ?", projectID)
+
+		if err := applyRecordFilter(q, filter); err != nil {
+			return err
+		}
+
+		if last != nil {
+			q.Where(
+				"(operation_time < ? OR (operation_time = ? AND id < ?))",
+				last.OperationTime,
+				last.OperationTime,
+				last.ID,
+			)
+		}
+
+		q.Order("operation_time DESC", "id DESC")
+		q.Limit(exportBatchSize)
+
+		if err := q.Scan(ctx); err != nil {
+			return fmt.Errorf("select records from db: %v", err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		if err := exportRecordModels(ctx, s.db, projectID, models, fn); err != nil {
+			return err
+		}
+
+		last = &models[len(models)-1]
+	}
+}
+
+// exportRecordModels selects resource changes of the records and passes
+// the records to fn.
+func exportRecordModels(
+	ctx context.Context,
+	idb bun.IDB,
+	projectID aud.ID,
+	models []recordModel,
+	fn func(aud.Record) error,
+) error {
+	ids := make([]aud.ID, len(models))
+	for i := range models {
+		ids[i] = models[i].ID
+	}
+
+	var changes []recordResourceChangeModel
+
+	err := idb.NewSelect().
+		Model(&changes).
+		Where("project_id = ?", projectID).
+		Where("record_id IN (?)", bun.In(ids)).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select record resource changes from db: %v", err)
+	}
+
+	changesByRecord := make(map[aud.ID][]recordResourceChangeModel, len(models))
+	for _, change := range changes {
+		changesByRecord[change.RecordID] = append(changesByRecord[change.RecordID], change)
+	}
+
+	for _, model := range models {
+		model.ResourceChanges = changesByRecord[model.ID]
+
+		if err := fn(fromRecordModel(model)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) UpdateRecord(
+	ctx context.Context,
+	projectID aud.ID,
+	id aud.ID,
+	update aud.RecordUpdate,
+) (aud.Record, error) {
+	var columns []string
+	if update.UpdateLabels {
+		columns = append(
+			columns,
+			"labels",
+		)
+	}
+	if update.UpdateResource {
+		columns = append(
+			columns,
+			"resource_type",
+			"resource_id",
+			"resource_metadata",
+		)
+	}
+	if update.UpdateOperation {
+		columns = append(
+			columns,
+			"operation_type",
+			"operation_id",
+			"operation_time",
+			"operation_metadata",
+			"operation_traceparent",
+			"operation_tracestate",
+			"operation_status",
+		)
+	}
+	if update.UpdateActor {
+		columns = append(
+			columns,
+			"actor_type",
+			"actor_id",
+			"actor_metadata",
+		)
+	}
+	if len(columns) == 0 {
+		return aud.Record{}, fmt.Errorf("nothing to update")
+	}
+
+	rec := aud.Record{
+		ID:        id,
+		ProjectID: projectID,
+		Labels:    update.Labels,
+		Resource:  update.Resource,
+		Operation: update.Operation,
+		Actor:     update.Actor,
+	}
+	model := toRecordModel(rec)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		proj, err := getProject(ctx, tx, projectID)
+		if err != nil {
+			return err
+		}
+
+		if proj.UpdateRecordEnabled.False() {
+			return aud.ErrDisabled
+		}
+
+		old, err := selectRecord(ctx, tx, projectID, id)
+		if err != nil {
+			return err
+		}
+
+		oldRecord := fromRecordModel(old)
+		newRecord := update.Apply(oldRecord)
+		err = adjustProjectUsage(ctx, tx, projectID, time.Time{}, 0, newRecord.Size()-oldRecord.Size())
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.NewUpdate().
+			Model(&model).
+			Column(columns...).
 			Where("project_id = ?", projectID).
 			Where("id = ?", id).
 			Returning("*").
@@ -496,6 +983,14 @@ func (s *Store) DeleteRecord(ctx context.Context, projectID aud.ID, id aud.ID) e
 			return aud.ErrDisabled
 		}
 
+		old, err := selectRecord(ctx, tx, projectID, id)
+		if errors.Is(err, aud.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
 		_, err = tx.NewDelete().
 			Model((*recordModel)(nil)).
 			Where("project_id = ?", projectID).
@@ -505,6 +1000,11 @@ func (s *Store) DeleteRecord(ctx context.Context, projectID aud.ID, id aud.ID) e
 			return fmt.Errorf("delete record into db: %v", err)
 		}
 
+		err = adjustProjectUsage(ctx, tx, projectID, time.Time{}, -1, -fromRecordModel(old).Size())
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -514,22 +1014,627 @@ func (s *Store) DeleteRecord(ctx context.Context, projectID aud.ID, id aud.ID) e
 	return nil
 }
 
-func getProject(ctx context.Context, idb bun.IDB, id aud.ID) (aud.Project, error) {
-	var model projectModel
+func (s *Store) CreateAPIKey(ctx context.Context, key aud.APIKey) error {
+	model := toAPIKeyModel(key)
 
-	err := idb.NewSelect().
+	_, err := s.db.NewInsert().
 		Model(&model).
-		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("insert api key into db: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Store) ListAPIKeys(
+	ctx context.Context,
+	limit int32,
+	cursor aud.APIKeyCursor,
+) ([]aud.APIKey, error) {
+	var models []apiKeyModel
+
+	q := s.db.NewSelect().
+		Model(&models)
+
+	if cursor.LastID != nil {
+		q.Where("id < ?", cursor.LastID)
+	}
+
+	q.Order("id DESC")
+	q.Limit(int(limit))
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("select api keys from db: %v", err)
+	}
+
+	return fromAPIKeyModels(models)
+}
+
+// GetAPIKeyByHash returns the API key by hash of the secret, including
+// revoked keys.
+func (s *Store) GetAPIKeyByHash(ctx context.Context, keyHash string) (aud.APIKey, error) {
+	var model apiKeyModel
+
+	err := s.db.NewSelect().
+		Model(&model).
+		Where("key_hash = ?", keyHash).
 		Scan(ctx)
 	if errors.Is(err, sql.ErrNoRows) {
-		return aud.Project{}, aud.ErrProjectNotFound
+		return aud.APIKey{}, aud.ErrAPIKeyNotFound
 	}
 	if err != nil {
-		return aud.Project{}, fmt.Errorf("select project from db: %v", err)
+		return aud.APIKey{}, fmt.Errorf("select api key from db: %v", err)
 	}
 
-	project := fromProjectModel(model)
-	return project, nil
+	return fromAPIKeyModel(model)
+}
+
+// RevokeAPIKey revokes the API key. Revoking an already revoked key keeps
+// the original revoke time.
+func (s *Store) RevokeAPIKey(ctx context.Context, id aud.ID, revokeTime time.Time) (aud.APIKey, error) {
+	var model apiKeyModel
+
+	result, err := s.db.NewUpdate().
+		Model(&model).
+		Set("revoke_time = COALESCE(revoke_time, ?)", revokeTime).
+		Where("id = ?", id).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return aud.APIKey{}, fmt.Errorf("update api key in db: %v", err)
+	}
+
+	if rowsAffected(result) == 0 {
+		return aud.APIKey{}, aud.ErrAPIKeyNotFound
+	}
+
+	return fromAPIKeyModel(model)
+}
+
+// SetRoleBinding creates the role binding or updates the role of the
+// existing one. Create time of the existing binding is kept.
+func (s *Store) SetRoleBinding(ctx context.Context, binding aud.RoleBinding) (aud.RoleBinding, error) {
+	model := toRoleBindingModel(binding)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, binding.ProjectID); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(&model).
+			On("CONFLICT (project_id, principal_id) DO UPDATE").
+			Set("role = EXCLUDED.role").
+			Set("update_time = EXCLUDED.update_time").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("upsert role binding into db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return aud.RoleBinding{}, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromRoleBindingModel(model)
+}
+
+// GetRoleBinding returns the role binding of the principal in the project.
+func (s *Store) GetRoleBinding(ctx context.Context, projectID aud.ID, principalID string) (aud.RoleBinding, error) {
+	var model roleBindingModel
+
+	err := s.db.NewSelect().
+		Model(&model).
+		Where("project_id = ?", projectID).
+		Where("principal_id = ?", principalID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return aud.RoleBinding{}, aud.ErrRoleBindingNotFound
+	}
+	if err != nil {
+		return aud.RoleBinding{}, fmt.Errorf("select role binding from db: %v", err)
+	}
+
+	return fromRoleBindingModel(model)
+}
+
+// ListRoleBindings returns role bindings of the project ordered by
+// principal ID.
+func (s *Store) ListRoleBindings(ctx context.Context, projectID aud.ID) ([]aud.RoleBinding, error) {
+	var models []roleBindingModel
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		err := tx.NewSelect().
+			Model(&models).
+			Where("project_id = ?", projectID).
+			Order("principal_id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select role bindings from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromRoleBindingModels(models)
+}
+
+// ListPrincipalRoleBindings returns role bindings of the principal in all
+// projects.
+func (s *Store) ListPrincipalRoleBindings(ctx context.Context, principalID string) ([]aud.RoleBinding, error) {
+	var models []roleBindingModel
+
+	err := s.db.NewSelect().
+		Model(&models).
+		Where("principal_id = ?", principalID).
+		Order("project_id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("select role bindings from db: %v", err)
+	}
+
+	return fromRoleBindingModels(models)
+}
+
+func (s *Store) DeleteRoleBinding(ctx context.Context, projectID aud.ID, principalID string) error {
+	result, err := s.db.NewDelete().
+		Model((*roleBindingModel)(nil)).
+		Where("project_id = ?", projectID).
+		Where("principal_id = ?", principalID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("delete role binding from db: %v", err)
+	}
+
+	if rowsAffected(result) == 0 {
+		return aud.ErrRoleBindingNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhook creates the webhook. May return [aud.ErrProjectNotFound].
+func (s *Store) CreateWebhook(ctx context.Context, webhook aud.Webhook) error {
+	model := toWebhookModel(webhook)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, webhook.ProjectID); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(&model).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("insert webhook into db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetWebhook(ctx context.Context, projectID aud.ID, id aud.ID) (aud.Webhook, error) {
+	var model webhookModel
+
+	err := s.db.NewSelect().
+		Model(&model).
+		Where("project_id = ?", projectID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return aud.Webhook{}, aud.ErrWebhookNotFound
+	}
+	if err != nil {
+		return aud.Webhook{}, fmt.Errorf("select webhook from db: %v", err)
+	}
+
+	return fromWebhookModel(model), nil
+}
+
+// ListWebhooks returns webhooks of the project ordered by creation.
+func (s *Store) ListWebhooks(ctx context.Context, projectID aud.ID) ([]aud.Webhook, error) {
+	var models []webhookModel
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		err := tx.NewSelect().
+			Model(&models).
+			Where("project_id = ?", projectID).
+			Order("id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select webhooks from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromWebhookModels(models), nil
+}
+
+// DeleteWebhook deletes the webhook along with its deliveries.
+func (s *Store) DeleteWebhook(ctx context.Context, projectID aud.ID, id aud.ID) error {
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().
+			Model((*webhookModel)(nil)).
+			Where("project_id = ?", projectID).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete webhook from db: %v", err)
+		}
+
+		if rowsAffected(result) == 0 {
+			return aud.ErrWebhookNotFound
+		}
+
+		// Foreign keys are not enforced by SQLite.
+		_, err = tx.NewDelete().
+			Model((*webhookDeliveryModel)(nil)).
+			Where("webhook_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete webhook deliveries from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries returns deliveries of the webhook, latest first.
+// May return [aud.ErrWebhookNotFound].
+func (s *Store) ListWebhookDeliveries(
+	ctx context.Context,
+	projectID aud.ID,
+	webhookID aud.ID,
+	filter aud.WebhookDeliveryFilter,
+	limit int32,
+	cursor aud.WebhookDeliveryCursor,
+) ([]aud.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, projectID, webhookID); err != nil {
+		return nil, err
+	}
+
+	var models []webhookDeliveryModel
+
+	q := s.db.NewSelect().
+		Model(&models).
+		Where("webhook_id = ?", webhookID)
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = status.String()
+		}
+		q.Where("status IN (?)", bun.In(statuses))
+	}
+
+	if cursor.LastID != nil {
+		q.Where("id < ?", cursor.LastID)
+	}
+
+	q.Order("id DESC")
+	q.Limit(int(limit))
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("select webhook deliveries from db: %v", err)
+	}
+
+	return fromWebhookDeliveryModels(models)
+}
+
+// ClaimWebhookDeliveries returns pending deliveries due at the time, and
+// postpones their next attempt until the lease time, so that other
+// instances do not attempt them concurrently.
+func (s *Store) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	leaseTime time.Time,
+	limit int,
+) ([]aud.WebhookDelivery, error) {
+	var models []webhookDeliveryModel
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		q := tx.NewSelect().
+			Model(&models).
+			Where("status = ?", aud.WebhookDeliveryStatusPending.String()).
+			Where("next_attempt_time <= ?", now).
+			Order("next_attempt_time ASC").
+			Limit(limit)
+
+		// SQLite serializes write transactions.
+		if s.db.Dialect().Name() == dialect.PG {
+			q.For("UPDATE SKIP LOCKED")
+		}
+
+		if err := q.Scan(ctx); err != nil {
+			return fmt.Errorf("select webhook deliveries from db: %v", err)
+		}
+
+		if len(models) == 0 {
+			return nil
+		}
+
+		ids := make([]aud.ID, len(models))
+		for i := range models {
+			ids[i] = models[i].ID
+			models[i].NextAttemptTime = bun.NullTime{Time: leaseTime}
+		}
+
+		_, err := tx.NewUpdate().
+			Model((*webhookDeliveryModel)(nil)).
+			Set("next_attempt_time = ?", leaseTime).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("update webhook deliveries in db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromWebhookDeliveryModels(models)
+}
+
+// UpdateWebhookDelivery saves the result of a delivery attempt.
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, delivery aud.WebhookDelivery) error {
+	model := toWebhookDeliveryModel(delivery)
+
+	_, err := s.db.NewUpdate().
+		Model(&model).
+		Column(
+			"status",
+			"attempts",
+			"next_attempt_time",
+			"last_attempt_time",
+			"last_response_code",
+			"last_error",
+		).
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("update webhook delivery in db: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookDeliveries deletes completed deliveries with the last
+// attempt before the time, and returns the number of deleted deliveries.
+func (s *Store) DeleteWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.NewDelete().
+		Model((*webhookDeliveryModel)(nil)).
+		Where("status <> ?", aud.WebhookDeliveryStatusPending.String()).
+		Where("last_attempt_time < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete webhook deliveries from db: %v", err)
+	}
+
+	return rowsAffected(result), nil
+}
+
+// CreateAlertRule creates the alert rule. May return
+// [aud.ErrProjectNotFound].
+func (s *Store) CreateAlertRule(ctx context.Context, rule aud.AlertRule) error {
+	model := toAlertRuleModel(rule)
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, rule.ProjectID); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(&model).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("insert alert rule into db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetAlertRule(ctx context.Context, projectID aud.ID, id aud.ID) (aud.AlertRule, error) {
+	var model alertRuleModel
+
+	err := s.db.NewSelect().
+		Model(&model).
+		Where("project_id = ?", projectID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return aud.AlertRule{}, aud.ErrAlertRuleNotFound
+	}
+	if err != nil {
+		return aud.AlertRule{}, fmt.Errorf("select alert rule from db: %v", err)
+	}
+
+	return fromAlertRuleModel(model)
+}
+
+// ListAlertRules returns alert rules of the project ordered by creation.
+func (s *Store) ListAlertRules(ctx context.Context, projectID aud.ID) ([]aud.AlertRule, error) {
+	var models []alertRuleModel
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := getProject(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		err := tx.NewSelect().
+			Model(&models).
+			Where("project_id = ?", projectID).
+			Order("id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select alert rules from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromAlertRuleModels(models)
+}
+
+// DeleteAlertRule deletes the alert rule along with its state. Alerts fired
+// by the rule are kept.
+func (s *Store) DeleteAlertRule(ctx context.Context, projectID aud.ID, id aud.ID) error {
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().
+			Model((*alertRuleModel)(nil)).
+			Where("project_id = ?", projectID).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete alert rule from db: %v", err)
+		}
+
+		if rowsAffected(result) == 0 {
+			return aud.ErrAlertRuleNotFound
+		}
+
+		// Foreign keys are not enforced by SQLite.
+		_, err = tx.NewDelete().
+			Model((*alertRuleStateModel)(nil)).
+			Where("rule_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete alert rule states from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("run transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListAlerts returns alerts of the project, latest first.
+// May return [aud.ErrProjectNotFound].
+func (s *Store) ListAlerts(
+	ctx context.Context,
+	projectID aud.ID,
+	filter aud.AlertFilter,
+	limit int32,
+	cursor aud.AlertCursor,
+) ([]aud.Alert, error) {
+	var models []alertModel
+
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := projectExists(ctx, tx, projectID); err != nil {
+			return err
+		}
+
+		q := tx.NewSelect().
+			Model(&models).
+			Where("project_id = ?", projectID)
+
+		if filter.RuleID != nil {
+			q.Where("rule_id = ?", filter.RuleID)
+		}
+
+		if cursor.LastID != nil {
+			q.Where("id < ?", cursor.LastID)
+		}
+
+		q.Order("id DESC")
+		q.Limit(int(limit))
+
+		if err := q.Scan(ctx); err != nil {
+			return fmt.Errorf("select alerts from db: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run transaction: %w", err)
+	}
+
+	return fromAlertModels(models)
+}
+
+func getProject(ctx context.Context, idb bun.IDB, id aud.ID) (aud.Project, error) {
+	var model projectModel
+
+	err := idb.NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return aud.Project{}, aud.ErrProjectNotFound
+	}
+	if err != nil {
+		return aud.Project{}, fmt.Errorf("select project from db: %v", err)
+	}
+
+	project := fromProjectModel(model)
+	return project, nil
+}
+
+func selectRecord(
+	ctx context.Context,
+	idb bun.IDB,
+	projectID aud.ID,
+	id aud.ID,
+) (recordModel, error) {
+	var model recordModel
+
+	err := idb.NewSelect().
+		Model(&model).
+		Relation(relationResourceChanges).
+		Where("project_id = ?", projectID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return recordModel{}, aud.ErrRecordNotFound
+	}
+	if err != nil {
+		return recordModel{}, fmt.Errorf("select record from db: %v", err)
+	}
+
+	return model, nil
 }
 
 func projectExists(ctx context.Context, idb bun.IDB, id aud.ID) error {
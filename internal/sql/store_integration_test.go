@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -28,8 +29,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
+	"go.uber.org/zap"
 
 	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/aud/expr"
 	"github.com/auditumio/auditum/internal/aud/types"
 	"github.com/auditumio/auditum/internal/sql/sqltest"
 )
@@ -188,6 +191,10 @@ func TestIntegration_Store_UpdateProject(t *testing.T) {
 				Bool:  true,
 				Valid: true,
 			},
+			ExternalID: sql.NullString{
+				String: "project-1",
+				Valid:  true,
+			},
 			DeleteRecordEnabled: sql.NullBool{
 				Bool:  false,
 				Valid: false,
@@ -232,6 +239,25 @@ func TestIntegration_Store_UpdateProject(t *testing.T) {
 				Valid: true,
 			},
 			UpdateDeleteRecordEnabled: true,
+			Description:               "Posts of the blog.",
+			UpdateDescription:         true,
+			Labels:                    map[string]string{"team": "blog"},
+			UpdateLabels:              true,
+			ExternalID:                "project-2",
+			UpdateExternalID:          true,
+			Quota: aud.ProjectQuota{
+				RecordsPerMinute: 100,
+				MaxBytes:         1 << 20,
+			},
+			UpdateQuota: true,
+			RecordsRestrictions: aud.RecordsRestrictions{
+				Resource: aud.RecordsRestrictionsResource{
+					Changes: aud.RecordsRestrictionsResourceChanges{
+						NewValue: aud.RestrictionsBytes{MaxSizeBytes: 65536},
+					},
+				},
+			},
+			UpdateRecordsRestrictions: true,
 		}
 
 		updatedProject, err := store.UpdateProject(ctx, id, update)
@@ -241,9 +267,173 @@ func TestIntegration_Store_UpdateProject(t *testing.T) {
 			ID:                  p2id,
 			CreateTime:          seededProjectModels[1].CreateTime,
 			DisplayName:         update.DisplayName,
+			Description:         update.Description,
+			Labels:              update.Labels,
 			UpdateRecordEnabled: update.UpdateRecordEnabled,
 			DeleteRecordEnabled: update.DeleteRecordEnabled,
+			ExternalID:          update.ExternalID,
+			Quota:               update.Quota,
+			RecordsRestrictions: update.RecordsRestrictions,
 		}, updatedProject)
+
+		gotProject, err := store.GetProject(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, updatedProject, gotProject)
+	})
+
+	t.Run("Should return conflict when external id is taken", func(t *testing.T) {
+		store := NewStore(db)
+
+		_, err := store.UpdateProject(ctx, p2id, aud.ProjectUpdate{
+			ExternalID:       "project-1",
+			UpdateExternalID: true,
+		})
+		assert.ErrorIs(t, err, aud.ErrConflict)
+	})
+
+	t.Run("Should unset external id", func(t *testing.T) {
+		store := NewStore(db)
+
+		updatedProject, err := store.UpdateProject(ctx, p1id, aud.ProjectUpdate{
+			UpdateExternalID: true,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, updatedProject.ExternalID)
+	})
+}
+
+func TestIntegration_Store_ListProjects(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seededProjectModels := []projectModel{
+		{
+			ID:              aud.MustParseID("01886e86-1963-7f3c-b672-000000000001"),
+			PartitionNumber: 1,
+			CreateTime:      time.Date(2023, 1, 2, 3, 1, 0, 0, time.UTC),
+			DisplayName:     "Blog Posts",
+			Labels:          map[string]string{"team": "blog", "env": "prod"},
+		},
+		{
+			ID:              aud.MustParseID("01886e86-1963-7f3c-b672-000000000002"),
+			PartitionNumber: 2,
+			CreateTime:      time.Date(2023, 1, 2, 3, 2, 0, 0, time.UTC),
+			DisplayName:     "Billing",
+			Description:     "Invoices and payments.",
+			Labels:          map[string]string{"team": "billing", "env": "prod"},
+		},
+		{
+			ID:              aud.MustParseID("01886e86-1963-7f3c-b672-000000000003"),
+			PartitionNumber: 3,
+			CreateTime:      time.Date(2023, 1, 2, 3, 3, 0, 0, time.UTC),
+			DisplayName:     "Blog Comments",
+			Labels:          map[string]string{"team": "blog", "env": "dev"},
+		},
+		{
+			ID:              aud.MustParseID("01886e86-1963-7f3c-b672-000000000004"),
+			PartitionNumber: 4,
+			CreateTime:      time.Date(2023, 1, 2, 3, 4, 0, 0, time.UTC),
+			DisplayName:     "Accounts",
+		},
+	}
+
+	seedProjects(ctx, t, db, seededProjectModels...)
+	setCleanupProjects(t, db)
+
+	displayNames := func(projects []aud.Project) []string {
+		names := make([]string, len(projects))
+		for i, p := range projects {
+			names[i] = p.DisplayName
+		}
+		return names
+	}
+
+	// Test
+
+	store := NewStore(db)
+
+	tests := []struct {
+		name   string
+		filter aud.ProjectFilter
+		order  aud.ProjectOrder
+		want   []string
+	}{
+		{
+			name:  "Should list newest projects first by default",
+			order: aud.ProjectOrderCreateTimeDesc,
+			want:  []string{"Accounts", "Blog Comments", "Billing", "Blog Posts"},
+		},
+		{
+			name:  "Should list oldest projects first",
+			order: aud.ProjectOrderCreateTimeAsc,
+			want:  []string{"Blog Posts", "Billing", "Blog Comments", "Accounts"},
+		},
+		{
+			name:  "Should list projects by display name",
+			order: aud.ProjectOrderDisplayNameAsc,
+			want:  []string{"Accounts", "Billing", "Blog Comments", "Blog Posts"},
+		},
+		{
+			name:  "Should list projects by display name descending",
+			order: aud.ProjectOrderDisplayNameDesc,
+			want:  []string{"Blog Posts", "Blog Comments", "Billing", "Accounts"},
+		},
+		{
+			name: "Should filter projects by ids",
+			filter: aud.ProjectFilter{IDs: []aud.ID{
+				seededProjectModels[0].ID,
+				seededProjectModels[3].ID,
+			}},
+			want: []string{"Accounts", "Blog Posts"},
+		},
+		{
+			name:   "Should filter projects by labels",
+			filter: aud.ProjectFilter{Labels: map[string]string{"team": "blog", "env": "prod"}},
+			want:   []string{"Blog Posts"},
+		},
+		{
+			name:   "Should filter projects by display name substring",
+			filter: aud.ProjectFilter{DisplayNameContains: "BLOG"},
+			order:  aud.ProjectOrderDisplayNameAsc,
+			want:   []string{"Blog Comments", "Blog Posts"},
+		},
+		{
+			name:   "Should escape display name substring",
+			filter: aud.ProjectFilter{DisplayNameContains: "%"},
+			want:   []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projects, err := store.ListProjects(ctx, test.filter, test.order, 10, aud.ProjectCursor{})
+			require.NoError(t, err)
+			assert.Equal(t, test.want, displayNames(projects))
+		})
+	}
+
+	t.Run("Should paginate projects ordered by display name", func(t *testing.T) {
+		var (
+			names  []string
+			cursor aud.ProjectCursor
+		)
+		for {
+			page, err := store.ListProjects(ctx, aud.ProjectFilter{}, aud.ProjectOrderDisplayNameAsc, 3, cursor)
+			require.NoError(t, err)
+
+			names = append(names, displayNames(page)...)
+
+			cursor = aud.NewProjectCursor(page, 3)
+			if cursor.Empty() {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"Accounts", "Billing", "Blog Comments", "Blog Posts"}, names)
 	})
 }
 
@@ -311,7 +501,7 @@ func TestIntegration_Store_CreateRecord(t *testing.T) {
 
 		store := NewStore(db)
 
-		err := store.CreateRecord(ctx, rec)
+		_, err := store.CreateRecord(ctx, rec)
 		assert.NoError(t, err)
 
 		// Check if record was created.
@@ -328,6 +518,69 @@ func TestIntegration_Store_CreateRecord(t *testing.T) {
 		want := rec
 		assert.Equal(t, want, got)
 	})
+
+	t.Run("Should skip existing records and return them", func(t *testing.T) {
+		newRecord := func(id aud.ID, actorID string) aud.Record {
+			return aud.Record{
+				ID:         id,
+				ProjectID:  testProjectID,
+				CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+				Resource: aud.Resource{
+					Type: "POST",
+					ID:   "post-1",
+					Changes: []aud.ResourceChange{
+						{
+							Name:     "title",
+							NewValue: json.RawMessage(`"Hello"`),
+						},
+					},
+				},
+				Operation: aud.Operation{
+					Type: "CREATE",
+					ID:   "example.v1.PostService/CreatePost",
+					Time: time.Date(2023, 1, 1, 2, 1, 0, 0, time.UTC),
+				},
+				Actor: aud.Actor{
+					Type: "USER",
+					ID:   actorID,
+				},
+			}
+		}
+
+		store := NewStore(db)
+
+		usage, err := store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+
+		first := newRecord(aud.MustNewID(), "user-1")
+		_, err = store.CreateRecord(ctx, first)
+		require.NoError(t, err)
+
+		second := newRecord(aud.MustNewID(), "user-2")
+		stored, err := store.CreateRecords(ctx, []aud.Record{
+			newRecord(first.ID, "user-3"),
+			second,
+			newRecord(second.ID, "user-4"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []aud.Record{first, second, second}, stored)
+
+		for _, want := range []aud.Record{first, second} {
+			var model recordModel
+			err = db.NewSelect().
+				Model(&model).
+				Relation(relationResourceChanges).
+				Where("id = ?", want.ID).
+				Scan(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, want, fromRecordModel(model))
+		}
+
+		got, err := store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, usage.Records+2, got.Records)
+		assert.Equal(t, usage.Bytes+first.Size()+second.Size(), got.Bytes)
+	})
 }
 
 func TestIntegration_Store_ListRecords(t *testing.T) {
@@ -598,8 +851,8 @@ func TestIntegration_Store_ListRecords(t *testing.T) {
 		store := NewStore(db)
 
 		filter := aud.RecordFilter{
-			ResourceType: "POST",
-			ResourceID:   "post-42",
+			ResourceTypes: []string{"POST"},
+			ResourceIDs:   []string{"post-42"},
 		}
 		limit := int32(10)
 		pag := aud.RecordCursor{}
@@ -618,8 +871,8 @@ func TestIntegration_Store_ListRecords(t *testing.T) {
 		store := NewStore(db)
 
 		filter := aud.RecordFilter{
-			OperationType: "UPDATE",
-			OperationID:   "example.v1.PostService/UpdatePost",
+			OperationTypes: []string{"UPDATE"},
+			OperationIDs:   []string{"example.v1.PostService/UpdatePost"},
 		}
 		limit := int32(10)
 		pag := aud.RecordCursor{}
@@ -657,8 +910,8 @@ func TestIntegration_Store_ListRecords(t *testing.T) {
 		store := NewStore(db)
 
 		filter := aud.RecordFilter{
-			ActorType: "USER",
-			ActorID:   "user-83",
+			ActorTypes: []string{"USER"},
+			ActorIDs:   []string{"user-83"},
 		}
 		limit := int32(10)
 		pag := aud.RecordCursor{}
@@ -672,207 +925,1594 @@ func TestIntegration_Store_ListRecords(t *testing.T) {
 			fromRecordModel(seededRecordModels[1]),
 		}, records)
 	})
-}
 
-func TestIntegration_Store_UpdateRecord(t *testing.T) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	t.Run("Should list records - filter by multiple values", func(t *testing.T) {
+		store := NewStore(db)
 
-	db := sqltest.NewDatabase(ctx, t)
+		filter := aud.RecordFilter{
+			ActorIDs: []string{"user-5", "user-10", "user-82"},
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
 
-	// Seed
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
 
-	seedTestProject(ctx, t, db)
-	setCleanupTestProject(t, db)
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[5]),
+			fromRecordModel(seededRecordModels[4]),
+			fromRecordModel(seededRecordModels[0]),
+		}, records)
+	})
 
-	r1id := aud.MustNewID()
-	r2id := aud.MustNewID()
-	r3id := aud.MustNewID()
+	t.Run("Should list records - filter by resource id prefix", func(t *testing.T) {
+		store := NewStore(db)
 
-	seededRecordModels := []recordModel{
-		{
-			ID:         r1id,
-			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
-			},
-			ResourceType: "POST",
-			ResourceID:   "post-42",
-			ResourceMeta: map[string]string{
-				"category": "funny",
-			},
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:  r1id,
-					ProjectID: testProjectID,
-					Name:      "text",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"My windows aren’t dirty, that’s my dog’s nose art."`),
-				},
-				{
-					RecordID:  r1id,
-					ProjectID: testProjectID,
-					Name:      "status",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"published"`),
-				},
-			},
-			OperationType:   "CREATE",
-			OperationID:     "example.v1.PostService/CreatePost",
-			OperationTime:   time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-82",
-		},
-		{
-			ID:         r2id,
-			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
-			},
-			ResourceType: "COMMENT",
-			ResourceID:   "comment-79",
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:  r2id,
-					ProjectID: testProjectID,
-					Name:      "text",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"Show us, my fiend!"`),
-				},
-				{
-					RecordID:  r2id,
-					ProjectID: testProjectID,
-					Name:      "status",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"published"`),
-				},
-			},
-			OperationType:   "CREATE",
-			OperationID:     "example.v1.PostService/CreatePostComment",
-			OperationTime:   time.Date(2023, 1, 1, 1, 2, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-83",
-		},
-		{
-			ID:         r3id,
-			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
-			},
-			ResourceType: "COMMENT",
-			ResourceID:   "comment-79",
-			ResourceMeta: map[string]string{
-				"status": "published",
-			},
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:    r3id,
-					ProjectID:   testProjectID,
-					Name:        "text",
-					Description: "Edit text",
-					OldValue:    json.RawMessage(`"Show us, my fiend!"`),
-					NewValue:    json.RawMessage(`"Show us, my friend!"`),
-				},
-			},
-			OperationType:   "UPDATE",
-			OperationID:     "example.v1.PostService/UpdatePostComment",
-			OperationTime:   time.Date(2023, 1, 1, 1, 3, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-83",
-		},
-	}
-	seedRecords(ctx, t, db, seededRecordModels...)
-	setCleanupRecords(t, db)
+		filter := aud.RecordFilter{
+			ResourceIDPrefix: "comment-",
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
 
-	// Test
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
 
-	t.Run("Should update record", func(t *testing.T) {
-		store := NewStore(db)
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[2]),
+			fromRecordModel(seededRecordModels[1]),
+		}, records)
+	})
 
-		id := r2id
+	t.Run("Should list records - filter by resource id prefix with wildcards", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			ResourceIDPrefix: "post_",
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Empty(t, records)
+	})
+
+	t.Run("Should list records - filter by operation status", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			OperationStatuses: []aud.OperationStatus{aud.OperationStatusFailed},
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[4]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by negation", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			ResourceTypes: []string{"POST"},
+			Not: aud.RecordFilterNot{
+				Labels: map[string]string{
+					"post_id": "post-55",
+				},
+				ActorIDs:          []string{"user-5"},
+				OperationStatuses: []aud.OperationStatus{aud.OperationStatusFailed},
+			},
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[0]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with OR", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `operation.status = FAILED OR actor.id = "user-5"`),
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[5]),
+			fromRecordModel(seededRecordModels[4]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with prefix and time", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `operation.id = "example.v1.PostService/UpdatePost*" operation.time < "2023-01-01T01:05:00Z"`),
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[3]),
+			fromRecordModel(seededRecordModels[2]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with map values", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `resource.type = "COMMENT" AND resource.metadata.status != "published"`),
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[1]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with map key presence", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `resource.type = "COMMENT" AND NOT resource.metadata:status`),
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[1]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with map value prefix", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `actor.metadata.as = "mod*"`),
+		}
+		limit := int32(10)
+		pag := aud.RecordCursor{}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, limit, pag)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[5]),
+		}, records)
+	})
+
+	t.Run("Should list records - filter by expression with map key with quote and backslash", func(t *testing.T) {
+		store := NewStore(db)
+
+		newRecord := func(metadata map[string]string) aud.Record {
+			return aud.Record{
+				ID:         aud.MustNewID(),
+				ProjectID:  testProjectID,
+				CreateTime: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				Resource: aud.Resource{
+					Type:     "KEYS",
+					ID:       "keys-1",
+					Metadata: metadata,
+				},
+				Operation: aud.Operation{
+					Type: "CREATE",
+					ID:   "example.v1.KeyService/CreateKey",
+					Time: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				Actor: aud.Actor{
+					Type: "USER",
+					ID:   "user-1",
+				},
+			}
+		}
+
+		quoted := newRecord(map[string]string{`a"b`: "1", `c\d`: "2"})
+		unquoted := newRecord(map[string]string{`a`: "1", `c`: "2"})
+
+		_, err := store.CreateRecords(ctx, []aud.Record{quoted, unquoted})
+		require.NoError(t, err)
+
+		filter := aud.RecordFilter{
+			Expression: mustParseRecordFilterExpression(t, `resource.type = "KEYS" AND resource.metadata:"a\"b" AND resource.metadata:"c\\d"`),
+		}
+
+		records, err := store.ListRecords(ctx, testProjectID, filter, 10, aud.RecordCursor{})
+		require.NoError(t, err)
+
+		require.Len(t, records, 1)
+		assert.Equal(t, quoted.ID, records[0].ID)
+	})
+}
+
+func TestIntegration_Store_ExportRecords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	operationTime := time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC)
+
+	var seededRecordModels []recordModel
+	for i := 0; i < 3; i++ {
+		id := aud.MustNewID()
+		seededRecordModels = append(seededRecordModels, recordModel{
+			ID:           id,
+			ProjectID:    testProjectID,
+			CreateTime:   time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			ResourceType: "POST",
+			ResourceID:   fmt.Sprintf("post-%d", i),
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:  id,
+					ProjectID: testProjectID,
+					Name:      "status",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"published"`),
+				},
+			},
+			OperationType: "CREATE",
+			OperationID:   "example.v1.PostService/CreatePost",
+			// Records with the same operation time are ordered by id.
+			OperationTime: operationTime,
+			ActorType:     "USER",
+			ActorID:       "user-82",
+		})
+	}
+	seedRecords(ctx, t, db, seededRecordModels...)
+	setCleanupRecords(t, db)
+
+	// Test
+
+	t.Run("Should export records", func(t *testing.T) {
+		store := NewStore(db)
+
+		var records []aud.Record
+		err := store.ExportRecords(ctx, testProjectID, aud.RecordFilter{}, func(record aud.Record) error {
+			records = append(records, record)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[2]),
+			fromRecordModel(seededRecordModels[1]),
+			fromRecordModel(seededRecordModels[0]),
+		}, records)
+	})
+
+	t.Run("Should export records - filter", func(t *testing.T) {
+		store := NewStore(db)
+
+		filter := aud.RecordFilter{
+			ResourceIDs: []string{"post-0", "post-2"},
+		}
+
+		var records []aud.Record
+		err := store.ExportRecords(ctx, testProjectID, filter, func(record aud.Record) error {
+			records = append(records, record)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []aud.Record{
+			fromRecordModel(seededRecordModels[2]),
+			fromRecordModel(seededRecordModels[0]),
+		}, records)
+	})
+
+	t.Run("Should stop export on error", func(t *testing.T) {
+		store := NewStore(db)
+
+		errStop := errors.New("stop")
+
+		var n int
+		err := store.ExportRecords(ctx, testProjectID, aud.RecordFilter{}, func(record aud.Record) error {
+			n++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("Should return error when project does not exist", func(t *testing.T) {
+		store := NewStore(db)
+
+		err := store.ExportRecords(ctx, aud.MustNewID(), aud.RecordFilter{}, func(record aud.Record) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+}
+
+func TestIntegration_Store_ImportRecords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	existingID := aud.MustNewID()
+	seedRecords(ctx, t, db, recordModel{
+		ID:           existingID,
+		ProjectID:    testProjectID,
+		CreateTime:   time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+		ResourceType: "POST",
+		ResourceID:   "post-0",
+		ResourceChanges: []recordResourceChangeModel{
+			{
+				RecordID:  existingID,
+				ProjectID: testProjectID,
+				Name:      "status",
+				OldValue:  json.RawMessage(`null`),
+				NewValue:  json.RawMessage(`"draft"`),
+			},
+		},
+		OperationType: "CREATE",
+		OperationID:   "example.v1.PostService/CreatePost",
+		OperationTime: time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC),
+		ActorType:     "USER",
+		ActorID:       "user-82",
+	})
+	setCleanupRecords(t, db)
+
+	// Test
+
+	t.Run("Should import records keeping ids and create time and skip existing", func(t *testing.T) {
+		store := NewStore(db)
+
+		newRecord := func(id aud.ID, resourceID string) aud.Record {
+			return aud.Record{
+				ID:         id,
+				ProjectID:  testProjectID,
+				CreateTime: time.Date(2022, 5, 6, 7, 8, 9, 0, time.UTC),
+				Resource: aud.Resource{
+					Type: "POST",
+					ID:   resourceID,
+					Changes: []aud.ResourceChange{
+						{
+							Name:     "status",
+							OldValue: json.RawMessage(`null`),
+							NewValue: json.RawMessage(`"published"`),
+						},
+					},
+				},
+				Operation: aud.Operation{
+					Type: "CREATE",
+					ID:   "example.v1.PostService/CreatePost",
+					Time: time.Date(2022, 5, 6, 7, 8, 0, 0, time.UTC),
+				},
+				Actor: aud.Actor{
+					Type: "USER",
+					ID:   "user-82",
+				},
+			}
+		}
+
+		importedID := aud.MustNewID()
+		records := []aud.Record{
+			newRecord(existingID, "post-changed"),
+			newRecord(importedID, "post-1"),
+		}
+
+		created, err := store.ImportRecords(ctx, records)
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+
+		imported, err := store.GetRecord(ctx, testProjectID, importedID)
+		require.NoError(t, err)
+		assert.Equal(t, records[1], imported)
+
+		existing, err := store.GetRecord(ctx, testProjectID, existingID)
+		require.NoError(t, err)
+		assert.Equal(t, "post-0", existing.Resource.ID)
+
+		created, err = store.ImportRecords(ctx, records)
+		require.NoError(t, err)
+		assert.Equal(t, 0, created)
+	})
+
+	t.Run("Should return error when project does not exist", func(t *testing.T) {
+		store := NewStore(db)
+
+		_, err := store.ImportRecords(ctx, []aud.Record{
+			{
+				ID:        aud.MustNewID(),
+				ProjectID: aud.MustNewID(),
+			},
+		})
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+}
+
+func TestIntegration_Store_UpdateRecord(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	r1id := aud.MustNewID()
+	r2id := aud.MustNewID()
+	r3id := aud.MustNewID()
+
+	seededRecordModels := []recordModel{
+		{
+			ID:         r1id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
+			},
+			ResourceType: "POST",
+			ResourceID:   "post-42",
+			ResourceMeta: map[string]string{
+				"category": "funny",
+			},
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:  r1id,
+					ProjectID: testProjectID,
+					Name:      "text",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"My windows aren’t dirty, that’s my dog’s nose art."`),
+				},
+				{
+					RecordID:  r1id,
+					ProjectID: testProjectID,
+					Name:      "status",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"published"`),
+				},
+			},
+			OperationType:   "CREATE",
+			OperationID:     "example.v1.PostService/CreatePost",
+			OperationTime:   time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-82",
+		},
+		{
+			ID:         r2id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
+			},
+			ResourceType: "COMMENT",
+			ResourceID:   "comment-79",
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:  r2id,
+					ProjectID: testProjectID,
+					Name:      "text",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"Show us, my fiend!"`),
+				},
+				{
+					RecordID:  r2id,
+					ProjectID: testProjectID,
+					Name:      "status",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"published"`),
+				},
+			},
+			OperationType:   "CREATE",
+			OperationID:     "example.v1.PostService/CreatePostComment",
+			OperationTime:   time.Date(2023, 1, 1, 1, 2, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-83",
+		},
+		{
+			ID:         r3id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
+			},
+			ResourceType: "COMMENT",
+			ResourceID:   "comment-79",
+			ResourceMeta: map[string]string{
+				"status": "published",
+			},
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:    r3id,
+					ProjectID:   testProjectID,
+					Name:        "text",
+					Description: "Edit text",
+					OldValue:    json.RawMessage(`"Show us, my fiend!"`),
+					NewValue:    json.RawMessage(`"Show us, my friend!"`),
+				},
+			},
+			OperationType:   "UPDATE",
+			OperationID:     "example.v1.PostService/UpdatePostComment",
+			OperationTime:   time.Date(2023, 1, 1, 1, 3, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-83",
+		},
+	}
+	seedRecords(ctx, t, db, seededRecordModels...)
+	setCleanupRecords(t, db)
+
+	// Test
+
+	t.Run("Should update record", func(t *testing.T) {
+		store := NewStore(db)
+
+		id := r2id
 
 		update := aud.RecordUpdate{
 			Labels: map[string]string{
-				"post_id": "post-43",
+				"post_id": "post-43",
+			},
+			UpdateLabels: true,
+			Resource: aud.Resource{
+				Type: "COMMENT",
+				ID:   "COMMENT-80",
+				Metadata: map[string]string{
+					"status": "published",
+				},
+				Changes: []aud.ResourceChange{
+					{
+						Name:     "text",
+						OldValue: json.RawMessage(`null`),
+						NewValue: json.RawMessage(`"Please show us, my friend!"`),
+					},
+				},
+			},
+			UpdateResource: true,
+			Operation: aud.Operation{
+				Type: "CREATE",
+				ID:   "example.v2.PostService/CreatePostComment",
+				Time: time.Date(2023, 2, 1, 1, 2, 0, 0, time.UTC),
+				Metadata: map[string]string{
+					"authorized": "true",
+				},
+				TraceContext: aud.TraceContext{
+					Traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+					Tracestate:  "congo=t61rcWkgMzE",
+				},
+				Status: aud.OperationStatusSucceeded,
+			},
+			UpdateOperation: true,
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   "user-84",
+				Metadata: map[string]string{
+					"device_type": "mobile",
+				},
+			},
+			UpdateActor: true,
+		}
+
+		updatedRecord, err := store.UpdateRecord(ctx, testProjectID, id, update)
+		assert.NoError(t, err)
+
+		assert.Equal(t, aud.Record{
+			ID:         r2id,
+			ProjectID:  testProjectID,
+			CreateTime: seededRecordModels[1].CreateTime,
+			Labels:     update.Labels,
+			Resource:   update.Resource,
+			Operation:  update.Operation,
+			Actor:      update.Actor,
+		}, updatedRecord)
+	})
+
+	t.Run("Should return error when record does not exist", func(t *testing.T) {
+		store := NewStore(db)
+
+		id := aud.MustNewID()
+
+		update := aud.RecordUpdate{
+			Labels: map[string]string{
+				"post_id": "post-43",
+			},
+			UpdateLabels: true,
+		}
+
+		_, err := store.UpdateRecord(ctx, testProjectID, id, update)
+		assert.ErrorIs(t, err, aud.ErrRecordNotFound)
+	})
+}
+
+func TestIntegration_Store_DeleteRecord(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	r1id := aud.MustNewID()
+	r2id := aud.MustNewID()
+	r3id := aud.MustNewID()
+
+	seededRecordModels := []recordModel{
+		{
+			ID:         r1id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
 			},
-			UpdateLabels: true,
+			ResourceType: "POST",
+			ResourceID:   "post-42",
+			ResourceMeta: map[string]string{
+				"category": "funny",
+			},
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:  r1id,
+					ProjectID: testProjectID,
+					Name:      "text",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"My windows aren’t dirty, that’s my dog’s nose art."`),
+				},
+				{
+					RecordID:  r1id,
+					ProjectID: testProjectID,
+					Name:      "status",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"published"`),
+				},
+			},
+			OperationType:   "CREATE",
+			OperationID:     "example.v1.PostService/CreatePost",
+			OperationTime:   time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-82",
+		},
+		{
+			ID:         r2id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
+			},
+			ResourceType: "COMMENT",
+			ResourceID:   "comment-79",
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:  r2id,
+					ProjectID: testProjectID,
+					Name:      "text",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"Show us, my fiend!"`),
+				},
+				{
+					RecordID:  r2id,
+					ProjectID: testProjectID,
+					Name:      "status",
+					OldValue:  json.RawMessage(`null`),
+					NewValue:  json.RawMessage(`"published"`),
+				},
+			},
+			OperationType:   "CREATE",
+			OperationID:     "example.v1.PostService/CreatePostComment",
+			OperationTime:   time.Date(2023, 1, 1, 1, 2, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-83",
+		},
+		{
+			ID:         r3id,
+			ProjectID:  testProjectID,
+			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
+			Labels: map[string]string{
+				"post_id": "post-42",
+			},
+			ResourceType: "COMMENT",
+			ResourceID:   "comment-79",
+			ResourceMeta: map[string]string{
+				"status": "published",
+			},
+			ResourceChanges: []recordResourceChangeModel{
+				{
+					RecordID:    r3id,
+					ProjectID:   testProjectID,
+					Name:        "text",
+					Description: "Edit text",
+					OldValue:    json.RawMessage(`"Show us, my fiend!"`),
+					NewValue:    json.RawMessage(`"Show us, my friend!"`),
+				},
+			},
+			OperationType:   "UPDATE",
+			OperationID:     "example.v1.PostService/UpdatePostComment",
+			OperationTime:   time.Date(2023, 1, 1, 1, 3, 0, 0, time.UTC),
+			OperationStatus: aud.OperationStatusSucceeded.Int(),
+			ActorType:       "USER",
+			ActorID:         "user-83",
+		},
+	}
+	seedRecords(ctx, t, db, seededRecordModels...)
+	setCleanupRecords(t, db)
+
+	// Test
+
+	t.Run("Should delete record", func(t *testing.T) {
+		store := NewStore(db)
+
+		id := r2id
+
+		err := store.DeleteRecord(ctx, testProjectID, id)
+		assert.NoError(t, err)
+
+		// Check if record was deleted.
+
+		var model recordModel
+		err = db.NewSelect().
+			Model(&model).
+			Relation(relationResourceChanges).
+			Where("id = ?", id).
+			Scan(ctx)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestIntegration_Store_ProjectQuota(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedProjects(ctx, t, db, projectModel{
+		ID:                    testProjectID,
+		CreateTime:            time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		PartitionNumber:       testProjectPartitionNumber,
+		DisplayName:           "Test Project",
+		QuotaRecordsPerMinute: 2,
+		QuotaMaxRecords:       4,
+	})
+	setCleanupTestProject(t, db)
+
+	setCleanupRecords(t, db)
+
+	newRecord := func(createTime time.Time) aud.Record {
+		return aud.Record{
+			ID:         aud.MustNewID(),
+			ProjectID:  testProjectID,
+			CreateTime: createTime,
 			Resource: aud.Resource{
-				Type: "COMMENT",
-				ID:   "COMMENT-80",
-				Metadata: map[string]string{
-					"status": "published",
+				Type: "POST",
+				ID:   "post-1",
+				Changes: []aud.ResourceChange{
+					{
+						Name:     "text",
+						NewValue: json.RawMessage(`"Hello"`),
+					},
+				},
+			},
+			Operation: aud.Operation{
+				Type: "CREATE",
+				ID:   "example.v1.PostService/CreatePost",
+				Time: createTime,
+			},
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   "user-1",
+			},
+		}
+	}
+
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	// Test
+
+	store := NewStore(db)
+
+	var created []aud.Record
+
+	t.Run("Should enforce records per minute quota", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			rec := newRecord(t0)
+			_, err := store.CreateRecord(ctx, rec)
+			require.NoError(t, err)
+			created = append(created, rec)
+		}
+
+		_, err := store.CreateRecord(ctx, newRecord(t0.Add(10*time.Second)))
+		assert.ErrorIs(t, err, aud.ErrQuotaExceeded)
+
+		var quotaErr *aud.QuotaExceededError
+		if assert.ErrorAs(t, err, &quotaErr) {
+			assert.Equal(t, &aud.QuotaExceededError{
+				ProjectID:  testProjectID,
+				Quota:      aud.QuotaRecordsPerMinute,
+				Limit:      2,
+				RetryDelay: 40 * time.Second,
+			}, quotaErr)
+		}
+	})
+
+	t.Run("Should enforce max records quota for batch", func(t *testing.T) {
+		t1 := t0.Add(time.Minute)
+
+		batch := []aud.Record{newRecord(t1), newRecord(t1)}
+		_, err := store.CreateRecords(ctx, batch)
+		require.NoError(t, err)
+		created = append(created, batch...)
+
+		t2 := t0.Add(2 * time.Minute)
+
+		_, err = store.CreateRecords(ctx, []aud.Record{newRecord(t2)})
+		assert.ErrorIs(t, err, aud.ErrQuotaExceeded)
+
+		var quotaErr *aud.QuotaExceededError
+		if assert.ErrorAs(t, err, &quotaErr) {
+			assert.Equal(t, aud.QuotaMaxRecords, quotaErr.Quota)
+			assert.Zero(t, quotaErr.RetryDelay)
+		}
+	})
+
+	t.Run("Should account project usage", func(t *testing.T) {
+		usage, err := store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+
+		recordSize := created[0].Size()
+
+		assert.Equal(t, aud.ProjectUsage{
+			ProjectID:       testProjectID,
+			Records:         4,
+			Bytes:           4 * recordSize,
+			MinuteStartTime: t0.Add(time.Minute).Truncate(time.Minute),
+			MinuteRecords:   2,
+			DayStartTime:    t0.Truncate(24 * time.Hour),
+			DayRecords:      4,
+		}, usage)
+
+		err = store.DeleteRecord(ctx, testProjectID, created[0].ID)
+		require.NoError(t, err)
+
+		usage, err = store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), usage.Records)
+		assert.Equal(t, 3*recordSize, usage.Bytes)
+
+		usages, err := store.ListProjectUsage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []aud.ProjectUsage{usage}, usages)
+	})
+
+	t.Run("Should return error when project does not exist", func(t *testing.T) {
+		_, err := store.GetProjectUsage(ctx, aud.MustNewID())
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+}
+
+func TestIntegration_Store_ProjectUsageWithoutQuota(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	setCleanupRecords(t, db)
+
+	newRecord := func(createTime time.Time) aud.Record {
+		return aud.Record{
+			ID:         aud.MustNewID(),
+			ProjectID:  testProjectID,
+			CreateTime: createTime,
+			Resource: aud.Resource{
+				Type: "POST",
+				ID:   "post-1",
+			},
+			Operation: aud.Operation{
+				Type: "CREATE",
+				ID:   "example.v1.PostService/CreatePost",
+				Time: createTime,
+			},
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   "user-1",
+			},
+		}
+	}
+
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	// Test
+
+	store := NewStore(db)
+
+	t.Run("Should account project usage without locking", func(t *testing.T) {
+		first := newRecord(t0)
+		_, err := store.CreateRecord(ctx, first)
+		require.NoError(t, err)
+
+		_, err = store.CreateRecords(ctx, []aud.Record{newRecord(t0.Add(20 * time.Second)), newRecord(t0.Add(20 * time.Second))})
+		require.NoError(t, err)
+
+		t1 := t0.Add(time.Minute)
+		_, err = store.CreateRecords(ctx, []aud.Record{newRecord(t1)})
+		require.NoError(t, err)
+
+		// A skewed time does not move the windows backwards.
+		_, err = store.CreateRecord(ctx, newRecord(t0))
+		require.NoError(t, err)
+
+		recordSize := first.Size()
+
+		usage, err := store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, aud.ProjectUsage{
+			ProjectID:       testProjectID,
+			Records:         5,
+			Bytes:           5 * recordSize,
+			MinuteStartTime: t1.Truncate(time.Minute),
+			MinuteRecords:   2,
+			DayStartTime:    t0.Truncate(24 * time.Hour),
+			DayRecords:      5,
+		}, usage)
+
+		err = store.DeleteRecord(ctx, testProjectID, first.ID)
+		require.NoError(t, err)
+
+		usage, err = store.GetProjectUsage(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), usage.Records)
+		assert.Equal(t, 4*recordSize, usage.Bytes)
+		assert.Equal(t, int64(2), usage.MinuteRecords)
+	})
+}
+
+func TestIntegration_Store_GetProjectStats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	setCleanupRecords(t, db)
+
+	// Test
+
+	store := NewStore(db)
+
+	t.Run("Should return empty stats when project has no records", func(t *testing.T) {
+		stats, err := store.GetProjectStats(ctx, testProjectID)
+		require.NoError(t, err)
+
+		assert.Equal(t, testProjectID, stats.ProjectID)
+		assert.Zero(t, stats.Records)
+		assert.Zero(t, stats.FirstOperationTime)
+		assert.Zero(t, stats.LastOperationTime)
+		assert.Zero(t, stats.LastCreateTime)
+		assert.Zero(t, stats.ResourceTypes)
+		assert.Zero(t, stats.OperationTypes)
+		assert.Zero(t, stats.ActorTypes)
+	})
+
+	t.Run("Should return stats of project records", func(t *testing.T) {
+		newRecord := func(resourceType, operationType, actorType string, createTime, operationTime time.Time) aud.Record {
+			return aud.Record{
+				ID:         aud.MustNewID(),
+				ProjectID:  testProjectID,
+				CreateTime: createTime,
+				Resource: aud.Resource{
+					Type: resourceType,
+					ID:   "resource-1",
 				},
-				Changes: []aud.ResourceChange{
-					{
-						Name:     "text",
-						OldValue: json.RawMessage(`null`),
-						NewValue: json.RawMessage(`"Please show us, my friend!"`),
-					},
+				Operation: aud.Operation{
+					Type: operationType,
+					ID:   "example.v1.PostService/UpdatePost",
+					Time: operationTime,
+				},
+				Actor: aud.Actor{
+					Type: actorType,
+					ID:   "actor-1",
 				},
+			}
+		}
+
+		records := []aud.Record{
+			newRecord(
+				"POST", "CREATE", "USER",
+				time.Date(2023, 1, 1, 3, 0, 0, 0, time.UTC),
+				time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC),
+			),
+			newRecord(
+				"POST", "UPDATE", "USER",
+				time.Date(2023, 1, 1, 3, 0, 1, 0, time.UTC),
+				time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC),
+			),
+			newRecord(
+				"COMMENT", "UPDATE", "SERVICE",
+				time.Date(2023, 1, 1, 3, 0, 2, 0, time.UTC),
+				time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC),
+			),
+		}
+
+		_, err := store.CreateRecords(ctx, records)
+		require.NoError(t, err)
+
+		stats, err := store.GetProjectStats(ctx, testProjectID)
+		require.NoError(t, err)
+
+		if db.Dialect().Name() == dialect.PG {
+			assert.Positive(t, stats.SizeBytes)
+		} else {
+			usage, err := store.GetProjectUsage(ctx, testProjectID)
+			require.NoError(t, err)
+			assert.Equal(t, usage.Bytes, stats.SizeBytes)
+		}
+		stats.SizeBytes = 0
+
+		assert.Equal(t, aud.ProjectStats{
+			ProjectID:          testProjectID,
+			Records:            3,
+			FirstOperationTime: time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC),
+			LastOperationTime:  time.Date(2023, 1, 1, 2, 0, 0, 0, time.UTC),
+			LastCreateTime:     time.Date(2023, 1, 1, 3, 0, 2, 0, time.UTC),
+			ResourceTypes:      2,
+			OperationTypes:     2,
+			ActorTypes:         2,
+		}, stats)
+	})
+
+	t.Run("Should return error when project does not exist", func(t *testing.T) {
+		_, err := store.GetProjectStats(ctx, aud.MustNewID())
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+}
+
+func TestIntegration_Store_APIKeys(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	setCleanupAPIKeys(t, db)
+
+	// Test
+
+	store := NewStore(db)
+
+	key1, secret1, err := aud.NewAPIKey(
+		aud.MustParseID("01886e86-1963-7f3c-b672-000000000001"),
+		time.Date(2023, 1, 2, 3, 1, 0, 0, time.UTC),
+		"Admin",
+		nil,
+		[]aud.Permission{aud.PermissionAdmin},
+	)
+	require.NoError(t, err)
+
+	key2, _, err := aud.NewAPIKey(
+		aud.MustParseID("01886e86-1963-7f3c-b672-000000000002"),
+		time.Date(2023, 1, 2, 3, 2, 0, 0, time.UTC),
+		"Ingestion",
+		[]aud.ID{testProjectID},
+		[]aud.Permission{aud.PermissionWrite, aud.PermissionRead},
+	)
+	require.NoError(t, err)
+
+	t.Run("Should create and get api keys", func(t *testing.T) {
+		require.NoError(t, store.CreateAPIKey(ctx, key1))
+		require.NoError(t, store.CreateAPIKey(ctx, key2))
+
+		got, err := store.GetAPIKeyByHash(ctx, aud.HashAPIKey(secret1))
+		require.NoError(t, err)
+		assert.Equal(t, key1, got)
+
+		_, err = store.GetAPIKeyByHash(ctx, aud.HashAPIKey("unknown"))
+		assert.ErrorIs(t, err, aud.ErrAPIKeyNotFound)
+	})
+
+	t.Run("Should list api keys", func(t *testing.T) {
+		keys, err := store.ListAPIKeys(ctx, 1, aud.APIKeyCursor{})
+		require.NoError(t, err)
+		assert.Equal(t, []aud.APIKey{key2}, keys)
+
+		keys, err = store.ListAPIKeys(ctx, 1, aud.NewAPIKeyCursor(keys, 1))
+		require.NoError(t, err)
+		assert.Equal(t, []aud.APIKey{key1}, keys)
+	})
+
+	t.Run("Should revoke api key", func(t *testing.T) {
+		revokeTime := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+
+		revoked, err := store.RevokeAPIKey(ctx, key1.ID, revokeTime)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked())
+		assert.Equal(t, revokeTime, revoked.RevokeTime)
+
+		// Revoke time is kept on repeated revocation.
+		revoked, err = store.RevokeAPIKey(ctx, key1.ID, revokeTime.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, revokeTime, revoked.RevokeTime)
+
+		_, err = store.RevokeAPIKey(ctx, aud.MustNewID(), revokeTime)
+		assert.ErrorIs(t, err, aud.ErrAPIKeyNotFound)
+	})
+}
+
+func TestIntegration_Store_RoleBindings(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+	setCleanupRoleBindings(t, db)
+
+	// Test
+
+	store := NewStore(db)
+
+	createTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	alice := aud.RoleBinding{
+		ProjectID:   testProjectID,
+		PrincipalID: "jwt:alice",
+		Role:        aud.RoleViewer,
+		CreateTime:  createTime,
+		UpdateTime:  createTime,
+	}
+	bob := aud.RoleBinding{
+		ProjectID:   testProjectID,
+		PrincipalID: "apikey:01886e86-1963-7f3c-b672-000000000001",
+		Role:        aud.RoleEditor,
+		CreateTime:  createTime,
+		UpdateTime:  createTime,
+	}
+
+	t.Run("Should set role bindings", func(t *testing.T) {
+		got, err := store.SetRoleBinding(ctx, alice)
+		require.NoError(t, err)
+		assert.Equal(t, alice, got)
+
+		got, err = store.SetRoleBinding(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, bob, got)
+	})
+
+	t.Run("Should update role and keep create time", func(t *testing.T) {
+		updateTime := createTime.Add(time.Hour)
+
+		got, err := store.SetRoleBinding(ctx, aud.RoleBinding{
+			ProjectID:   testProjectID,
+			PrincipalID: alice.PrincipalID,
+			Role:        aud.RoleWriter,
+			CreateTime:  updateTime,
+			UpdateTime:  updateTime,
+		})
+		require.NoError(t, err)
+
+		alice.Role = aud.RoleWriter
+		alice.UpdateTime = updateTime
+		assert.Equal(t, alice, got)
+	})
+
+	t.Run("Should return error when setting role binding in unknown project", func(t *testing.T) {
+		_, err := store.SetRoleBinding(ctx, aud.RoleBinding{
+			ProjectID:   aud.MustNewID(),
+			PrincipalID: "jwt:alice",
+			Role:        aud.RoleViewer,
+			CreateTime:  createTime,
+			UpdateTime:  createTime,
+		})
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+
+	t.Run("Should get role binding", func(t *testing.T) {
+		got, err := store.GetRoleBinding(ctx, testProjectID, alice.PrincipalID)
+		require.NoError(t, err)
+		assert.Equal(t, alice, got)
+
+		_, err = store.GetRoleBinding(ctx, testProjectID, "jwt:unknown")
+		assert.ErrorIs(t, err, aud.ErrRoleBindingNotFound)
+	})
+
+	t.Run("Should list role bindings", func(t *testing.T) {
+		got, err := store.ListRoleBindings(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []aud.RoleBinding{bob, alice}, got)
+
+		_, err = store.ListRoleBindings(ctx, aud.MustNewID())
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+
+	t.Run("Should list principal role bindings", func(t *testing.T) {
+		got, err := store.ListPrincipalRoleBindings(ctx, alice.PrincipalID)
+		require.NoError(t, err)
+		assert.Equal(t, []aud.RoleBinding{alice}, got)
+
+		got, err = store.ListPrincipalRoleBindings(ctx, "jwt:unknown")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Should delete role binding", func(t *testing.T) {
+		err := store.DeleteRoleBinding(ctx, testProjectID, alice.PrincipalID)
+		require.NoError(t, err)
+
+		_, err = store.GetRoleBinding(ctx, testProjectID, alice.PrincipalID)
+		assert.ErrorIs(t, err, aud.ErrRoleBindingNotFound)
+
+		err = store.DeleteRoleBinding(ctx, testProjectID, alice.PrincipalID)
+		assert.ErrorIs(t, err, aud.ErrRoleBindingNotFound)
+	})
+}
+
+func TestIntegration_Store_WatchRecords(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	setCleanupRecords(t, db)
+
+	newRecord := func(createTime time.Time, resourceType string) aud.Record {
+		return aud.Record{
+			ID:         aud.MustNewID(),
+			ProjectID:  testProjectID,
+			CreateTime: createTime,
+			Resource: aud.Resource{
+				Type: resourceType,
+				ID:   "post-1",
 			},
-			UpdateResource: true,
 			Operation: aud.Operation{
 				Type: "CREATE",
-				ID:   "example.v2.PostService/CreatePostComment",
-				Time: time.Date(2023, 2, 1, 1, 2, 0, 0, time.UTC),
-				Metadata: map[string]string{
-					"authorized": "true",
-				},
-				TraceContext: aud.TraceContext{
-					Traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
-					Tracestate:  "congo=t61rcWkgMzE",
-				},
-				Status: aud.OperationStatusSucceeded,
+				ID:   "example.v1.PostService/CreatePost",
+				Time: createTime,
 			},
-			UpdateOperation: true,
 			Actor: aud.Actor{
 				Type: "USER",
-				ID:   "user-84",
-				Metadata: map[string]string{
-					"device_type": "mobile",
-				},
+				ID:   "user-1",
 			},
-			UpdateActor: true,
 		}
+	}
+
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	// Test
+
+	store := NewStore(db)
+
+	listenerCtx, listenerCancel := context.WithCancel(ctx)
+	defer listenerCancel()
+	go store.RunRecordsListener(listenerCtx, zap.NewNop())
+
+	subscribe := func(ctx context.Context) <-chan []aud.ID {
+		var sub <-chan []aud.ID
+		require.Eventually(t, func() bool {
+			var err error
+			sub, err = store.SubscribeRecords(ctx, testProjectID)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		return sub
+	}
+
+	var created []aud.Record
+
+	t.Run("Should notify subscribers about created records", func(t *testing.T) {
+		subCtx, subCancel := context.WithCancel(ctx)
+		defer subCancel()
+
+		sub := subscribe(subCtx)
+
+		batch := []aud.Record{newRecord(t0, "POST"), newRecord(t0, "COMMENT")}
+		_, err := store.CreateRecords(ctx, batch)
+		require.NoError(t, err)
+
+		record := newRecord(t0.Add(time.Second), "POST")
+		_, err = store.CreateRecord(ctx, record)
+		require.NoError(t, err)
+
+		created = append(created, batch...)
+		created = append(created, record)
+
+		assert.Equal(t, []aud.ID{batch[0].ID, batch[1].ID}, <-sub)
+		assert.Equal(t, []aud.ID{record.ID}, <-sub)
+
+		subCancel()
+
+		assert.Eventually(t, func() bool {
+			_, ok := <-sub
+			return !ok
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Should list records created after cursor", func(t *testing.T) {
+		records, err := store.ListRecordsCreatedAfter(ctx, testProjectID, aud.RecordFilter{}, 10, aud.RecordWatchCursor{})
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+
+		// Records of the batch have the same create time.
+		assert.ElementsMatch(t, []aud.ID{created[0].ID, created[1].ID}, []aud.ID{records[0].ID, records[1].ID})
+		assert.Equal(t, created[2].ID, records[2].ID)
+
+		cursor := aud.RecordWatchCursor{}.Advance(records[0])
+		records, err = store.ListRecordsCreatedAfter(ctx, testProjectID, aud.RecordFilter{}, 10, cursor)
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+
+		cursor = cursor.Advance(records[1])
+		records, err = store.ListRecordsCreatedAfter(ctx, testProjectID, aud.RecordFilter{}, 10, cursor)
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("Should list records by ids and filter", func(t *testing.T) {
+		filter := aud.RecordFilter{
+			IDs:           []aud.ID{created[0].ID, created[1].ID},
+			ResourceTypes: []string{"POST"},
+		}
+
+		records, err := store.ListRecordsCreatedAfter(ctx, testProjectID, filter, 10, aud.RecordWatchCursor{})
+		require.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, created[0].ID, records[0].ID)
+		}
+	})
+
+	t.Run("Should return error when project does not exist", func(t *testing.T) {
+		_, err := store.ListRecordsCreatedAfter(ctx, aud.MustNewID(), aud.RecordFilter{}, 10, aud.RecordWatchCursor{})
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+}
+
+func TestIntegration_Store_Webhooks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db := sqltest.NewDatabase(ctx, t)
+
+	// Seed
+
+	seedTestProject(ctx, t, db)
+	setCleanupTestProject(t, db)
+
+	setCleanupRecords(t, db)
+	setCleanupWebhooks(t, db)
+
+	newRecord := func(createTime time.Time, resourceType string) aud.Record {
+		return aud.Record{
+			ID:         aud.MustNewID(),
+			ProjectID:  testProjectID,
+			CreateTime: createTime,
+			Resource: aud.Resource{
+				Type: resourceType,
+				ID:   "role-1",
+			},
+			Operation: aud.Operation{
+				Type:   "UPDATE",
+				ID:     "example.v1.RoleService/UpdateRole",
+				Time:   createTime,
+				Status: aud.OperationStatusFailed,
+			},
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   "user-1",
+			},
+		}
+	}
+
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	// Test
+
+	store := NewStore(db)
+
+	all := aud.Webhook{
+		ID:          aud.MustNewID(),
+		ProjectID:   testProjectID,
+		CreateTime:  t0,
+		DisplayName: "All records",
+		URL:         "https://example.com/all",
+		Secret:      "whsec_all",
+	}
+	roles := aud.Webhook{
+		ID:          aud.MustNewID(),
+		ProjectID:   testProjectID,
+		CreateTime:  t0,
+		DisplayName: "Failed role operations",
+		URL:         "https://example.com/roles",
+		Secret:      "whsec_roles",
+		Filter:      `operation.status = FAILED AND resource.type = "iam.role"`,
+	}
+
+	t.Run("Should create webhooks", func(t *testing.T) {
+		require.NoError(t, store.CreateWebhook(ctx, all))
+		require.NoError(t, store.CreateWebhook(ctx, roles))
+
+		got, err := store.GetWebhook(ctx, testProjectID, roles.ID)
+		require.NoError(t, err)
+		assert.Equal(t, roles, got)
+
+		list, err := store.ListWebhooks(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []aud.Webhook{all, roles}, list)
+	})
+
+	t.Run("Should return error when creating webhook in unknown project", func(t *testing.T) {
+		webhook := all
+		webhook.ID = aud.MustNewID()
+		webhook.ProjectID = aud.MustNewID()
+
+		err := store.CreateWebhook(ctx, webhook)
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+
+	roleRecord := newRecord(t0, "iam.role")
+	userRecord := newRecord(t0, "iam.user")
+
+	t.Run("Should queue deliveries of matching records", func(t *testing.T) {
+		_, err := store.CreateRecords(ctx, []aud.Record{roleRecord, userRecord})
+		require.NoError(t, err)
+
+		deliveries, err := store.ListWebhookDeliveries(ctx, testProjectID, all.ID, aud.WebhookDeliveryFilter{}, 10, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.ElementsMatch(t,
+			[]aud.ID{roleRecord.ID, userRecord.ID},
+			[]aud.ID{deliveries[0].RecordID, deliveries[1].RecordID},
+		)
+
+		deliveries, err = store.ListWebhookDeliveries(ctx, testProjectID, roles.ID, aud.WebhookDeliveryFilter{}, 10, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, aud.WebhookDelivery{
+			ID:              deliveries[0].ID,
+			ProjectID:       testProjectID,
+			WebhookID:       roles.ID,
+			RecordID:        roleRecord.ID,
+			CreateTime:      t0,
+			Status:          aud.WebhookDeliveryStatusPending,
+			NextAttemptTime: t0,
+		}, deliveries[0])
+	})
+
+	t.Run("Should claim due deliveries until lease time", func(t *testing.T) {
+		now := t0.Add(time.Second)
+		leaseTime := now.Add(time.Minute)
+
+		claimed, err := store.ClaimWebhookDeliveries(ctx, now, leaseTime, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 3)
+		for _, delivery := range claimed {
+			assert.Equal(t, leaseTime, delivery.NextAttemptTime)
+		}
+
+		claimed, err = store.ClaimWebhookDeliveries(ctx, now, leaseTime, 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		claimed, err = store.ClaimWebhookDeliveries(ctx, leaseTime, leaseTime.Add(time.Minute), 2)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 2)
+	})
+
+	t.Run("Should update delivery and filter by status", func(t *testing.T) {
+		deliveries, err := store.ListWebhookDeliveries(ctx, testProjectID, roles.ID, aud.WebhookDeliveryFilter{}, 10, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		delivery := deliveries[0]
+		delivery.Status = aud.WebhookDeliveryStatusSucceeded
+		delivery.Attempts = 1
+		delivery.NextAttemptTime = time.Time{}
+		delivery.LastAttemptTime = t0.Add(time.Second)
+		delivery.LastResponseCode = 200
+		require.NoError(t, store.UpdateWebhookDelivery(ctx, delivery))
+
+		got, err := store.ListWebhookDeliveries(ctx, testProjectID, roles.ID, aud.WebhookDeliveryFilter{
+			Statuses: []aud.WebhookDeliveryStatus{aud.WebhookDeliveryStatusSucceeded},
+		}, 10, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		assert.Equal(t, []aud.WebhookDelivery{delivery}, got)
+
+		got, err = store.ListWebhookDeliveries(ctx, testProjectID, roles.ID, aud.WebhookDeliveryFilter{
+			Statuses: []aud.WebhookDeliveryStatus{aud.WebhookDeliveryStatusPending},
+		}, 10, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Should paginate deliveries", func(t *testing.T) {
+		first, err := store.ListWebhookDeliveries(ctx, testProjectID, all.ID, aud.WebhookDeliveryFilter{}, 1, aud.WebhookDeliveryCursor{})
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		second, err := store.ListWebhookDeliveries(ctx, testProjectID, all.ID, aud.WebhookDeliveryFilter{}, 1, aud.NewWebhookDeliveryCursor(first, 1))
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+		assert.NotEqual(t, first[0].ID, second[0].ID)
+	})
 
-		updatedRecord, err := store.UpdateRecord(ctx, testProjectID, id, update)
-		assert.NoError(t, err)
+	t.Run("Should delete completed deliveries", func(t *testing.T) {
+		n, err := store.DeleteWebhookDeliveries(ctx, t0)
+		require.NoError(t, err)
+		assert.Zero(t, n)
 
-		assert.Equal(t, aud.Record{
-			ID:         r2id,
-			ProjectID:  testProjectID,
-			CreateTime: seededRecordModels[1].CreateTime,
-			Labels:     update.Labels,
-			Resource:   update.Resource,
-			Operation:  update.Operation,
-			Actor:      update.Actor,
-		}, updatedRecord)
+		n, err = store.DeleteWebhookDeliveries(ctx, t0.Add(time.Hour))
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, n)
 	})
 
-	t.Run("Should return error when record does not exist", func(t *testing.T) {
-		store := NewStore(db)
+	t.Run("Should delete webhook with deliveries", func(t *testing.T) {
+		require.NoError(t, store.DeleteWebhook(ctx, testProjectID, all.ID))
 
-		id := aud.MustNewID()
+		err := store.DeleteWebhook(ctx, testProjectID, all.ID)
+		assert.ErrorIs(t, err, aud.ErrWebhookNotFound)
 
-		update := aud.RecordUpdate{
-			Labels: map[string]string{
-				"post_id": "post-43",
-			},
-			UpdateLabels: true,
-		}
+		_, err = store.ListWebhookDeliveries(ctx, testProjectID, all.ID, aud.WebhookDeliveryFilter{}, 10, aud.WebhookDeliveryCursor{})
+		assert.ErrorIs(t, err, aud.ErrWebhookNotFound)
 
-		_, err := store.UpdateRecord(ctx, testProjectID, id, update)
-		assert.ErrorIs(t, err, aud.ErrRecordNotFound)
+		var count int
+		count, err = db.NewSelect().
+			Model((*webhookDeliveryModel)(nil)).
+			Where("webhook_id = ?", all.ID).
+			Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
 	})
 }
 
-func TestIntegration_Store_DeleteRecord(t *testing.T) {
+func TestIntegration_Store_Alerts(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -883,130 +2523,212 @@ func TestIntegration_Store_DeleteRecord(t *testing.T) {
 	seedTestProject(ctx, t, db)
 	setCleanupTestProject(t, db)
 
-	r1id := aud.MustNewID()
-	r2id := aud.MustNewID()
-	r3id := aud.MustNewID()
+	setCleanupRecords(t, db)
+	setCleanupAlerts(t, db)
 
-	seededRecordModels := []recordModel{
-		{
-			ID:         r1id,
+	newRecord := func(operationTime time.Time, operationType string, actorID string) aud.Record {
+		return aud.Record{
+			ID:         aud.MustNewID(),
 			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
-			},
-			ResourceType: "POST",
-			ResourceID:   "post-42",
-			ResourceMeta: map[string]string{
-				"category": "funny",
-			},
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:  r1id,
-					ProjectID: testProjectID,
-					Name:      "text",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"My windows aren’t dirty, that’s my dog’s nose art."`),
-				},
-				{
-					RecordID:  r1id,
-					ProjectID: testProjectID,
-					Name:      "status",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"published"`),
-				},
+			CreateTime: operationTime,
+			Resource: aud.Resource{
+				Type: "account",
+				ID:   actorID,
 			},
-			OperationType:   "CREATE",
-			OperationID:     "example.v1.PostService/CreatePost",
-			OperationTime:   time.Date(2023, 1, 1, 1, 1, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-82",
-		},
-		{
-			ID:         r2id,
-			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
+			Operation: aud.Operation{
+				Type:   operationType,
+				ID:     "example.v1.AccountService/" + operationType,
+				Time:   operationTime,
+				Status: aud.OperationStatusFailed,
 			},
-			ResourceType: "COMMENT",
-			ResourceID:   "comment-79",
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:  r2id,
-					ProjectID: testProjectID,
-					Name:      "text",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"Show us, my fiend!"`),
-				},
-				{
-					RecordID:  r2id,
-					ProjectID: testProjectID,
-					Name:      "status",
-					OldValue:  json.RawMessage(`null`),
-					NewValue:  json.RawMessage(`"published"`),
-				},
+			Actor: aud.Actor{
+				Type: "USER",
+				ID:   actorID,
 			},
-			OperationType:   "CREATE",
-			OperationID:     "example.v1.PostService/CreatePostComment",
-			OperationTime:   time.Date(2023, 1, 1, 1, 2, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-83",
+		}
+	}
+
+	t0 := time.Date(2023, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	// Test
+
+	store := NewStore(db)
+
+	threshold := aud.AlertRule{
+		ID:          aud.MustNewID(),
+		ProjectID:   testProjectID,
+		CreateTime:  t0,
+		DisplayName: "Failed logins",
+		Threshold: &aud.AlertRuleThreshold{
+			Filter: `operation.type = "login" AND operation.status = FAILED`,
+			Count:  3,
 		},
-		{
-			ID:         r3id,
-			ProjectID:  testProjectID,
-			CreateTime: time.Date(2023, 1, 1, 2, 3, 4, 0, time.UTC),
-			Labels: map[string]string{
-				"post_id": "post-42",
-			},
-			ResourceType: "COMMENT",
-			ResourceID:   "comment-79",
-			ResourceMeta: map[string]string{
-				"status": "published",
-			},
-			ResourceChanges: []recordResourceChangeModel{
-				{
-					RecordID:    r3id,
-					ProjectID:   testProjectID,
-					Name:        "text",
-					Description: "Edit text",
-					OldValue:    json.RawMessage(`"Show us, my fiend!"`),
-					NewValue:    json.RawMessage(`"Show us, my friend!"`),
-				},
+		GroupBy: []string{"actor.id"},
+		Window:  5 * time.Minute,
+	}
+	sequence := aud.AlertRule{
+		ID:          aud.MustNewID(),
+		ProjectID:   testProjectID,
+		CreateTime:  t0,
+		DisplayName: "Grant and export",
+		Sequence: &aud.AlertRuleSequence{
+			Steps: []string{
+				`operation.type = "permission.grant"`,
+				`operation.type = "data.export"`,
 			},
-			OperationType:   "UPDATE",
-			OperationID:     "example.v1.PostService/UpdatePostComment",
-			OperationTime:   time.Date(2023, 1, 1, 1, 3, 0, 0, time.UTC),
-			OperationStatus: aud.OperationStatusSucceeded.Int(),
-			ActorType:       "USER",
-			ActorID:         "user-83",
 		},
+		GroupBy: []string{"actor.id"},
+		Window:  time.Hour,
 	}
-	seedRecords(ctx, t, db, seededRecordModels...)
-	setCleanupRecords(t, db)
 
-	// Test
+	t.Run("Should create alert rules", func(t *testing.T) {
+		require.NoError(t, store.CreateAlertRule(ctx, threshold))
+		require.NoError(t, store.CreateAlertRule(ctx, sequence))
 
-	t.Run("Should delete record", func(t *testing.T) {
-		store := NewStore(db)
+		got, err := store.GetAlertRule(ctx, testProjectID, sequence.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sequence, got)
 
-		id := r2id
+		list, err := store.ListAlertRules(ctx, testProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, []aud.AlertRule{threshold, sequence}, list)
+	})
 
-		err := store.DeleteRecord(ctx, testProjectID, id)
-		assert.NoError(t, err)
+	t.Run("Should return error when creating alert rule in unknown project", func(t *testing.T) {
+		rule := threshold
+		rule.ID = aud.MustNewID()
+		rule.ProjectID = aud.MustNewID()
 
-		// Check if record was deleted.
+		err := store.CreateAlertRule(ctx, rule)
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
 
-		var model recordModel
+	login1 := newRecord(t0, "login", "user-1")
+	login2 := newRecord(t0.Add(time.Minute), "login", "user-1")
+	login3 := newRecord(t0.Add(time.Minute), "login", "user-2")
+	login4 := newRecord(t0.Add(2*time.Minute), "login", "user-1")
+
+	t.Run("Should fire threshold alert across requests", func(t *testing.T) {
+		_, err := store.CreateRecord(ctx, login1)
+		require.NoError(t, err)
+		_, err = store.CreateRecords(ctx, []aud.Record{login2, login3})
+		require.NoError(t, err)
+
+		alerts, err := store.ListAlerts(ctx, testProjectID, aud.AlertFilter{}, 10, aud.AlertCursor{})
+		require.NoError(t, err)
+		assert.Empty(t, alerts)
+
+		_, err = store.CreateRecord(ctx, login4)
+		require.NoError(t, err)
+
+		alerts, err = store.ListAlerts(ctx, testProjectID, aud.AlertFilter{}, 10, aud.AlertCursor{})
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, aud.Alert{
+			ID:                 alerts[0].ID,
+			ProjectID:          testProjectID,
+			RuleID:             threshold.ID,
+			CreateTime:         login4.CreateTime,
+			Group:              map[string]string{"actor.id": "user-1"},
+			RecordIDs:          []aud.ID{login1.ID, login2.ID, login4.ID},
+			FirstOperationTime: login1.Operation.Time,
+			LastOperationTime:  login4.Operation.Time,
+		}, alerts[0])
+	})
+
+	t.Run("Should expire state by latest operation time", func(t *testing.T) {
+		login := newRecord(t0.Add(3*time.Minute), "login", "user-4")
+		login.CreateTime = t0.Add(time.Hour)
+
+		_, err := store.CreateRecord(ctx, login)
+		require.NoError(t, err)
+
+		var states []alertRuleStateModel
 		err = db.NewSelect().
-			Model(&model).
-			Relation(relationResourceChanges).
-			Where("id = ?", id).
+			Model(&states).
+			Where("rule_id = ?", threshold.ID).
 			Scan(ctx)
-		assert.ErrorIs(t, err, sql.ErrNoRows)
+		require.NoError(t, err)
+
+		var expireTimes []time.Time
+		for _, state := range states {
+			expireTimes = append(expireTimes, state.ExpireTime.UTC())
+		}
+		assert.ElementsMatch(t, []time.Time{
+			login3.Operation.Time.Add(threshold.Window),
+			login.Operation.Time.Add(threshold.Window),
+		}, expireTimes)
+	})
+
+	t.Run("Should delete states expired by latest operation time of rule", func(t *testing.T) {
+		login := newRecord(t0.Add(10*time.Minute), "login", "user-5")
+
+		_, err := store.CreateRecord(ctx, login)
+		require.NoError(t, err)
+
+		err = deleteExpiredAlertRuleStates(ctx, db)
+		require.NoError(t, err)
+
+		var states []alertRuleStateModel
+		err = db.NewSelect().
+			Model(&states).
+			Where("rule_id = ?", threshold.ID).
+			Scan(ctx)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Equal(t, login.Operation.Time.Add(threshold.Window), states[0].ExpireTime.UTC())
+	})
+
+	t.Run("Should fire sequence alert within batch", func(t *testing.T) {
+		grant := newRecord(t0.Add(10*time.Minute), "permission.grant", "user-3")
+		export := newRecord(t0.Add(20*time.Minute), "data.export", "user-3")
+
+		_, err := store.CreateRecords(ctx, []aud.Record{grant, export})
+		require.NoError(t, err)
+
+		alerts, err := store.ListAlerts(ctx, testProjectID, aud.AlertFilter{RuleID: &sequence.ID}, 10, aud.AlertCursor{})
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, []aud.ID{grant.ID, export.ID}, alerts[0].RecordIDs)
+		assert.Equal(t, map[string]string{"actor.id": "user-3"}, alerts[0].Group)
+	})
+
+	t.Run("Should list alerts with pagination", func(t *testing.T) {
+		page1, err := store.ListAlerts(ctx, testProjectID, aud.AlertFilter{}, 1, aud.AlertCursor{})
+		require.NoError(t, err)
+		require.Len(t, page1, 1)
+		assert.Equal(t, sequence.ID, page1[0].RuleID)
+
+		page2, err := store.ListAlerts(ctx, testProjectID, aud.AlertFilter{}, 1, aud.NewAlertCursor(page1, 1))
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		assert.Equal(t, threshold.ID, page2[0].RuleID)
+	})
+
+	t.Run("Should return error when listing alerts of unknown project", func(t *testing.T) {
+		_, err := store.ListAlerts(ctx, aud.MustNewID(), aud.AlertFilter{}, 10, aud.AlertCursor{})
+		assert.ErrorIs(t, err, aud.ErrProjectNotFound)
+	})
+
+	t.Run("Should delete alert rule and keep alerts", func(t *testing.T) {
+		require.NoError(t, store.DeleteAlertRule(ctx, testProjectID, threshold.ID))
+
+		_, err := store.GetAlertRule(ctx, testProjectID, threshold.ID)
+		assert.ErrorIs(t, err, aud.ErrAlertRuleNotFound)
+
+		err = store.DeleteAlertRule(ctx, testProjectID, threshold.ID)
+		assert.ErrorIs(t, err, aud.ErrAlertRuleNotFound)
+
+		count, err := db.NewSelect().
+			Model((*alertRuleStateModel)(nil)).
+			Where("rule_id = ?", threshold.ID).
+			Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		alerts, err := store.ListAlerts(ctx, testProjectID, aud.AlertFilter{RuleID: &threshold.ID}, 10, aud.AlertCursor{})
+		require.NoError(t, err)
+		assert.Len(t, alerts, 1)
 	})
 }
 
@@ -1056,7 +2778,7 @@ func TestIntegration_Store_recordsIDs(t *testing.T) {
 
 			records[i] = rec
 
-			err := store.CreateRecord(ctx, rec)
+			_, err := store.CreateRecord(ctx, rec)
 			assert.NoError(t, err)
 		}
 
@@ -1190,6 +2912,12 @@ func deleteProject(ctx context.Context, t *testing.T, db *bun.DB, model projectM
 	}
 
 	_, err := db.NewDelete().
+		Model(&projectUsageModel{}).
+		Where("project_id = ?", model.ID).
+		Exec(ctx)
+	require.NoError(t, err)
+
+	_, err = db.NewDelete().
 		Model(&projectModel{}).
 		Where("id = ?", model.ID).
 		Exec(ctx)
@@ -1214,6 +2942,87 @@ func seedRecords(ctx context.Context, t *testing.T, db *bun.DB, recordModels ...
 	}
 }
 
+func mustParseRecordFilterExpression(t *testing.T, s string) expr.Expr {
+	t.Helper()
+
+	e, err := expr.Parse(s)
+	require.NoError(t, err)
+
+	err = expr.Check(e, aud.RecordFilterSchema)
+	require.NoError(t, err)
+
+	return e
+}
+
+func setCleanupAPIKeys(t *testing.T, db *bun.DB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := db.NewTruncateTable().
+			Model((*apiKeyModel)(nil)).
+			Exec(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func setCleanupRoleBindings(t *testing.T, db *bun.DB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := db.NewTruncateTable().
+			Model((*roleBindingModel)(nil)).
+			Exec(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func setCleanupWebhooks(t *testing.T, db *bun.DB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_, err := db.NewTruncateTable().
+			Model((*webhookDeliveryModel)(nil)).
+			Exec(ctx)
+		require.NoError(t, err)
+
+		_, err = db.NewTruncateTable().
+			Model((*webhookModel)(nil)).
+			Cascade().
+			Exec(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func setCleanupAlerts(t *testing.T, db *bun.DB) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for _, model := range []any{
+			(*alertModel)(nil),
+			(*alertRuleStateModel)(nil),
+			(*alertRuleModel)(nil),
+		} {
+			_, err := db.NewTruncateTable().
+				Model(model).
+				Cascade().
+				Exec(ctx)
+			require.NoError(t, err)
+		}
+	})
+}
+
 func setCleanupRecords(t *testing.T, db *bun.DB) {
 	t.Helper()
 
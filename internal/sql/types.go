@@ -16,6 +16,9 @@ package sql
 
 import (
 	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
 
 	"github.com/auditumio/auditum/internal/aud/types"
 )
@@ -47,3 +50,17 @@ func fromNullString(src sql.NullString) string {
 	}
 	return src.String
 }
+
+func toNullTime(src time.Time) bun.NullTime {
+	if src.IsZero() {
+		return bun.NullTime{}
+	}
+	return bun.NullTime{Time: src}
+}
+
+func fromNullTime(src bun.NullTime) time.Time {
+	if src.IsZero() {
+		return time.Time{}
+	}
+	return src.Time.UTC()
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/auditumio/auditum/internal/aud"
+)
+
+// webhookDeliveriesInsertSize is the maximum number of deliveries inserted
+// at once, to keep the number of query parameters within database limits.
+const webhookDeliveriesInsertSize = 500
+
+type webhookModel struct {
+	bun.BaseModel `bun:"table:webhooks,alias:webhooks"`
+
+	ID          aud.ID    `bun:"id,pk"`
+	ProjectID   aud.ID    `bun:"project_id,notnull"`
+	CreateTime  time.Time `bun:"create_time,notnull"`
+	DisplayName string    `bun:"display_name,notnull"`
+	URL         string    `bun:"url,notnull"`
+	Secret      string    `bun:"secret,notnull"`
+	Filter      string    `bun:"filter,notnull"`
+}
+
+func toWebhookModel(webhook aud.Webhook) webhookModel {
+	return webhookModel{
+		ID:          webhook.ID,
+		ProjectID:   webhook.ProjectID,
+		CreateTime:  webhook.CreateTime,
+		DisplayName: webhook.DisplayName,
+		URL:         webhook.URL,
+		Secret:      webhook.Secret,
+		Filter:      webhook.Filter,
+	}
+}
+
+func fromWebhookModel(model webhookModel) aud.Webhook {
+	return aud.Webhook{
+		ID:          model.ID,
+		ProjectID:   model.ProjectID,
+		CreateTime:  model.CreateTime.UTC(),
+		DisplayName: model.DisplayName,
+		URL:         model.URL,
+		Secret:      model.Secret,
+		Filter:      model.Filter,
+	}
+}
+
+func fromWebhookModels(models []webhookModel) []aud.Webhook {
+	webhooks := make([]aud.Webhook, len(models))
+	for i, model := range models {
+		webhooks[i] = fromWebhookModel(model)
+	}
+	return webhooks
+}
+
+type webhookDeliveryModel struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:webhook_deliveries"`
+
+	ID               aud.ID       `bun:"id,pk"`
+	ProjectID        aud.ID       `bun:"project_id,notnull"`
+	WebhookID        aud.ID       `bun:"webhook_id,notnull"`
+	RecordID         aud.ID       `bun:"record_id,notnull"`
+	CreateTime       time.Time    `bun:"create_time,notnull"`
+	Status           string       `bun:"status,notnull"`
+	Attempts         int          `bun:"attempts,notnull"`
+	NextAttemptTime  bun.NullTime `bun:"next_attempt_time"`
+	LastAttemptTime  bun.NullTime `bun:"last_attempt_time"`
+	LastResponseCode int          `bun:"last_response_code,notnull"`
+	LastError        string       `bun:"last_error,notnull"`
+}
+
+func toWebhookDeliveryModel(delivery aud.WebhookDelivery) webhookDeliveryModel {
+	return webhookDeliveryModel{
+		ID:               delivery.ID,
+		ProjectID:        delivery.ProjectID,
+		WebhookID:        delivery.WebhookID,
+		RecordID:         delivery.RecordID,
+		CreateTime:       delivery.CreateTime,
+		Status:           delivery.Status.String(),
+		Attempts:         delivery.Attempts,
+		NextAttemptTime:  toNullTime(delivery.NextAttemptTime),
+		LastAttemptTime:  toNullTime(delivery.LastAttemptTime),
+		LastResponseCode: delivery.LastResponseCode,
+		LastError:        delivery.LastError,
+	}
+}
+
+func fromWebhookDeliveryModel(model webhookDeliveryModel) (aud.WebhookDelivery, error) {
+	status, err := aud.ParseWebhookDeliveryStatus(model.Status)
+	if err != nil {
+		return aud.WebhookDelivery{}, err
+	}
+
+	return aud.WebhookDelivery{
+		ID:               model.ID,
+		ProjectID:        model.ProjectID,
+		WebhookID:        model.WebhookID,
+		RecordID:         model.RecordID,
+		CreateTime:       model.CreateTime.UTC(),
+		Status:           status,
+		Attempts:         model.Attempts,
+		NextAttemptTime:  fromNullTime(model.NextAttemptTime),
+		LastAttemptTime:  fromNullTime(model.LastAttemptTime),
+		LastResponseCode: model.LastResponseCode,
+		LastError:        model.LastError,
+	}, nil
+}
+
+func fromWebhookDeliveryModels(models []webhookDeliveryModel) ([]aud.WebhookDelivery, error) {
+	deliveries := make([]aud.WebhookDelivery, len(models))
+	for i, model := range models {
+		delivery, err := fromWebhookDeliveryModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("webhook delivery %s: %v", model.ID, err)
+		}
+		deliveries[i] = delivery
+	}
+	return deliveries, nil
+}
+
+// createWebhookDeliveries queues delivery of the records to matching
+// webhooks of the project. It runs in the transaction creating the records,
+// so that deliveries are not lost.
+func createWebhookDeliveries(ctx context.Context, tx bun.Tx, projectID aud.ID, records []aud.Record) error {
+	var webhooks []webhookModel
+
+	err := tx.NewSelect().
+		Model(&webhooks).
+		Column("id", "filter").
+		Where("project_id = ?", projectID).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select webhooks from db: %v", err)
+	}
+
+	var deliveries []webhookDeliveryModel
+	for _, webhook := range webhooks {
+		matches := func(aud.Record) bool { return true }
+		if webhook.Filter != "" {
+			filter, err := aud.ParseRecordFilterExpression(webhook.Filter)
+			if err != nil {
+				return fmt.Errorf("parse filter of webhook %s: %v", webhook.ID, err)
+			}
+			matches = func(record aud.Record) bool {
+				return aud.MatchRecordExpression(filter, record)
+			}
+		}
+
+		for _, record := range records {
+			if !matches(record) {
+				continue
+			}
+
+			id, err := aud.NewID()
+			if err != nil {
+				return fmt.Errorf("generate webhook delivery id: %v", err)
+			}
+
+			deliveries = append(deliveries, toWebhookDeliveryModel(aud.WebhookDelivery{
+				ID:              id,
+				ProjectID:       projectID,
+				WebhookID:       webhook.ID,
+				RecordID:        record.ID,
+				CreateTime:      record.CreateTime,
+				Status:          aud.WebhookDeliveryStatusPending,
+				NextAttemptTime: record.CreateTime,
+			}))
+		}
+	}
+
+	for len(deliveries) > 0 {
+		chunk := deliveries[:min(len(deliveries), webhookDeliveriesInsertSize)]
+		deliveries = deliveries[len(chunk):]
+
+		_, err := tx.NewInsert().
+			Model(&chunk).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("insert webhook deliveries into db: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+)
+
+// ProjectMapping selects the project of messages matching the hostname and
+// app name glob patterns. Empty pattern matches any value.
+type ProjectMapping struct {
+	Hostname  string
+	AppName   string
+	ProjectID string
+}
+
+// Rule maps messages matching the hostname, app name and message id glob
+// patterns, and the message regular expression, to a record. Empty pattern
+// matches any value.
+//
+// Record templates may reference hostname, app_name, proc_id, msg_id,
+// facility, severity, message, sd.<id>.<param> for structured data
+// parameters, and named groups of the message regular expression.
+type Rule struct {
+	Hostname string
+	AppName  string
+	MsgID    string
+	Message  string
+	Record   ingest.RecordTemplate
+}
+
+var (
+	errNoProject = errors.New("no project mapping matched")
+	errNoRule    = errors.New("no rule matched")
+)
+
+// Mapper maps messages to records.
+type Mapper struct {
+	projects []ProjectMapping
+	rules    []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+
+	message *regexp.Regexp
+	record  *ingest.RecordMapper
+}
+
+// NewMapper returns a mapper of messages. Projects and rules are matched in
+// order, the first match is used.
+func NewMapper(projects []ProjectMapping, rules []Rule) (*Mapper, error) {
+	m := &Mapper{
+		projects: projects,
+		rules:    make([]compiledRule, 0, len(rules)),
+	}
+
+	for i, p := range projects {
+		if err := ingest.ValidatePatterns(p.Hostname, p.AppName); err != nil {
+			return nil, fmt.Errorf("invalid project mapping %d: %v", i, err)
+		}
+	}
+
+	for i, r := range rules {
+		rule, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %v", i, err)
+		}
+		m.rules = append(m.rules, rule)
+	}
+
+	return m, nil
+}
+
+func compileRule(r Rule) (rule compiledRule, err error) {
+	rule.Rule = r
+
+	if err := ingest.ValidatePatterns(r.Hostname, r.AppName, r.MsgID); err != nil {
+		return rule, err
+	}
+
+	if r.Message != "" {
+		rule.message, err = regexp.Compile(r.Message)
+		if err != nil {
+			return rule, fmt.Errorf("invalid message regexp: %v", err)
+		}
+	}
+
+	rule.record, err = ingest.NewRecordMapper(r.Record)
+	if err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+// Map maps the message to a record of the mapped project. Operation time
+// is the message timestamp, or the receive time when the message has none.
+// Structured data parameters are added to operation metadata.
+func (m *Mapper) Map(msg Message, receiveTime time.Time) (*auditumv1alpha1.Record, error) {
+	projectID := ""
+	for _, p := range m.projects {
+		if ingest.Match(p.Hostname, msg.Hostname) && ingest.Match(p.AppName, msg.AppName) {
+			projectID = p.ProjectID
+			break
+		}
+	}
+	if projectID == "" {
+		return nil, errNoProject
+	}
+
+	for _, rule := range m.rules {
+		if !ingest.Match(rule.Hostname, msg.Hostname) ||
+			!ingest.Match(rule.AppName, msg.AppName) ||
+			!ingest.Match(rule.MsgID, msg.MsgID) {
+			continue
+		}
+
+		vars := messageVars(msg)
+
+		if rule.message != nil {
+			groups := rule.message.FindStringSubmatch(msg.Text)
+			if groups == nil {
+				continue
+			}
+			for i, name := range rule.message.SubexpNames() {
+				if name != "" {
+					vars[name] = groups[i]
+				}
+			}
+		}
+
+		operationTime := msg.Timestamp
+		if operationTime.IsZero() {
+			operationTime = receiveTime
+		}
+
+		record, err := rule.record.Record(projectID, ingest.MapLookup(vars), operationTime)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, params := range msg.StructuredData {
+			for name, value := range params {
+				if record.Operation.Metadata == nil {
+					record.Operation.Metadata = make(map[string]string)
+				}
+				record.Operation.Metadata[structuredDataKey(id, name)] = value
+			}
+		}
+
+		return record, nil
+	}
+
+	return nil, errNoRule
+}
+
+var invalidKeyChars = regexp.MustCompile(`[^a-zA-Z0-9-_]`)
+
+// structuredDataKey returns the metadata key of the structured data
+// parameter, with characters not allowed in keys replaced.
+func structuredDataKey(id, name string) string {
+	return invalidKeyChars.ReplaceAllString(id+"_"+name, "-")
+}
+
+var facilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var severities = []string{
+	"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug",
+}
+
+func messageVars(msg Message) map[string]string {
+	vars := map[string]string{
+		"hostname": msg.Hostname,
+		"app_name": msg.AppName,
+		"proc_id":  msg.ProcID,
+		"msg_id":   msg.MsgID,
+		"facility": strconv.Itoa(msg.Facility),
+		"severity": strconv.Itoa(msg.Severity),
+		"message":  msg.Text,
+	}
+
+	if msg.Facility < len(facilities) {
+		vars["facility"] = facilities[msg.Facility]
+	}
+	if msg.Severity < len(severities) {
+		vars["severity"] = severities[msg.Severity]
+	}
+
+	for id, params := range msg.StructuredData {
+		for name, value := range params {
+			vars["sd."+id+"."+name] = value
+		}
+	}
+
+	return vars
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/syslog"
+)
+
+const testProjectID = "0190dc5c-1b0d-7bd4-9b0e-5e3a1e1b1e1b"
+
+var testProjects = []syslog.ProjectMapping{
+	{Hostname: "web*", ProjectID: testProjectID},
+}
+
+var testRules = []syslog.Rule{
+	{
+		AppName: "sshd",
+		Message: `^(?P<result>Accepted|Failed) (?P<method>\S+) for (?P<user>\S+) from (?P<ip>\S+)`,
+		Record: ingest.RecordTemplate{
+			ResourceType:  "host",
+			ResourceID:    "${hostname}",
+			OperationType: "ssh.login",
+			OperationID:   "${proc_id:-unknown}",
+			ActorType:     "user",
+			ActorID:       "${user}",
+			Labels: map[string]string{
+				"method": "${method}",
+			},
+			Metadata: map[string]string{
+				"ip":       "${ip}",
+				"severity": "${severity}",
+			},
+		},
+	},
+	{
+		Record: ingest.RecordTemplate{
+			ResourceType:    "host",
+			ResourceID:      "${hostname}",
+			OperationType:   "${msg_id:-log}",
+			OperationID:     "${app_name:-syslog}",
+			OperationStatus: "${sd.status@1.value}",
+			ActorType:       "application",
+			ActorID:         "${app_name:-unknown}",
+		},
+	},
+}
+
+func TestMapper_Map(t *testing.T) {
+	mapper, err := syslog.NewMapper(testProjects, testRules)
+	require.NoError(t, err)
+
+	receiveTime := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should map message matched by regexp", func(t *testing.T) {
+		msg, err := syslog.Parse([]byte("<38>Oct 19 11:00:00 web01 sshd[4123]: Accepted publickey for alice from 10.0.0.1 port 22"), receiveTime)
+		require.NoError(t, err)
+
+		got, err := mapper.Map(msg, receiveTime)
+		require.NoError(t, err)
+
+		want := &auditumv1alpha1.Record{
+			ProjectId: testProjectID,
+			Labels: map[string]string{
+				"method": "publickey",
+			},
+			Resource: &auditumv1alpha1.Resource{
+				Type: "host",
+				Id:   "web01",
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type: "ssh.login",
+				Id:   "4123",
+				Time: timestamppb.New(time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)),
+				Metadata: map[string]string{
+					"ip":       "10.0.0.1",
+					"severity": "info",
+				},
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type: "user",
+				Id:   "alice",
+			},
+		}
+		assertRecord(t, want, got)
+	})
+
+	t.Run("Should map message with structured data", func(t *testing.T) {
+		msg, err := syslog.Parse([]byte(`<13>1 - web02 deployer - release [status@1 value="failed"][meta version="1.2"] text`), receiveTime)
+		require.NoError(t, err)
+
+		got, err := mapper.Map(msg, receiveTime)
+		require.NoError(t, err)
+
+		want := &auditumv1alpha1.Record{
+			ProjectId: testProjectID,
+			Resource: &auditumv1alpha1.Resource{
+				Type: "host",
+				Id:   "web02",
+			},
+			Operation: &auditumv1alpha1.Operation{
+				Type: "release",
+				Id:   "deployer",
+				Time: timestamppb.New(receiveTime),
+				Metadata: map[string]string{
+					"status-1_value": "failed",
+					"meta_version":   "1.2",
+				},
+				Status: auditumv1alpha1.OperationStatus_FAILED,
+			},
+			Actor: &auditumv1alpha1.Actor{
+				Type: "application",
+				Id:   "deployer",
+			},
+		}
+		assertRecord(t, want, got)
+	})
+
+	t.Run("Should fall through rules not matching message regexp", func(t *testing.T) {
+		msg, err := syslog.Parse([]byte("<38>Oct 19 11:00:00 web01 sshd[4123]: Connection closed"), receiveTime)
+		require.NoError(t, err)
+
+		got, err := mapper.Map(msg, receiveTime)
+		require.NoError(t, err)
+		assert.Equal(t, "log", got.GetOperation().GetType())
+		assert.Equal(t, "sshd", got.GetActor().GetId())
+	})
+
+	t.Run("Should not map message of unmapped host", func(t *testing.T) {
+		msg, err := syslog.Parse([]byte("<38>Oct 19 11:00:00 db01 sshd[4123]: Connection closed"), receiveTime)
+		require.NoError(t, err)
+
+		_, err = mapper.Map(msg, receiveTime)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should not map message with invalid status", func(t *testing.T) {
+		msg, err := syslog.Parse([]byte(`<13>1 - web02 deployer - - [status@1 value="unknown"]`), receiveTime)
+		require.NoError(t, err)
+
+		_, err = mapper.Map(msg, receiveTime)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewMapper_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule syslog.Rule
+	}{
+		{
+			name: "invalid pattern",
+			rule: syslog.Rule{Hostname: "[web"},
+		},
+		{
+			name: "invalid regexp",
+			rule: syslog.Rule{Message: "(?P<user"},
+		},
+		{
+			name: "unterminated reference",
+			rule: syslog.Rule{Record: ingest.RecordTemplate{ActorID: "${user"}},
+		},
+		{
+			name: "invalid status",
+			rule: syslog.Rule{Record: ingest.RecordTemplate{OperationStatus: "DONE"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := syslog.NewMapper(nil, []syslog.Rule{test.rule})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func assertRecord(t *testing.T, want, got *auditumv1alpha1.Record) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want:\n%v\ngot:\n%v", want, got)
+}
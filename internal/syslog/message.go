@@ -0,0 +1,276 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package syslog receives syslog messages and creates records from them.
+package syslog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Message formats.
+const (
+	FormatRFC5424 = "rfc5424"
+	FormatRFC3164 = "rfc3164"
+)
+
+// nilValue is the RFC 5424 value of a missing field.
+const nilValue = "-"
+
+// Message is a parsed syslog message. Missing fields are empty.
+type Message struct {
+	Format    string
+	Facility  int
+	Severity  int
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	MsgID     string
+	// StructuredData maps SD-IDs to their parameters. Only RFC 5424
+	// messages have structured data.
+	StructuredData map[string]map[string]string
+	Text           string
+}
+
+var errInvalidPriority = errors.New("invalid priority")
+
+// Parse parses a RFC 5424 or RFC 3164 message. The format is detected by
+// the version following the priority, which only RFC 5424 messages have.
+// Timestamps of RFC 3164 messages, which have no year and time zone, are
+// considered UTC in the year of now.
+func Parse(b []byte, now time.Time) (Message, error) {
+	b = bytes.TrimRight(b, "\r\n\x00")
+
+	if !utf8.Valid(b) {
+		b = bytes.ToValidUTF8(b, []byte("\ufffd"))
+	}
+
+	pri, rest, err := parsePriority(string(b))
+	if err != nil {
+		return Message{}, err
+	}
+
+	msg := Message{
+		Facility: pri / 8,
+		Severity: pri % 8,
+	}
+
+	if strings.HasPrefix(rest, "1 ") {
+		msg.Format = FormatRFC5424
+		if err := parseRFC5424(&msg, rest[2:]); err != nil {
+			return Message{}, err
+		}
+		return msg, nil
+	}
+
+	msg.Format = FormatRFC3164
+	parseRFC3164(&msg, rest, now)
+	return msg, nil
+}
+
+func parsePriority(s string) (int, string, error) {
+	if !strings.HasPrefix(s, "<") {
+		return 0, "", errInvalidPriority
+	}
+
+	end := strings.IndexByte(s, '>')
+	if end < 2 || end > 4 {
+		return 0, "", errInvalidPriority
+	}
+
+	pri, err := strconv.Atoi(s[1:end])
+	if err != nil || pri < 0 || pri > 191 {
+		return 0, "", errInvalidPriority
+	}
+
+	return pri, s[end+1:], nil
+}
+
+func parseRFC5424(msg *Message, s string) error {
+	var fields [5]string
+	for i := range fields {
+		field, rest, ok := strings.Cut(s, " ")
+		if !ok {
+			return fmt.Errorf("missing header fields")
+		}
+		if field != nilValue {
+			fields[i] = field
+		}
+		s = rest
+	}
+
+	if fields[0] != "" {
+		timestamp, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid timestamp: %v", err)
+		}
+		msg.Timestamp = timestamp
+	}
+
+	msg.Hostname = fields[1]
+	msg.AppName = fields[2]
+	msg.ProcID = fields[3]
+	msg.MsgID = fields[4]
+
+	sd, rest, err := parseStructuredData(s)
+	if err != nil {
+		return fmt.Errorf("invalid structured data: %v", err)
+	}
+	msg.StructuredData = sd
+
+	rest = strings.TrimPrefix(rest, " ")
+	rest = strings.TrimPrefix(rest, "\ufeff")
+	msg.Text = rest
+
+	return nil
+}
+
+// parseStructuredData parses SD-ELEMENTs, returning the rest of the
+// message.
+func parseStructuredData(s string) (map[string]map[string]string, string, error) {
+	if strings.HasPrefix(s, nilValue) {
+		return nil, s[len(nilValue):], nil
+	}
+
+	sd := make(map[string]map[string]string)
+
+	for strings.HasPrefix(s, "[") {
+		s = s[1:]
+
+		end := strings.IndexAny(s, " ]")
+		if end < 1 {
+			return nil, "", fmt.Errorf("missing SD-ID")
+		}
+		id := s[:end]
+		s = s[end:]
+
+		params := make(map[string]string)
+
+		for strings.HasPrefix(s, " ") {
+			s = s[1:]
+
+			name, rest, ok := strings.Cut(s, `="`)
+			if !ok || name == "" {
+				return nil, "", fmt.Errorf("invalid parameter of %q", id)
+			}
+			s = rest
+
+			value, rest, err := parseParamValue(s)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid parameter %q of %q: %v", name, id, err)
+			}
+			s = rest
+
+			params[name] = value
+		}
+
+		if !strings.HasPrefix(s, "]") {
+			return nil, "", fmt.Errorf("unterminated element %q", id)
+		}
+		s = s[1:]
+
+		sd[id] = params
+	}
+
+	if len(sd) == 0 {
+		return nil, "", fmt.Errorf("expected SD-ELEMENT or NILVALUE")
+	}
+
+	return sd, s, nil
+}
+
+// parseParamValue parses the escaped value up to the closing quote.
+func parseParamValue(s string) (string, string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\' || s[i+1] == ']') {
+				i++
+				b.WriteByte(s[i])
+			} else {
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated value")
+}
+
+// rfc3164TimestampLayout is the BSD syslog timestamp, e.g.
+// "Oct 11 22:14:15" or "Oct  1 22:14:15".
+const rfc3164TimestampLayout = "Jan _2 15:04:05"
+
+func parseRFC3164(msg *Message, s string, now time.Time) {
+	if len(s) >= len(rfc3164TimestampLayout) {
+		timestamp, err := time.Parse(rfc3164TimestampLayout, s[:len(rfc3164TimestampLayout)])
+		if err == nil {
+			now = now.UTC()
+			timestamp = timestamp.AddDate(now.Year(), 0, 0)
+			// Messages sent at the end of the year may be received in the
+			// next one.
+			if timestamp.After(now.AddDate(0, 0, 1)) {
+				timestamp = timestamp.AddDate(-1, 0, 0)
+			}
+			msg.Timestamp = timestamp
+			s = strings.TrimPrefix(s[len(rfc3164TimestampLayout):], " ")
+
+			// The hostname follows the timestamp, unless it is omitted and
+			// the tag follows instead.
+			if host, rest, ok := strings.Cut(s, " "); ok && !isRFC3164Tag(host) {
+				msg.Hostname = host
+				s = rest
+			}
+		}
+	}
+
+	// The tag is the app name, optionally followed by the process id in
+	// brackets, and a colon.
+	end := strings.IndexAny(s, "[: ")
+	if end > 0 && end <= 48 {
+		tag := s[:end]
+		rest := s[end:]
+
+		if strings.HasPrefix(rest, "[") {
+			if pid, after, ok := strings.Cut(rest[1:], "]"); ok {
+				msg.ProcID = pid
+				rest = after
+			}
+		}
+
+		if strings.HasPrefix(rest, ":") {
+			msg.AppName = tag
+			s = strings.TrimPrefix(rest[1:], " ")
+		}
+	}
+
+	msg.Text = s
+}
+
+// isRFC3164Tag reports whether the token is a tag rather than a hostname.
+func isRFC3164Tag(s string) bool {
+	return strings.HasSuffix(s, ":") || strings.Contains(s, "[")
+}
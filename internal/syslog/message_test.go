@@ -0,0 +1,166 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/auditumio/auditum/internal/syslog"
+)
+
+var now = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  syslog.Message
+	}{
+		{
+			name:  "RFC 5424 with structured data",
+			input: `<165>1 2026-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"][examplePriority@32473 class="high"] An application event log entry`,
+			want: syslog.Message{
+				Format:    syslog.FormatRFC5424,
+				Facility:  20,
+				Severity:  5,
+				Timestamp: time.Date(2026, 10, 11, 22, 14, 15, 3000000, time.UTC),
+				Hostname:  "mymachine.example.com",
+				AppName:   "evntslog",
+				MsgID:     "ID47",
+				StructuredData: map[string]map[string]string{
+					"exampleSDID@32473": {
+						"iut":         "3",
+						"eventSource": "Application",
+						"eventID":     "1011",
+					},
+					"examplePriority@32473": {
+						"class": "high",
+					},
+				},
+				Text: "An application event log entry",
+			},
+		},
+		{
+			name:  "RFC 5424 with nil values and BOM",
+			input: "<34>1 - - su 123 - - \ufeff'su root' failed for lonvick on /dev/pts/8\n",
+			want: syslog.Message{
+				Format:   syslog.FormatRFC5424,
+				Facility: 4,
+				Severity: 2,
+				AppName:  "su",
+				ProcID:   "123",
+				Text:     "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			name:  "RFC 5424 with escaped parameter values",
+			input: `<13>1 2026-10-11T22:14:15+02:00 host app - - [meta q="a \"b\" \\ \]"]`,
+			want: syslog.Message{
+				Format:    syslog.FormatRFC5424,
+				Facility:  1,
+				Severity:  5,
+				Timestamp: time.Date(2026, 10, 11, 22, 14, 15, 0, time.FixedZone("", 2*60*60)),
+				Hostname:  "host",
+				AppName:   "app",
+				StructuredData: map[string]map[string]string{
+					"meta": {"q": `a "b" \ ]`},
+				},
+			},
+		},
+		{
+			name:  "RFC 3164",
+			input: "<38>Oct  9 22:33:20 web01 sshd[4123]: Accepted publickey for alice from 10.0.0.1",
+			want: syslog.Message{
+				Format:    syslog.FormatRFC3164,
+				Facility:  4,
+				Severity:  6,
+				Timestamp: time.Date(2026, 10, 9, 22, 33, 20, 0, time.UTC),
+				Hostname:  "web01",
+				AppName:   "sshd",
+				ProcID:    "4123",
+				Text:      "Accepted publickey for alice from 10.0.0.1",
+			},
+		},
+		{
+			name:  "RFC 3164 without hostname",
+			input: "<13>Oct 19 11:00:00 cron: job started",
+			want: syslog.Message{
+				Format:    syslog.FormatRFC3164,
+				Facility:  1,
+				Severity:  5,
+				Timestamp: time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+				AppName:   "cron",
+				Text:      "job started",
+			},
+		},
+		{
+			name:  "RFC 3164 from previous year",
+			input: "<13>Dec 31 23:59:59 host app: last",
+			want: syslog.Message{
+				Format:    syslog.FormatRFC3164,
+				Facility:  1,
+				Severity:  5,
+				Timestamp: time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+				Hostname:  "host",
+				AppName:   "app",
+				Text:      "last",
+			},
+		},
+		{
+			name:  "RFC 3164 without header",
+			input: "<13>just some text",
+			want: syslog.Message{
+				Format:   syslog.FormatRFC3164,
+				Facility: 1,
+				Severity: 5,
+				Text:     "just some text",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := syslog.Parse([]byte(test.input), now)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "missing priority", input: "Oct 11 22:14:15 host app: text"},
+		{name: "invalid priority", input: "<192>1 - - - - - -"},
+		{name: "missing header fields", input: "<13>1 - host"},
+		{name: "invalid timestamp", input: "<13>1 yesterday host app - - -"},
+		{name: "missing structured data", input: "<13>1 - host app - - text"},
+		{name: "unterminated structured data", input: `<13>1 - host app - - [meta q="a"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := syslog.Parse([]byte(test.input), now)
+			assert.Error(t, err)
+		})
+	}
+}
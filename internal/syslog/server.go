@@ -0,0 +1,402 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/ingest"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+// RecordCreator creates records, e.g. the record service client.
+type RecordCreator interface {
+	CreateRecord(
+		ctx context.Context,
+		req *auditumv1alpha1.CreateRecordRequest,
+	) (*auditumv1alpha1.CreateRecordResponse, error)
+}
+
+// Config is the configuration of the server.
+type Config struct {
+	// UDPAddr is the address to receive messages over UDP on. Empty
+	// disables UDP.
+	UDPAddr string
+	// TCPAddr is the address to receive messages over TCP on, with TLS
+	// when TLS is set. Empty disables TCP.
+	TCPAddr string
+	TLS     *tls.Config
+	// MaxMessageSize is the maximum size of a message in bytes. Larger
+	// messages are dropped, and TCP connections sending them are closed.
+	MaxMessageSize int
+	// Concurrency is the number of messages processed at once.
+	Concurrency int
+
+	Projects []ProjectMapping
+	Rules    []Rule
+}
+
+const metricsSource = "syslog"
+
+var errMessageTooLarge = errors.New("message too large")
+
+// Server receives syslog messages over UDP and TCP, and creates records
+// from them.
+//
+// TCP messages are framed either by octet counting or by LF, as described
+// in RFC 6587.
+type Server struct {
+	conf    Config
+	mapper  *Mapper
+	creator RecordCreator
+	log     *zap.Logger
+	now     func() time.Time
+
+	packetConn net.PacketConn
+	listener   net.Listener
+	messages   chan received
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+
+	readers sync.WaitGroup
+	workers sync.WaitGroup
+}
+
+type received struct {
+	data        []byte
+	receiveTime time.Time
+	remoteAddr  net.Addr
+}
+
+func NewServer(conf Config, creator RecordCreator, log *zap.Logger) (*Server, error) {
+	mapper, err := NewMapper(conf.Projects, conf.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		conf:     conf,
+		mapper:   mapper,
+		creator:  creator,
+		log:      log.Named("syslog"),
+		now:      time.Now,
+		messages: make(chan received, conf.Concurrency),
+		conns:    make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// Start starts receiving messages. Records are created with the context
+// values, but are not canceled with it.
+func (s *Server) Start(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			s.closeListeners()
+		}
+	}()
+
+	if s.conf.UDPAddr != "" {
+		s.packetConn, err = net.ListenPacket("udp", s.conf.UDPAddr)
+		if err != nil {
+			return fmt.Errorf("listen udp: %v", err)
+		}
+	}
+
+	if s.conf.TCPAddr != "" {
+		s.listener, err = net.Listen("tcp", s.conf.TCPAddr)
+		if err != nil {
+			return fmt.Errorf("listen tcp: %v", err)
+		}
+		if s.conf.TLS != nil {
+			s.listener = tls.NewListener(s.listener, s.conf.TLS)
+		}
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	for i := 0; i < s.conf.Concurrency; i++ {
+		s.workers.Add(1)
+		go s.work(ctx)
+	}
+
+	if s.packetConn != nil {
+		s.log.Info("Receiving syslog messages over UDP", zap.Stringer("addr", s.packetConn.LocalAddr()))
+		s.readers.Add(1)
+		go s.serveUDP()
+	}
+
+	if s.listener != nil {
+		s.log.Info("Receiving syslog messages over TCP", zap.Stringer("addr", s.listener.Addr()), zap.Bool("tls", s.conf.TLS != nil))
+		s.readers.Add(1)
+		go s.serveTCP()
+	}
+
+	return nil
+}
+
+// UDPAddr returns the address messages are received over UDP on, or nil.
+func (s *Server) UDPAddr() net.Addr {
+	if s.packetConn == nil {
+		return nil
+	}
+	return s.packetConn.LocalAddr()
+}
+
+// TCPAddr returns the address messages are received over TCP on, or nil.
+func (s *Server) TCPAddr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop stops receiving messages, closing TCP connections, and waits for
+// received messages to be processed.
+func (s *Server) Stop() {
+	s.closeListeners()
+
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.readers.Wait()
+	close(s.messages)
+	s.workers.Wait()
+}
+
+func (s *Server) closeListeners() {
+	if s.packetConn != nil {
+		_ = s.packetConn.Close()
+	}
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+}
+
+func (s *Server) serveUDP() {
+	defer s.readers.Done()
+
+	// One more byte than allowed detects messages too large.
+	buf := make([]byte, s.conf.MaxMessageSize+1)
+
+	for {
+		n, addr, err := s.packetConn.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			s.log.Warn("Read UDP message", zap.Error(err))
+			continue
+		}
+
+		if n > s.conf.MaxMessageSize {
+			s.log.Debug("Drop UDP message", zap.Stringer("remote_addr", addr), zap.Error(errMessageTooLarge))
+			metrics.IngestedMessage(metricsSource, ingest.ResultInvalid)
+			continue
+		}
+
+		s.messages <- received{
+			data:        append([]byte(nil), buf[:n]...),
+			receiveTime: s.now(),
+			remoteAddr:  addr,
+		}
+	}
+}
+
+func (s *Server) serveTCP() {
+	defer s.readers.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			s.log.Warn("Accept TCP connection", zap.Error(err))
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.readers.Add(1)
+		s.mu.Unlock()
+
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer s.readers.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+
+	for {
+		data, err := readFrame(r, s.conf.MaxMessageSize)
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			s.log.Debug("Close TCP connection", zap.Stringer("remote_addr", conn.RemoteAddr()), zap.Error(err))
+			if errors.Is(err, errMessageTooLarge) {
+				metrics.IngestedMessage(metricsSource, ingest.ResultInvalid)
+			}
+			return
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		s.messages <- received{
+			data:        data,
+			receiveTime: s.now(),
+			remoteAddr:  conn.RemoteAddr(),
+		}
+	}
+}
+
+// readFrame reads a message framed by octet counting, i.e. prefixed with
+// its length and a space, or terminated by LF.
+func readFrame(r *bufio.Reader, maxSize int) ([]byte, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if first[0] >= '1' && first[0] <= '9' {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return nil, err
+		}
+
+		n := 0
+		for _, c := range length[:len(length)-1] {
+			if c < '0' || c > '9' {
+				return nil, fmt.Errorf("invalid message length %q", length)
+			}
+			n = n*10 + int(c-'0')
+			if n > maxSize {
+				return nil, errMessageTooLarge
+			}
+		}
+
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	var data []byte
+	for {
+		fragment, err := r.ReadSlice('\n')
+		data = append(data, fragment...)
+		if len(data) > maxSize+1 {
+			return nil, errMessageTooLarge
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if errors.Is(err, io.EOF) && len(data) > 0 {
+			return trimNewline(data), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return trimNewline(data), nil
+	}
+}
+
+func trimNewline(b []byte) []byte {
+	for len(b) > 0 && (b[len(b)-1] == '\n' || b[len(b)-1] == '\r') {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+func (s *Server) work(ctx context.Context) {
+	defer s.workers.Done()
+
+	for m := range s.messages {
+		result := s.process(ctx, m)
+		metrics.IngestedMessage(metricsSource, result)
+	}
+}
+
+func (s *Server) process(ctx context.Context, m received) string {
+	log := s.log.With(zap.Stringer("remote_addr", m.remoteAddr))
+
+	msg, err := Parse(m.data, m.receiveTime)
+	if err != nil {
+		log.Debug("Drop invalid message", zap.Error(err))
+		return ingest.ResultInvalid
+	}
+
+	record, err := s.mapper.Map(msg, m.receiveTime)
+	if errors.Is(err, errNoProject) || errors.Is(err, errNoRule) {
+		log.Debug("Drop unmatched message",
+			zap.String("hostname", msg.Hostname),
+			zap.String("app_name", msg.AppName),
+			zap.Error(err),
+		)
+		return ingest.ResultUnmatched
+	}
+	if err != nil {
+		log.Warn("Drop message not mapped to record", zap.Error(err))
+		return ingest.ResultInvalid
+	}
+
+	_, err = s.creator.CreateRecord(ctx, &auditumv1alpha1.CreateRecordRequest{
+		Record: record,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		log.Warn("Drop message mapped to invalid record", zap.Error(err))
+		return ingest.ResultInvalid
+	}
+	if err != nil {
+		log.Error("Create record from message", zap.Error(err))
+		return ingest.ResultFailed
+	}
+
+	return ingest.ResultCreated
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslog_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	auditumv1alpha1 "github.com/auditumio/auditum/api/gen/go/auditumio/auditum/v1alpha1"
+	"github.com/auditumio/auditum/internal/syslog"
+)
+
+type recordCreatorFunc func(req *auditumv1alpha1.CreateRecordRequest)
+
+func (f recordCreatorFunc) CreateRecord(
+	_ context.Context,
+	req *auditumv1alpha1.CreateRecordRequest,
+) (*auditumv1alpha1.CreateRecordResponse, error) {
+	f(req)
+	return &auditumv1alpha1.CreateRecordResponse{Record: req.GetRecord()}, nil
+}
+
+func startServer(t *testing.T) (*syslog.Server, <-chan *auditumv1alpha1.Record) {
+	t.Helper()
+
+	records := make(chan *auditumv1alpha1.Record, 10)
+	creator := recordCreatorFunc(func(req *auditumv1alpha1.CreateRecordRequest) {
+		records <- req.GetRecord()
+	})
+
+	server, err := syslog.NewServer(syslog.Config{
+		UDPAddr:        "127.0.0.1:0",
+		TCPAddr:        "127.0.0.1:0",
+		MaxMessageSize: 256,
+		Concurrency:    1,
+		Projects:       testProjects,
+		Rules:          testRules,
+	}, creator, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, server.Start(context.Background()))
+
+	return server, records
+}
+
+func receiveRecord(t *testing.T, records <-chan *auditumv1alpha1.Record) *auditumv1alpha1.Record {
+	t.Helper()
+
+	select {
+	case record := <-records:
+		return record
+	case <-time.After(5 * time.Second):
+		t.Fatal("record is not created")
+		return nil
+	}
+}
+
+func TestServer(t *testing.T) {
+	t.Run("Should create records from UDP messages", func(t *testing.T) {
+		server, records := startServer(t)
+		defer server.Stop()
+
+		conn, err := net.Dial("udp", server.UDPAddr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("<38>Oct 19 11:00:00 web01 sshd[4123]: Failed password for bob from 10.0.0.2"))
+		require.NoError(t, err)
+
+		record := receiveRecord(t, records)
+		assert.Equal(t, "bob", record.GetActor().GetId())
+	})
+
+	t.Run("Should create records from TCP messages in both framings", func(t *testing.T) {
+		server, records := startServer(t)
+		defer server.Stop()
+
+		conn, err := net.Dial("tcp", server.TCPAddr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		octetCounted := "<13>1 - web01 first - - -"
+		_, err = conn.Write([]byte(strconv.Itoa(len(octetCounted)) + " " + octetCounted + "<13>1 - web01 second - - -\n\n<13>1 - web01 third - - -\n"))
+		require.NoError(t, err)
+
+		for _, want := range []string{"first", "second", "third"} {
+			record := receiveRecord(t, records)
+			assert.Equal(t, want, record.GetActor().GetId())
+		}
+	})
+
+	t.Run("Should close TCP connection sending too large messages", func(t *testing.T) {
+		server, records := startServer(t)
+		defer server.Stop()
+
+		conn, err := net.Dial("tcp", server.TCPAddr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("<13>1 - web01 app - - - " + strings.Repeat("x", 300) + "\n"))
+		require.NoError(t, err)
+
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		assert.Error(t, err)
+		assert.Empty(t, records)
+	})
+
+	t.Run("Should close TCP connections on stop", func(t *testing.T) {
+		server, _ := startServer(t)
+
+		conn, err := net.Dial("tcp", server.TCPAddr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// Make sure the connection is accepted.
+		_, err = conn.Write([]byte("\n"))
+		require.NoError(t, err)
+		time.Sleep(50 * time.Millisecond)
+
+		server.Stop()
+
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, os.ErrDeadlineExceeded)
+	})
+}
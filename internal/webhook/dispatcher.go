@@ -0,0 +1,457 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/metrics"
+)
+
+const (
+	// eventTypeRecordCreated is the type of payloads of created records.
+	eventTypeRecordCreated = "record.created"
+
+	// leaseMargin is added to the request timeout to get the time other
+	// instances wait before attempting a claimed delivery.
+	leaseMargin = 30 * time.Second
+
+	// cleanupInterval is how often completed deliveries past retention are
+	// deleted.
+	cleanupInterval = time.Hour
+
+	// maxResponseSize is the size of the response body read to reuse the
+	// connection.
+	maxResponseSize = 64 << 10
+
+	// maxErrorLength is the maximum length of the last error of a delivery.
+	maxErrorLength = 1024
+
+	userAgent = "Auditum-Webhook/1.0"
+)
+
+// Delivery attempt results for metrics.
+const (
+	resultSucceeded = "succeeded"
+	resultRetried   = "retried"
+	resultFailed    = "failed"
+)
+
+type Store interface {
+	// Returns pending deliveries due at now and postpones them until
+	// leaseTime.
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, leaseTime time.Time, limit int) ([]aud.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery aud.WebhookDelivery) error
+	DeleteWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+
+	// May return [aud.ErrWebhookNotFound].
+	GetWebhook(ctx context.Context, projectID aud.ID, id aud.ID) (aud.Webhook, error)
+	// May return [aud.ErrRecordNotFound].
+	GetRecord(ctx context.Context, projectID aud.ID, id aud.ID) (aud.Record, error)
+}
+
+// RecordEncoder encodes the record to JSON as it is returned by the API.
+type RecordEncoder func(record aud.Record) ([]byte, error)
+
+type Config struct {
+	// PollInterval is how often due deliveries are checked.
+	PollInterval time.Duration
+	// Timeout of a delivery request.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts before a delivery fails.
+	MaxAttempts int
+	// MinBackoff is the delay before the second attempt. It doubles with
+	// every attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Retention is how long completed deliveries are kept.
+	Retention time.Duration
+	// Concurrency is the number of deliveries attempted at once.
+	Concurrency int
+	// AllowPrivateNetworks allows delivering to loopback, private,
+	// link-local and other special-purpose addresses. Otherwise they are
+	// refused when connecting, after the host is resolved, and proxies
+	// from the environment are not used.
+	AllowPrivateNetworks bool
+}
+
+// Dispatcher delivers records queued in the store to webhooks, retrying
+// failed deliveries with exponential backoff. Multiple instances may run
+// dispatchers concurrently, since deliveries are claimed in the store.
+type Dispatcher struct {
+	store  Store
+	encode RecordEncoder
+	conf   Config
+	client *http.Client
+	log    *zap.Logger
+
+	now func() time.Time
+}
+
+func NewDispatcher(
+	store Store,
+	encode RecordEncoder,
+	conf Config,
+	log *zap.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		encode: encode,
+		conf:   conf,
+		client: &http.Client{
+			Transport: newTransport(conf.AllowPrivateNetworks),
+			Timeout:   conf.Timeout,
+			// Redirects are not followed, so that the signed payload is
+			// sent only to the configured URL.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		log: log.Named("webhook_dispatcher"),
+		now: time.Now,
+	}
+}
+
+func newTransport(allowPrivateNetworks bool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if allowPrivateNetworks {
+		return transport
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		// Addresses are checked when connecting rather than when resolving,
+		// so that a host cannot resolve to another address in between.
+		Control: checkPublicAddress,
+	}
+	transport.DialContext = dialer.DialContext
+	// A proxy would connect to the webhook instead of the dialer.
+	transport.Proxy = nil
+
+	return transport
+}
+
+// specialPrefixes are special-purpose networks that are not reachable
+// publicly and are not covered by methods of [netip.Addr], and IPv6
+// transition networks embedding IPv4 addresses, which may be private.
+var specialPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64.
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64.
+	netip.MustParsePrefix("2001::/32"),      // Teredo.
+	netip.MustParsePrefix("2002::/16"),      // 6to4.
+}
+
+func checkPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %v", address, err)
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if isPublicAddr(addr) {
+		return nil
+	}
+
+	return fmt.Errorf("address %s is not allowed, delivering to private networks is disabled", addr)
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	if addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range specialPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Run delivers due deliveries and deletes old completed ones until ctx is
+// done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	poll := time.NewTicker(d.conf.PollInterval)
+	defer poll.Stop()
+
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-cleanup.C:
+			d.cleanup(ctx)
+		}
+	}
+}
+
+// dispatch attempts due deliveries until there are none left.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := d.now().UTC()
+
+		deliveries, err := d.store.ClaimWebhookDeliveries(
+			ctx,
+			now,
+			now.Add(d.conf.Timeout+leaseMargin),
+			d.conf.Concurrency,
+		)
+		if err != nil {
+			if ctx.Err() == nil {
+				d.log.Error("Claim webhook deliveries", zap.Error(err))
+			}
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.attempt(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < d.conf.Concurrency {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery aud.WebhookDelivery) {
+	log := d.log.With(
+		zap.String("project_id", delivery.ProjectID.String()),
+		zap.String("webhook_id", delivery.WebhookID.String()),
+		zap.String("delivery_id", delivery.ID.String()),
+	)
+
+	webhook, err := d.store.GetWebhook(ctx, delivery.ProjectID, delivery.WebhookID)
+	if errors.Is(err, aud.ErrWebhookNotFound) {
+		// Deliveries are deleted along with the webhook.
+		return
+	}
+	if err != nil {
+		log.Error("Get webhook from store", zap.Error(err))
+		return
+	}
+
+	record, err := d.store.GetRecord(ctx, delivery.ProjectID, delivery.RecordID)
+	if errors.Is(err, aud.ErrRecordNotFound) {
+		d.complete(ctx, log, delivery, 0, errors.New("record not found"), false)
+		return
+	}
+	if err != nil {
+		log.Error("Get record from store", zap.Error(err))
+		return
+	}
+
+	payload, err := d.payload(delivery, record)
+	if err != nil {
+		log.Error("Encode webhook payload", zap.Error(err))
+		d.complete(ctx, log, delivery, 0, fmt.Errorf("encode payload: %v", err), false)
+		return
+	}
+
+	code, err := d.send(ctx, webhook, delivery, payload)
+	if ctx.Err() != nil {
+		// Shutting down, the delivery is attempted again after the lease.
+		return
+	}
+
+	d.complete(ctx, log, delivery, code, err, true)
+}
+
+type payload struct {
+	// ID is the delivery identifier, which is the same for all attempts.
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	WebhookID string          `json:"webhook_id"`
+	ProjectID string          `json:"project_id"`
+	Record    json.RawMessage `json:"record"`
+}
+
+func (d *Dispatcher) payload(delivery aud.WebhookDelivery, record aud.Record) (_ []byte, err error) {
+	// Encoding may panic on invalid stored records, which must not stop the
+	// dispatcher.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	encoded, err := d.encode(record)
+	if err != nil {
+		return nil, fmt.Errorf("encode record: %v", err)
+	}
+
+	return json.Marshal(payload{
+		ID:        delivery.ID.String(),
+		Type:      eventTypeRecordCreated,
+		WebhookID: delivery.WebhookID.String(),
+		ProjectID: delivery.ProjectID.String(),
+		Record:    encoded,
+	})
+}
+
+// send posts the payload to the webhook URL and returns the response status
+// code, if a response was received.
+func (d *Dispatcher) send(
+	ctx context.Context,
+	webhook aud.Webhook,
+	delivery aud.WebhookDelivery,
+	payload []byte,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %v", err)
+	}
+
+	timestamp := d.now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderWebhookID, webhook.ID.String())
+	req.Header.Set(HeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// complete saves the result of the attempt and schedules the next one if
+// the delivery failed and may be retried.
+func (d *Dispatcher) complete(
+	ctx context.Context,
+	log *zap.Logger,
+	delivery aud.WebhookDelivery,
+	code int,
+	err error,
+	retry bool,
+) {
+	now := d.now().UTC()
+
+	delivery.Attempts++
+	delivery.LastAttemptTime = now
+	delivery.LastResponseCode = code
+	delivery.LastError = ""
+	delivery.NextAttemptTime = time.Time{}
+
+	var result string
+	switch {
+	case err == nil:
+		delivery.Status = aud.WebhookDeliveryStatusSucceeded
+		result = resultSucceeded
+	case retry && delivery.Attempts < d.conf.MaxAttempts:
+		delivery.Status = aud.WebhookDeliveryStatusPending
+		delivery.NextAttemptTime = now.Add(d.backoff(delivery.Attempts))
+		delivery.LastError = truncateError(err)
+		result = resultRetried
+	default:
+		delivery.Status = aud.WebhookDeliveryStatusFailed
+		delivery.LastError = truncateError(err)
+		result = resultFailed
+	}
+
+	metrics.WebhookDeliveryAttempt(result)
+
+	if err != nil {
+		log.Debug("Webhook delivery attempt failed",
+			zap.Int("attempts", delivery.Attempts),
+			zap.String("status", delivery.Status.String()),
+			zap.Error(err),
+		)
+	}
+
+	if err := d.store.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		log.Error("Update webhook delivery in store", zap.Error(err))
+	}
+}
+
+// backoff returns the delay after the number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.conf.MinBackoff
+	for i := 1; i < attempts && delay < d.conf.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.conf.MaxBackoff)
+}
+
+func (d *Dispatcher) cleanup(ctx context.Context) {
+	n, err := d.store.DeleteWebhookDeliveries(ctx, d.now().UTC().Add(-d.conf.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error("Delete completed webhook deliveries", zap.Error(err))
+		}
+		return
+	}
+
+	if n > 0 {
+		d.log.Debug("Deleted completed webhook deliveries", zap.Int64("count", n))
+	}
+}
+
+func truncateError(err error) string {
+	s := err.Error()
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/auditumio/auditum/internal/aud"
+	"github.com/auditumio/auditum/internal/webhook"
+)
+
+type fakeStore struct {
+	webhook aud.Webhook
+	record  aud.Record
+
+	mu         sync.Mutex
+	deliveries []aud.WebhookDelivery
+	updates    chan aud.WebhookDelivery
+}
+
+func (s *fakeStore) ClaimWebhookDeliveries(_ context.Context, _ time.Time, _ time.Time, limit int) ([]aud.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := min(limit, len(s.deliveries))
+	claimed := s.deliveries[:n]
+	s.deliveries = s.deliveries[n:]
+	return claimed, nil
+}
+
+func (s *fakeStore) UpdateWebhookDelivery(_ context.Context, delivery aud.WebhookDelivery) error {
+	s.updates <- delivery
+	return nil
+}
+
+func (s *fakeStore) DeleteWebhookDeliveries(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (s *fakeStore) GetWebhook(_ context.Context, projectID aud.ID, id aud.ID) (aud.Webhook, error) {
+	if projectID != s.webhook.ProjectID || id != s.webhook.ID {
+		return aud.Webhook{}, aud.ErrWebhookNotFound
+	}
+	return s.webhook, nil
+}
+
+func (s *fakeStore) GetRecord(_ context.Context, projectID aud.ID, id aud.ID) (aud.Record, error) {
+	if projectID != s.record.ProjectID || id != s.record.ID {
+		return aud.Record{}, aud.ErrRecordNotFound
+	}
+	return s.record, nil
+}
+
+func encodeRecord(record aud.Record) ([]byte, error) {
+	return json.Marshal(map[string]string{"id": record.ID.String()})
+}
+
+var testConfig = webhook.Config{
+	PollInterval: 10 * time.Millisecond,
+	Timeout:      time.Second,
+	MaxAttempts:  5,
+	MinBackoff:   time.Second,
+	MaxBackoff:   time.Hour,
+	Retention:    time.Hour,
+	Concurrency:  2,
+	// Test servers listen on loopback.
+	AllowPrivateNetworks: true,
+}
+
+func TestDispatcher(t *testing.T) {
+	projectID := aud.MustNewID()
+
+	newStore := func(url string) *fakeStore {
+		return &fakeStore{
+			webhook: aud.Webhook{
+				ID:        aud.MustNewID(),
+				ProjectID: projectID,
+				URL:       url,
+				Secret:    "whsec_test",
+			},
+			record: aud.Record{
+				ID:        aud.MustNewID(),
+				ProjectID: projectID,
+			},
+			updates: make(chan aud.WebhookDelivery, 1),
+		}
+	}
+
+	newDelivery := func(store *fakeStore, attempts int) aud.WebhookDelivery {
+		return aud.WebhookDelivery{
+			ID:        aud.MustNewID(),
+			ProjectID: projectID,
+			WebhookID: store.webhook.ID,
+			RecordID:  store.record.ID,
+			Status:    aud.WebhookDeliveryStatusPending,
+			Attempts:  attempts,
+		}
+	}
+
+	runWithConfig := func(t *testing.T, store *fakeStore, conf webhook.Config) aud.WebhookDelivery {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		go webhook.NewDispatcher(store, encodeRecord, conf, zap.NewNop()).Run(ctx)
+
+		select {
+		case delivery := <-store.updates:
+			return delivery
+		case <-ctx.Done():
+			t.Fatal("delivery was not updated")
+			return aud.WebhookDelivery{}
+		}
+	}
+
+	run := func(t *testing.T, store *fakeStore) aud.WebhookDelivery {
+		return runWithConfig(t, store, testConfig)
+	}
+
+	t.Run("Should deliver signed payload", func(t *testing.T) {
+		var store *fakeStore
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			unix, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, store.webhook.ID.String(), r.Header.Get(webhook.HeaderWebhookID))
+			assert.Equal(t,
+				webhook.Sign(store.webhook.Secret, time.Unix(unix, 0), body),
+				r.Header.Get(webhook.HeaderSignature),
+			)
+			assert.JSONEq(t, `{
+				"id": "`+r.Header.Get(webhook.HeaderDeliveryID)+`",
+				"type": "record.created",
+				"webhook_id": "`+store.webhook.ID.String()+`",
+				"project_id": "`+projectID.String()+`",
+				"record": {"id": "`+store.record.ID.String()+`"}
+			}`, string(body))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		store = newStore(srv.URL)
+		delivery := newDelivery(store, 0)
+		store.deliveries = []aud.WebhookDelivery{delivery}
+
+		got := run(t, store)
+		assert.Equal(t, delivery.ID, got.ID)
+		assert.Equal(t, aud.WebhookDeliveryStatusSucceeded, got.Status)
+		assert.Equal(t, 1, got.Attempts)
+		assert.Equal(t, http.StatusNoContent, got.LastResponseCode)
+		assert.Empty(t, got.LastError)
+		assert.True(t, got.NextAttemptTime.IsZero())
+		assert.False(t, got.LastAttemptTime.IsZero())
+	})
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	t.Run("Should retry failed delivery with exponential backoff", func(t *testing.T) {
+		store := newStore(failing.URL)
+		store.deliveries = []aud.WebhookDelivery{newDelivery(store, 2)}
+
+		got := run(t, store)
+		assert.Equal(t, aud.WebhookDeliveryStatusPending, got.Status)
+		assert.Equal(t, 3, got.Attempts)
+		assert.Equal(t, http.StatusServiceUnavailable, got.LastResponseCode)
+		assert.Equal(t, "unexpected response status 503", got.LastError)
+		assert.Equal(t, 4*time.Second, got.NextAttemptTime.Sub(got.LastAttemptTime))
+	})
+
+	t.Run("Should fail delivery after the last attempt", func(t *testing.T) {
+		store := newStore(failing.URL)
+		store.deliveries = []aud.WebhookDelivery{newDelivery(store, testConfig.MaxAttempts-1)}
+
+		got := run(t, store)
+		assert.Equal(t, aud.WebhookDeliveryStatusFailed, got.Status)
+		assert.Equal(t, testConfig.MaxAttempts, got.Attempts)
+		assert.True(t, got.NextAttemptTime.IsZero())
+	})
+
+	t.Run("Should fail delivery of deleted record", func(t *testing.T) {
+		store := newStore(failing.URL)
+		delivery := newDelivery(store, 0)
+		delivery.RecordID = aud.MustNewID()
+		store.deliveries = []aud.WebhookDelivery{delivery}
+
+		got := run(t, store)
+		assert.Equal(t, aud.WebhookDeliveryStatusFailed, got.Status)
+		assert.Equal(t, 1, got.Attempts)
+		assert.Equal(t, "record not found", got.LastError)
+	})
+
+	t.Run("Should refuse delivery to private network", func(t *testing.T) {
+		var requested bool
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		conf := testConfig
+		conf.AllowPrivateNetworks = false
+
+		for _, url := range []string{
+			srv.URL,
+			strings.Replace(srv.URL, "127.0.0.1", "localhost", 1),
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::ffff:10.0.0.1]/",
+			"http://[64:ff9b::a00:1]/",
+			"http://[64:ff9b:1::a00:1]/",
+			"http://[2001:0:a00:1::1]/",
+			"http://[2002:a00:1::1]/",
+		} {
+			store := newStore(url)
+			store.deliveries = []aud.WebhookDelivery{newDelivery(store, 0)}
+
+			got := runWithConfig(t, store, conf)
+			assert.Equal(t, aud.WebhookDeliveryStatusPending, got.Status, url)
+			assert.Zero(t, got.LastResponseCode, url)
+			assert.Contains(t, got.LastError, "delivering to private networks is disabled", url)
+		}
+
+		assert.False(t, requested)
+	})
+}
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	payload := []byte(`{"id":"1"}`)
+
+	sig := webhook.Sign("whsec_test", timestamp, payload)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, sig)
+
+	assert.Equal(t, sig, webhook.Sign("whsec_test", timestamp, payload))
+	assert.NotEqual(t, sig, webhook.Sign("whsec_other", timestamp, payload))
+	assert.NotEqual(t, sig, webhook.Sign("whsec_test", timestamp.Add(time.Second), payload))
+	assert.NotEqual(t, sig, webhook.Sign("whsec_test", timestamp, []byte(`{"id":"2"}`)))
+}
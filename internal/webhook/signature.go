@@ -0,0 +1,46 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook delivers created records to webhooks.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers of delivery requests.
+const (
+	HeaderWebhookID  = "X-Auditum-Webhook-Id"
+	HeaderDeliveryID = "X-Auditum-Delivery-Id"
+	HeaderTimestamp  = "X-Auditum-Timestamp"
+	HeaderSignature  = "X-Auditum-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the payload sent at the time: "sha256="
+// followed by hex-encoded HMAC-SHA256 of "<timestamp>.<payload>" with the
+// secret, where timestamp is in Unix seconds as in the timestamp header.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -15,15 +15,61 @@
 package bunx
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file" // init driver for fs
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"go.uber.org/zap"
 
 	"github.com/auditumio/auditum/pkg/fragma/zapx/zapxmigrate"
 )
 
 func RunMigrations(mig *migrate.Migrate, log any) error {
+	if err := setMigrateLogger(mig, log); err != nil {
+		return err
+	}
+
+	err := mig.Up()
+	if err == migrate.ErrNoChange {
+		if mig.Log != nil {
+			mig.Log.Printf("No migrations to apply")
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("migrate up: %v", err)
+	}
+
+	return nil
+}
+
+// OpenMigrationsSource opens migrations from the directory, if it is set,
+// or from the embedded file system otherwise. Returns the source name and
+// driver.
+func OpenMigrationsSource(embedded fs.FS, dir string) (string, source.Driver, error) {
+	if dir != "" {
+		src, err := source.Open("file://" + dir)
+		if err != nil {
+			return "", nil, fmt.Errorf("open migrations directory %s: %v", dir, err)
+		}
+		return "file", src, nil
+	}
+
+	src, err := iofs.New(embedded, ".")
+	if err != nil {
+		return "", nil, fmt.Errorf("open embedded migrations: %v", err)
+	}
+	return "iofs", src, nil
+}
+
+func setMigrateLogger(mig *migrate.Migrate, log any) error {
 	switch l := log.(type) {
 	case nil:
 		// Do nothing.
@@ -35,16 +81,326 @@ func RunMigrations(mig *migrate.Migrate, log any) error {
 		return fmt.Errorf("unsupported log type: %T", log)
 	}
 
-	err := mig.Up()
-	if err == migrate.ErrNoChange {
-		if mig.Log != nil {
-			mig.Log.Printf("No migrations to apply")
+	return nil
+}
+
+// Migration is a migration available in the source.
+type Migration struct {
+	Version    uint
+	Identifier string
+}
+
+// MigrationStatus is the state of database migrations.
+type MigrationStatus struct {
+	// Version is the current version. It is zero if no migrations are
+	// applied.
+	Version uint
+	// Dirty is true if the last migration failed and the database must be
+	// fixed manually.
+	Dirty bool
+	// Pending lists migrations that are not applied yet.
+	Pending []Migration
+}
+
+// PlannedMigration is a migration that would be applied.
+type PlannedMigration struct {
+	Migration
+	Direction source.Direction
+	SQL       string
+}
+
+// Migrator manages database migrations.
+type Migrator struct {
+	mig *migrate.Migrate
+	src source.Driver
+}
+
+// NewMigrator returns a new Migrator. Closing the migrator closes the source
+// and the database.
+func NewMigrator(
+	sourceName string,
+	src source.Driver,
+	databaseName string,
+	db database.Driver,
+	log any,
+) (*Migrator, error) {
+	mig, err := migrate.NewWithInstance(sourceName, src, databaseName, db)
+	if err != nil {
+		return nil, fmt.Errorf("create migrate instance: %v", err)
+	}
+
+	if err := setMigrateLogger(mig, log); err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		mig: mig,
+		src: src,
+	}, nil
+}
+
+// Close closes the source and the database.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.mig.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// Status returns the current state of migrations.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	var status MigrationStatus
+
+	version, ok, dirty, err := m.version()
+	if err != nil {
+		return status, err
+	}
+	status.Version = version
+	status.Dirty = dirty
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return status, err
+	}
+
+	for _, migration := range migrations {
+		if !ok || migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+
+	return status, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	return m.run("migrate up", m.mig.Up)
+}
+
+// Down reverts n last applied migrations.
+func (m *Migrator) Down(n int) error {
+	if n < 1 {
+		return fmt.Errorf("number of migrations must be positive")
+	}
+
+	return m.run("migrate down", func() error {
+		return m.mig.Steps(-n)
+	})
+}
+
+// Goto migrates up or down to the version.
+func (m *Migrator) Goto(version uint) error {
+	return m.run("migrate to version", func() error {
+		return m.mig.Migrate(version)
+	})
+}
+
+// Force sets the version without running migrations and resets the dirty
+// state. Version -1 means that no migrations are applied.
+func (m *Migrator) Force(version int) error {
+	if version < -1 {
+		return fmt.Errorf("version must be at least -1")
+	}
+
+	if err := m.mig.Force(version); err != nil {
+		return fmt.Errorf("force version: %v", err)
+	}
+
+	return nil
+}
+
+// PlanUp returns migrations that Up would apply.
+func (m *Migrator) PlanUp() ([]PlannedMigration, error) {
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.plan(status.Pending, source.Up)
+}
+
+// PlanDown returns migrations that Down would revert.
+func (m *Migrator) PlanDown(n int) ([]PlannedMigration, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("number of migrations must be positive")
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) < n {
+		return nil, fmt.Errorf("only %d migrations can be reverted", len(applied))
+	}
+
+	return m.plan(applied[:n], source.Down)
+}
+
+// PlanGoto returns migrations that Goto would apply or revert.
+func (m *Migrator) PlanGoto(version uint) ([]PlannedMigration, error) {
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, migration := range migrations {
+		if migration.Version == version {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown version %d", version)
+	}
+
+	if version >= status.Version {
+		var up []Migration
+		for _, migration := range status.Pending {
+			if migration.Version <= version {
+				up = append(up, migration)
+			}
+		}
+		return m.plan(up, source.Up)
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var down []Migration
+	for _, migration := range applied {
+		if migration.Version > version {
+			down = append(down, migration)
+		}
+	}
+
+	return m.plan(down, source.Down)
+}
+
+func (m *Migrator) run(action string, fn func() error) error {
+	err := fn()
+	if errors.Is(err, migrate.ErrNoChange) {
+		if m.mig.Log != nil {
+			m.mig.Log.Printf("No migrations to apply")
 		}
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("migrate up: %v", err)
+		return fmt.Errorf("%s: %v", action, err)
 	}
 
 	return nil
 }
+
+func (m *Migrator) version() (version uint, ok bool, dirty bool, err error) {
+	version, dirty, err = m.mig.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, fmt.Errorf("get version: %v", err)
+	}
+
+	return version, true, dirty, nil
+}
+
+func (m *Migrator) cleanStatus() (MigrationStatus, error) {
+	status, err := m.Status()
+	if err != nil {
+		return status, err
+	}
+
+	if status.Dirty {
+		return status, migrate.ErrDirty{Version: int(status.Version)}
+	}
+
+	return status, nil
+}
+
+// applied returns applied migrations from the latest to the earliest.
+func (m *Migrator) applied() ([]Migration, error) {
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if status.Version != 0 && migrations[i].Version <= status.Version {
+			applied = append(applied, migrations[i])
+		}
+	}
+
+	return applied, nil
+}
+
+// migrations returns all migrations available in the source in ascending
+// order of versions.
+func (m *Migrator) migrations() ([]Migration, error) {
+	var migrations []Migration
+
+	version, err := m.src.First()
+	for err == nil {
+		migration := Migration{Version: version}
+
+		r, identifier, rerr := m.src.ReadUp(version)
+		if rerr != nil {
+			return nil, fmt.Errorf("read migration %d: %v", version, rerr)
+		}
+		_ = r.Close()
+		migration.Identifier = identifier
+
+		migrations = append(migrations, migration)
+
+		version, err = m.src.Next(version)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read migrations: %v", err)
+	}
+
+	return migrations, nil
+}
+
+func (m *Migrator) plan(migrations []Migration, direction source.Direction) ([]PlannedMigration, error) {
+	planned := make([]PlannedMigration, 0, len(migrations))
+
+	for _, migration := range migrations {
+		read := m.src.ReadUp
+		if direction == source.Down {
+			read = m.src.ReadDown
+		}
+
+		r, _, err := read(migration.Version)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no %s migration for version %d", direction, migration.Version)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read migration %d: %v", migration.Version, err)
+		}
+
+		b, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read migration %d: %v", migration.Version, err)
+		}
+
+		planned = append(planned, PlannedMigration{
+			Migration: migration,
+			Direction: direction,
+			SQL:       string(b),
+		})
+	}
+
+	return planned, nil
+}
@@ -15,7 +15,10 @@
 package grpcx
 
 import (
+	"crypto/tls"
+	"net"
 	"runtime/debug"
+	"slices"
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
@@ -24,29 +27,98 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-func NewServer(log *zap.Logger) *grpc.Server {
+type serverOptions struct {
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	tlsConfig          *tls.Config
+}
+
+type ServerOption func(*serverOptions)
+
+// ServerWithUnaryInterceptors adds interceptors to the chain, after logging
+// and metrics interceptors.
+func ServerWithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// ServerWithStreamInterceptors adds interceptors to the chain, after logging
+// and metrics interceptors.
+func ServerWithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// ServerWithTLS enables TLS for TCP connections. Connections over unix
+// socket, e.g. from gRPC-Gateway, are not encrypted.
+func ServerWithTLS(config *tls.Config) ServerOption {
+	return func(o *serverOptions) {
+		o.tlsConfig = config
+	}
+}
+
+func NewServer(log *zap.Logger, opts ...ServerOption) *grpc.Server {
+	var options serverOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	panicRecoveryHandler := newPanicRecoveryHandler(log.Named("grpc_panic_handler"))
 	panicRecoveryInterceptor := recovery.UnaryServerInterceptor(
 		recovery.WithRecoveryHandler(panicRecoveryHandler),
 	)
+	streamPanicRecoveryInterceptor := recovery.StreamServerInterceptor(
+		recovery.WithRecoveryHandler(panicRecoveryHandler),
+	)
 
 	// NOTE: two interceptors is not a mistake. The last one should catch RPC
 	// panics, so interceptors can build based on it, e.g. finish call logging.
 	// The first one should catch all other panics, i.e. it is a guard for
 	// other interceptor panics.
 
-	server := grpc.NewServer(
+	var serverOpts []grpc.ServerOption
+	if options.tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(&tcpTLSCredentials{
+			TransportCredentials: credentials.NewTLS(options.tlsConfig),
+		}))
+	}
+
+	server := grpc.NewServer(slices.Concat(serverOpts, []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
-			panicRecoveryInterceptor,
-			LoggingUnaryServerInterceptor(log),
-			grpc_prometheus.UnaryServerInterceptor,
-			panicRecoveryInterceptor,
+			slices.Concat(
+				[]grpc.UnaryServerInterceptor{
+					panicRecoveryInterceptor,
+					LoggingUnaryServerInterceptor(log),
+					grpc_prometheus.UnaryServerInterceptor,
+				},
+				options.unaryInterceptors,
+				[]grpc.UnaryServerInterceptor{
+					panicRecoveryInterceptor,
+				},
+			)...,
+		),
+		grpc.ChainStreamInterceptor(
+			slices.Concat(
+				[]grpc.StreamServerInterceptor{
+					streamPanicRecoveryInterceptor,
+					LoggingStreamServerInterceptor(log),
+					grpc_prometheus.StreamServerInterceptor,
+				},
+				options.streamInterceptors,
+				[]grpc.StreamServerInterceptor{
+					streamPanicRecoveryInterceptor,
+				},
+			)...,
 		),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     5 * time.Minute,
@@ -55,7 +127,7 @@ func NewServer(log *zap.Logger) *grpc.Server {
 			Time:                  2 * time.Minute,
 			Timeout:               1 * time.Minute,
 		}),
-	)
+	})...)
 
 	reflection.Register(server)
 
@@ -76,3 +148,23 @@ func newPanicRecoveryHandler(log *zap.Logger) func(p any) error {
 		return status.Error(codes.Internal, "")
 	}
 }
+
+// tcpTLSCredentials uses TLS for TCP connections and no encryption for unix
+// socket connections.
+type tcpTLSCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c *tcpTLSCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := conn.LocalAddr().(*net.UnixAddr); ok {
+		return insecure.NewCredentials().ServerHandshake(conn)
+	}
+
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c *tcpTLSCredentials) Clone() credentials.TransportCredentials {
+	return &tcpTLSCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+	}
+}
@@ -214,6 +214,11 @@ func (sm *ServerController) dial(ctx context.Context) (*grpc.ClientConn, error)
 }
 
 func (sm *ServerController) dialAddr() string {
+	// Prefer unix socket, as TCP connections may require TLS.
+	if sm.unixAddr != "" {
+		return "unix://" + sm.unixAddr
+	}
+
 	addr := sm.addr
 	if strings.HasPrefix(addr, ":") {
 		addr = "localhost" + addr
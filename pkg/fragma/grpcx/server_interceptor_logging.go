@@ -27,6 +27,10 @@ func LoggingUnaryServerInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor
 	return logging.UnaryServerInterceptor(interceptorLogger(log.Named("grpc")))
 }
 
+func LoggingStreamServerInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return logging.StreamServerInterceptor(interceptorLogger(log.Named("grpc")))
+}
+
 // interceptorLogger adapts zap logger to interceptor logger.
 func interceptorLogger(l *zap.Logger) logging.Logger {
 	return logging.LoggerFunc(func(_ context.Context, lvl logging.Level, msg string, fields ...any) {
@@ -0,0 +1,96 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/auditumio/auditum/pkg/fragma/grpcx"
+)
+
+func TestNewServer_StreamInterceptors(t *testing.T) {
+	var intercepted []string
+
+	server := grpcx.NewServer(
+		zap.NewNop(),
+		grpcx.ServerWithStreamInterceptors(func(
+			srv any,
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			intercepted = append(intercepted, info.FullMethod)
+			return handler(srv, ss)
+		}),
+	)
+
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Service",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName: "Panic",
+				Handler: func(any, grpc.ServerStream) error {
+					panic("test")
+				},
+				ClientStreams: true,
+				ServerStreams: true,
+			},
+		},
+	}, nil)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	stream, err := conn.NewStream(
+		context.Background(),
+		&grpc.StreamDesc{ClientStreams: true, ServerStreams: true},
+		"/test.Service/Panic",
+	)
+	require.NoError(t, err)
+
+	// Handler panic is recovered and returned as internal error.
+	err = stream.RecvMsg(&emptypb.Empty{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	assert.Equal(t, []string{"/test.Service/Panic"}, intercepted)
+}
@@ -67,7 +67,14 @@ func (sm *ServerController) Stop(ctx context.Context) error {
 func (sm *ServerController) start() {
 	defer close(sm.errs)
 
-	if err := sm.server.ListenAndServe(); err != nil {
+	var err error
+	if sm.server.TLSConfig != nil {
+		// Certificates are provided by TLS config.
+		err = sm.server.ListenAndServeTLS("", "")
+	} else {
+		err = sm.server.ListenAndServe()
+	}
+	if err != nil {
 		if errors.Is(err, http.ErrServerClosed) {
 			return
 		}
@@ -0,0 +1,212 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsx contains TLS utilities.
+package tlsx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// reloadCheckInterval limits how often files are checked for changes.
+const reloadCheckInterval = time.Second
+
+// ServerConfig describes TLS configuration of a server.
+type ServerConfig struct {
+	// CertFile and KeyFile are PEM encoded certificate chain and private key.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is PEM encoded CA certificates to verify client
+	// certificates. If set, clients must present a valid certificate.
+	ClientCAFile string
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS12.
+	MinVersion uint16
+	// NextProtos is the list of supported application protocols.
+	NextProtos []string
+}
+
+// NewServerTLSConfig returns TLS configuration of a server. Files are
+// checked for changes on new connections, and certificates are reloaded
+// without restart. If reload fails, previous certificates are used.
+func NewServerTLSConfig(conf ServerConfig, log *zap.Logger) (*tls.Config, error) {
+	r := &reloader{
+		conf: conf,
+		log: log.Named("tls").
+			With(zap.String("cert_file", conf.CertFile)),
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:         conf.MinVersion,
+		NextProtos:         conf.NextProtos,
+		GetConfigForClient: r.getConfigForClient,
+		// Not used as GetConfigForClient takes precedence, but required by
+		// some servers to detect that certificates are configured.
+		GetCertificate: r.getCertificate,
+	}, nil
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+type reloader struct {
+	conf ServerConfig
+	log  *zap.Logger
+
+	mu      sync.Mutex
+	config  *tls.Config
+	stamps  []fileStamp
+	checked time.Time
+}
+
+func (r *reloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) >= reloadCheckInterval {
+		r.checked = time.Now()
+
+		if r.changed() {
+			if err := r.reload(); err != nil {
+				r.log.Error("Failed to reload TLS certificates, keep using previous", zap.Error(err))
+			} else {
+				r.log.Info("Reloaded TLS certificates")
+			}
+		}
+	}
+
+	return r.config, nil
+}
+
+func (r *reloader) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	config, _ := r.getConfigForClient(hello)
+	return &config.Certificates[0], nil
+}
+
+func (r *reloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checked = time.Now()
+	return r.reload()
+}
+
+// reload must be called with mu held.
+func (r *reloader) reload() error {
+	stamps, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %v", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   r.conf.MinVersion,
+		NextProtos:   r.conf.NextProtos,
+	}
+
+	if r.conf.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.conf.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA file: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client CA file has no certificates")
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.config = config
+	r.stamps = stamps
+
+	return nil
+}
+
+// changed must be called with mu held.
+func (r *reloader) changed() bool {
+	stamps, err := r.stat()
+	if err != nil {
+		// Files may be replaced at the moment, check next time.
+		return false
+	}
+
+	for i := range stamps {
+		if stamps[i] != r.stamps[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reloader) stat() ([]fileStamp, error) {
+	files := []string{r.conf.CertFile, r.conf.KeyFile}
+	if r.conf.ClientCAFile != "" {
+		files = append(files, r.conf.ClientCAFile)
+	}
+
+	stamps := make([]fileStamp, 0, len(files))
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		stamps = append(stamps, fileStamp{modTime: info.ModTime(), size: info.Size()})
+	}
+	return stamps, nil
+}
+
+// ParseVersion parses TLS version, e.g. "1.2".
+func ParseVersion(s string) (uint16, error) {
+	switch s {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unknown TLS version %q", s)
+	}
+}
+
+// ClientCertificateSubject returns the subject of the verified client
+// certificate, if any.
+func ClientCertificateSubject(state *tls.ConnectionState) (string, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return state.VerifiedChains[0][0].Subject.String(), true
+}
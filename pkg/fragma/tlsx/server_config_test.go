@@ -0,0 +1,261 @@
+// Copyright 2023 Igor Zibarev
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by the parent, or self-signed
+// CA certificate if parent is nil.
+func newTestCert(t *testing.T, commonName string, parent *testCert) testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCert{cert: cert, key: key}
+}
+
+func (c testCert) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+
+	if keyFile != "" {
+		der, err := x509.MarshalECPrivateKey(c.key)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	}
+}
+
+func (c testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{c.cert.Raw},
+		PrivateKey:  c.key,
+	}
+}
+
+// handshake performs TLS handshake and returns the server connection state.
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+		if err == nil {
+			// Wait for server to finish the handshake.
+			_, _ = conn.Read(make([]byte, 1))
+			_ = conn.Close()
+		}
+	}()
+
+	conn, err := lis.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	server := tls.Server(conn, serverConfig)
+	err = server.Handshake()
+	return server.ConnectionState(), err
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCert(t, "ca", nil)
+	ca.write(t, caFile, "")
+	newTestCert(t, "server", &ca).write(t, certFile, keyFile)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	serverConfig, err := NewServerTLSConfig(ServerConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		MinVersion:   tls.VersionTLS12,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	t.Run("client certificate is verified", func(t *testing.T) {
+		client := newTestCert(t, "client", &ca)
+
+		state, err := handshake(t, serverConfig, &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{client.tlsCertificate()},
+		})
+		require.NoError(t, err)
+
+		subject, ok := ClientCertificateSubject(&state)
+		assert.True(t, ok)
+		assert.Equal(t, "CN=client,O=Example", subject)
+	})
+
+	t.Run("client without certificate is rejected", func(t *testing.T) {
+		_, err := handshake(t, serverConfig, &tls.Config{
+			RootCAs:    roots,
+			ServerName: "localhost",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate of unknown CA is rejected", func(t *testing.T) {
+		otherCA := newTestCert(t, "other", nil)
+		client := newTestCert(t, "client", &otherCA)
+
+		_, err := handshake(t, serverConfig, &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{client.tlsCertificate()},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("old TLS version is rejected", func(t *testing.T) {
+		client := newTestCert(t, "client", &ca)
+
+		_, err := handshake(t, serverConfig, &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{client.tlsCertificate()},
+			MaxVersion:   tls.VersionTLS11,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewServerTLSConfig_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewServerTLSConfig(ServerConfig{
+		CertFile: filepath.Join(dir, "missing.crt"),
+		KeyFile:  filepath.Join(dir, "missing.key"),
+	}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	cert1 := newTestCert(t, "server1", nil)
+	cert1.write(t, certFile, keyFile)
+
+	r := &reloader{
+		conf: ServerConfig{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+		log: zap.NewNop(),
+	}
+	require.NoError(t, r.load())
+
+	leaf := func() string {
+		config, err := r.getConfigForClient(nil)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+		require.NoError(t, err)
+		return cert.Subject.CommonName
+	}
+
+	assert.Equal(t, "server1", leaf())
+
+	// Replace certificate.
+	cert2 := newTestCert(t, "server2", nil)
+	cert2.write(t, certFile, keyFile)
+	touch(t, certFile, keyFile)
+
+	// Not checked until interval passes.
+	assert.Equal(t, "server1", leaf())
+
+	r.checked = time.Time{}
+	assert.Equal(t, "server2", leaf())
+
+	// Invalid certificate keeps the previous one.
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0o600))
+	touch(t, certFile)
+
+	r.checked = time.Time{}
+	assert.Equal(t, "server2", leaf())
+}
+
+var touches int
+
+// touch changes modification time of the files, as they may be written
+// within file system time resolution.
+func touch(t *testing.T, files ...string) {
+	t.Helper()
+
+	touches++
+	mtime := time.Now().Add(time.Duration(touches) * time.Minute)
+	for _, f := range files {
+		require.NoError(t, os.Chtimes(f, mtime, mtime))
+	}
+}